@@ -22,26 +22,34 @@ import (
 // for the Agent process to finish.
 type Commander struct {
 	logger  *zap.Logger
-	cfg     config.Agent
 	args    []string
 	cmd     *exec.Cmd
 	doneCh  chan struct{}
 	exitCh  chan struct{}
 	running *atomic.Int64
+	// executable is the path of the Agent executable, which can change while the Commander is used
+	executable *atomic.Value
 }
 
 func NewCommander(logger *zap.Logger, cfg config.Agent, args ...string) (*Commander, error) {
+	executable := &atomic.Value{}
+	executable.Store(cfg.Executable)
 	return &Commander{
-		logger:  logger,
-		cfg:     cfg,
-		args:    args,
-		running: &atomic.Int64{},
+		logger:     logger,
+		args:       args,
+		running:    &atomic.Int64{},
+		executable: executable,
 		// Buffer channels so we can send messages without blocking on listeners.
 		doneCh: make(chan struct{}, 1),
 		exitCh: make(chan struct{}, 1),
 	}, nil
 }
 
+// SetExecutable sets the path of the Agent executable used from the next start of the Agent.
+func (c *Commander) SetExecutable(executable string) {
+	c.executable.Store(executable)
+}
+
 // Start the Agent and begin watching the process.
 // Agent's stdout and stderr are written to a file.
 // Calling this method when a command is already running
@@ -67,7 +75,8 @@ func (c *Commander) Start(ctx context.Context) error {
 		}
 	}
 
-	c.logger.Debug("Starting agent", zap.String("agent", c.cfg.Executable))
+	executable := c.executable.Load().(string)
+	c.logger.Debug("Starting agent", zap.String("agent", executable))
 
 	logFilePath := "agent.log"
 	logFile, err := os.Create(logFilePath)
@@ -75,7 +84,7 @@ func (c *Commander) Start(ctx context.Context) error {
 		return fmt.Errorf("cannot create %s: %w", logFilePath, err)
 	}
 
-	c.cmd = exec.CommandContext(ctx, c.cfg.Executable, c.args...) // #nosec G204
+	c.cmd = exec.CommandContext(ctx, executable, c.args...) // #nosec G204
 
 	// Capture standard output and standard error.
 	// https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/21072
@@ -95,7 +104,7 @@ func (c *Commander) Start(ctx context.Context) error {
 }
 
 func (c *Commander) Restart(ctx context.Context) error {
-	c.logger.Debug("Restarting agent", zap.String("agent", c.cfg.Executable))
+	c.logger.Debug("Restarting agent", zap.String("agent", c.executable.Load().(string)))
 	if err := c.Stop(ctx); err != nil {
 		return err
 	}
@@ -23,6 +23,7 @@ type Supervisor struct {
 	Agent        Agent
 	Capabilities Capabilities `mapstructure:"capabilities"`
 	Storage      Storage      `mapstructure:"storage"`
+	Packages     Packages     `mapstructure:"packages"`
 }
 
 func (s Supervisor) Validate() error {
@@ -34,6 +35,14 @@ func (s Supervisor) Validate() error {
 		return err
 	}
 
+	if err := s.Packages.Validate(); err != nil {
+		return err
+	}
+
+	if s.Capabilities.AcceptsPackages && s.Packages.PublicKeyFile == "" && !s.Packages.AllowUnsigned {
+		return errors.New("packages::public_key_file must be specified when capabilities::accepts_packages is true, unless packages::allow_unsigned is set")
+	}
+
 	return nil
 }
 
@@ -51,6 +60,7 @@ type Capabilities struct {
 	ReportsOwnMetrics              bool `mapstructure:"reports_own_metrics"`
 	ReportsHealth                  bool `mapstructure:"reports_health"`
 	ReportsRemoteConfig            bool `mapstructure:"reports_remote_config"`
+	AcceptsPackages                bool `mapstructure:"accepts_packages"`
 }
 
 func (c Capabilities) SupportedCapabilities() protobufs.AgentCapabilities {
@@ -84,6 +94,11 @@ func (c Capabilities) SupportedCapabilities() protobufs.AgentCapabilities {
 		supportedCapabilities |= protobufs.AgentCapabilities_AgentCapabilities_AcceptsOpAMPConnectionSettings
 	}
 
+	if c.AcceptsPackages {
+		supportedCapabilities |= protobufs.AgentCapabilities_AgentCapabilities_AcceptsPackages |
+			protobufs.AgentCapabilities_AgentCapabilities_ReportsPackageStatuses
+	}
+
 	return supportedCapabilities
 }
 
@@ -121,6 +136,9 @@ type Agent struct {
 	Executable              string
 	OrphanDetectionInterval time.Duration    `mapstructure:"orphan_detection_interval"`
 	Description             AgentDescription `mapstructure:"description"`
+	// ConfigApplyTimeout is how long the agent has to become healthy after a new config is applied,
+	// before the Supervisor rolls back to the last known-good config. Zero disables the rollback.
+	ConfigApplyTimeout time.Duration `mapstructure:"config_apply_timeout"`
 }
 
 func (a Agent) Validate() error {
@@ -128,6 +146,10 @@ func (a Agent) Validate() error {
 		return errors.New("agent::orphan_detection_interval must be positive")
 	}
 
+	if a.ConfigApplyTimeout < 0 {
+		return errors.New("agent::config_apply_timeout must not be negative")
+	}
+
 	if a.Executable == "" {
 		return errors.New("agent::executable must be specified")
 	}
@@ -145,6 +167,29 @@ type AgentDescription struct {
 	NonIdentifyingAttributes map[string]string `mapstructure:"non_identifying_attributes"`
 }
 
+// Packages configures the installation of the packages offered by the OpAMP server.
+type Packages struct {
+	// PublicKeyFile is the path to a PEM encoded ed25519 public key. Only the packages whose
+	// signature of the SHA-256 hash of their content is verified by this key are installed.
+	// It is required when packages are accepted, unless AllowUnsigned is set.
+	PublicKeyFile string `mapstructure:"public_key_file"`
+	// AllowUnsigned installs the packages without verifying their signature when no public key
+	// is configured, in which case only the SHA-256 hash of their content is checked.
+	AllowUnsigned bool `mapstructure:"allow_unsigned"`
+}
+
+func (p Packages) Validate() error {
+	if p.PublicKeyFile == "" {
+		return nil
+	}
+
+	if _, err := os.Stat(p.PublicKeyFile); err != nil {
+		return fmt.Errorf("could not stat packages::public_key_file path: %w", err)
+	}
+
+	return nil
+}
+
 // DefaultSupervisor returns the default supervisor config
 func DefaultSupervisor() Supervisor {
 	defaultStorageDir := "/var/lib/otelcol/supervisor"
@@ -175,6 +220,7 @@ func DefaultSupervisor() Supervisor {
 		},
 		Agent: Agent{
 			OrphanDetectionInterval: 5 * time.Second,
+			ConfigApplyTimeout:      30 * time.Second,
 		},
 	}
 }
@@ -0,0 +1,435 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/config"
+)
+
+const (
+	packagesDir                 = "packages"
+	packagesStateFile           = "packages.yaml"
+	lastReportedPackageStatuses = "last_reported_package_statuses.dat"
+)
+
+// installedPackage is the persisted state of a package offered by the OpAMP server.
+// The hashes are hex encoded.
+type installedPackage struct {
+	Type        protobufs.PackageType `yaml:"type"`
+	Version     string                `yaml:"version"`
+	Hash        string                `yaml:"hash"`
+	ContentHash string                `yaml:"content_hash"`
+}
+
+// installedPackages is the persisted state of all the packages offered by the OpAMP server.
+type installedPackages struct {
+	AllPackagesHash string                       `yaml:"all_packages_hash"`
+	Packages        map[string]*installedPackage `yaml:"packages"`
+}
+
+// packageManager implements types.PackagesStateProvider. It installs the packages offered by the
+// OpAMP server in the storage directory, once the hash and, if a public key is configured, the
+// signature of their content is verified. The top-level package is the agent executable.
+type packageManager struct {
+	logger    *zap.Logger
+	dir       string
+	publicKey ed25519.PublicKey
+
+	// mu protects state and offered
+	mu    sync.Mutex
+	state installedPackages
+	// offered holds the files of the last offered packages, which carry the content signatures
+	offered map[string]*protobufs.DownloadableFile
+
+	// agentUpdated is signaled when the top-level package is installed or deleted
+	agentUpdated chan struct{}
+}
+
+var _ types.PackagesStateProvider = (*packageManager)(nil)
+
+func newPackageManager(logger *zap.Logger, storageDir string, cfg config.Packages) (*packageManager, error) {
+	p := &packageManager{
+		logger: logger,
+		dir:    filepath.Join(storageDir, packagesDir),
+		state: installedPackages{
+			Packages: map[string]*installedPackage{},
+		},
+		offered:      map[string]*protobufs.DownloadableFile{},
+		agentUpdated: make(chan struct{}, 1),
+	}
+
+	if cfg.PublicKeyFile != "" {
+		publicKey, err := loadPublicKey(cfg.PublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not load packages public key: %w", err)
+		}
+		p.publicKey = publicKey
+	} else {
+		logger.Warn("The signatures of the packages are not verified, as packages::allow_unsigned is set without packages::public_key_file")
+	}
+
+	if err := os.MkdirAll(p.dir, 0700); err != nil {
+		return nil, fmt.Errorf("error creating packages dir: %w", err)
+	}
+
+	by, err := os.ReadFile(p.stateFile())
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return p, nil
+	case err != nil:
+		return nil, err
+	}
+
+	if err = yaml.Unmarshal(by, &p.state); err != nil {
+		return nil, fmt.Errorf("cannot parse packages state: %w", err)
+	}
+	if p.state.Packages == nil {
+		p.state.Packages = map[string]*installedPackage{}
+	}
+
+	return p, nil
+}
+
+func loadPublicKey(file string) (ed25519.PublicKey, error) {
+	by, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(by)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported public key type %T, must be ed25519", key)
+	}
+
+	return publicKey, nil
+}
+
+// setOffered records the files of the offered packages, before they are synced.
+func (p *packageManager) setOffered(available *protobufs.PackagesAvailable) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.offered = make(map[string]*protobufs.DownloadableFile, len(available.GetPackages()))
+	for name, pkg := range available.GetPackages() {
+		p.offered[name] = pkg.GetFile()
+	}
+}
+
+// agentExecutable returns the path of the installed top-level package, if any.
+func (p *packageManager) agentExecutable() (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, pkg := range p.state.Packages {
+		if pkg.Type == protobufs.PackageType_PackageType_TopLevel && pkg.ContentHash != "" {
+			return p.agentPath(), true
+		}
+	}
+
+	return "", false
+}
+
+func (p *packageManager) AllPackagesHash() ([]byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return hex.DecodeString(p.state.AllPackagesHash)
+}
+
+func (p *packageManager) SetAllPackagesHash(hash []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.state.AllPackagesHash = hex.EncodeToString(hash)
+	return p.writeState()
+}
+
+func (p *packageManager) Packages() ([]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	names := make([]string, 0, len(p.state.Packages))
+	for name := range p.state.Packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func (p *packageManager) PackageState(packageName string) (types.PackageState, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pkg, ok := p.state.Packages[packageName]
+	if !ok {
+		return types.PackageState{Exists: false}, nil
+	}
+
+	hash, err := hex.DecodeString(pkg.Hash)
+	if err != nil {
+		return types.PackageState{}, err
+	}
+
+	return types.PackageState{
+		Exists:  true,
+		Type:    pkg.Type,
+		Hash:    hash,
+		Version: pkg.Version,
+	}, nil
+}
+
+func (p *packageManager) SetPackageState(packageName string, state types.PackageState) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pkg, ok := p.state.Packages[packageName]
+	if !ok {
+		return fmt.Errorf("package %q does not exist", packageName)
+	}
+
+	pkg.Type = state.Type
+	pkg.Hash = hex.EncodeToString(state.Hash)
+	pkg.Version = state.Version
+
+	return p.writeState()
+}
+
+func (p *packageManager) CreatePackage(packageName string, typ protobufs.PackageType) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.state.Packages[packageName]; ok {
+		return fmt.Errorf("package %q already exists", packageName)
+	}
+
+	if typ == protobufs.PackageType_PackageType_TopLevel {
+		for name, pkg := range p.state.Packages {
+			if pkg.Type == protobufs.PackageType_PackageType_TopLevel {
+				return fmt.Errorf("cannot create top-level package %q, package %q is already the top-level package", packageName, name)
+			}
+		}
+	} else if packageName == "" {
+		return errors.New("addon packages must be named")
+	}
+
+	p.state.Packages[packageName] = &installedPackage{Type: typ}
+
+	return p.writeState()
+}
+
+func (p *packageManager) FileContentHash(packageName string) ([]byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pkg, ok := p.state.Packages[packageName]
+	if !ok {
+		return nil, nil
+	}
+
+	return hex.DecodeString(pkg.ContentHash)
+}
+
+// UpdateContent downloads the content of the package into a temporary file, verifies it, and
+// only then replaces the installed content with it.
+func (p *packageManager) UpdateContent(_ context.Context, packageName string, data io.Reader, contentHash []byte) error {
+	p.mu.Lock()
+	pkg, ok := p.state.Packages[packageName]
+	offered := p.offered[packageName]
+	p.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("package %q does not exist", packageName)
+	}
+
+	if len(contentHash) == 0 {
+		return fmt.Errorf("package %q has no content hash", packageName)
+	}
+
+	tmp, err := os.CreateTemp(p.dir, "download-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	hasher := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tmp, hasher), data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("could not download package %q: %w", packageName, err)
+	}
+
+	if err = p.verify(packageName, hasher.Sum(nil), contentHash, offered.GetSignature()); err != nil {
+		return err
+	}
+
+	path := p.packagePath(packageName, pkg.Type)
+	if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	if err = os.Chmod(tmp.Name(), 0700); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("could not install package %q: %w", packageName, err)
+	}
+
+	p.mu.Lock()
+	pkg.ContentHash = hex.EncodeToString(contentHash)
+	err = p.writeState()
+	p.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	p.logger.Info("Installed package", zap.String("package", packageName), zap.String("version", pkg.Version))
+	if pkg.Type == protobufs.PackageType_PackageType_TopLevel {
+		p.signalAgentUpdated()
+	}
+
+	return nil
+}
+
+// verify checks the SHA-256 hash of the downloaded content against the hash offered by the
+// server, and the signature of that hash unless unsigned packages are allowed.
+func (p *packageManager) verify(packageName string, hash, contentHash, signature []byte) error {
+	if !bytes.Equal(hash, contentHash) {
+		return fmt.Errorf("content hash mismatch for package %q: expected %x, got %x", packageName, contentHash, hash)
+	}
+
+	if p.publicKey == nil {
+		return nil
+	}
+
+	if len(signature) == 0 {
+		return fmt.Errorf("package %q is not signed", packageName)
+	}
+
+	if !ed25519.Verify(p.publicKey, hash, signature) {
+		return fmt.Errorf("invalid signature for package %q", packageName)
+	}
+
+	return nil
+}
+
+func (p *packageManager) DeletePackage(packageName string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pkg, ok := p.state.Packages[packageName]
+	if !ok {
+		return nil
+	}
+
+	if err := os.Remove(p.packagePath(packageName, pkg.Type)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	delete(p.state.Packages, packageName)
+	if err := p.writeState(); err != nil {
+		return err
+	}
+
+	p.logger.Info("Deleted package", zap.String("package", packageName))
+	if pkg.Type == protobufs.PackageType_PackageType_TopLevel && pkg.ContentHash != "" {
+		p.signalAgentUpdated()
+	}
+
+	return nil
+}
+
+func (p *packageManager) LastReportedStatuses() (*protobufs.PackageStatuses, error) {
+	by, err := os.ReadFile(filepath.Join(p.dir, lastReportedPackageStatuses))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return &protobufs.PackageStatuses{}, nil
+	case err != nil:
+		return nil, err
+	}
+
+	statuses := &protobufs.PackageStatuses{}
+	if err = proto.Unmarshal(by, statuses); err != nil {
+		return nil, err
+	}
+
+	return statuses, nil
+}
+
+func (p *packageManager) SetLastReportedStatuses(statuses *protobufs.PackageStatuses) error {
+	by, err := proto.Marshal(statuses)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(p.dir, lastReportedPackageStatuses), by, 0600)
+}
+
+func (p *packageManager) signalAgentUpdated() {
+	select {
+	case p.agentUpdated <- struct{}{}:
+	default:
+	}
+}
+
+func (p *packageManager) writeState() error {
+	by, err := yaml.Marshal(&p.state)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(p.stateFile(), by, 0600)
+}
+
+func (p *packageManager) stateFile() string {
+	return filepath.Join(p.dir, packagesStateFile)
+}
+
+func (p *packageManager) agentPath() string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(p.dir, "agent.exe")
+	}
+	return filepath.Join(p.dir, "agent")
+}
+
+func (p *packageManager) packagePath(packageName string, typ protobufs.PackageType) string {
+	if typ == protobufs.PackageType_PackageType_TopLevel {
+		return p.agentPath()
+	}
+	return filepath.Join(p.dir, "addons", url.PathEscape(packageName))
+}
@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/config"
+)
+
+func TestPackageManager_UpdateContent(t *testing.T) {
+	content := []byte("#!/bin/sh\necho collector\n")
+	contentHash := sha256.Sum256(content)
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		signed        bool
+		contentHash   []byte
+		signature     []byte
+		expectedError string
+	}{
+		{
+			name:        "Unsigned package allowed",
+			contentHash: contentHash[:],
+		},
+		{
+			name:          "Content hash mismatch",
+			contentHash:   []byte{0x01, 0x02},
+			expectedError: `content hash mismatch for package ""`,
+		},
+		{
+			name:        "Valid signature",
+			signed:      true,
+			contentHash: contentHash[:],
+			signature:   ed25519.Sign(privateKey, contentHash[:]),
+		},
+		{
+			name:          "Invalid signature",
+			signed:        true,
+			contentHash:   contentHash[:],
+			signature:     ed25519.Sign(privateKey, []byte("something else")),
+			expectedError: `invalid signature for package ""`,
+		},
+		{
+			name:          "Missing signature",
+			signed:        true,
+			contentHash:   contentHash[:],
+			expectedError: `package "" is not signed`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := config.Packages{AllowUnsigned: true}
+			if tc.signed {
+				cfg = config.Packages{PublicKeyFile: writePublicKey(t, publicKey)}
+			}
+
+			p, err := newPackageManager(zap.NewNop(), t.TempDir(), cfg)
+			require.NoError(t, err)
+
+			p.setOffered(&protobufs.PackagesAvailable{
+				Packages: map[string]*protobufs.PackageAvailable{
+					"": {
+						Type: protobufs.PackageType_PackageType_TopLevel,
+						File: &protobufs.DownloadableFile{
+							ContentHash: tc.contentHash,
+							Signature:   tc.signature,
+						},
+					},
+				},
+			})
+			require.NoError(t, p.CreatePackage("", protobufs.PackageType_PackageType_TopLevel))
+
+			err = p.UpdateContent(context.Background(), "", bytes.NewReader(content), tc.contentHash)
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+
+				_, ok := p.agentExecutable()
+				require.False(t, ok)
+				require.NoFileExists(t, p.agentPath())
+				return
+			}
+			require.NoError(t, err)
+
+			path, ok := p.agentExecutable()
+			require.True(t, ok)
+			by, err := os.ReadFile(path)
+			require.NoError(t, err)
+			require.Equal(t, content, by)
+
+			hash, err := p.FileContentHash("")
+			require.NoError(t, err)
+			require.Equal(t, tc.contentHash, hash)
+
+			select {
+			case <-p.agentUpdated:
+			default:
+				t.Fatal("expected the agent update to be signaled")
+			}
+		})
+	}
+}
+
+func TestPackageManager_State(t *testing.T) {
+	dir := t.TempDir()
+	p, err := newPackageManager(zap.NewNop(), dir, config.Packages{AllowUnsigned: true})
+	require.NoError(t, err)
+
+	require.NoError(t, p.CreatePackage("", protobufs.PackageType_PackageType_TopLevel))
+	require.NoError(t, p.CreatePackage("addon", protobufs.PackageType_PackageType_Addon))
+	require.ErrorContains(t, p.CreatePackage("other", protobufs.PackageType_PackageType_TopLevel), "is already the top-level package")
+	require.ErrorContains(t, p.CreatePackage("addon", protobufs.PackageType_PackageType_Addon), `package "addon" already exists`)
+
+	require.NoError(t, p.SetAllPackagesHash([]byte{0x01}))
+	require.NoError(t, p.SetPackageState("addon", types.PackageState{
+		Exists:  true,
+		Type:    protobufs.PackageType_PackageType_Addon,
+		Hash:    []byte{0x02},
+		Version: "1.0.0",
+	}))
+	require.Error(t, p.SetPackageState("unknown", types.PackageState{}))
+
+	addon := []byte("addon content")
+	addonHash := sha256.Sum256(addon)
+	require.NoError(t, p.UpdateContent(context.Background(), "addon", bytes.NewReader(addon), addonHash[:]))
+	require.FileExists(t, filepath.Join(dir, packagesDir, "addons", "addon"))
+
+	// Test that loading the state again has the packages that were created
+	loaded, err := newPackageManager(zap.NewNop(), dir, config.Packages{AllowUnsigned: true})
+	require.NoError(t, err)
+
+	hash, err := loaded.AllPackagesHash()
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x01}, hash)
+
+	names, err := loaded.Packages()
+	require.NoError(t, err)
+	require.Equal(t, []string{"", "addon"}, names)
+
+	state, err := loaded.PackageState("addon")
+	require.NoError(t, err)
+	require.Equal(t, types.PackageState{
+		Exists:  true,
+		Type:    protobufs.PackageType_PackageType_Addon,
+		Hash:    []byte{0x02},
+		Version: "1.0.0",
+	}, state)
+
+	state, err = loaded.PackageState("unknown")
+	require.NoError(t, err)
+	require.False(t, state.Exists)
+
+	require.NoError(t, loaded.DeletePackage("addon"))
+	require.NoFileExists(t, filepath.Join(dir, packagesDir, "addons", "addon"))
+	names, err = loaded.Packages()
+	require.NoError(t, err)
+	require.Equal(t, []string{""}, names)
+
+	// The top-level package has no content, so deleting it does not update the agent
+	require.NoError(t, loaded.DeletePackage(""))
+	require.Empty(t, loaded.agentUpdated)
+}
+
+func TestPackageManager_InvalidPublicKey(t *testing.T) {
+	f := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(f, []byte("not a key"), 0600))
+
+	_, err := newPackageManager(zap.NewNop(), t.TempDir(), config.Packages{PublicKeyFile: f})
+	require.ErrorContains(t, err, "could not load packages public key: no PEM block found")
+}
+
+func writePublicKey(t *testing.T, publicKey ed25519.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+
+	f := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(f, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+
+	return f
+}
@@ -4,8 +4,10 @@
 package supervisor
 
 import (
+	"encoding/hex"
 	"errors"
 	"os"
+	"sync"
 
 	"github.com/google/uuid"
 	"gopkg.in/yaml.v3"
@@ -15,16 +17,42 @@ import (
 type persistentState struct {
 	InstanceID uuid.UUID `yaml:"instance_id"`
 
+	// RolledBackConfigHash is the hex encoded hash of the last remote config
+	// the Supervisor rolled back, which is not applied again.
+	RolledBackConfigHash string `yaml:"rolled_back_config_hash,omitempty"`
+
+	// mu protects the fields against concurrent updates.
+	mu sync.Mutex `yaml:"-"`
+
 	// Path to the config file that the state should be saved to.
 	// This is not marshaled.
 	configPath string `yaml:"-"`
 }
 
 func (p *persistentState) SetInstanceID(id uuid.UUID) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	p.InstanceID = id
 	return p.writeState()
 }
 
+func (p *persistentState) SetRolledBackConfigHash(hash []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.RolledBackConfigHash = hex.EncodeToString(hash)
+	return p.writeState()
+}
+
+// IsRolledBackConfig returns whether the remote config with the given hash was rolled back.
+func (p *persistentState) IsRolledBackConfig(hash []byte) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(hash) != 0 && p.RolledBackConfigHash == hex.EncodeToString(hash)
+}
+
 func (p *persistentState) writeState() error {
 	by, err := yaml.Marshal(p)
 	if err != nil {
@@ -60,3 +60,25 @@ func TestPersistentState_SetInstanceID(t *testing.T) {
 
 	require.Equal(t, newUUID, loadedState.InstanceID)
 }
+
+func TestPersistentState_SetRolledBackConfigHash(t *testing.T) {
+	f := filepath.Join(t.TempDir(), "state.yaml")
+	state, err := createNewPersistentState(f)
+	require.NoError(t, err)
+
+	require.False(t, state.IsRolledBackConfig([]byte{0x01, 0x02}))
+	require.False(t, state.IsRolledBackConfig(nil))
+
+	err = state.SetRolledBackConfigHash([]byte{0x01, 0x02})
+	require.NoError(t, err)
+
+	require.True(t, state.IsRolledBackConfig([]byte{0x01, 0x02}))
+	require.False(t, state.IsRolledBackConfig([]byte{0x03}))
+
+	// Test that loading the state after rolling back a config still has the hash
+	loadedState, err := loadPersistentState(f)
+	require.NoError(t, err)
+
+	require.True(t, loadedState.IsRolledBackConfig([]byte{0x01, 0x02}))
+	require.Equal(t, state.InstanceID, loadedState.InstanceID)
+}
@@ -55,8 +55,10 @@ var (
 	//go:embed templates/owntelemetry.yaml
 	ownTelemetryTpl string
 
-	lastRecvRemoteConfigFile     = "last_recv_remote_config.dat"
-	lastRecvOwnMetricsConfigFile = "last_recv_own_metrics_config.dat"
+	lastRecvRemoteConfigFile      = "last_recv_remote_config.dat"
+	lastRecvOwnMetricsConfigFile  = "last_recv_own_metrics_config.dat"
+	lastKnownGoodConfigFile       = "last_known_good_config.yaml"
+	lastKnownGoodRemoteConfigFile = "last_known_good_remote_config.dat"
 )
 
 const (
@@ -108,9 +110,20 @@ type Supervisor struct {
 	// Final effective config of the Collector.
 	effectiveConfig *atomic.Value
 
+	// remoteConfigMu protects remoteConfig and the composition of mergedConfig, as the
+	// config can be rolled back while messages from the OpAMP Server are processed.
+	remoteConfigMu sync.Mutex
+
 	// Last received remote config.
 	remoteConfig *protobufs.AgentRemoteConfig
 
+	// The config last applied to the agent, until the config apply timeout fires.
+	// Only accessed by the goroutine running the agent process.
+	pendingConfigApply *configApply
+
+	// Installs the packages offered by the OpAMP Server, nil unless packages are accepted.
+	packageManager *packageManager
+
 	// A channel to indicate there is a new config to apply.
 	hasNewConfig chan struct{}
 
@@ -131,6 +144,32 @@ type Supervisor struct {
 	opampServerPort int
 }
 
+// configApply is a config applied to the agent. It becomes the last known-good config
+// once the agent is healthy with it.
+type configApply struct {
+	mergedConfig string
+	remoteConfig *protobufs.AgentRemoteConfig
+	// becameHealthy is set once the agent is healthy with the config.
+	becameHealthy bool
+	// failedAfterHealthy is set if the agent is not healthy anymore after becoming healthy with the config.
+	failedAfterHealthy bool
+}
+
+// observeHealth records the result of a health check of the agent running with the config.
+func (c *configApply) observeHealth(healthy bool) {
+	switch {
+	case healthy:
+		c.becameHealthy = true
+	case c.becameHealthy:
+		c.failedAfterHealthy = true
+	}
+}
+
+// isKnownGood returns whether the agent became healthy with the config and stayed healthy since.
+func (c *configApply) isKnownGood() bool {
+	return c.becameHealthy && !c.failedAfterHealthy
+}
+
 func NewSupervisor(logger *zap.Logger, configFile string) (*Supervisor, error) {
 	s := &Supervisor{
 		logger:                       logger,
@@ -165,6 +204,13 @@ func NewSupervisor(logger *zap.Logger, configFile string) (*Supervisor, error) {
 		return nil, err
 	}
 
+	if s.config.Capabilities.AcceptsPackages {
+		s.packageManager, err = newPackageManager(logger, s.config.Storage.Directory, s.config.Packages)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if err = s.getBootstrapInfo(); err != nil {
 		return nil, fmt.Errorf("could not get bootstrap info from the Collector: %w", err)
 	}
@@ -195,7 +241,7 @@ func NewSupervisor(logger *zap.Logger, configFile string) (*Supervisor, error) {
 
 	s.commander, err = commander.NewCommander(
 		s.logger,
-		s.config.Agent,
+		s.agentConfig(),
 		"--config", agentConfigFilePath,
 	)
 	if err != nil {
@@ -213,6 +259,18 @@ func NewSupervisor(logger *zap.Logger, configFile string) (*Supervisor, error) {
 	return s, nil
 }
 
+// agentConfig returns the config of the agent, whose executable is the one installed
+// from the top-level package offered by the OpAMP Server, if any.
+func (s *Supervisor) agentConfig() config.Agent {
+	cfg := s.config.Agent
+	if s.packageManager != nil {
+		if executable, ok := s.packageManager.agentExecutable(); ok {
+			cfg.Executable = executable
+		}
+	}
+	return cfg
+}
+
 func (s *Supervisor) createTemplates() error {
 	var err error
 
@@ -329,7 +387,7 @@ func (s *Supervisor) getBootstrapInfo() (err error) {
 
 	cmd, err := commander.NewCommander(
 		s.logger,
-		s.config.Agent,
+		s.agentConfig(),
 		"--config", agentConfigFilePath,
 	)
 	if err != nil {
@@ -418,6 +476,9 @@ func (s *Supervisor) startOpAMPClient() error {
 		},
 		Capabilities: s.config.Capabilities.SupportedCapabilities(),
 	}
+	if s.packageManager != nil {
+		settings.PackagesStateProvider = s.packageManager
+	}
 	ad := s.agentDescription.Load().(*protobufs.AgentDescription)
 	if err = s.opampClient.SetAgentDescription(ad); err != nil {
 		return err
@@ -920,9 +981,11 @@ func (s *Supervisor) startHealthCheckTicker() {
 	s.healthCheckTicker = backoff.NewTicker(healthCheckBackoff)
 }
 
-func (s *Supervisor) healthCheck() {
+// healthCheck checks the health of the agent, reports it to the OpAMP Server when
+// it changed, and returns whether the agent is healthy.
+func (s *Supervisor) healthCheck() bool {
 	if !s.commander.IsRunning() {
-		return
+		return false
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
@@ -932,7 +995,7 @@ func (s *Supervisor) healthCheck() {
 
 	if errors.Is(err, s.lastHealthCheckErr) {
 		// No difference from last check. Nothing new to report.
-		return
+		return err == nil
 	}
 
 	// Prepare OpAMP health report.
@@ -958,29 +1021,54 @@ func (s *Supervisor) healthCheck() {
 	// Report via OpAMP.
 	if err2 := s.opampClient.SetHealth(health); err2 != nil {
 		s.logger.Error("Could not report health to OpAMP server", zap.Error(err2))
-		return
+		return err == nil
 	}
 
 	s.lastHealthCheckErr = err
+	return err == nil
 }
 
 func (s *Supervisor) runAgentProcess() {
-	if _, err := os.Stat(agentConfigFilePath); err == nil {
+	restartTimer := time.NewTimer(0)
+	restartTimer.Stop()
+
+	// Fires when the agent did not become healthy in time after a new config was applied.
+	configApplyTimer := time.NewTimer(0)
+	configApplyTimer.Stop()
+
+	if effectiveConfig, err := os.ReadFile(agentConfigFilePath); err == nil {
 		// We have an effective config file saved previously. Use it to start the agent.
 		s.logger.Debug("Effective config found, starting agent initial time")
 		s.startAgent()
+
+		// The initial config becomes the known-good config like any applied config.
+		s.remoteConfigMu.Lock()
+		applied := &configApply{
+			mergedConfig: string(effectiveConfig),
+			remoteConfig: s.remoteConfig,
+		}
+		s.remoteConfigMu.Unlock()
+		s.trackConfigApply(applied, configApplyTimer)
 	}
 
-	restartTimer := time.NewTimer(0)
-	restartTimer.Stop()
+	var agentUpdated <-chan struct{}
+	if s.packageManager != nil {
+		agentUpdated = s.packageManager.agentUpdated
+	}
 
 	for {
 		select {
 		case <-s.hasNewConfig:
 			s.logger.Debug("Restarting agent due to new config")
 			restartTimer.Stop()
-			s.stopAgentApplyConfig()
+			applied := s.stopAgentApplyConfig()
 			s.startAgent()
+			s.trackConfigApply(applied, configApplyTimer)
+
+		case <-agentUpdated:
+			s.logger.Debug("Restarting agent due to new agent executable")
+			restartTimer.Stop()
+			s.restartAgentWithNewExecutable()
 
 		case <-s.commander.Exited():
 			// the agent process exit is expected for restart command and will not attempt to restart
@@ -1016,7 +1104,13 @@ func (s *Supervisor) runAgentProcess() {
 			s.startAgent()
 
 		case <-s.healthCheckTicker.C:
-			s.healthCheck()
+			healthy := s.healthCheck()
+			if s.pendingConfigApply != nil {
+				s.pendingConfigApply.observeHealth(healthy)
+			}
+
+		case <-configApplyTimer.C:
+			s.completeConfigApply()
 
 		case <-s.doneChan:
 			err := s.commander.Stop(context.Background())
@@ -1028,18 +1122,180 @@ func (s *Supervisor) runAgentProcess() {
 	}
 }
 
-func (s *Supervisor) stopAgentApplyConfig() {
+// trackConfigApply sets the config the agent was started with as pending until the config apply
+// timeout fires.
+func (s *Supervisor) trackConfigApply(applied *configApply, configApplyTimer *time.Timer) {
+	if s.config.Agent.ConfigApplyTimeout <= 0 {
+		return
+	}
+	s.pendingConfigApply = applied
+	if !configApplyTimer.Stop() {
+		select {
+		case <-configApplyTimer.C: // Try to drain the channel
+		default:
+		}
+	}
+	configApplyTimer.Reset(s.config.Agent.ConfigApplyTimeout)
+}
+
+// stopAgentApplyConfig stops the agent and writes the merged config for its next start,
+// and returns the applied config.
+func (s *Supervisor) stopAgentApplyConfig() *configApply {
 	s.logger.Debug("Stopping the agent to apply new config")
-	cfg := s.mergedConfig.Load().(string)
+	s.remoteConfigMu.Lock()
+	applied := &configApply{
+		mergedConfig: s.mergedConfig.Load().(string),
+		remoteConfig: s.remoteConfig,
+	}
+	s.remoteConfigMu.Unlock()
 	err := s.commander.Stop(context.Background())
 
 	if err != nil {
 		s.logger.Error("Could not stop agent process", zap.Error(err))
 	}
 
-	if err := os.WriteFile(agentConfigFilePath, []byte(cfg), 0600); err != nil {
+	if err := os.WriteFile(agentConfigFilePath, []byte(applied.mergedConfig), 0600); err != nil {
 		s.logger.Error("Failed to write agent config.", zap.Error(err))
 	}
+
+	return applied
+}
+
+// completeConfigApply saves the pending config as the last known-good config if the agent became
+// healthy with it and stayed healthy until the config apply timeout, and rolls it back otherwise.
+// Saving the config as soon as the agent is healthy would not catch the configs the agent fails
+// with shortly after starting.
+func (s *Supervisor) completeConfigApply() {
+	if s.pendingConfigApply == nil {
+		return
+	}
+	if s.pendingConfigApply.isKnownGood() {
+		s.saveKnownGoodConfig()
+		return
+	}
+	s.rollbackConfig()
+}
+
+// saveKnownGoodConfig persists the config the agent was healthy with as the
+// last known-good config, and reports its remote config as applied.
+func (s *Supervisor) saveKnownGoodConfig() {
+	applied := s.pendingConfigApply
+	s.pendingConfigApply = nil
+
+	s.logger.Debug("Agent is healthy with the applied config, saving it as the last known-good config")
+	if err := os.WriteFile(filepath.Join(s.config.Storage.Directory, lastKnownGoodConfigFile), []byte(applied.mergedConfig), 0600); err != nil {
+		s.logger.Error("Could not save the last known-good config", zap.Error(err))
+		return
+	}
+
+	if applied.remoteConfig == nil {
+		err := os.Remove(filepath.Join(s.config.Storage.Directory, lastKnownGoodRemoteConfigFile))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			s.logger.Error("Could not remove the last known-good remote config", zap.Error(err))
+		}
+		return
+	}
+
+	if err := s.saveRemoteConfig(applied.remoteConfig, lastKnownGoodRemoteConfigFile); err != nil {
+		s.logger.Error("Could not save the last known-good remote config", zap.Error(err))
+	}
+	s.reportRemoteConfigStatus(applied.remoteConfig.ConfigHash, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, "")
+}
+
+// rollbackConfig restarts the agent with the last known-good config when the agent
+// did not become healthy with the applied config.
+func (s *Supervisor) rollbackConfig() {
+	applied := s.pendingConfigApply
+	if applied == nil {
+		return
+	}
+	s.pendingConfigApply = nil
+
+	if !s.restoreKnownGoodConfig(applied) {
+		return
+	}
+
+	s.stopAgentApplyConfig()
+	s.startAgent()
+
+	if err := s.opampClient.UpdateEffectiveConfig(context.Background()); err != nil {
+		s.logger.Error("The OpAMP client failed to update the effective config", zap.Error(err))
+	}
+}
+
+// restoreKnownGoodConfig restores the last known-good config in place of the applied
+// config, reports the remote config of the applied config as failed, and returns whether
+// there was a known-good config to restore. The rolled back remote config is not applied
+// again if the OpAMP Server offers it again.
+func (s *Supervisor) restoreKnownGoodConfig(applied *configApply) bool {
+	var failedHash []byte
+	if applied.remoteConfig != nil {
+		failedHash = applied.remoteConfig.ConfigHash
+	}
+	errMsg := fmt.Sprintf("agent was not healthy %s after applying the config", s.config.Agent.ConfigApplyTimeout)
+
+	knownGoodConfig, err := os.ReadFile(filepath.Join(s.config.Storage.Directory, lastKnownGoodConfigFile))
+	if err != nil {
+		s.logger.Error("Agent is not healthy with the applied config, and there is no known-good config to roll back to", zap.Error(err))
+		s.reportRemoteConfigStatus(failedHash, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, errMsg)
+		return false
+	}
+
+	var knownGoodRemoteConfig *protobufs.AgentRemoteConfig
+	if by, readErr := os.ReadFile(filepath.Join(s.config.Storage.Directory, lastKnownGoodRemoteConfigFile)); readErr == nil {
+		knownGoodRemoteConfig = &protobufs.AgentRemoteConfig{}
+		if err = proto.Unmarshal(by, knownGoodRemoteConfig); err != nil {
+			s.logger.Error("Cannot parse the last known-good remote config", zap.Error(err))
+			knownGoodRemoteConfig = nil
+		}
+	}
+
+	s.logger.Warn("Agent is not healthy with the applied config, rolling back to the last known-good config",
+		zap.String("hash", fmt.Sprintf("%x", failedHash)))
+
+	s.remoteConfigMu.Lock()
+	s.remoteConfig = knownGoodRemoteConfig
+	s.mergedConfig.Store(string(knownGoodConfig))
+	s.remoteConfigMu.Unlock()
+
+	// The Supervisor starts with the known-good config from now on.
+	if knownGoodRemoteConfig != nil {
+		err = s.saveRemoteConfig(knownGoodRemoteConfig, lastRecvRemoteConfigFile)
+	} else {
+		err = os.Remove(filepath.Join(s.config.Storage.Directory, lastRecvRemoteConfigFile))
+		if errors.Is(err, os.ErrNotExist) {
+			err = nil
+		}
+	}
+	if err != nil {
+		s.logger.Error("Could not save the rolled back remote config", zap.Error(err))
+	}
+
+	// The remote config isn't at fault when the agent was healthy with it before, e.g. when
+	// the own metrics config changed.
+	if len(failedHash) == 0 || (knownGoodRemoteConfig != nil && bytes.Equal(knownGoodRemoteConfig.ConfigHash, failedHash)) {
+		return true
+	}
+
+	if err = s.persistentState.SetRolledBackConfigHash(failedHash); err != nil {
+		s.logger.Error("Could not persist the hash of the rolled back config", zap.Error(err))
+	}
+	s.reportRemoteConfigStatus(failedHash, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, errMsg+", rolled back to the last known-good config")
+
+	return true
+}
+
+// restartAgentWithNewExecutable restarts the agent with the executable installed from the
+// top-level package, or with the configured executable if the package was deleted.
+func (s *Supervisor) restartAgentWithNewExecutable() {
+	if err := s.commander.Stop(context.Background()); err != nil {
+		s.logger.Error("Could not stop agent process", zap.Error(err))
+	}
+
+	// The commander is kept, as it is used by the restart command concurrently.
+	s.commander.SetExecutable(s.agentConfig().Executable)
+
+	s.startAgent()
 }
 
 func (s *Supervisor) Shutdown() {
@@ -1085,13 +1341,24 @@ func (s *Supervisor) Shutdown() {
 	}
 }
 
-func (s *Supervisor) saveLastReceivedConfig(config *protobufs.AgentRemoteConfig) error {
+func (s *Supervisor) saveRemoteConfig(config *protobufs.AgentRemoteConfig, filePath string) error {
 	cfg, err := proto.Marshal(config)
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(filepath.Join(s.config.Storage.Directory, lastRecvRemoteConfigFile), cfg, 0600)
+	return os.WriteFile(filepath.Join(s.config.Storage.Directory, filePath), cfg, 0600)
+}
+
+func (s *Supervisor) reportRemoteConfigStatus(hash []byte, status protobufs.RemoteConfigStatuses, errMsg string) {
+	err := s.opampClient.SetRemoteConfigStatus(&protobufs.RemoteConfigStatus{
+		LastRemoteConfigHash: hash,
+		Status:               status,
+		ErrorMessage:         errMsg,
+	})
+	if err != nil {
+		s.logger.Error("Could not report OpAMP remote config status", zap.String("status", status.String()), zap.Error(err))
+	}
 }
 
 func (s *Supervisor) saveLastReceivedOwnTelemetrySettings(set *protobufs.TelemetryConnectionSettings, filePath string) error {
@@ -1104,9 +1371,16 @@ func (s *Supervisor) saveLastReceivedOwnTelemetrySettings(set *protobufs.Telemet
 }
 
 func (s *Supervisor) onMessage(ctx context.Context, msg *types.MessageData) {
+	s.remoteConfigMu.Lock()
+	defer s.remoteConfigMu.Unlock()
+
 	configChanged := false
-	if msg.RemoteConfig != nil {
-		if err := s.saveLastReceivedConfig(msg.RemoteConfig); err != nil {
+	if msg.RemoteConfig != nil && s.persistentState.IsRolledBackConfig(msg.RemoteConfig.ConfigHash) {
+		s.logger.Debug("Ignoring remote config that was rolled back", zap.String("hash", fmt.Sprintf("%x", msg.RemoteConfig.ConfigHash)))
+		s.reportRemoteConfigStatus(msg.RemoteConfig.ConfigHash, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED,
+			"the agent was not healthy with this config, it was rolled back to the last known-good config")
+	} else if msg.RemoteConfig != nil {
+		if err := s.saveRemoteConfig(msg.RemoteConfig, lastRecvRemoteConfigFile); err != nil {
 			s.logger.Error("Could not save last received remote config", zap.Error(err))
 		}
 		s.remoteConfig = msg.RemoteConfig
@@ -1114,24 +1388,23 @@ func (s *Supervisor) onMessage(ctx context.Context, msg *types.MessageData) {
 
 		var err error
 		configChanged, err = s.composeMergedConfig(s.remoteConfig)
-		if err != nil {
+		switch {
+		case err != nil:
 			s.logger.Error("Error composing merged config. Reporting failed remote config status.", zap.Error(err))
-			err = s.opampClient.SetRemoteConfigStatus(&protobufs.RemoteConfigStatus{
-				LastRemoteConfigHash: msg.RemoteConfig.ConfigHash,
-				Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED,
-				ErrorMessage:         err.Error(),
-			})
-			if err != nil {
-				s.logger.Error("Could not report failed OpAMP remote config status", zap.Error(err))
-			}
-		} else {
-			err = s.opampClient.SetRemoteConfigStatus(&protobufs.RemoteConfigStatus{
-				LastRemoteConfigHash: msg.RemoteConfig.ConfigHash,
-				Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED,
-			})
-			if err != nil {
-				s.logger.Error("Could not report applied OpAMP remote config status", zap.Error(err))
-			}
+			s.reportRemoteConfigStatus(msg.RemoteConfig.ConfigHash, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, err.Error())
+		case configChanged && s.config.Agent.ConfigApplyTimeout > 0:
+			// The config is reported as applied once the agent is healthy with it.
+			s.reportRemoteConfigStatus(msg.RemoteConfig.ConfigHash, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLYING, "")
+		default:
+			s.reportRemoteConfigStatus(msg.RemoteConfig.ConfigHash, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, "")
+		}
+	}
+
+	if msg.PackagesAvailable != nil && msg.PackageSyncer != nil && s.packageManager != nil {
+		s.logger.Debug("Received packages from server", zap.String("hash", fmt.Sprintf("%x", msg.PackagesAvailable.AllPackagesHash)))
+		s.packageManager.setOffered(msg.PackagesAvailable)
+		if err := msg.PackageSyncer.Sync(ctx); err != nil {
+			s.logger.Error("Could not sync the packages offered by the server", zap.Error(err))
 		}
 	}
 
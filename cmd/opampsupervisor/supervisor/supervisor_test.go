@@ -7,8 +7,10 @@ import (
 	"bytes"
 	"context"
 	"os"
+	"path/filepath"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/open-telemetry/opamp-go/client"
@@ -142,6 +144,150 @@ func Test_onMessage(t *testing.T) {
 	})
 }
 
+func Test_restoreKnownGoodConfig(t *testing.T) {
+	t.Run("Rolls back to the last known-good config", func(t *testing.T) {
+		storageDir := t.TempDir()
+		state, err := createNewPersistentState(filepath.Join(storageDir, persistentStateFilePath))
+		require.NoError(t, err)
+
+		s := Supervisor{
+			logger:          zap.NewNop(),
+			config:          config.Supervisor{Storage: config.Storage{Directory: storageDir}},
+			persistentState: state,
+			mergedConfig:    &atomic.Value{},
+			opampClient:     client.NewHTTP(newLoggerFromZap(zap.NewNop())),
+		}
+		s.mergedConfig.Store("bad config")
+
+		s.pendingConfigApply = &configApply{mergedConfig: "good config"}
+		s.saveKnownGoodConfig()
+
+		badRemoteConfig := &protobufs.AgentRemoteConfig{ConfigHash: []byte{0x01, 0x02}}
+		require.True(t, s.restoreKnownGoodConfig(&configApply{
+			mergedConfig: "bad config",
+			remoteConfig: badRemoteConfig,
+		}))
+
+		require.Equal(t, "good config", s.mergedConfig.Load().(string))
+		require.Nil(t, s.remoteConfig)
+		require.True(t, s.persistentState.IsRolledBackConfig(badRemoteConfig.ConfigHash))
+
+		// The rolled back config is not applied again when the server sends it
+		s.onMessage(context.Background(), &types.MessageData{RemoteConfig: badRemoteConfig})
+		require.Nil(t, s.remoteConfig)
+		require.Equal(t, "good config", s.mergedConfig.Load().(string))
+	})
+
+	t.Run("No known-good config", func(t *testing.T) {
+		storageDir := t.TempDir()
+		state, err := createNewPersistentState(filepath.Join(storageDir, persistentStateFilePath))
+		require.NoError(t, err)
+
+		s := Supervisor{
+			logger:          zap.NewNop(),
+			config:          config.Supervisor{Storage: config.Storage{Directory: storageDir}},
+			persistentState: state,
+			mergedConfig:    &atomic.Value{},
+			opampClient:     client.NewHTTP(newLoggerFromZap(zap.NewNop())),
+		}
+		s.mergedConfig.Store("bad config")
+
+		require.False(t, s.restoreKnownGoodConfig(&configApply{
+			mergedConfig: "bad config",
+			remoteConfig: &protobufs.AgentRemoteConfig{ConfigHash: []byte{0x01, 0x02}},
+		}))
+		require.Equal(t, "bad config", s.mergedConfig.Load().(string))
+		require.False(t, s.persistentState.IsRolledBackConfig([]byte{0x01, 0x02}))
+	})
+}
+
+func Test_trackConfigApply(t *testing.T) {
+	applied := &configApply{mergedConfig: "config"}
+
+	t.Run("Tracks the applied config until the timeout", func(t *testing.T) {
+		s := Supervisor{config: config.Supervisor{Agent: config.Agent{ConfigApplyTimeout: time.Millisecond}}}
+		configApplyTimer := time.NewTimer(time.Hour)
+
+		s.trackConfigApply(applied, configApplyTimer)
+
+		require.Equal(t, applied, s.pendingConfigApply)
+		select {
+		case <-configApplyTimer.C:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the config apply timer did not fire")
+		}
+	})
+
+	t.Run("No config apply timeout", func(t *testing.T) {
+		s := Supervisor{}
+		configApplyTimer := time.NewTimer(0)
+		configApplyTimer.Stop()
+
+		s.trackConfigApply(applied, configApplyTimer)
+
+		require.Nil(t, s.pendingConfigApply)
+	})
+}
+
+func Test_completeConfigApply(t *testing.T) {
+	newSupervisor := func(t *testing.T) *Supervisor {
+		storageDir := t.TempDir()
+		state, err := createNewPersistentState(filepath.Join(storageDir, persistentStateFilePath))
+		require.NoError(t, err)
+		s := &Supervisor{
+			logger:          zap.NewNop(),
+			config:          config.Supervisor{Storage: config.Storage{Directory: storageDir}},
+			persistentState: state,
+			mergedConfig:    &atomic.Value{},
+			opampClient:     client.NewHTTP(newLoggerFromZap(zap.NewNop())),
+		}
+		s.mergedConfig.Store("config")
+		return s
+	}
+
+	t.Run("Saves the config the agent stayed healthy with", func(t *testing.T) {
+		s := newSupervisor(t)
+		s.pendingConfigApply = &configApply{mergedConfig: "config"}
+		// the agent is not healthy while starting
+		s.pendingConfigApply.observeHealth(false)
+		s.pendingConfigApply.observeHealth(true)
+		s.pendingConfigApply.observeHealth(true)
+
+		s.completeConfigApply()
+
+		require.Nil(t, s.pendingConfigApply)
+		by, err := os.ReadFile(filepath.Join(s.config.Storage.Directory, lastKnownGoodConfigFile))
+		require.NoError(t, err)
+		require.Equal(t, "config", string(by))
+	})
+
+	t.Run("Does not save the config the agent failed with after being healthy", func(t *testing.T) {
+		s := newSupervisor(t)
+		s.pendingConfigApply = &configApply{mergedConfig: "config"}
+		s.pendingConfigApply.observeHealth(true)
+		s.pendingConfigApply.observeHealth(false)
+		s.pendingConfigApply.observeHealth(true)
+
+		s.completeConfigApply()
+
+		require.Nil(t, s.pendingConfigApply)
+		_, err := os.Stat(filepath.Join(s.config.Storage.Directory, lastKnownGoodConfigFile))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("Does not save the config the agent never became healthy with", func(t *testing.T) {
+		s := newSupervisor(t)
+		s.pendingConfigApply = &configApply{mergedConfig: "config"}
+		s.pendingConfigApply.observeHealth(false)
+
+		s.completeConfigApply()
+
+		require.Nil(t, s.pendingConfigApply)
+		_, err := os.Stat(filepath.Join(s.config.Storage.Directory, lastKnownGoodConfigFile))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
 type staticPIDProvider int
 
 func (s staticPIDProvider) PID() int {
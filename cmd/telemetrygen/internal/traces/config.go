@@ -23,6 +23,13 @@ type Config struct {
 	LoadSize         int
 
 	SpanDuration time.Duration
+
+	// Replay mode
+	ReplayFiles   []string
+	ReplaySpeedup float64
+
+	// Scenario mode
+	ScenarioFile string
 }
 
 // Flags registers config flags.
@@ -39,4 +46,8 @@ func (c *Config) Flags(fs *pflag.FlagSet) {
 	fs.BoolVar(&c.Batch, "batch", true, "Whether to batch traces")
 	fs.IntVar(&c.LoadSize, "size", 0, "Desired minimum size in MB of string data for each trace generated. This can be used to test traces with large payloads, i.e. when testing the OTLP receiver endpoint max receive size.")
 	fs.DurationVar(&c.SpanDuration, "span-duration", 123*time.Microsecond, "The duration of each generated span.")
+
+	fs.StringSliceVar(&c.ReplayFiles, "replay-file", nil, "OTLP JSON files with recorded traces to replay instead of generating traces. Timestamps and IDs are rewritten on every replay.")
+	fs.Float64Var(&c.ReplaySpeedup, "replay-speedup", 1, "How much faster than recorded the traces are replayed, e.g. 2 replays the traces twice as fast (only used with replay-file)")
+	fs.StringVar(&c.ScenarioFile, "scenario", "", "YAML file describing a multi-service call graph to generate traces from, instead of the fixed shape of generated traces")
 }
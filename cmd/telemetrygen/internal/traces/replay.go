@@ -0,0 +1,350 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package traces
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+)
+
+// recordedTrace holds the spans of a single recorded trace and the time range they cover.
+type recordedTrace struct {
+	traces ptrace.Traces
+	start  pcommon.Timestamp
+	end    pcommon.Timestamp
+}
+
+// traceGroup collects the spans of a trace spread across the recorded requests.
+type traceGroup struct {
+	trace     recordedTrace
+	resources map[int]ptrace.ResourceSpans
+	scopes    map[[2]int]ptrace.ScopeSpans
+}
+
+// loadRecording reads the traces of the given OTLP JSON files. A file may hold a single
+// request or several consecutive requests, like the ones written by the file exporter.
+// The spans are grouped by trace, and the traces are sorted by start time.
+func loadRecording(files []string) ([]recordedTrace, error) {
+	groups := map[pcommon.TraceID]*traceGroup{}
+	var resourceIndex int
+
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open the recorded traces: %w", err)
+		}
+
+		dec := json.NewDecoder(f)
+		unmarshaler := ptrace.JSONUnmarshaler{}
+		for {
+			var raw json.RawMessage
+			if err = dec.Decode(&raw); errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				_ = f.Close()
+				return nil, fmt.Errorf("failed to read the recorded traces from %q: %w", file, err)
+			}
+
+			td, err := unmarshaler.UnmarshalTraces(raw)
+			if err != nil {
+				_ = f.Close()
+				return nil, fmt.Errorf("failed to parse the recorded traces from %q: %w", file, err)
+			}
+
+			for i := 0; i < td.ResourceSpans().Len(); i++ {
+				groupSpans(groups, td.ResourceSpans().At(i), resourceIndex)
+				resourceIndex++
+			}
+		}
+
+		if err = f.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	recording := make([]recordedTrace, 0, len(groups))
+	for _, group := range groups {
+		recording = append(recording, group.trace)
+	}
+	sort.Slice(recording, func(i, j int) bool {
+		return recording[i].start < recording[j].start
+	})
+
+	return recording, nil
+}
+
+func groupSpans(groups map[pcommon.TraceID]*traceGroup, rs ptrace.ResourceSpans, resourceIndex int) {
+	for j := 0; j < rs.ScopeSpans().Len(); j++ {
+		ss := rs.ScopeSpans().At(j)
+		for k := 0; k < ss.Spans().Len(); k++ {
+			span := ss.Spans().At(k)
+
+			group, ok := groups[span.TraceID()]
+			if !ok {
+				group = &traceGroup{
+					trace: recordedTrace{
+						traces: ptrace.NewTraces(),
+						start:  span.StartTimestamp(),
+						end:    span.EndTimestamp(),
+					},
+					resources: map[int]ptrace.ResourceSpans{},
+					scopes:    map[[2]int]ptrace.ScopeSpans{},
+				}
+				groups[span.TraceID()] = group
+			}
+
+			scopeSpans, ok := group.scopes[[2]int{resourceIndex, j}]
+			if !ok {
+				resourceSpans, ok := group.resources[resourceIndex]
+				if !ok {
+					resourceSpans = group.trace.traces.ResourceSpans().AppendEmpty()
+					rs.Resource().CopyTo(resourceSpans.Resource())
+					resourceSpans.SetSchemaUrl(rs.SchemaUrl())
+					group.resources[resourceIndex] = resourceSpans
+				}
+
+				scopeSpans = resourceSpans.ScopeSpans().AppendEmpty()
+				ss.Scope().CopyTo(scopeSpans.Scope())
+				scopeSpans.SetSchemaUrl(ss.SchemaUrl())
+				group.scopes[[2]int{resourceIndex, j}] = scopeSpans
+			}
+
+			span.CopyTo(scopeSpans.Spans().AppendEmpty())
+			if span.StartTimestamp() < group.trace.start {
+				group.trace.start = span.StartTimestamp()
+			}
+			if span.EndTimestamp() > group.trace.end {
+				group.trace.end = span.EndTimestamp()
+			}
+		}
+	}
+}
+
+// scheduledTrace is a recorded trace along with when it starts, relative to the start of
+// the replay, and how long it lasts.
+type scheduledTrace struct {
+	recordedTrace
+	offset   time.Duration
+	duration time.Duration
+}
+
+// schedule spreads the recorded traces according to the speed-up. Only the gaps between the
+// traces are shortened, the spans keep their recorded durations. The traces are sorted by
+// the time they end, which is when they are emitted.
+func schedule(recording []recordedTrace, speedup float64) []scheduledTrace {
+	scheduled := make([]scheduledTrace, len(recording))
+	for i, t := range recording {
+		scheduled[i] = scheduledTrace{
+			recordedTrace: t,
+			offset:        time.Duration(float64(t.start-recording[0].start) / speedup),
+			duration:      time.Duration(t.end - t.start),
+		}
+	}
+	sort.SliceStable(scheduled, func(i, j int) bool {
+		return scheduled[i].offset+scheduled[i].duration < scheduled[j].offset+scheduled[j].duration
+	})
+	return scheduled
+}
+
+// idRewriter assigns new random IDs to the recorded traces and spans, consistently within a
+// single replay, so that the parent-child relationships and links are kept.
+type idRewriter struct {
+	rand     *rand.Rand
+	traceIDs map[pcommon.TraceID]pcommon.TraceID
+	spanIDs  map[spanKey]pcommon.SpanID
+}
+
+type spanKey struct {
+	traceID pcommon.TraceID
+	spanID  pcommon.SpanID
+}
+
+func newIDRewriter(r *rand.Rand) *idRewriter {
+	return &idRewriter{
+		rand:     r,
+		traceIDs: map[pcommon.TraceID]pcommon.TraceID{},
+		spanIDs:  map[spanKey]pcommon.SpanID{},
+	}
+}
+
+func (r *idRewriter) traceID(id pcommon.TraceID) pcommon.TraceID {
+	if id.IsEmpty() {
+		return id
+	}
+	newID, ok := r.traceIDs[id]
+	if !ok {
+		newID = newTraceID(r.rand)
+		r.traceIDs[id] = newID
+	}
+	return newID
+}
+
+func (r *idRewriter) spanID(traceID pcommon.TraceID, id pcommon.SpanID) pcommon.SpanID {
+	if id.IsEmpty() {
+		return id
+	}
+	key := spanKey{traceID: traceID, spanID: id}
+	newID, ok := r.spanIDs[key]
+	if !ok {
+		newID = newSpanID(r.rand)
+		r.spanIDs[key] = newID
+	}
+	return newID
+}
+
+// rewriteTraces shifts all the timestamps of the traces by delta nanoseconds and, unless ids
+// is nil, replaces their trace and span IDs.
+func rewriteTraces(td ptrace.Traces, delta int64, ids *idRewriter) {
+	shift := func(ts pcommon.Timestamp) pcommon.Timestamp {
+		return pcommon.Timestamp(int64(ts) + delta)
+	}
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		sss := rss.At(i).ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				span.SetStartTimestamp(shift(span.StartTimestamp()))
+				span.SetEndTimestamp(shift(span.EndTimestamp()))
+				for l := 0; l < span.Events().Len(); l++ {
+					event := span.Events().At(l)
+					event.SetTimestamp(shift(event.Timestamp()))
+				}
+
+				if ids == nil {
+					continue
+				}
+
+				traceID := span.TraceID()
+				span.SetTraceID(ids.traceID(traceID))
+				span.SetSpanID(ids.spanID(traceID, span.SpanID()))
+				span.SetParentSpanID(ids.spanID(traceID, span.ParentSpanID()))
+				for l := 0; l < span.Links().Len(); l++ {
+					link := span.Links().At(l)
+					link.SetSpanID(ids.spanID(link.TraceID(), link.SpanID()))
+					link.SetTraceID(ids.traceID(link.TraceID()))
+				}
+			}
+		}
+	}
+}
+
+func newTraceID(r *rand.Rand) pcommon.TraceID {
+	var id pcommon.TraceID
+	_, _ = r.Read(id[:])
+	return id
+}
+
+func newSpanID(r *rand.Rand) pcommon.SpanID {
+	var id pcommon.SpanID
+	_, _ = r.Read(id[:])
+	return id
+}
+
+// replayer replays the recorded traces, as if they had just happened.
+type replayer struct {
+	traces []scheduledTrace
+	sp     sdktrace.SpanProcessor
+	rand   *rand.Rand
+}
+
+// replay replays all the recorded traces once, and returns how many were replayed before
+// the context was done.
+func (r *replayer) replay(ctx context.Context) int {
+	replayStart := time.Now()
+	ids := newIDRewriter(r.rand)
+
+	var replayed int
+	for _, t := range r.traces {
+		start := replayStart.Add(t.offset)
+		if !waitUntil(ctx, start.Add(t.duration)) {
+			break
+		}
+
+		td := ptrace.NewTraces()
+		t.traces.CopyTo(td)
+		rewriteTraces(td, start.UnixNano()-int64(t.start), ids)
+		emitTraces(r.sp, td)
+		replayed++
+	}
+	return replayed
+}
+
+func waitUntil(ctx context.Context, t time.Time) bool {
+	timer := time.NewTimer(time.Until(t))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// Replay replays the recorded traces of the replay files with the given span processor.
+// The recording is replayed once by each worker, or until the duration expires.
+func Replay(c *Config, sp sdktrace.SpanProcessor, logger *zap.Logger) error {
+	if c.ReplaySpeedup <= 0 {
+		return fmt.Errorf("expected `replay-speedup` to be greater than 0, got %v instead", c.ReplaySpeedup)
+	}
+
+	recording, err := loadRecording(c.ReplayFiles)
+	if err != nil {
+		return err
+	}
+	if len(recording) == 0 {
+		return errors.New("no traces found in the replay files")
+	}
+	scheduled := schedule(recording, c.ReplaySpeedup)
+	logger.Info("replaying recorded traces", zap.Int("traces", len(scheduled)), zap.Float64("speedup", c.ReplaySpeedup))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if c.TotalDuration > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.TotalDuration)
+		defer cancel()
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < c.WorkerCount; i++ {
+		wg.Add(1)
+		r := &replayer{
+			traces: scheduled,
+			sp:     sp,
+			rand:   rand.New(rand.NewSource(time.Now().UnixNano() + int64(i))), // #nosec G404 -- IDs of test data
+		}
+		workerLogger := logger.With(zap.Int("worker", i))
+
+		go func() {
+			defer wg.Done()
+
+			var replayed int
+			for {
+				replayed += r.replay(ctx)
+				if c.TotalDuration <= 0 || ctx.Err() != nil {
+					break
+				}
+			}
+			workerLogger.Info("traces replayed", zap.Int("traces", replayed))
+		}()
+	}
+	wg.Wait()
+	return nil
+}
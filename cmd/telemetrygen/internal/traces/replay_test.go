@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package traces
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+func TestLoadRecording(t *testing.T) {
+	recording, err := loadRecording([]string{filepath.Join("testdata", "recording.json")})
+	require.NoError(t, err)
+	require.Len(t, recording, 2)
+
+	// the spans of the first trace are spread across both requests
+	assert.Equal(t, 3, recording[0].traces.SpanCount())
+	assert.Equal(t, 2, recording[0].traces.ResourceSpans().Len())
+	assert.Equal(t, pcommon.Timestamp(1000000000), recording[0].start)
+	assert.Equal(t, pcommon.Timestamp(1050000000), recording[0].end)
+
+	assert.Equal(t, 1, recording[1].traces.SpanCount())
+	assert.Equal(t, pcommon.Timestamp(1100000000), recording[1].start)
+	assert.Equal(t, pcommon.Timestamp(1110000000), recording[1].end)
+}
+
+func TestLoadRecordingMissingFile(t *testing.T) {
+	_, err := loadRecording([]string{filepath.Join("testdata", "missing.json")})
+	assert.ErrorContains(t, err, "failed to open the recorded traces")
+}
+
+func TestSchedule(t *testing.T) {
+	recording, err := loadRecording([]string{filepath.Join("testdata", "recording.json")})
+	require.NoError(t, err)
+
+	scheduled := schedule(recording, 10)
+	require.Len(t, scheduled, 2)
+
+	// only the gap between the traces is shortened, so the second trace now ends first
+	assert.Equal(t, 10*time.Millisecond, scheduled[0].offset)
+	assert.Equal(t, 10*time.Millisecond, scheduled[0].duration)
+	assert.Equal(t, time.Duration(0), scheduled[1].offset)
+	assert.Equal(t, 50*time.Millisecond, scheduled[1].duration)
+}
+
+func TestReplay(t *testing.T) {
+	// prepare
+	syncer := &mockSyncer{}
+	sp := sdktrace.NewSimpleSpanProcessor(syncer)
+
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount: 1,
+		},
+		ReplayFiles:   []string{filepath.Join("testdata", "recording.json")},
+		ReplaySpeedup: 100,
+	}
+
+	// test
+	before := time.Now()
+	require.NoError(t, Replay(cfg, sp, zap.NewNop()))
+
+	// verify
+	require.Len(t, syncer.spans, 4)
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range syncer.spans {
+		spans[span.Name()] = span
+		assert.False(t, span.StartTime().Before(before))
+		assert.False(t, span.EndTime().After(time.Now()))
+	}
+
+	root := spans["GET /"]
+	assert.NotEqual(t, "5b8efff798038103d269b633813fc60c", root.SpanContext().TraceID().String())
+	assert.NotEqual(t, "eee19b7ec3c1b174", root.SpanContext().SpanID().String())
+	assert.False(t, root.Parent().IsValid())
+	assert.Equal(t, 50*time.Millisecond, root.EndTime().Sub(root.StartTime()))
+	assert.Equal(t, trace.SpanKindServer, root.SpanKind())
+	assert.Equal(t, []attribute.KeyValue{attribute.Int64("http.status_code", 200)}, root.Attributes())
+	assert.Equal(t, []attribute.KeyValue{semconv.ServiceNameKey.String("frontend")}, root.Resource().Attributes())
+
+	client := spans["get-cart"]
+	assert.Equal(t, root.SpanContext().TraceID(), client.SpanContext().TraceID())
+	assert.Equal(t, root.SpanContext().SpanID(), client.Parent().SpanID())
+	assert.Equal(t, 10*time.Millisecond, client.StartTime().Sub(root.StartTime()))
+	require.Len(t, client.Events(), 1)
+	assert.Equal(t, 10*time.Millisecond, client.Events()[0].Time.Sub(client.StartTime()))
+
+	server := spans["GetCart"]
+	assert.Equal(t, client.SpanContext().SpanID(), server.Parent().SpanID())
+	assert.Equal(t, sdktrace.Status{Code: codes.Error, Description: "out of stock"}, server.Status())
+	assert.Equal(t, []attribute.KeyValue{semconv.ServiceNameKey.String("cart")}, server.Resource().Attributes())
+
+	cleanup := spans["cleanup"]
+	assert.NotEqual(t, root.SpanContext().TraceID(), cleanup.SpanContext().TraceID())
+	require.Len(t, cleanup.Links(), 1)
+	assert.Equal(t, root.SpanContext().TraceID(), cleanup.Links()[0].SpanContext.TraceID())
+	assert.Equal(t, root.SpanContext().SpanID(), cleanup.Links()[0].SpanContext.SpanID())
+}
+
+func TestReplayInvalidSpeedup(t *testing.T) {
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount: 1,
+		},
+		ReplayFiles: []string{filepath.Join("testdata", "recording.json")},
+	}
+
+	assert.EqualError(t, Replay(cfg, sdktrace.NewSimpleSpanProcessor(&mockSyncer{}), zap.NewNop()),
+		"expected `replay-speedup` to be greater than 0, got 0 instead")
+}
@@ -0,0 +1,347 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package traces
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+	"gopkg.in/yaml.v3"
+)
+
+// scenario describes the call graph of the generated traces, starting from the root operation.
+type scenario struct {
+	// Services holds the settings of the services of the call graph, by name.
+	Services map[string]scenarioService `yaml:"services"`
+	Root     *operation                 `yaml:"root"`
+}
+
+type scenarioService struct {
+	ResourceAttributes map[string]string `yaml:"resource_attributes"`
+}
+
+// operation is a span of the call graph, along with the operations it calls.
+type operation struct {
+	Service    string              `yaml:"service"`
+	Name       string              `yaml:"name"`
+	Latency    latency             `yaml:"latency"`
+	ErrorRate  float64             `yaml:"error_rate"`
+	Attributes []scenarioAttribute `yaml:"attributes"`
+	// Probability is the probability that the operation is called by its parent, 1 if unset.
+	Probability float64      `yaml:"probability"`
+	Calls       []*operation `yaml:"calls"`
+}
+
+// latency is the distribution of the time an operation spends on its own, besides the
+// time spent in the operations it calls.
+type latency struct {
+	Distribution string        `yaml:"distribution"`
+	Mean         time.Duration `yaml:"mean"`
+	StdDev       time.Duration `yaml:"stddev"`
+	Min          time.Duration `yaml:"min"`
+	Max          time.Duration `yaml:"max"`
+}
+
+// scenarioAttribute is a span attribute, whose value is either picked from the given values
+// or generated among as many distinct values as its cardinality.
+type scenarioAttribute struct {
+	Key         string   `yaml:"key"`
+	Values      []string `yaml:"values"`
+	Cardinality int      `yaml:"cardinality"`
+}
+
+const (
+	distributionConstant    = "constant"
+	distributionUniform     = "uniform"
+	distributionNormal      = "normal"
+	distributionExponential = "exponential"
+)
+
+func loadScenario(file string) (*scenario, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the scenario: %w", err)
+	}
+	defer f.Close()
+
+	s := &scenario{}
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err = dec.Decode(s); err != nil {
+		return nil, fmt.Errorf("failed to parse the scenario: %w", err)
+	}
+
+	if err = s.validate(); err != nil {
+		return nil, fmt.Errorf("invalid scenario: %w", err)
+	}
+	return s, nil
+}
+
+func (s *scenario) validate() error {
+	if s.Root == nil {
+		return errors.New("the root operation must be specified")
+	}
+	return s.Root.validate("root")
+}
+
+func (o *operation) validate(path string) error {
+	if o.Service == "" {
+		return fmt.Errorf("%s: service must be specified", path)
+	}
+	if o.Name == "" {
+		return fmt.Errorf("%s: name must be specified", path)
+	}
+	path = fmt.Sprintf("%s (%s %q)", path, o.Service, o.Name)
+
+	if o.ErrorRate < 0 || o.ErrorRate > 1 {
+		return fmt.Errorf("%s: error_rate must be between 0 and 1, got %v", path, o.ErrorRate)
+	}
+	if o.Probability < 0 || o.Probability > 1 {
+		return fmt.Errorf("%s: probability must be between 0 and 1, got %v", path, o.Probability)
+	}
+	if err := o.Latency.validate(); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	for _, attr := range o.Attributes {
+		switch {
+		case attr.Key == "":
+			return fmt.Errorf("%s: attribute key must be specified", path)
+		case len(attr.Values) > 0 && attr.Cardinality != 0:
+			return fmt.Errorf("%s: attribute %q must have either values or a cardinality, not both", path, attr.Key)
+		case len(attr.Values) == 0 && attr.Cardinality <= 0:
+			return fmt.Errorf("%s: attribute %q must have values or a positive cardinality", path, attr.Key)
+		}
+	}
+
+	for i, call := range o.Calls {
+		if err := call.validate(fmt.Sprintf("%s.calls[%d]", path, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l latency) validate() error {
+	if l.Mean < 0 || l.StdDev < 0 || l.Min < 0 || l.Max < 0 {
+		return errors.New("latency durations must not be negative")
+	}
+
+	switch l.Distribution {
+	case "", distributionConstant, distributionNormal, distributionExponential:
+	case distributionUniform:
+		if l.Max < l.Min {
+			return fmt.Errorf("latency max (%s) must not be lower than min (%s)", l.Max, l.Min)
+		}
+	default:
+		return fmt.Errorf("unknown latency distribution %q, must be one of %q, %q, %q or %q",
+			l.Distribution, distributionConstant, distributionUniform, distributionNormal, distributionExponential)
+	}
+	return nil
+}
+
+func (l latency) sample(r *rand.Rand) time.Duration {
+	var d float64
+	switch l.Distribution {
+	case distributionUniform:
+		d = float64(l.Min) + r.Float64()*float64(l.Max-l.Min)
+	case distributionNormal:
+		d = float64(l.Mean) + r.NormFloat64()*float64(l.StdDev)
+	case distributionExponential:
+		d = r.ExpFloat64() * float64(l.Mean)
+	default:
+		d = float64(l.Mean)
+	}
+	return time.Duration(max(d, 0))
+}
+
+// scenarioGenerator generates traces following the call graph of a scenario.
+type scenarioGenerator struct {
+	scenario           *scenario
+	rand               *rand.Rand
+	resourceAttributes map[string]string
+	spanAttributes     map[string]string
+}
+
+// generatedTrace is the trace being generated, with the spans of each service.
+type generatedTrace struct {
+	traces  ptrace.Traces
+	traceID pcommon.TraceID
+	spans   map[string]ptrace.SpanSlice
+}
+
+// generate generates a trace of the scenario, which ends at the given time.
+func (g *scenarioGenerator) generate(end time.Time) ptrace.Traces {
+	t := &generatedTrace{
+		traces:  ptrace.NewTraces(),
+		traceID: newTraceID(g.rand),
+		spans:   map[string]ptrace.SpanSlice{},
+	}
+
+	// the call graph is laid out from the given time, then shifted to end at that time
+	generatedEnd := g.generateOperation(t, g.scenario.Root, pcommon.NewSpanIDEmpty(), "", end)
+	rewriteTraces(t.traces, end.Sub(generatedEnd).Nanoseconds(), nil)
+
+	return t.traces
+}
+
+// generateOperation generates the span of the operation starting at the given time, and those
+// of the operations it calls, and returns the time the operation ends. Calls to another service
+// are made of a client span in the calling service and a server span in the called one.
+func (g *scenarioGenerator) generateOperation(t *generatedTrace, op *operation, parentID pcommon.SpanID, caller string, start time.Time) time.Time {
+	var client ptrace.Span
+	remote := caller != "" && caller != op.Service
+	if remote {
+		client = g.newSpan(t, caller, op.Name, ptrace.SpanKindClient, parentID)
+		client.Attributes().PutStr(string(semconv.PeerServiceKey), op.Service)
+		parentID = client.SpanID()
+	}
+
+	kind := ptrace.SpanKindServer
+	if caller == op.Service {
+		kind = ptrace.SpanKindInternal
+	}
+	span := g.newSpan(t, op.Service, op.Name, kind, parentID)
+	for _, attr := range op.Attributes {
+		if len(attr.Values) > 0 {
+			span.Attributes().PutStr(attr.Key, attr.Values[g.rand.Intn(len(attr.Values))])
+		} else {
+			span.Attributes().PutStr(attr.Key, fmt.Sprintf("%s-%d", attr.Key, g.rand.Intn(attr.Cardinality)))
+		}
+	}
+
+	// half of the operation's own latency is spent before the calls, and half after
+	self := op.Latency.sample(g.rand)
+	end := start.Add(self / 2)
+	for _, call := range op.Calls {
+		if call.Probability > 0 && g.rand.Float64() >= call.Probability {
+			continue
+		}
+		end = g.generateOperation(t, call, span.SpanID(), op.Service, end)
+	}
+	end = end.Add(self - self/2)
+
+	span.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+	span.SetEndTimestamp(pcommon.NewTimestampFromTime(end))
+	if op.ErrorRate > 0 && g.rand.Float64() < op.ErrorRate {
+		span.Status().SetCode(ptrace.StatusCodeError)
+		span.Status().SetMessage("simulated error")
+	}
+
+	if remote {
+		client.SetStartTimestamp(span.StartTimestamp())
+		client.SetEndTimestamp(span.EndTimestamp())
+		span.Status().CopyTo(client.Status())
+	}
+
+	return end
+}
+
+func (g *scenarioGenerator) newSpan(t *generatedTrace, service, name string, kind ptrace.SpanKind, parentID pcommon.SpanID) ptrace.Span {
+	spans, ok := t.spans[service]
+	if !ok {
+		rs := t.traces.ResourceSpans().AppendEmpty()
+		// may be overridden by the service's resource attributes
+		for k, v := range g.resourceAttributes {
+			rs.Resource().Attributes().PutStr(k, v)
+		}
+		for k, v := range g.scenario.Services[service].ResourceAttributes {
+			rs.Resource().Attributes().PutStr(k, v)
+		}
+		rs.Resource().Attributes().PutStr(string(semconv.ServiceNameKey), service)
+
+		ss := rs.ScopeSpans().AppendEmpty()
+		ss.Scope().SetName("telemetrygen")
+		spans = ss.Spans()
+		t.spans[service] = spans
+	}
+
+	span := spans.AppendEmpty()
+	span.SetTraceID(t.traceID)
+	span.SetSpanID(newSpanID(g.rand))
+	span.SetParentSpanID(parentID)
+	span.SetName(name)
+	span.SetKind(kind)
+	for k, v := range g.spanAttributes {
+		span.Attributes().PutStr(k, v)
+	}
+	return span
+}
+
+// RunScenario generates traces following the call graph of the scenario file with the given
+// span processor.
+func RunScenario(c *Config, sp sdktrace.SpanProcessor, logger *zap.Logger) error {
+	if c.TotalDuration > 0 {
+		c.NumTraces = 0
+	} else if c.NumTraces <= 0 {
+		return fmt.Errorf("either `traces` or `duration` must be greater than 0")
+	}
+
+	s, err := loadScenario(c.ScenarioFile)
+	if err != nil {
+		return err
+	}
+
+	limit := rate.Limit(c.Rate)
+	if c.Rate == 0 {
+		limit = rate.Inf
+		logger.Info("generation of traces isn't being throttled")
+	} else {
+		logger.Info("generation of traces is limited", zap.Float64("per-second", float64(limit)))
+	}
+
+	wg := sync.WaitGroup{}
+
+	running := &atomic.Bool{}
+	running.Store(true)
+
+	for i := 0; i < c.WorkerCount; i++ {
+		wg.Add(1)
+		g := &scenarioGenerator{
+			scenario:           s,
+			rand:               rand.New(rand.NewSource(time.Now().UnixNano() + int64(i))), // #nosec G404 -- randomness of test data
+			resourceAttributes: c.ResourceAttributes,
+			spanAttributes:     c.TelemetryAttributes,
+		}
+		workerLogger := logger.With(zap.Int("worker", i))
+
+		go func() {
+			defer wg.Done()
+
+			limiter := rate.NewLimiter(limit, 1)
+			var generated int
+			for running.Load() {
+				if err := limiter.Wait(context.Background()); err != nil {
+					workerLogger.Fatal("limiter waited failed, retry", zap.Error(err))
+				}
+
+				emitTraces(sp, g.generate(time.Now()))
+
+				generated++
+				if c.NumTraces != 0 && generated >= c.NumTraces {
+					break
+				}
+			}
+			workerLogger.Info("traces generated", zap.Int("traces", generated))
+		}()
+	}
+	if c.TotalDuration > 0 {
+		time.Sleep(c.TotalDuration)
+		running.Store(false)
+	}
+	wg.Wait()
+	return nil
+}
@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package traces
+
+import (
+	"math/rand"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+func TestRunScenario(t *testing.T) {
+	// prepare
+	syncer := &mockSyncer{}
+	sp := sdktrace.NewSimpleSpanProcessor(syncer)
+
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount:         1,
+			TelemetryAttributes: common.KeyValue{telemetryAttrKeyOne: telemetryAttrValueOne},
+		},
+		NumTraces:    1,
+		ScenarioFile: filepath.Join("testdata", "scenario.yaml"),
+	}
+
+	// test
+	before := time.Now()
+	require.NoError(t, RunScenario(cfg, sp, zap.NewNop()))
+
+	// verify
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range syncer.spans {
+		spans[span.SpanKind().String()+" "+span.Name()] = span
+		assert.Contains(t, span.Attributes(), attribute.String(telemetryAttrKeyOne, telemetryAttrValueOne))
+		assert.False(t, span.EndTime().Before(span.StartTime()))
+		assert.False(t, span.EndTime().After(time.Now()))
+	}
+	// the call to the inventory service is only made half of the time
+	if _, ok := spans["server Reserve"]; ok {
+		require.Len(t, syncer.spans, 6)
+	} else {
+		require.Len(t, syncer.spans, 4)
+	}
+
+	root := spans["server GET /checkout"]
+	require.NotNil(t, root)
+	assert.False(t, root.Parent().IsValid())
+	assert.False(t, root.EndTime().Before(before))
+	assert.Contains(t, root.Attributes(), attribute.String("http.method", "GET"))
+	assert.Contains(t, root.Resource().Attributes(), attribute.String("service.name", "frontend"))
+
+	render := spans["internal render"]
+	require.NotNil(t, render)
+	assert.Equal(t, root.SpanContext().SpanID(), render.Parent().SpanID())
+	assert.Equal(t, root.Resource(), render.Resource())
+
+	client := spans["client GetCart"]
+	require.NotNil(t, client)
+	assert.Equal(t, root.SpanContext().SpanID(), client.Parent().SpanID())
+	assert.Contains(t, client.Attributes(), attribute.String("peer.service", "cart"))
+	assert.Equal(t, root.Resource(), client.Resource())
+	assert.False(t, client.StartTime().Before(render.EndTime()))
+
+	server := spans["server GetCart"]
+	require.NotNil(t, server)
+	assert.Equal(t, root.SpanContext().TraceID(), server.SpanContext().TraceID())
+	assert.Equal(t, client.SpanContext().SpanID(), server.Parent().SpanID())
+	assert.Equal(t, client.StartTime(), server.StartTime())
+	assert.Equal(t, client.EndTime(), server.EndTime())
+	assert.Equal(t, sdktrace.Status{Code: codes.Error, Description: "simulated error"}, server.Status())
+	assert.Equal(t, server.Status(), client.Status())
+	assert.Contains(t, server.Resource().Attributes(), attribute.String("service.name", "cart"))
+	assert.Contains(t, server.Resource().Attributes(), attribute.String("deployment.environment", "test"))
+
+	var customerID string
+	for _, attr := range server.Attributes() {
+		if attr.Key == "customer.id" {
+			customerID = attr.Value.AsString()
+		}
+	}
+	assert.True(t, strings.HasPrefix(customerID, "customer.id-"), customerID)
+
+	assert.Equal(t, trace.SpanKindServer, root.SpanKind())
+}
+
+func TestRunScenarioInvalid(t *testing.T) {
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount: 1,
+		},
+		NumTraces:    1,
+		ScenarioFile: filepath.Join("testdata", "missing.yaml"),
+	}
+
+	assert.ErrorContains(t, RunScenario(cfg, sdktrace.NewSimpleSpanProcessor(&mockSyncer{}), zap.NewNop()), "failed to open the scenario")
+}
+
+func TestScenarioValidate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		scenario      scenario
+		expectedError string
+	}{
+		{
+			name:          "Missing root",
+			scenario:      scenario{},
+			expectedError: "the root operation must be specified",
+		},
+		{
+			name:          "Missing service",
+			scenario:      scenario{Root: &operation{Name: "GET /"}},
+			expectedError: "root: service must be specified",
+		},
+		{
+			name: "Invalid error rate",
+			scenario: scenario{Root: &operation{
+				Service:   "frontend",
+				Name:      "GET /",
+				ErrorRate: 1.5,
+			}},
+			expectedError: `root (frontend "GET /"): error_rate must be between 0 and 1, got 1.5`,
+		},
+		{
+			name: "Unknown distribution",
+			scenario: scenario{Root: &operation{
+				Service: "frontend",
+				Name:    "GET /",
+				Calls: []*operation{
+					{Service: "cart", Name: "GetCart", Latency: latency{Distribution: "pareto"}},
+				},
+			}},
+			expectedError: `root (frontend "GET /").calls[0] (cart "GetCart"): unknown latency distribution "pareto"`,
+		},
+		{
+			name: "Invalid uniform distribution",
+			scenario: scenario{Root: &operation{
+				Service: "frontend",
+				Name:    "GET /",
+				Latency: latency{Distribution: distributionUniform, Min: time.Second, Max: time.Millisecond},
+			}},
+			expectedError: "latency max (1ms) must not be lower than min (1s)",
+		},
+		{
+			name: "Attribute with values and cardinality",
+			scenario: scenario{Root: &operation{
+				Service:    "frontend",
+				Name:       "GET /",
+				Attributes: []scenarioAttribute{{Key: "region", Values: []string{"eu"}, Cardinality: 2}},
+			}},
+			expectedError: `attribute "region" must have either values or a cardinality, not both`,
+		},
+		{
+			name: "Attribute without values",
+			scenario: scenario{Root: &operation{
+				Service:    "frontend",
+				Name:       "GET /",
+				Attributes: []scenarioAttribute{{Key: "region"}},
+			}},
+			expectedError: `attribute "region" must have values or a positive cardinality`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.ErrorContains(t, tc.scenario.validate(), tc.expectedError)
+		})
+	}
+}
+
+func TestLatencySample(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	assert.Equal(t, 5*time.Millisecond, latency{Mean: 5 * time.Millisecond}.sample(r))
+
+	uniform := latency{Distribution: distributionUniform, Min: time.Millisecond, Max: 2 * time.Millisecond}
+	for i := 0; i < 100; i++ {
+		d := uniform.sample(r)
+		assert.GreaterOrEqual(t, d, time.Millisecond)
+		assert.LessOrEqual(t, d, 2*time.Millisecond)
+	}
+
+	normal := latency{Distribution: distributionNormal, Mean: time.Millisecond, StdDev: time.Second}
+	for i := 0; i < 100; i++ {
+		assert.GreaterOrEqual(t, normal.sample(r), time.Duration(0))
+	}
+}
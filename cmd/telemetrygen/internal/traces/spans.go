@@ -0,0 +1,195 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package traces
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// emitTraces hands the spans of the given traces over to the span processor, as if they
+// had just ended. This lets the replayed and scenario traces use the same exporters as the
+// generated ones.
+func emitTraces(sp sdktrace.SpanProcessor, td ptrace.Traces) int {
+	var count int
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		res := resource.NewWithAttributes(rs.SchemaUrl(), toAttributes(rs.Resource().Attributes())...)
+
+		sss := rs.ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			ss := sss.At(j)
+			scope := instrumentation.Scope{
+				Name:      ss.Scope().Name(),
+				Version:   ss.Scope().Version(),
+				SchemaURL: ss.SchemaUrl(),
+			}
+
+			spans := ss.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				sp.OnEnd(toReadOnlySpan(spans.At(k), res, scope))
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func toReadOnlySpan(span ptrace.Span, res *resource.Resource, scope instrumentation.Scope) sdktrace.ReadOnlySpan {
+	stub := tracetest.SpanStub{
+		Name:                   span.Name(),
+		SpanContext:            toSpanContext(span.TraceID(), span.SpanID(), span.TraceState()),
+		SpanKind:               toSpanKind(span.Kind()),
+		StartTime:              span.StartTimestamp().AsTime(),
+		EndTime:                span.EndTimestamp().AsTime(),
+		Attributes:             toAttributes(span.Attributes()),
+		Status:                 toStatus(span.Status()),
+		DroppedAttributes:      int(span.DroppedAttributesCount()),
+		DroppedEvents:          int(span.DroppedEventsCount()),
+		DroppedLinks:           int(span.DroppedLinksCount()),
+		Resource:               res,
+		InstrumentationLibrary: scope,
+	}
+
+	if !span.ParentSpanID().IsEmpty() {
+		stub.Parent = toSpanContext(span.TraceID(), span.ParentSpanID(), pcommon.NewTraceState())
+	}
+
+	for i := 0; i < span.Events().Len(); i++ {
+		event := span.Events().At(i)
+		stub.Events = append(stub.Events, sdktrace.Event{
+			Name:                  event.Name(),
+			Attributes:            toAttributes(event.Attributes()),
+			DroppedAttributeCount: int(event.DroppedAttributesCount()),
+			Time:                  event.Timestamp().AsTime(),
+		})
+	}
+
+	for i := 0; i < span.Links().Len(); i++ {
+		link := span.Links().At(i)
+		stub.Links = append(stub.Links, sdktrace.Link{
+			SpanContext:           toSpanContext(link.TraceID(), link.SpanID(), link.TraceState()),
+			Attributes:            toAttributes(link.Attributes()),
+			DroppedAttributeCount: int(link.DroppedAttributesCount()),
+		})
+	}
+
+	return stub.Snapshot()
+}
+
+func toSpanContext(traceID pcommon.TraceID, spanID pcommon.SpanID, traceState pcommon.TraceState) trace.SpanContext {
+	// an invalid trace state is dropped rather than failing the whole span
+	ts, _ := trace.ParseTraceState(traceState.AsRaw())
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID(traceID),
+		SpanID:     trace.SpanID(spanID),
+		TraceFlags: trace.FlagsSampled,
+		TraceState: ts,
+	})
+}
+
+func toSpanKind(kind ptrace.SpanKind) trace.SpanKind {
+	switch kind {
+	case ptrace.SpanKindInternal:
+		return trace.SpanKindInternal
+	case ptrace.SpanKindServer:
+		return trace.SpanKindServer
+	case ptrace.SpanKindClient:
+		return trace.SpanKindClient
+	case ptrace.SpanKindProducer:
+		return trace.SpanKindProducer
+	case ptrace.SpanKindConsumer:
+		return trace.SpanKindConsumer
+	default:
+		return trace.SpanKindUnspecified
+	}
+}
+
+func toStatus(status ptrace.Status) sdktrace.Status {
+	switch status.Code() {
+	case ptrace.StatusCodeError:
+		return sdktrace.Status{Code: codes.Error, Description: status.Message()}
+	case ptrace.StatusCodeOk:
+		return sdktrace.Status{Code: codes.Ok}
+	default:
+		return sdktrace.Status{Code: codes.Unset}
+	}
+}
+
+func toAttributes(m pcommon.Map) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, m.Len())
+	m.Range(func(k string, v pcommon.Value) bool {
+		attrs = append(attrs, toAttribute(k, v))
+		return true
+	})
+	return attrs
+}
+
+// toAttribute converts a pdata value to an attribute. Maps, bytes and slices of mixed
+// types have no attribute equivalent, so they are converted to strings.
+func toAttribute(k string, v pcommon.Value) attribute.KeyValue {
+	switch v.Type() {
+	case pcommon.ValueTypeBool:
+		return attribute.Bool(k, v.Bool())
+	case pcommon.ValueTypeInt:
+		return attribute.Int64(k, v.Int())
+	case pcommon.ValueTypeDouble:
+		return attribute.Float64(k, v.Double())
+	case pcommon.ValueTypeSlice:
+		if kv, ok := toSliceAttribute(k, v.Slice()); ok {
+			return kv
+		}
+	}
+	return attribute.String(k, v.AsString())
+}
+
+func toSliceAttribute(k string, s pcommon.Slice) (attribute.KeyValue, bool) {
+	if s.Len() == 0 {
+		return attribute.StringSlice(k, nil), true
+	}
+
+	typ := s.At(0).Type()
+	for i := 1; i < s.Len(); i++ {
+		if s.At(i).Type() != typ {
+			return attribute.KeyValue{}, false
+		}
+	}
+
+	switch typ {
+	case pcommon.ValueTypeStr:
+		values := make([]string, s.Len())
+		for i := range values {
+			values[i] = s.At(i).Str()
+		}
+		return attribute.StringSlice(k, values), true
+	case pcommon.ValueTypeBool:
+		values := make([]bool, s.Len())
+		for i := range values {
+			values[i] = s.At(i).Bool()
+		}
+		return attribute.BoolSlice(k, values), true
+	case pcommon.ValueTypeInt:
+		values := make([]int64, s.Len())
+		for i := range values {
+			values[i] = s.At(i).Int()
+		}
+		return attribute.Int64Slice(k, values), true
+	case pcommon.ValueTypeDouble:
+		values := make([]float64, s.Len())
+		for i := range values {
+			values[i] = s.At(i).Double()
+		}
+		return attribute.Float64Slice(k, values), true
+	default:
+		return attribute.KeyValue{}, false
+	}
+}
@@ -5,6 +5,7 @@ package traces
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"strings"
@@ -33,6 +34,10 @@ func Start(cfg *Config) error {
 		return err
 	}
 
+	if len(cfg.ReplayFiles) > 0 && cfg.ScenarioFile != "" {
+		return errors.New("`replay-file` and `scenario` cannot be used together")
+	}
+
 	var exp *otlptrace.Exporter
 	if cfg.UseHTTP {
 		var exporterOpts []otlptracehttp.Option
@@ -78,6 +83,24 @@ func Start(cfg *Config) error {
 		}()
 	}
 
+	if len(cfg.ReplayFiles) > 0 || cfg.ScenarioFile != "" {
+		// the replayed and scenario traces bring their own resources and IDs, so their
+		// spans are handed to the span processor directly rather than through a tracer
+		if ssp == nil {
+			ssp = sdktrace.NewSimpleSpanProcessor(exp)
+		}
+
+		run := RunScenario
+		if len(cfg.ReplayFiles) > 0 {
+			run = Replay
+		}
+		if err = run(cfg, ssp, logger); err != nil {
+			logger.Error("failed to execute the test scenario.", zap.Error(err))
+			return err
+		}
+		return nil
+	}
+
 	var attributes []attribute.KeyValue
 	// may be overridden by `--otlp-attributes service.name="foo"`
 	attributes = append(attributes, semconv.ServiceNameKey.String(cfg.ServiceName))
@@ -4,7 +4,9 @@
 package countconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/countconnector"
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
@@ -44,8 +46,30 @@ type MetricInfo struct {
 	Description string            `mapstructure:"description"`
 	Conditions  []string          `mapstructure:"conditions"`
 	Attributes  []AttributeConfig `mapstructure:"attributes"`
+
+	// Value is an OTTL value expression, such as a path to a log attribute or
+	// body field, which is evaluated for each matching log record. When set,
+	// the metric aggregates this value instead of counting records.
+	// Only supported for logs.
+	Value       string      `mapstructure:"value"`
+	Aggregation Aggregation `mapstructure:"aggregation"`
+	// Buckets are the explicit bounds of the histogram aggregation.
+	Buckets []float64 `mapstructure:"buckets"`
 }
 
+// Aggregation determines how the values of a metric are combined.
+type Aggregation string
+
+const (
+	AggregationSum       Aggregation = "sum"
+	AggregationMin       Aggregation = "min"
+	AggregationMax       Aggregation = "max"
+	AggregationHistogram Aggregation = "histogram"
+)
+
+// defaultBuckets are used by the histogram aggregation if no buckets are configured.
+var defaultBuckets = []float64{0, 5, 10, 25, 50, 75, 100, 250, 500, 750, 1000, 2500, 5000, 7500, 10000}
+
 type AttributeConfig struct {
 	Key          string `mapstructure:"key"`
 	DefaultValue any    `mapstructure:"default_value"`
@@ -62,6 +86,9 @@ func (c *Config) Validate() error {
 		if err := info.validateAttributes(); err != nil {
 			return fmt.Errorf("spans attributes: metric %q: %w", name, err)
 		}
+		if info.hasValue() {
+			return fmt.Errorf("spans value not supported: metric %q", name)
+		}
 	}
 	for name, info := range c.SpanEvents {
 		if name == "" {
@@ -73,6 +100,9 @@ func (c *Config) Validate() error {
 		if err := info.validateAttributes(); err != nil {
 			return fmt.Errorf("spanevents attributes: metric %q: %w", name, err)
 		}
+		if info.hasValue() {
+			return fmt.Errorf("spanevents value not supported: metric %q", name)
+		}
 	}
 	for name, info := range c.Metrics {
 		if name == "" {
@@ -84,6 +114,9 @@ func (c *Config) Validate() error {
 		if len(info.Attributes) > 0 {
 			return fmt.Errorf("metrics attributes not supported: metric %q", name)
 		}
+		if info.hasValue() {
+			return fmt.Errorf("metrics value not supported: metric %q", name)
+		}
 	}
 
 	for name, info := range c.DataPoints {
@@ -96,6 +129,9 @@ func (c *Config) Validate() error {
 		if err := info.validateAttributes(); err != nil {
 			return fmt.Errorf("spans attributes: metric %q: %w", name, err)
 		}
+		if info.hasValue() {
+			return fmt.Errorf("datapoints value not supported: metric %q", name)
+		}
 	}
 	for name, info := range c.Logs {
 		if name == "" {
@@ -107,6 +143,9 @@ func (c *Config) Validate() error {
 		if err := info.validateAttributes(); err != nil {
 			return fmt.Errorf("logs attributes: metric %q: %w", name, err)
 		}
+		if err := info.validateValue(); err != nil {
+			return fmt.Errorf("logs value: metric %q: %w", name, err)
+		}
 	}
 	return nil
 }
@@ -120,6 +159,43 @@ func (i *MetricInfo) validateAttributes() error {
 	return nil
 }
 
+func (i *MetricInfo) hasValue() bool {
+	return i.Value != "" || i.Aggregation != "" || len(i.Buckets) > 0
+}
+
+func (i *MetricInfo) validateValue() error {
+	if i.Value == "" {
+		if i.Aggregation != "" || len(i.Buckets) > 0 {
+			return errors.New("aggregation and buckets require a value")
+		}
+		return nil
+	}
+	if _, err := newLogValueExpr(i.Value, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
+		return err
+	}
+	switch i.Aggregation {
+	case "", AggregationSum, AggregationMin, AggregationMax:
+		if len(i.Buckets) > 0 {
+			return fmt.Errorf("buckets not supported for %q aggregation", i.aggregation())
+		}
+	case AggregationHistogram:
+		if !sort.Float64sAreSorted(i.Buckets) {
+			return errors.New("buckets must be sorted in ascending order")
+		}
+	default:
+		return fmt.Errorf("unsupported aggregation %q", i.Aggregation)
+	}
+	return nil
+}
+
+// aggregation returns the configured aggregation, which defaults to sum.
+func (i *MetricInfo) aggregation() Aggregation {
+	if i.Aggregation == "" {
+		return AggregationSum
+	}
+	return i.Aggregation
+}
+
 var _ confmap.Unmarshaler = (*Config)(nil)
 
 // Unmarshal with custom logic to set default values.
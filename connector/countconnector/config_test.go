@@ -510,6 +510,82 @@ func TestConfigErrors(t *testing.T) {
 			},
 			expect: fmt.Sprintf("logs condition: metric %q: unable to parse OTTL condition", defaultMetricNameLogs),
 		},
+		{
+			name: "value_span",
+			input: &Config{
+				Spans: map[string]MetricInfo{
+					defaultMetricNameSpans: {
+						Description: defaultMetricDescSpans,
+						Value:       `attributes["duration"]`,
+					},
+				},
+			},
+			expect: fmt.Sprintf("spans value not supported: metric %q", defaultMetricNameSpans),
+		},
+		{
+			name: "invalid_value_log",
+			input: &Config{
+				Logs: map[string]MetricInfo{
+					defaultMetricNameLogs: {
+						Description: defaultMetricDescLogs,
+						Value:       `attributes[`,
+					},
+				},
+			},
+			expect: fmt.Sprintf("logs value: metric %q: value expression has invalid syntax", defaultMetricNameLogs),
+		},
+		{
+			name: "aggregation_without_value_log",
+			input: &Config{
+				Logs: map[string]MetricInfo{
+					defaultMetricNameLogs: {
+						Description: defaultMetricDescLogs,
+						Aggregation: AggregationMax,
+					},
+				},
+			},
+			expect: fmt.Sprintf("logs value: metric %q: aggregation and buckets require a value", defaultMetricNameLogs),
+		},
+		{
+			name: "invalid_aggregation_log",
+			input: &Config{
+				Logs: map[string]MetricInfo{
+					defaultMetricNameLogs: {
+						Description: defaultMetricDescLogs,
+						Value:       `attributes["duration"]`,
+						Aggregation: "avg",
+					},
+				},
+			},
+			expect: fmt.Sprintf("logs value: metric %q: unsupported aggregation \"avg\"", defaultMetricNameLogs),
+		},
+		{
+			name: "buckets_without_histogram_log",
+			input: &Config{
+				Logs: map[string]MetricInfo{
+					defaultMetricNameLogs: {
+						Description: defaultMetricDescLogs,
+						Value:       `attributes["duration"]`,
+						Buckets:     []float64{1, 10},
+					},
+				},
+			},
+			expect: fmt.Sprintf("logs value: metric %q: buckets not supported for \"sum\" aggregation", defaultMetricNameLogs),
+		},
+		{
+			name: "unsorted_buckets_log",
+			input: &Config{
+				Logs: map[string]MetricInfo{
+					defaultMetricNameLogs: {
+						Description: defaultMetricDescLogs,
+						Value:       `attributes["duration"]`,
+						Aggregation: AggregationHistogram,
+						Buckets:     []float64{10, 1},
+					},
+				},
+			},
+			expect: fmt.Sprintf("logs value: metric %q: buckets must be sorted in ascending order", defaultMetricNameLogs),
+		},
 	}
 
 	for _, tc := range testCases {
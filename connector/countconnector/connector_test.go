@@ -692,3 +692,140 @@ func TestLogsToMetrics(t *testing.T) {
 		})
 	}
 }
+
+// The test input file contains a single resource with access logs of a web server.
+// Each log has the attributes http.route and http.response.status_code, and a body
+// with the request_time as a string and the bytes_sent as an integer.
+// The last log has no such body fields, and is not aggregated.
+func TestLogsToMetricsValue(t *testing.T) {
+	testCases := []struct {
+		name string
+		cfg  *Config
+	}{
+		{
+			name: "value_sum",
+			cfg: &Config{
+				Logs: map[string]MetricInfo{
+					"http.server.response.body.size": {
+						Description: "Bytes sent by route",
+						Value:       `body["bytes_sent"]`,
+						Attributes: []AttributeConfig{
+							{
+								Key: "http.route",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "value_min_max",
+			cfg: &Config{
+				Logs: map[string]MetricInfo{
+					"http.server.request.duration.min": {
+						Description: "Minimum request duration",
+						Value:       `body["request_time"]`,
+						Aggregation: AggregationMin,
+					},
+					"http.server.request.duration.max": {
+						Description: "Maximum request duration",
+						Value:       `body["request_time"]`,
+						Aggregation: AggregationMax,
+					},
+				},
+			},
+		},
+		{
+			name: "value_histogram",
+			cfg: &Config{
+				Logs: map[string]MetricInfo{
+					"http.server.request.duration": {
+						Description: "Request duration by route",
+						Value:       `body["request_time"]`,
+						Aggregation: AggregationHistogram,
+						Buckets:     []float64{0.01, 0.1, 1},
+						Attributes: []AttributeConfig{
+							{
+								Key: "http.route",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "value_condition",
+			cfg: &Config{
+				Logs: map[string]MetricInfo{
+					"http.server.request.errors": {
+						Description: "Server error count",
+						Conditions: []string{
+							`attributes["http.response.status_code"] >= 500`,
+						},
+					},
+					"http.server.request.errors.duration": {
+						Description: "Server error duration",
+						Value:       `body["request_time"]`,
+						Conditions: []string{
+							`attributes["http.response.status_code"] >= 500`,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, tc.cfg.Validate())
+			factory := NewFactory()
+			sink := &consumertest.MetricsSink{}
+			conn, err := factory.CreateLogsToMetrics(context.Background(),
+				connectortest.NewNopSettings(), tc.cfg, sink)
+			require.NoError(t, err)
+			require.NotNil(t, conn)
+
+			require.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() {
+				assert.NoError(t, conn.Shutdown(context.Background()))
+			}()
+
+			testLogs, err := golden.ReadLogs(filepath.Join("testdata", "logs", "input_values.yaml"))
+			assert.NoError(t, err)
+			assert.NoError(t, conn.ConsumeLogs(context.Background(), testLogs))
+
+			allMetrics := sink.AllMetrics()
+			assert.Equal(t, 1, len(allMetrics))
+
+			// golden.WriteMetrics(t, filepath.Join("testdata", "logs", tc.name+".yaml"), allMetrics[0])
+			expected, err := golden.ReadMetrics(filepath.Join("testdata", "logs", tc.name+".yaml"))
+			assert.NoError(t, err)
+			assert.NoError(t, pmetrictest.CompareMetrics(expected, allMetrics[0],
+				pmetrictest.IgnoreTimestamp(),
+				pmetrictest.IgnoreResourceMetricsOrder(),
+				pmetrictest.IgnoreMetricsOrder(),
+				pmetrictest.IgnoreMetricDataPointsOrder()))
+		})
+	}
+}
+
+func TestLogsToMetricsValueNotNumeric(t *testing.T) {
+	cfg := &Config{
+		Logs: map[string]MetricInfo{
+			"log.value": {
+				Value: `body`,
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	factory := NewFactory()
+	sink := &consumertest.MetricsSink{}
+	conn, err := factory.CreateLogsToMetrics(context.Background(),
+		connectortest.NewNopSettings(), cfg, sink)
+	require.NoError(t, err)
+
+	testLogs, err := golden.ReadLogs(filepath.Join("testdata", "logs", "input.yaml"))
+	require.NoError(t, err)
+	assert.ErrorContains(t, conn.ConsumeLogs(context.Background(), testLogs), `metric "log.value": value "This is a log message" is not numeric`)
+	assert.Empty(t, sink.AllMetrics())
+}
@@ -6,6 +6,10 @@ package countconnector // import "github.com/open-telemetry/opentelemetry-collec
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
 	"time"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
@@ -33,6 +37,10 @@ type counter[K any] struct {
 type attrCounter struct {
 	attrs pcommon.Map
 	count uint64
+
+	// only tracked for metrics that aggregate a value
+	sum, min, max float64
+	bucketCounts  []uint64
 }
 
 func (c *counter[K]) update(ctx context.Context, attrs pcommon.Map, tCtx K) error {
@@ -74,20 +82,67 @@ func (c *counter[K]) update(ctx context.Context, attrs pcommon.Map, tCtx K) erro
 
 		// No conditions, so match all.
 		if md.condition == nil {
-			multiError = errors.Join(multiError, c.increment(name, countAttrs))
+			multiError = errors.Join(multiError, c.record(ctx, name, md, countAttrs, tCtx))
 			continue
 		}
 
 		if match, err := md.condition.Eval(ctx, tCtx); err != nil {
 			multiError = errors.Join(multiError, err)
 		} else if match {
-			multiError = errors.Join(multiError, c.increment(name, countAttrs))
+			multiError = errors.Join(multiError, c.record(ctx, name, md, countAttrs, tCtx))
 		}
 	}
 	return multiError
 }
 
+// record counts a match, or aggregates its value if the metric has one.
+func (c *counter[K]) record(ctx context.Context, metricName string, md metricDef[K], attrs pcommon.Map, tCtx K) error {
+	if md.value == nil {
+		return c.increment(metricName, attrs)
+	}
+
+	raw, err := md.value.Eval(ctx, tCtx)
+	if err != nil {
+		return err
+	}
+	// Records without the value are not aggregated
+	if raw == nil {
+		return nil
+	}
+	val, err := toFloat64(raw)
+	if err != nil {
+		return fmt.Errorf("metric %q: %w", metricName, err)
+	}
+	c.observe(metricName, md, attrs, val)
+	return nil
+}
+
 func (c *counter[K]) increment(metricName string, attrs pcommon.Map) error {
+	c.attrCounter(metricName, attrs).count++
+	return nil
+}
+
+func (c *counter[K]) observe(metricName string, md metricDef[K], attrs pcommon.Map, val float64) {
+	ac := c.attrCounter(metricName, attrs)
+	if ac.count == 0 {
+		ac.min, ac.max = val, val
+	} else {
+		ac.min = math.Min(ac.min, val)
+		ac.max = math.Max(ac.max, val)
+	}
+	ac.count++
+	ac.sum += val
+
+	if md.aggregation == AggregationHistogram {
+		if ac.bucketCounts == nil {
+			ac.bucketCounts = make([]uint64, len(md.buckets)+1)
+		}
+		// Bucket i counts values in (buckets[i-1], buckets[i]]
+		ac.bucketCounts[sort.SearchFloat64s(md.buckets, val)]++
+	}
+}
+
+func (c *counter[K]) attrCounter(metricName string, attrs pcommon.Map) *attrCounter {
 	if _, ok := c.counts[metricName]; !ok {
 		c.counts[metricName] = make(map[[16]byte]*attrCounter)
 	}
@@ -100,9 +155,24 @@ func (c *counter[K]) increment(metricName string, attrs pcommon.Map) error {
 	if _, ok := c.counts[metricName][key]; !ok {
 		c.counts[metricName][key] = &attrCounter{attrs: attrs}
 	}
+	return c.counts[metricName][key]
+}
 
-	c.counts[metricName][key].count++
-	return nil
+func toFloat64(val any) (float64, error) {
+	switch v := val.(type) {
+	case int64:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, fmt.Errorf("value %q is not numeric", v)
+		}
+		return f, nil
+	default:
+		return 0, fmt.Errorf("value of type %T is not numeric", val)
+	}
 }
 
 func (c *counter[K]) appendMetricsTo(metricSlice pmetric.MetricSlice) {
@@ -113,6 +183,10 @@ func (c *counter[K]) appendMetricsTo(metricSlice pmetric.MetricSlice) {
 		countMetric := metricSlice.AppendEmpty()
 		countMetric.SetName(name)
 		countMetric.SetDescription(md.desc)
+		if md.value != nil {
+			c.appendValueMetric(countMetric, name, md)
+			continue
+		}
 		sum := countMetric.SetEmptySum()
 		// The delta value is always positive, so a value accumulated downstream is monotonic
 		sum.SetIsMonotonic(true)
@@ -126,3 +200,46 @@ func (c *counter[K]) appendMetricsTo(metricSlice pmetric.MetricSlice) {
 		}
 	}
 }
+
+func (c *counter[K]) appendValueMetric(metric pmetric.Metric, name string, md metricDef[K]) {
+	timestamp := pcommon.NewTimestampFromTime(c.timestamp)
+	switch md.aggregation {
+	case AggregationMin, AggregationMax:
+		gauge := metric.SetEmptyGauge()
+		for _, ac := range c.counts[name] {
+			dp := gauge.DataPoints().AppendEmpty()
+			ac.attrs.CopyTo(dp.Attributes())
+			if md.aggregation == AggregationMin {
+				dp.SetDoubleValue(ac.min)
+			} else {
+				dp.SetDoubleValue(ac.max)
+			}
+			dp.SetTimestamp(timestamp)
+		}
+	case AggregationHistogram:
+		histogram := metric.SetEmptyHistogram()
+		histogram.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		for _, ac := range c.counts[name] {
+			dp := histogram.DataPoints().AppendEmpty()
+			ac.attrs.CopyTo(dp.Attributes())
+			dp.SetCount(ac.count)
+			dp.SetSum(ac.sum)
+			dp.SetMin(ac.min)
+			dp.SetMax(ac.max)
+			dp.ExplicitBounds().FromRaw(md.buckets)
+			dp.BucketCounts().FromRaw(ac.bucketCounts)
+			dp.SetTimestamp(timestamp)
+		}
+	default:
+		sum := metric.SetEmptySum()
+		// Values may be negative, so the sum is not necessarily monotonic
+		sum.SetIsMonotonic(false)
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		for _, ac := range c.counts[name] {
+			dp := sum.DataPoints().AppendEmpty()
+			ac.attrs.CopyTo(dp.Attributes())
+			dp.SetDoubleValue(ac.sum)
+			dp.SetTimestamp(timestamp)
+		}
+	}
+}
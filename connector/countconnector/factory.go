@@ -146,6 +146,15 @@ func createLogsToMetrics(
 			condition, _ := filterottl.NewBoolExprForLog(info.Conditions, filterottl.StandardLogFuncs(), ottl.PropagateError, set.TelemetrySettings)
 			md.condition = condition
 		}
+		if info.Value != "" {
+			// Error checked in Config.Validate()
+			md.value, _ = newLogValueExpr(info.Value, set.TelemetrySettings)
+			md.aggregation = info.aggregation()
+			md.buckets = info.Buckets
+			if md.aggregation == AggregationHistogram && len(md.buckets) == 0 {
+				md.buckets = defaultBuckets
+			}
+		}
 		metricDefs[name] = md
 	}
 
@@ -159,4 +168,17 @@ type metricDef[K any] struct {
 	condition expr.BoolExpr[K]
 	desc      string
 	attrs     []AttributeConfig
+
+	// value is only set for metrics that aggregate a value instead of counting
+	value       *ottl.ValueExpression[K]
+	aggregation Aggregation
+	buckets     []float64
+}
+
+func newLogValueExpr(value string, set component.TelemetrySettings) (*ottl.ValueExpression[ottllog.TransformContext], error) {
+	parser, err := ottllog.NewParser(filterottl.StandardLogFuncs(), set)
+	if err != nil {
+		return nil, err
+	}
+	return parser.ParseValueExpression(value)
 }
@@ -13,6 +13,9 @@ import (
 var (
 	errNoPipelinePriority    = errors.New("No pipelines are defined in the priority list")
 	errInvalidRetryIntervals = errors.New("Retry interval must be positive, and retry_interval must be greater than retry_gap times the length of the priority list")
+	errInvalidHealthWindow   = errors.New("Health window and open_duration must be positive, and latency_threshold must not be negative")
+	errInvalidHealthRatios   = errors.New("Health error_rate_threshold and probe_ratio must be greater than 0 and at most 1")
+	errInvalidHealthCounts   = errors.New("Health min_requests and success_threshold must be positive")
 )
 
 type Config struct {
@@ -33,6 +36,44 @@ type Config struct {
 	// MaxRetry is the maximum retries per level, once this limit is hit for a level, even if the next pipeline level fails,
 	// it will not try to recover the level that exceeded the maximum retries
 	MaxRetries int `mapstructure:"max_retries"`
+
+	// Health configures the health scored failover, which replaces the retry schedule when enabled
+	Health HealthSettings `mapstructure:"health"`
+}
+
+// HealthSettings configures how the health of the priority levels is scored. The error rate and latency of
+// each level are measured over a sliding window, and a level whose circuit breaker opens is failed over
+// until it recovered from probing.
+type HealthSettings struct {
+	// Enabled enables the health scored failover
+	Enabled bool `mapstructure:"enabled"`
+
+	// Window is the duration of the sliding window the error rate and latency are measured over
+	Window time.Duration `mapstructure:"window"`
+
+	// MinRequests is the minimum number of requests within the window before the health of a level is scored
+	MinRequests int `mapstructure:"min_requests"`
+
+	// ErrorRateThreshold is the rate of failed requests within the window, from 0 to 1, at which the
+	// circuit breaker of a level opens
+	ErrorRateThreshold float64 `mapstructure:"error_rate_threshold"`
+
+	// LatencyThreshold is the average latency within the window above which the circuit breaker of a level
+	// opens, 0 to ignore the latency. Probes slower than the threshold are considered failed
+	LatencyThreshold time.Duration `mapstructure:"latency_threshold"`
+
+	// OpenDuration is how long the circuit breaker of a level stays open before the level is probed
+	OpenDuration time.Duration `mapstructure:"open_duration"`
+
+	// ProbeRatio is the fraction of the traffic, from 0 to 1, sent to a level being probed
+	ProbeRatio float64 `mapstructure:"probe_ratio"`
+
+	// SuccessThreshold is the number of consecutive successful probes after which a level is healthy again
+	SuccessThreshold int `mapstructure:"success_threshold"`
+
+	// Mirror sends the probes to both the level being probed and the current level instead of only the
+	// level being probed, so that no data is lost while failing back
+	Mirror bool `mapstructure:"mirror"`
 }
 
 // Validate needs to ensure RetryInterval > # elements in PriorityList * RetryGap
@@ -44,5 +85,21 @@ func (c *Config) Validate() error {
 	if c.RetryGap <= 0 || c.RetryInterval <= 0 || c.RetryInterval <= retryTime {
 		return errInvalidRetryIntervals
 	}
+	if c.Health.Enabled {
+		return c.Health.validate()
+	}
+	return nil
+}
+
+func (h *HealthSettings) validate() error {
+	if h.Window <= 0 || h.OpenDuration <= 0 || h.LatencyThreshold < 0 {
+		return errInvalidHealthWindow
+	}
+	if h.ErrorRateThreshold <= 0 || h.ErrorRateThreshold > 1 || h.ProbeRatio <= 0 || h.ProbeRatio > 1 {
+		return errInvalidHealthRatios
+	}
+	if h.MinRequests <= 0 || h.SuccessThreshold <= 0 {
+		return errInvalidHealthCounts
+	}
 	return nil
 }
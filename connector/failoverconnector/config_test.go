@@ -17,6 +17,7 @@ import (
 )
 
 func TestLoadConfig(t *testing.T) {
+	defaultHealth := createDefaultConfig().(*Config).Health
 	testcases := []struct {
 		id       component.ID
 		expected *Config
@@ -32,6 +33,7 @@ func TestLoadConfig(t *testing.T) {
 				RetryInterval: 10 * time.Minute,
 				RetryGap:      30 * time.Second,
 				MaxRetries:    10,
+				Health:        defaultHealth,
 			},
 		},
 		{
@@ -55,6 +57,34 @@ func TestLoadConfig(t *testing.T) {
 				RetryInterval: 5 * time.Minute,
 				RetryGap:      time.Minute,
 				MaxRetries:    10,
+				Health:        defaultHealth,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "health"),
+			expected: &Config{
+				PipelinePriority: [][]component.ID{
+					{
+						component.NewIDWithName(component.DataTypeTraces, "first"),
+					},
+					{
+						component.NewIDWithName(component.DataTypeTraces, "second"),
+					},
+				},
+				RetryInterval: 10 * time.Minute,
+				RetryGap:      30 * time.Second,
+				MaxRetries:    10,
+				Health: HealthSettings{
+					Enabled:            true,
+					Window:             2 * time.Minute,
+					MinRequests:        20,
+					ErrorRateThreshold: 0.25,
+					LatencyThreshold:   5 * time.Second,
+					OpenDuration:       time.Minute,
+					ProbeRatio:         0.2,
+					SuccessThreshold:   10,
+					Mirror:             true,
+				},
 			},
 		},
 	}
@@ -93,6 +123,11 @@ func TestValidateConfig(t *testing.T) {
 			id:   component.NewIDWithName(metadata.Type, "invalid"),
 			err:  errInvalidRetryIntervals,
 		},
+		{
+			name: "invalid error_rate_threshold",
+			id:   component.NewIDWithName(metadata.Type, "invalid_health"),
+			err:  errInvalidHealthRatios,
+		},
 	}
 
 	for _, tc := range testcases {
@@ -29,6 +29,14 @@ func createDefaultConfig() component.Config {
 		RetryGap:      30 * time.Second,
 		RetryInterval: 10 * time.Minute,
 		MaxRetries:    10,
+		Health: HealthSettings{
+			Window:             time.Minute,
+			MinRequests:        10,
+			ErrorRateThreshold: 0.5,
+			OpenDuration:       30 * time.Second,
+			ProbeRatio:         0.1,
+			SuccessThreshold:   5,
+		},
 	}
 }
 
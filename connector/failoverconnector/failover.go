@@ -4,8 +4,10 @@
 package failoverconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector"
 
 import (
+	"context"
 	"errors"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 
@@ -18,6 +20,7 @@ type failoverRouter[C any] struct {
 	consumerProvider consumerProvider[C]
 	cfg              *Config
 	pS               *state.PipelineSelector
+	hS               *state.HealthSelector
 	wg               *sync.WaitGroup
 	consumers        []C
 
@@ -40,15 +43,56 @@ func newFailoverRouter[C any](provider consumerProvider[C], cfg *Config) *failov
 
 	selector := state.NewPipelineSelector(len(cfg.PipelinePriority), pSConstants)
 	selector.Start(done, &wg)
+
+	var healthSelector *state.HealthSelector
+	if cfg.Health.Enabled {
+		healthSelector = state.NewHealthSelector(len(cfg.PipelinePriority), state.HSConstants{
+			Window:             cfg.Health.Window,
+			MinRequests:        cfg.Health.MinRequests,
+			ErrorRateThreshold: cfg.Health.ErrorRateThreshold,
+			LatencyThreshold:   cfg.Health.LatencyThreshold,
+			OpenDuration:       cfg.Health.OpenDuration,
+			ProbeRatio:         cfg.Health.ProbeRatio,
+			SuccessThreshold:   cfg.Health.SuccessThreshold,
+		})
+	}
 	return &failoverRouter[C]{
 		consumerProvider: provider,
 		cfg:              cfg,
 		pS:               selector,
+		hS:               healthSelector,
 		done:             done,
 		wg:               &wg,
 	}
 }
 
+// routeByHealth sends the data to the level selected from the health of the levels, and to the next levels
+// whose circuit breaker isn't open in the case of an error. When the request probes a recovering level,
+// the data is sent to it first, or a copy of the data is mirrored to it if mirroring is enabled.
+func (f *failoverRouter[C]) routeByHealth(ctx context.Context, consume func(context.Context, C) error, consumeCopy func(context.Context, C) error) error {
+	route := f.hS.Route()
+	if route.Probe != -1 {
+		if f.cfg.Health.Mirror {
+			_ = f.consumeAndReport(ctx, route.Probe, consumeCopy)
+		} else if f.consumeAndReport(ctx, route.Probe, consume) == nil {
+			return nil
+		}
+	}
+	for idx := route.Primary; idx != -1; idx = f.hS.Next(idx) {
+		if f.consumeAndReport(ctx, idx, consume) == nil {
+			return nil
+		}
+	}
+	return errNoValidPipeline
+}
+
+func (f *failoverRouter[C]) consumeAndReport(ctx context.Context, idx int, consume func(context.Context, C) error) error {
+	start := time.Now()
+	err := consume(ctx, f.consumers[idx])
+	f.hS.Report(idx, err, time.Since(start))
+	return err
+}
+
 func (f *failoverRouter[C]) getCurrentConsumer() (C, chan bool, bool) {
 	var nilConsumer C
 	pl, ch := f.pS.SelectedPipeline()
@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package state // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector/internal/state"
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// windowBuckets is the number of buckets the sliding window of a level is split into
+const windowBuckets = 10
+
+type HSConstants struct {
+	Window             time.Duration
+	MinRequests        int
+	ErrorRateThreshold float64
+	LatencyThreshold   time.Duration
+	OpenDuration       time.Duration
+	ProbeRatio         float64
+	SuccessThreshold   int
+}
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+type bucket struct {
+	epoch    int64
+	requests int
+	failures int
+	latency  time.Duration
+}
+
+// slidingWindow counts the requests, failures and latency of a level over the last window, in buckets
+type slidingWindow struct {
+	width   time.Duration
+	buckets [windowBuckets]bucket
+}
+
+func (w *slidingWindow) epoch(now time.Time) int64 {
+	return now.UnixNano() / int64(w.width)
+}
+
+func (w *slidingWindow) add(now time.Time, failed bool, latency time.Duration) {
+	epoch := w.epoch(now)
+	b := &w.buckets[epoch%windowBuckets]
+	if b.epoch != epoch {
+		*b = bucket{epoch: epoch}
+	}
+	b.requests++
+	if failed {
+		b.failures++
+	}
+	b.latency += latency
+}
+
+func (w *slidingWindow) totals(now time.Time) (requests int, failures int, latency time.Duration) {
+	epoch := w.epoch(now)
+	for _, b := range w.buckets {
+		if b.epoch > epoch-windowBuckets && b.epoch <= epoch {
+			requests += b.requests
+			failures += b.failures
+			latency += b.latency
+		}
+	}
+	return requests, failures, latency
+}
+
+func (w *slidingWindow) reset() {
+	w.buckets = [windowBuckets]bucket{}
+}
+
+// levelHealth is the circuit breaker of a priority level
+type levelHealth struct {
+	lock           sync.Mutex
+	window         slidingWindow
+	state          breakerState
+	openedAt       time.Time
+	probeSuccesses int
+}
+
+// loadState returns the state of the breaker, which becomes half-open once it was open for the open duration
+func (l *levelHealth) loadState(now time.Time, openDuration time.Duration) breakerState {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.state == breakerOpen && now.Sub(l.openedAt) >= openDuration {
+		l.state = breakerHalfOpen
+		l.probeSuccesses = 0
+	}
+	return l.state
+}
+
+func (l *levelHealth) open(now time.Time) {
+	l.state = breakerOpen
+	l.openedAt = now
+	l.probeSuccesses = 0
+}
+
+// Route is the decision of the HealthSelector for a single request
+type Route struct {
+	// Primary is the level the data is sent to, -1 if the breakers of all levels are open
+	Primary int
+	// Probe is the recovering level of higher priority the request is also sent to, -1 if none
+	Probe int
+}
+
+// HealthSelector selects the priority level from the error rate and latency of each level over a sliding
+// window. Each level has a circuit breaker, which opens when the level is unhealthy and becomes half-open
+// after the open duration: a fraction of the requests then probe the level, which is closed again once
+// enough consecutive probes succeeded.
+type HealthSelector struct {
+	constants HSConstants
+	levels    []*levelHealth
+	requests  atomic.Uint64
+	now       func() time.Time
+}
+
+func NewHealthSelector(lenPriority int, consts HSConstants) *HealthSelector {
+	width := consts.Window / windowBuckets
+	if width <= 0 {
+		width = 1
+	}
+	levels := make([]*levelHealth, lenPriority)
+	for i := range levels {
+		levels[i] = &levelHealth{window: slidingWindow{width: width}}
+	}
+	return &HealthSelector{
+		constants: consts,
+		levels:    levels,
+		now:       time.Now,
+	}
+}
+
+// Route returns the highest priority level whose breaker is closed, along with the highest priority
+// half-open level above it when the request is selected as a probe
+func (s *HealthSelector) Route() Route {
+	now := s.now()
+	route := Route{Primary: -1, Probe: -1}
+	for i, l := range s.levels {
+		state := l.loadState(now, s.constants.OpenDuration)
+		if state == breakerClosed {
+			route.Primary = i
+			break
+		}
+		if state == breakerHalfOpen && route.Probe == -1 {
+			route.Probe = i
+		}
+	}
+	if route.Primary == -1 {
+		// no level is healthy, the recovering level takes all the traffic
+		route.Primary, route.Probe = route.Probe, -1
+		return route
+	}
+	if route.Probe != -1 && !s.sampleProbe() {
+		route.Probe = -1
+	}
+	return route
+}
+
+// Next returns the first level after idx whose breaker isn't open, -1 if none
+func (s *HealthSelector) Next(idx int) int {
+	now := s.now()
+	for i := idx + 1; i < len(s.levels); i++ {
+		if s.levels[i].loadState(now, s.constants.OpenDuration) != breakerOpen {
+			return i
+		}
+	}
+	return -1
+}
+
+// Report records the outcome of sending data to a level
+func (s *HealthSelector) Report(idx int, err error, latency time.Duration) {
+	now := s.now()
+	failed := err != nil
+	slow := s.constants.LatencyThreshold > 0 && latency > s.constants.LatencyThreshold
+
+	l := s.levels[idx]
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	switch l.state {
+	case breakerClosed:
+		l.window.add(now, failed, latency)
+		if s.unhealthy(&l.window, now) {
+			l.open(now)
+		}
+	case breakerHalfOpen:
+		if failed || slow {
+			l.open(now)
+			return
+		}
+		l.probeSuccesses++
+		if l.probeSuccesses >= s.constants.SuccessThreshold {
+			l.state = breakerClosed
+			l.window.reset()
+		}
+	case breakerOpen:
+		// late outcome of a request sent before the breaker opened
+	}
+}
+
+func (s *HealthSelector) unhealthy(w *slidingWindow, now time.Time) bool {
+	requests, failures, latency := w.totals(now)
+	if requests == 0 || requests < s.constants.MinRequests {
+		return false
+	}
+	if float64(failures)/float64(requests) >= s.constants.ErrorRateThreshold {
+		return true
+	}
+	return s.constants.LatencyThreshold > 0 && latency/time.Duration(requests) > s.constants.LatencyThreshold
+}
+
+// sampleProbe selects ProbeRatio of the requests as probes, evenly spread
+func (s *HealthSelector) sampleProbe() bool {
+	n := float64(s.requests.Add(1))
+	return math.Floor(n*s.constants.ProbeRatio) > math.Floor((n-1)*s.constants.ProbeRatio)
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package state
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errTest = errors.New("test error")
+
+func newTestHealthSelector(lenPriority int) (*HealthSelector, *time.Time) {
+	constants := HSConstants{
+		Window:             time.Minute,
+		MinRequests:        4,
+		ErrorRateThreshold: 0.5,
+		LatencyThreshold:   time.Second,
+		OpenDuration:       30 * time.Second,
+		ProbeRatio:         0.5,
+		SuccessThreshold:   2,
+	}
+	hS := NewHealthSelector(lenPriority, constants)
+	now := time.Unix(1700000000, 0)
+	hS.now = func() time.Time { return now }
+	return hS, &now
+}
+
+func TestHealthSelectorErrorRate(t *testing.T) {
+	hS, _ := newTestHealthSelector(3)
+	require.Equal(t, Route{Primary: 0, Probe: -1}, hS.Route())
+
+	// a single error doesn't open the breaker before enough requests are seen
+	hS.Report(0, errTest, time.Millisecond)
+	require.Equal(t, 0, hS.Route().Primary)
+
+	hS.Report(0, nil, time.Millisecond)
+	hS.Report(0, nil, time.Millisecond)
+	require.Equal(t, 0, hS.Route().Primary)
+
+	hS.Report(0, errTest, time.Millisecond)
+	require.Equal(t, Route{Primary: 1, Probe: -1}, hS.Route())
+	require.Equal(t, 1, hS.Next(-1))
+	require.Equal(t, 2, hS.Next(1))
+	require.Equal(t, -1, hS.Next(2))
+}
+
+func TestHealthSelectorLatency(t *testing.T) {
+	hS, _ := newTestHealthSelector(2)
+	for i := 0; i < 4; i++ {
+		hS.Report(0, nil, 2*time.Second)
+	}
+	require.Equal(t, 1, hS.Route().Primary)
+}
+
+func TestHealthSelectorSlidingWindow(t *testing.T) {
+	hS, now := newTestHealthSelector(2)
+	hS.Report(0, errTest, time.Millisecond)
+	hS.Report(0, errTest, time.Millisecond)
+	hS.Report(0, errTest, time.Millisecond)
+
+	// the errors fall out of the window
+	*now = now.Add(2 * time.Minute)
+	hS.Report(0, errTest, time.Millisecond)
+	require.Equal(t, 0, hS.Route().Primary)
+}
+
+func TestHealthSelectorHalfOpen(t *testing.T) {
+	hS, now := newTestHealthSelector(2)
+	for i := 0; i < 4; i++ {
+		hS.Report(0, errTest, time.Millisecond)
+	}
+	require.Equal(t, Route{Primary: 1, Probe: -1}, hS.Route())
+
+	// half of the requests probe the recovering level once the open duration passed
+	*now = now.Add(30 * time.Second)
+	require.Equal(t, Route{Primary: 1, Probe: -1}, hS.Route())
+	require.Equal(t, Route{Primary: 1, Probe: 0}, hS.Route())
+	require.Equal(t, Route{Primary: 1, Probe: -1}, hS.Route())
+	require.Equal(t, Route{Primary: 1, Probe: 0}, hS.Route())
+
+	// a failed probe opens the breaker again
+	hS.Report(0, errTest, time.Millisecond)
+	require.Equal(t, Route{Primary: 1, Probe: -1}, hS.Route())
+	require.Equal(t, Route{Primary: 1, Probe: -1}, hS.Route())
+
+	// the level is closed after enough successful probes
+	*now = now.Add(30 * time.Second)
+	hS.Route()
+	hS.Report(0, nil, time.Millisecond)
+	require.Equal(t, 1, hS.Route().Primary)
+	hS.Report(0, nil, time.Millisecond)
+	require.Equal(t, Route{Primary: 0, Probe: -1}, hS.Route())
+}
+
+func TestHealthSelectorSlowProbe(t *testing.T) {
+	hS, now := newTestHealthSelector(2)
+	for i := 0; i < 4; i++ {
+		hS.Report(0, errTest, time.Millisecond)
+	}
+	*now = now.Add(30 * time.Second)
+	hS.Route()
+
+	hS.Report(0, nil, 2*time.Second)
+	*now = now.Add(29 * time.Second)
+	require.Equal(t, -1, hS.Route().Probe)
+	require.Equal(t, -1, hS.Route().Probe)
+}
+
+func TestHealthSelectorAllLevelsUnhealthy(t *testing.T) {
+	hS, now := newTestHealthSelector(2)
+	for i := 0; i < 4; i++ {
+		hS.Report(0, errTest, time.Millisecond)
+		hS.Report(1, errTest, time.Millisecond)
+	}
+	require.Equal(t, Route{Primary: -1, Probe: -1}, hS.Route())
+
+	// the first recovering level takes all the traffic
+	*now = now.Add(30 * time.Second)
+	require.Equal(t, Route{Primary: 0, Probe: -1}, hS.Route())
+	require.Equal(t, Route{Primary: 0, Probe: -1}, hS.Route())
+}
@@ -29,6 +29,9 @@ func (f *logsFailover) Capabilities() consumer.Capabilities {
 
 // ConsumeLogs will try to export to the current set priority level and handle failover in the case of an error
 func (f *logsFailover) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	if f.failover.hS != nil {
+		return f.consumeLogsByHealth(ctx, ld)
+	}
 	tc, ch, ok := f.failover.getCurrentConsumer()
 	if !ok {
 		return errNoValidPipeline
@@ -56,6 +59,21 @@ func (f *logsFailover) FailoverLogs(ctx context.Context, ld plog.Logs) error {
 	return errNoValidPipeline
 }
 
+// consumeLogsByHealth routes the logs according to the health of the priority levels
+func (f *logsFailover) consumeLogsByHealth(ctx context.Context, ld plog.Logs) error {
+	err := f.failover.routeByHealth(ctx, func(ctx context.Context, lc consumer.Logs) error {
+		return lc.ConsumeLogs(ctx, ld)
+	}, func(ctx context.Context, lc consumer.Logs) error {
+		clone := plog.NewLogs()
+		ld.CopyTo(clone)
+		return lc.ConsumeLogs(ctx, clone)
+	})
+	if err != nil {
+		f.logger.Error("All provided pipelines return errors, dropping data")
+	}
+	return err
+}
+
 func (f *logsFailover) Shutdown(_ context.Context) error {
 	if f.failover != nil {
 		f.failover.Shutdown()
@@ -29,6 +29,9 @@ func (f *metricsFailover) Capabilities() consumer.Capabilities {
 
 // ConsumeMetrics will try to export to the current set priority level and handle failover in the case of an error
 func (f *metricsFailover) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if f.failover.hS != nil {
+		return f.consumeMetricsByHealth(ctx, md)
+	}
 	tc, ch, ok := f.failover.getCurrentConsumer()
 	if !ok {
 		return errNoValidPipeline
@@ -56,6 +59,21 @@ func (f *metricsFailover) FailoverMetrics(ctx context.Context, md pmetric.Metric
 	return errNoValidPipeline
 }
 
+// consumeMetricsByHealth routes the metrics according to the health of the priority levels
+func (f *metricsFailover) consumeMetricsByHealth(ctx context.Context, md pmetric.Metrics) error {
+	err := f.failover.routeByHealth(ctx, func(ctx context.Context, mc consumer.Metrics) error {
+		return mc.ConsumeMetrics(ctx, md)
+	}, func(ctx context.Context, mc consumer.Metrics) error {
+		clone := pmetric.NewMetrics()
+		md.CopyTo(clone)
+		return mc.ConsumeMetrics(ctx, clone)
+	})
+	if err != nil {
+		f.logger.Error("All provided pipelines return errors, dropping data")
+	}
+	return err
+}
+
 func (f *metricsFailover) Shutdown(_ context.Context) error {
 	if f.failover != nil {
 		f.failover.Shutdown()
@@ -29,6 +29,9 @@ func (f *tracesFailover) Capabilities() consumer.Capabilities {
 
 // ConsumeTraces will try to export to the current set priority level and handle failover in the case of an error
 func (f *tracesFailover) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	if f.failover.hS != nil {
+		return f.consumeTracesByHealth(ctx, td)
+	}
 	tc, ch, ok := f.failover.getCurrentConsumer()
 	if !ok {
 		return errNoValidPipeline
@@ -56,6 +59,21 @@ func (f *tracesFailover) FailoverTraces(ctx context.Context, td ptrace.Traces) e
 	return errNoValidPipeline
 }
 
+// consumeTracesByHealth routes the traces according to the health of the priority levels
+func (f *tracesFailover) consumeTracesByHealth(ctx context.Context, td ptrace.Traces) error {
+	err := f.failover.routeByHealth(ctx, func(ctx context.Context, tc consumer.Traces) error {
+		return tc.ConsumeTraces(ctx, td)
+	}, func(ctx context.Context, tc consumer.Traces) error {
+		clone := ptrace.NewTraces()
+		td.CopyTo(clone)
+		return tc.ConsumeTraces(ctx, clone)
+	})
+	if err != nil {
+		f.logger.Error("All provided pipelines return errors, dropping data")
+	}
+	return err
+}
+
 func (f *tracesFailover) Shutdown(_ context.Context) error {
 	if f.failover != nil {
 		f.failover.Shutdown()
@@ -5,6 +5,7 @@ package failoverconnector // import "github.com/open-telemetry/opentelemetry-col
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -16,6 +17,8 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector/internal/state"
 )
 
 var errTracesConsumer = errors.New("Error from ConsumeTraces")
@@ -137,6 +140,99 @@ func TestTracesWithFailoverError(t *testing.T) {
 	assert.EqualError(t, conn.ConsumeTraces(context.Background(), tr), "All provided pipelines return errors")
 }
 
+func TestTracesWithHealthFailover(t *testing.T) {
+	for _, mirror := range []bool{false, true} {
+		t.Run(fmt.Sprintf("mirror %t", mirror), func(t *testing.T) {
+			var sinkFirst, sinkSecond consumertest.TracesSink
+			tracesFirst := component.NewIDWithName(component.DataTypeTraces, "traces/first")
+			tracesSecond := component.NewIDWithName(component.DataTypeTraces, "traces/second")
+
+			cfg := &Config{
+				PipelinePriority: [][]component.ID{{tracesFirst}, {tracesSecond}},
+				RetryInterval:    50 * time.Millisecond,
+				RetryGap:         10 * time.Millisecond,
+				MaxRetries:       10000,
+				Health: HealthSettings{
+					Enabled:            true,
+					Window:             time.Minute,
+					MinRequests:        2,
+					ErrorRateThreshold: 0.5,
+					OpenDuration:       100 * time.Millisecond,
+					ProbeRatio:         1,
+					SuccessThreshold:   2,
+					Mirror:             mirror,
+				},
+			}
+
+			router := connector.NewTracesRouter(map[component.ID]consumer.Traces{
+				tracesFirst:  &sinkFirst,
+				tracesSecond: &sinkSecond,
+			})
+
+			conn, err := NewFactory().CreateTracesToTraces(context.Background(),
+				connectortest.NewNopSettings(), cfg, router.(consumer.Traces))
+			require.NoError(t, err)
+
+			failoverConnector := conn.(*tracesFailover)
+			failoverConnector.failover.ModifyConsumerAtIndex(0, consumertest.NewErr(errTracesConsumer))
+			defer func() {
+				assert.NoError(t, failoverConnector.Shutdown(context.Background()))
+			}()
+
+			tr := sampleTrace()
+
+			// the errors of the first level open its breaker, the data going to the second level
+			for i := 0; i < 3; i++ {
+				require.NoError(t, conn.ConsumeTraces(context.Background(), tr))
+			}
+			assert.Equal(t, 3, sinkSecond.SpanCount())
+			assert.Equal(t, state.Route{Primary: 1, Probe: -1}, failoverConnector.failover.hS.Route())
+
+			// the first level recovers and is probed until it's healthy again
+			failoverConnector.failover.ModifyConsumerAtIndex(0, &sinkFirst)
+			requests := 3
+			require.Eventually(t, func() bool {
+				requests++
+				require.NoError(t, conn.ConsumeTraces(context.Background(), tr))
+				return sinkFirst.SpanCount() == 2
+			}, 3*time.Second, 5*time.Millisecond)
+			if mirror {
+				assert.Equal(t, requests, sinkSecond.SpanCount())
+			} else {
+				assert.Equal(t, requests, sinkFirst.SpanCount()+sinkSecond.SpanCount())
+			}
+
+			secondSpans := sinkSecond.SpanCount()
+			require.NoError(t, conn.ConsumeTraces(context.Background(), tr))
+			assert.Equal(t, 3, sinkFirst.SpanCount())
+			assert.Equal(t, secondSpans, sinkSecond.SpanCount())
+		})
+	}
+}
+
+func TestTracesWithHealthFailoverError(t *testing.T) {
+	tracesFirst := component.NewIDWithName(component.DataTypeTraces, "traces/first")
+	tracesSecond := component.NewIDWithName(component.DataTypeTraces, "traces/second")
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.PipelinePriority = [][]component.ID{{tracesFirst}, {tracesSecond}}
+	cfg.Health.Enabled = true
+
+	router := connector.NewTracesRouter(map[component.ID]consumer.Traces{
+		tracesFirst:  consumertest.NewErr(errTracesConsumer),
+		tracesSecond: consumertest.NewErr(errTracesConsumer),
+	})
+
+	conn, err := NewFactory().CreateTracesToTraces(context.Background(),
+		connectortest.NewNopSettings(), cfg, router.(consumer.Traces))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, conn.Shutdown(context.Background()))
+	}()
+
+	assert.EqualError(t, conn.ConsumeTraces(context.Background(), sampleTrace()), "All provided pipelines return errors")
+}
+
 func consumeTracesAndCheckStable(conn *tracesFailover, idx int, tr ptrace.Traces) bool {
 	_ = conn.ConsumeTraces(context.Background(), tr)
 	stableIndex := conn.failover.pS.TestStableIndex()
@@ -5,6 +5,7 @@ package routingconnector // import "github.com/open-telemetry/opentelemetry-coll
 
 import (
 	"errors"
+	"fmt"
 
 	"go.opentelemetry.io/collector/component"
 
@@ -16,6 +17,17 @@ var (
 	errNoPipelines        = errors.New("invalid route: no pipelines defined")
 	errUnexpectedConsumer = errors.New("expected consumer to be a connector router")
 	errNoTableItems       = errors.New("invalid routing table: the routing table is empty")
+	errInvalidContext     = errors.New("invalid route: unsupported context")
+)
+
+// The contexts the statement of a route can be evaluated in
+const (
+	resourceContext  = "resource"
+	requestContext   = "request"
+	spanContext      = "span"
+	metricContext    = "metric"
+	dataPointContext = "datapoint"
+	logContext       = "log"
 )
 
 // Config defines configuration for the Routing processor.
@@ -62,6 +74,16 @@ func (c *Config) Validate() error {
 		if len(item.Pipelines) == 0 {
 			return errNoPipelines
 		}
+
+		switch item.Context {
+		case "", resourceContext, spanContext, metricContext, dataPointContext, logContext:
+		case requestContext:
+			if _, err := parseRequestCondition(item.Statement); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%w: %q", errInvalidContext, item.Context)
+		}
 	}
 
 	return nil
@@ -69,6 +91,13 @@ func (c *Config) Validate() error {
 
 // RoutingTableItem specifies how data should be routed to the different pipelines
 type RoutingTableItem struct {
+	// Context is the context the statement is evaluated in, one of `resource`, `request`, `span`, `metric`,
+	// `datapoint` or `log`. Statements in the `request` context match on the metadata of the request, such
+	// as HTTP headers or gRPC metadata: `route() where request["X-Tenant"] == "acme"`. Routes in the span,
+	// metric, datapoint or log contexts route the matching records only, splitting them from their resource.
+	// Optional. Defaults to `resource`.
+	Context string `mapstructure:"context"`
+
 	// Statement is a OTTL statement used for making a routing decision.
 	// Required when 'Value' isn't provided.
 	Statement string `mapstructure:"statement"`
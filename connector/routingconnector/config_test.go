@@ -44,6 +44,13 @@ func TestLoadConfig(t *testing.T) {
 							component.NewIDWithName(component.DataTypeTraces, "otlp-globex"),
 						},
 					},
+					{
+						Context:   "request",
+						Statement: `route() where request["X-Tenant"] == "initech"`,
+						Pipelines: []component.ID{
+							component.NewIDWithName(component.DataTypeTraces, "otlp-initech"),
+						},
+					},
 				},
 			},
 		},
@@ -156,6 +163,36 @@ func TestValidateConfig(t *testing.T) {
 			},
 			error: "invalid routing table: the routing table is empty",
 		},
+		{
+			name: "unsupported context",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Context:   "scope",
+						Statement: `route() where name == "acme"`,
+						Pipelines: []component.ID{
+							component.NewIDWithName(component.DataTypeTraces, "otlp"),
+						},
+					},
+				},
+			},
+			error: `invalid route: unsupported context: "scope"`,
+		},
+		{
+			name: "unsupported request statement",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Context:   "request",
+						Statement: `route() where IsMatch(request["X-Tenant"], ".*corp")`,
+						Pipelines: []component.ID{
+							component.NewIDWithName(component.DataTypeTraces, "otlp"),
+						},
+					},
+				},
+			},
+			error: `invalid route: unsupported statement in the request context: "route() where IsMatch(request[\"X-Tenant\"], \".*corp\")", expected route() where request["<key>"] == "<value>" or !=`,
+		},
 		{
 			name:   "empty config",
 			config: &Config{},
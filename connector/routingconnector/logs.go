@@ -14,6 +14,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
 )
 
@@ -24,6 +25,8 @@ type logsConnector struct {
 	logger *zap.Logger
 	config *Config
 	router *router[consumer.Logs]
+	// byLog is set when a route is in the log context, the log records being routed one by one
+	byLog bool
 }
 
 func newLogsConnector(
@@ -47,11 +50,15 @@ func newLogsConnector(
 	if err != nil {
 		return nil, err
 	}
+	if err = r.checkContexts(logContext); err != nil {
+		return nil, err
+	}
 
 	return &logsConnector{
 		logger: set.TelemetrySettings.Logger,
 		config: cfg,
 		router: r,
+		byLog:  r.hasRouteIn(logContext),
 	}, nil
 }
 
@@ -60,6 +67,10 @@ func (c *logsConnector) Capabilities() consumer.Capabilities {
 }
 
 func (c *logsConnector) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	if c.byLog {
+		return c.consumeByLog(ctx, ld)
+	}
+
 	// routingEntry is used to group plog.ResourceLogs that are routed to
 	// the same set of exporters.
 	// This way we're not ending up with all the logs split up which would cause
@@ -73,7 +84,7 @@ func (c *logsConnector) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 
 		noRoutesMatch := true
 		for _, route := range c.router.routeSlice {
-			_, isMatch, err := route.statement.Execute(ctx, rtx)
+			isMatch, err := route.matchResource(ctx, rtx)
 			if err != nil {
 				if c.config.ErrorMode == ottl.PropagateError {
 					return err
@@ -117,3 +128,80 @@ func (c *logsConnector) group(
 	logs.CopyTo(group.ResourceLogs().AppendEmpty())
 	groups[consumer] = group
 }
+
+// consumeByLog routes the log records one by one, grouping the log records routed to the same set of
+// pipelines under copies of their resource and scope.
+func (c *logsConnector) consumeByLog(ctx context.Context, ld plog.Logs) error {
+	groups := make(map[consumer.Logs]*logGroup)
+
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rlogs := ld.ResourceLogs().At(i)
+		rtx := ottlresource.NewTransformContext(rlogs.Resource())
+		for j := 0; j < rlogs.ScopeLogs().Len(); j++ {
+			slogs := rlogs.ScopeLogs().At(j)
+			for k := 0; k < slogs.LogRecords().Len(); k++ {
+				log := slogs.LogRecords().At(k)
+				ltx := ottllog.NewTransformContext(log, slogs.Scope(), rlogs.Resource())
+				err := c.router.matchRecord(func(route routingItem[consumer.Logs]) (bool, error) {
+					if route.logStatement == nil {
+						return route.matchResource(ctx, rtx)
+					}
+					_, isMatch, err := route.logStatement.Execute(ctx, ltx)
+					return isMatch, err
+				}, c.config.ErrorMode, c.config.MatchOnce, func(consumer consumer.Logs) {
+					groupLog(groups, consumer, i, j, rlogs, slogs, log)
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	var errs error
+	for consumer, group := range groups {
+		errs = errors.Join(errs, consumer.ConsumeLogs(ctx, group.logs))
+	}
+	return errs
+}
+
+// logGroup holds the log records routed to a consumer. resource and scope are the indexes of the resource
+// and scope the last log record came from, the log records being grouped in order.
+type logGroup struct {
+	logs     plog.Logs
+	resource int
+	scope    int
+}
+
+func groupLog(
+	groups map[consumer.Logs]*logGroup,
+	consumer consumer.Logs,
+	resource, scope int,
+	rlogs plog.ResourceLogs,
+	slogs plog.ScopeLogs,
+	log plog.LogRecord,
+) {
+	if consumer == nil {
+		return
+	}
+	group, ok := groups[consumer]
+	if !ok {
+		group = &logGroup{logs: plog.NewLogs(), resource: -1, scope: -1}
+		groups[consumer] = group
+	}
+	if group.resource != resource {
+		rl := group.logs.ResourceLogs().AppendEmpty()
+		rlogs.Resource().CopyTo(rl.Resource())
+		rl.SetSchemaUrl(rlogs.SchemaUrl())
+		group.resource, group.scope = resource, -1
+	}
+	rl := group.logs.ResourceLogs().At(group.logs.ResourceLogs().Len() - 1)
+	if group.scope != scope {
+		sl := rl.ScopeLogs().AppendEmpty()
+		slogs.Scope().CopyTo(sl.Scope())
+		sl.SetSchemaUrl(slogs.SchemaUrl())
+		group.scope = scope
+	}
+	sl := rl.ScopeLogs().At(rl.ScopeLogs().Len() - 1)
+	log.CopyTo(sl.LogRecords().AppendEmpty())
+}
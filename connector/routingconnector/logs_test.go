@@ -438,6 +438,58 @@ func TestLogsResourceAttributeDroppedByOTTL(t *testing.T) {
 	)
 }
 
+func TestLogsSplitPerLogRecord(t *testing.T) {
+	logsDefault := component.NewIDWithName(component.DataTypeLogs, "default")
+	logs0 := component.NewIDWithName(component.DataTypeLogs, "0")
+
+	cfg := &Config{
+		DefaultPipelines: []component.ID{logsDefault},
+		Table: []RoutingTableItem{
+			{
+				Context:   logContext,
+				Statement: `route() where severity_number >= SEVERITY_NUMBER_ERROR`,
+				Pipelines: []component.ID{logs0},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	var defaultSink, sink0 consumertest.LogsSink
+	router := connector.NewLogsRouter(map[component.ID]consumer.Logs{
+		logsDefault: &defaultSink,
+		logs0:       &sink0,
+	})
+
+	conn, err := NewFactory().CreateLogsToLogs(context.Background(),
+		connectortest.NewNopSettings(), cfg, router.(consumer.Logs))
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "checkout")
+	sl := rl.ScopeLogs().AppendEmpty()
+	for _, severity := range []plog.SeverityNumber{plog.SeverityNumberInfo, plog.SeverityNumberError, plog.SeverityNumberFatal} {
+		lr := sl.LogRecords().AppendEmpty()
+		lr.SetSeverityNumber(severity)
+		lr.Body().SetStr(severity.String())
+	}
+
+	require.NoError(t, conn.ConsumeLogs(context.Background(), ld))
+
+	require.Len(t, sink0.AllLogs(), 1)
+	errorLogs := sink0.AllLogs()[0]
+	require.Equal(t, 1, errorLogs.ResourceLogs().Len())
+	service, _ := errorLogs.ResourceLogs().At(0).Resource().Attributes().Get("service.name")
+	assert.Equal(t, "checkout", service.Str())
+	records := errorLogs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 2, records.Len())
+	assert.Equal(t, "Error", records.At(0).Body().Str())
+	assert.Equal(t, "Fatal", records.At(1).Body().Str())
+
+	require.Len(t, defaultSink.AllLogs(), 1)
+	assert.Equal(t, 1, defaultSink.AllLogs()[0].LogRecordCount())
+}
+
 func TestLogsConnectorCapabilities(t *testing.T) {
 	logsDefault := component.NewIDWithName(component.DataTypeLogs, "default")
 	logsOther := component.NewIDWithName(component.DataTypeLogs, "other")
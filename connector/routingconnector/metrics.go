@@ -14,6 +14,8 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
 )
 
@@ -24,6 +26,10 @@ type metricsConnector struct {
 	logger *zap.Logger
 	config *Config
 	router *router[consumer.Metrics]
+	// byMetric is set when a route is in the metric context, the metrics being routed one by one
+	byMetric bool
+	// byDataPoint is set when a route is in the datapoint context, the data points being routed one by one
+	byDataPoint bool
 }
 
 func newMetricsConnector(
@@ -47,11 +53,16 @@ func newMetricsConnector(
 	if err != nil {
 		return nil, err
 	}
+	if err = r.checkContexts(metricContext, dataPointContext); err != nil {
+		return nil, err
+	}
 
 	return &metricsConnector{
-		logger: set.TelemetrySettings.Logger,
-		config: cfg,
-		router: r,
+		logger:      set.TelemetrySettings.Logger,
+		config:      cfg,
+		router:      r,
+		byMetric:    r.hasRouteIn(metricContext),
+		byDataPoint: r.hasRouteIn(dataPointContext),
 	}, nil
 }
 
@@ -60,6 +71,10 @@ func (c *metricsConnector) Capabilities() consumer.Capabilities {
 }
 
 func (c *metricsConnector) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if c.byMetric || c.byDataPoint {
+		return c.consumeByMetric(ctx, md)
+	}
+
 	// groups is used to group pmetric.ResourceMetrics that are routed to
 	// the same set of exporters. This way we're not ending up with all the
 	// metrics split up which would cause higher CPU usage.
@@ -73,7 +88,7 @@ func (c *metricsConnector) ConsumeMetrics(ctx context.Context, md pmetric.Metric
 
 		noRoutesMatch := true
 		for _, route := range c.router.routeSlice {
-			_, isMatch, err := route.statement.Execute(ctx, rtx)
+			isMatch, err := route.matchResource(ctx, rtx)
 			if err != nil {
 				if c.config.ErrorMode == ottl.PropagateError {
 					return err
@@ -118,3 +133,206 @@ func (c *metricsConnector) group(
 	metrics.CopyTo(group.ResourceMetrics().AppendEmpty())
 	groups[consumer] = group
 }
+
+// consumeByMetric routes the metrics, or their data points when a route is in the datapoint context,
+// one by one, grouping the metrics routed to the same set of pipelines under copies of their resource
+// and scope.
+func (c *metricsConnector) consumeByMetric(ctx context.Context, md pmetric.Metrics) error {
+	groups := make(map[consumer.Metrics]*metricGroup)
+
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rmetrics := md.ResourceMetrics().At(i)
+		rtx := ottlresource.NewTransformContext(rmetrics.Resource())
+		for j := 0; j < rmetrics.ScopeMetrics().Len(); j++ {
+			smetrics := rmetrics.ScopeMetrics().At(j)
+			for k := 0; k < smetrics.Metrics().Len(); k++ {
+				metric := smetrics.Metrics().At(k)
+				mtx := ottlmetric.NewTransformContext(metric, smetrics.Metrics(), smetrics.Scope(), rmetrics.Resource())
+				matchMetric := func(route routingItem[consumer.Metrics]) (bool, error) {
+					if route.metricStatement == nil {
+						return route.matchResource(ctx, rtx)
+					}
+					_, isMatch, err := route.metricStatement.Execute(ctx, mtx)
+					return isMatch, err
+				}
+
+				if !c.byDataPoint || dataPointCount(metric) == 0 {
+					// metrics without data points are routed as a whole, by the routes which are not in
+					// the datapoint context
+					err := c.router.matchRecord(func(route routingItem[consumer.Metrics]) (bool, error) {
+						if route.dataPointStatement != nil {
+							return false, nil
+						}
+						return matchMetric(route)
+					}, c.config.ErrorMode, c.config.MatchOnce, func(consumer consumer.Metrics) {
+						groupMetric(groups, consumer, i, j, k, rmetrics, smetrics, metric, nil)
+					})
+					if err != nil {
+						return err
+					}
+					continue
+				}
+
+				err := forEachDataPoint(metric, func(dataPoint any) error {
+					dtx := ottldatapoint.NewTransformContext(dataPoint, metric, smetrics.Metrics(), smetrics.Scope(), rmetrics.Resource())
+					return c.router.matchRecord(func(route routingItem[consumer.Metrics]) (bool, error) {
+						if route.dataPointStatement == nil {
+							return matchMetric(route)
+						}
+						_, isMatch, err := route.dataPointStatement.Execute(ctx, dtx)
+						return isMatch, err
+					}, c.config.ErrorMode, c.config.MatchOnce, func(consumer consumer.Metrics) {
+						groupMetric(groups, consumer, i, j, k, rmetrics, smetrics, metric, dataPoint)
+					})
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	var errs error
+	for consumer, group := range groups {
+		errs = errors.Join(errs, consumer.ConsumeMetrics(ctx, group.metrics))
+	}
+	return errs
+}
+
+// metricGroup holds the metrics routed to a consumer. resource, scope and metric are the indexes of the
+// resource, scope and metric the last metric or data point came from, the metrics being grouped in order.
+type metricGroup struct {
+	metrics  pmetric.Metrics
+	resource int
+	scope    int
+	metric   int
+}
+
+// groupMetric adds the metric, or only the given data point of the metric if not nil, to the group of
+// the consumer
+func groupMetric(
+	groups map[consumer.Metrics]*metricGroup,
+	consumer consumer.Metrics,
+	resource, scope, metricIndex int,
+	rmetrics pmetric.ResourceMetrics,
+	smetrics pmetric.ScopeMetrics,
+	metric pmetric.Metric,
+	dataPoint any,
+) {
+	if consumer == nil {
+		return
+	}
+	group, ok := groups[consumer]
+	if !ok {
+		group = &metricGroup{metrics: pmetric.NewMetrics(), resource: -1, scope: -1, metric: -1}
+		groups[consumer] = group
+	}
+	if group.resource != resource {
+		rm := group.metrics.ResourceMetrics().AppendEmpty()
+		rmetrics.Resource().CopyTo(rm.Resource())
+		rm.SetSchemaUrl(rmetrics.SchemaUrl())
+		group.resource, group.scope = resource, -1
+	}
+	rm := group.metrics.ResourceMetrics().At(group.metrics.ResourceMetrics().Len() - 1)
+	if group.scope != scope {
+		sm := rm.ScopeMetrics().AppendEmpty()
+		smetrics.Scope().CopyTo(sm.Scope())
+		sm.SetSchemaUrl(smetrics.SchemaUrl())
+		group.scope, group.metric = scope, -1
+	}
+	sm := rm.ScopeMetrics().At(rm.ScopeMetrics().Len() - 1)
+	if dataPoint == nil {
+		metric.CopyTo(sm.Metrics().AppendEmpty())
+		return
+	}
+	if group.metric != metricIndex {
+		copyMetricDescription(metric, sm.Metrics().AppendEmpty())
+		group.metric = metricIndex
+	}
+	appendDataPoint(sm.Metrics().At(sm.Metrics().Len()-1), dataPoint)
+}
+
+func forEachDataPoint(metric pmetric.Metric, fn func(dataPoint any) error) error {
+	var err error
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		for i := 0; i < metric.Gauge().DataPoints().Len() && err == nil; i++ {
+			err = fn(metric.Gauge().DataPoints().At(i))
+		}
+	case pmetric.MetricTypeSum:
+		for i := 0; i < metric.Sum().DataPoints().Len() && err == nil; i++ {
+			err = fn(metric.Sum().DataPoints().At(i))
+		}
+	case pmetric.MetricTypeHistogram:
+		for i := 0; i < metric.Histogram().DataPoints().Len() && err == nil; i++ {
+			err = fn(metric.Histogram().DataPoints().At(i))
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for i := 0; i < metric.ExponentialHistogram().DataPoints().Len() && err == nil; i++ {
+			err = fn(metric.ExponentialHistogram().DataPoints().At(i))
+		}
+	case pmetric.MetricTypeSummary:
+		for i := 0; i < metric.Summary().DataPoints().Len() && err == nil; i++ {
+			err = fn(metric.Summary().DataPoints().At(i))
+		}
+	case pmetric.MetricTypeEmpty:
+	}
+	return err
+}
+
+func dataPointCount(metric pmetric.Metric) int {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		return metric.Gauge().DataPoints().Len()
+	case pmetric.MetricTypeSum:
+		return metric.Sum().DataPoints().Len()
+	case pmetric.MetricTypeHistogram:
+		return metric.Histogram().DataPoints().Len()
+	case pmetric.MetricTypeExponentialHistogram:
+		return metric.ExponentialHistogram().DataPoints().Len()
+	case pmetric.MetricTypeSummary:
+		return metric.Summary().DataPoints().Len()
+	case pmetric.MetricTypeEmpty:
+	}
+	return 0
+}
+
+// copyMetricDescription copies the metric to dest, without its data points
+func copyMetricDescription(metric pmetric.Metric, dest pmetric.Metric) {
+	dest.SetName(metric.Name())
+	dest.SetDescription(metric.Description())
+	dest.SetUnit(metric.Unit())
+	metric.Metadata().CopyTo(dest.Metadata())
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		dest.SetEmptyGauge()
+	case pmetric.MetricTypeSum:
+		sum := dest.SetEmptySum()
+		sum.SetAggregationTemporality(metric.Sum().AggregationTemporality())
+		sum.SetIsMonotonic(metric.Sum().IsMonotonic())
+	case pmetric.MetricTypeHistogram:
+		dest.SetEmptyHistogram().SetAggregationTemporality(metric.Histogram().AggregationTemporality())
+	case pmetric.MetricTypeExponentialHistogram:
+		dest.SetEmptyExponentialHistogram().SetAggregationTemporality(metric.ExponentialHistogram().AggregationTemporality())
+	case pmetric.MetricTypeSummary:
+		dest.SetEmptySummary()
+	case pmetric.MetricTypeEmpty:
+	}
+}
+
+func appendDataPoint(dest pmetric.Metric, dataPoint any) {
+	switch dp := dataPoint.(type) {
+	case pmetric.NumberDataPoint:
+		if dest.Type() == pmetric.MetricTypeGauge {
+			dp.CopyTo(dest.Gauge().DataPoints().AppendEmpty())
+		} else {
+			dp.CopyTo(dest.Sum().DataPoints().AppendEmpty())
+		}
+	case pmetric.HistogramDataPoint:
+		dp.CopyTo(dest.Histogram().DataPoints().AppendEmpty())
+	case pmetric.ExponentialHistogramDataPoint:
+		dp.CopyTo(dest.ExponentialHistogram().DataPoints().AppendEmpty())
+	case pmetric.SummaryDataPoint:
+		dp.CopyTo(dest.Summary().DataPoints().AppendEmpty())
+	}
+}
@@ -468,6 +468,112 @@ func TestMetricsResourceAttributeDroppedByOTTL(t *testing.T) {
 	)
 }
 
+func TestMetricsSplitPerMetric(t *testing.T) {
+	metricsDefault := component.NewIDWithName(component.DataTypeMetrics, "default")
+	metrics0 := component.NewIDWithName(component.DataTypeMetrics, "0")
+
+	cfg := &Config{
+		DefaultPipelines: []component.ID{metricsDefault},
+		Table: []RoutingTableItem{
+			{
+				Context:   metricContext,
+				Statement: `route() where name == "cpu"`,
+				Pipelines: []component.ID{metrics0},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	var defaultSink, sink0 consumertest.MetricsSink
+	router := connector.NewMetricsRouter(map[component.ID]consumer.Metrics{
+		metricsDefault: &defaultSink,
+		metrics0:       &sink0,
+	})
+
+	conn, err := NewFactory().CreateMetricsToMetrics(context.Background(),
+		connectortest.NewNopSettings(), cfg, router.(consumer.Metrics))
+	require.NoError(t, err)
+
+	md := pmetric.NewMetrics()
+	sm := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	for _, name := range []string{"cpu", "memory", "cpu"} {
+		sm.Metrics().AppendEmpty().SetName(name)
+	}
+
+	require.NoError(t, conn.ConsumeMetrics(context.Background(), md))
+
+	require.Len(t, sink0.AllMetrics(), 1)
+	assert.Equal(t, 2, sink0.AllMetrics()[0].MetricCount())
+	assert.Equal(t, 1, sink0.AllMetrics()[0].ResourceMetrics().Len())
+	require.Len(t, defaultSink.AllMetrics(), 1)
+	assert.Equal(t, 1, defaultSink.AllMetrics()[0].MetricCount())
+}
+
+func TestMetricsSplitPerDataPoint(t *testing.T) {
+	metricsDefault := component.NewIDWithName(component.DataTypeMetrics, "default")
+	metrics0 := component.NewIDWithName(component.DataTypeMetrics, "0")
+
+	cfg := &Config{
+		DefaultPipelines: []component.ID{metricsDefault},
+		Table: []RoutingTableItem{
+			{
+				Context:   dataPointContext,
+				Statement: `route() where attributes["env"] == "prod"`,
+				Pipelines: []component.ID{metrics0},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	var defaultSink, sink0 consumertest.MetricsSink
+	router := connector.NewMetricsRouter(map[component.ID]consumer.Metrics{
+		metricsDefault: &defaultSink,
+		metrics0:       &sink0,
+	})
+
+	conn, err := NewFactory().CreateMetricsToMetrics(context.Background(),
+		connectortest.NewNopSettings(), cfg, router.(consumer.Metrics))
+	require.NoError(t, err)
+
+	md := pmetric.NewMetrics()
+	sm := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	sum := sm.Metrics().AppendEmpty()
+	sum.SetName("requests")
+	sum.SetUnit("1")
+	sum.SetEmptySum().SetIsMonotonic(true)
+	sum.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	for _, env := range []string{"prod", "dev", "prod"} {
+		dp := sum.Sum().DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("env", env)
+		dp.SetIntValue(1)
+	}
+	histogram := sm.Metrics().AppendEmpty()
+	histogram.SetName("latency")
+	histogram.SetEmptyHistogram().DataPoints().AppendEmpty().Attributes().PutStr("env", "dev")
+	// a metric without data points goes to the default pipelines
+	empty := sm.Metrics().AppendEmpty()
+	empty.SetName("up")
+	empty.SetEmptyGauge()
+
+	require.NoError(t, conn.ConsumeMetrics(context.Background(), md))
+
+	require.Len(t, sink0.AllMetrics(), 1)
+	prod := sink0.AllMetrics()[0]
+	require.Equal(t, 1, prod.MetricCount())
+	requests := prod.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "requests", requests.Name())
+	assert.Equal(t, "1", requests.Unit())
+	assert.True(t, requests.Sum().IsMonotonic())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, requests.Sum().AggregationTemporality())
+	assert.Equal(t, 2, requests.Sum().DataPoints().Len())
+
+	require.Len(t, defaultSink.AllMetrics(), 1)
+	dev := defaultSink.AllMetrics()[0]
+	assert.Equal(t, 3, dev.MetricCount())
+	assert.Equal(t, 2, dev.DataPointCount())
+	assert.Equal(t, "up", dev.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(2).Name())
+}
+
 func TestMetricsConnectorCapabilities(t *testing.T) {
 	metricsDefault := component.NewIDWithName(component.DataTypeMetrics, "default")
 	metricsOther := component.NewIDWithName(component.DataTypeMetrics, "other")
@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"go.opentelemetry.io/collector/client"
+	"google.golang.org/grpc/metadata"
+)
+
+var (
+	errInvalidRequestStatement = errors.New("invalid route: unsupported statement in the request context")
+
+	requestStatementRegexp = regexp.MustCompile(`^\s*route\(\)\s+where\s+request\["([^"]+)"\]\s*(==|!=)\s*"([^"]*)"\s*$`)
+)
+
+// requestCondition is the condition of a route in the request context, comparing the values of a metadata
+// key of the request with a value
+type requestCondition struct {
+	key      string
+	value    string
+	notEqual bool
+}
+
+func parseRequestCondition(statement string) (*requestCondition, error) {
+	matches := requestStatementRegexp.FindStringSubmatch(statement)
+	if matches == nil {
+		return nil, fmt.Errorf(`%w: %q, expected route() where request["<key>"] == "<value>" or !=`, errInvalidRequestStatement, statement)
+	}
+	return &requestCondition{
+		key:      matches[1],
+		notEqual: matches[2] == "!=",
+		value:    matches[3],
+	}, nil
+}
+
+// match returns whether one of the values of the key equals the value of the condition, or, with !=,
+// whether none of them does. The values are looked up in the gRPC metadata of the request first, and in
+// the metadata of the client otherwise, which receivers fill in with HTTP headers when configured to.
+func (c *requestCondition) match(ctx context.Context) bool {
+	return slices.Contains(c.values(ctx), c.value) != c.notEqual
+}
+
+func (c *requestCondition) values(ctx context.Context) []string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(c.key); len(values) > 0 {
+			return values
+		}
+	}
+	return client.FromContext(ctx).Metadata.Get(c.key)
+}
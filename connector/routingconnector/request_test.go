@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector"
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestParseRequestCondition(t *testing.T) {
+	condition, err := parseRequestCondition(`route() where request["X-Tenant"] == "acme"`)
+	require.NoError(t, err)
+	assert.Equal(t, &requestCondition{key: "X-Tenant", value: "acme"}, condition)
+
+	condition, err = parseRequestCondition(`route() where request["X-Tenant"] != ""`)
+	require.NoError(t, err)
+	assert.Equal(t, &requestCondition{key: "X-Tenant", notEqual: true}, condition)
+
+	_, err = parseRequestCondition(`route() where attributes["X-Tenant"] == "acme"`)
+	assert.ErrorIs(t, err, errInvalidRequestStatement)
+}
+
+func TestRequestConditionMatch(t *testing.T) {
+	equal := &requestCondition{key: "X-Tenant", value: "acme"}
+	notEqual := &requestCondition{key: "X-Tenant", value: "acme", notEqual: true}
+
+	testcases := []struct {
+		name  string
+		ctx   context.Context
+		match bool
+	}{
+		{
+			name:  "no metadata",
+			ctx:   context.Background(),
+			match: false,
+		},
+		{
+			name:  "grpc metadata",
+			ctx:   metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-tenant", "acme")),
+			match: true,
+		},
+		{
+			name: "client metadata",
+			ctx: client.NewContext(context.Background(), client.Info{
+				Metadata: client.NewMetadata(map[string][]string{"X-Tenant": {"globex", "acme"}}),
+			}),
+			match: true,
+		},
+		{
+			name: "other value",
+			ctx: client.NewContext(context.Background(), client.Info{
+				Metadata: client.NewMetadata(map[string][]string{"X-Tenant": {"globex"}}),
+			}),
+			match: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.match, equal.match(tc.ctx))
+			assert.Equal(t, !tc.match, notEqual.match(tc.ctx))
+		})
+	}
+}
@@ -4,8 +4,10 @@
 package routingconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector"
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"go.opentelemetry.io/collector/component"
@@ -13,7 +15,11 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/common"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 )
 
 var errPipelineNotFound = errors.New("pipeline not found")
@@ -27,8 +33,12 @@ type consumerProvider[C any] func(...component.ID) (C, error)
 // parameter C is expected to be one of: consumer.Traces, consumer.Metrics, or
 // consumer.Logs.
 type router[C any] struct {
-	logger *zap.Logger
-	parser ottl.Parser[ottlresource.TransformContext]
+	logger          *zap.Logger
+	parser          ottl.Parser[ottlresource.TransformContext]
+	spanParser      ottl.Parser[ottlspan.TransformContext]
+	metricParser    ottl.Parser[ottlmetric.TransformContext]
+	dataPointParser ottl.Parser[ottldatapoint.TransformContext]
+	logParser       ottl.Parser[ottllog.TransformContext]
 
 	table      []RoutingTableItem
 	routes     map[string]routingItem[C]
@@ -51,6 +61,22 @@ func newRouter[C any](
 		settings,
 	)
 
+	if err != nil {
+		return nil, err
+	}
+	spanParser, err := ottlspan.NewParser(common.Functions[ottlspan.TransformContext](), settings)
+	if err != nil {
+		return nil, err
+	}
+	metricParser, err := ottlmetric.NewParser(common.Functions[ottlmetric.TransformContext](), settings)
+	if err != nil {
+		return nil, err
+	}
+	dataPointParser, err := ottldatapoint.NewParser(common.Functions[ottldatapoint.TransformContext](), settings)
+	if err != nil {
+		return nil, err
+	}
+	logParser, err := ottllog.NewParser(common.Functions[ottllog.TransformContext](), settings)
 	if err != nil {
 		return nil, err
 	}
@@ -58,6 +84,10 @@ func newRouter[C any](
 	r := &router[C]{
 		logger:           settings.Logger,
 		parser:           parser,
+		spanParser:       spanParser,
+		metricParser:     metricParser,
+		dataPointParser:  dataPointParser,
+		logParser:        logParser,
 		table:            table,
 		routes:           make(map[string]routingItem[C]),
 		consumerProvider: provider,
@@ -70,9 +100,26 @@ func newRouter[C any](
 	return r, nil
 }
 
+// routingItem is a route of the routing table. Depending on the context of the route, one of the
+// request condition or statements is set.
 type routingItem[C any] struct {
-	consumer  C
-	statement *ottl.Statement[ottlresource.TransformContext]
+	consumer           C
+	statementContext   string
+	request            *requestCondition
+	statement          *ottl.Statement[ottlresource.TransformContext]
+	spanStatement      *ottl.Statement[ottlspan.TransformContext]
+	metricStatement    *ottl.Statement[ottlmetric.TransformContext]
+	dataPointStatement *ottl.Statement[ottldatapoint.TransformContext]
+	logStatement       *ottl.Statement[ottllog.TransformContext]
+}
+
+// matchResource evaluates a route in the resource or request context
+func (r routingItem[C]) matchResource(ctx context.Context, rtx ottlresource.TransformContext) (bool, error) {
+	if r.request != nil {
+		return r.request.match(ctx), nil
+	}
+	_, isMatch, err := r.statement.Execute(ctx, rtx)
+	return isMatch, err
 }
 
 func (r *router[C]) registerConsumers(defaultPipelineIDs []component.ID) error {
@@ -112,14 +159,11 @@ func (r *router[C]) registerDefaultConsumer(pipelineIDs []component.ID) error {
 // for each route
 func (r *router[C]) registerRouteConsumers() error {
 	for _, item := range r.table {
-		statement, err := r.getStatementFrom(item)
-		if err != nil {
-			return err
-		}
-
 		route, ok := r.routes[key(item)]
 		if !ok {
-			route.statement = statement
+			if err := r.setStatementFrom(&route, item); err != nil {
+				return err
+			}
 		} else {
 			pipelineNames := []string{}
 			for _, pipeline := range item.Pipelines {
@@ -143,21 +187,91 @@ func (r *router[C]) registerRouteConsumers() error {
 	return nil
 }
 
-// getStatementFrom builds a routing OTTL statement from the provided
-// routing table entry configuration. If the routing table entry configuration
-// does not contain a valid OTTL statement then nil is returned.
-func (r *router[C]) getStatementFrom(item RoutingTableItem) (*ottl.Statement[ottlresource.TransformContext], error) {
-	var statement *ottl.Statement[ottlresource.TransformContext]
-	if item.Statement != "" {
-		var err error
-		statement, err = r.parser.ParseStatement(item.Statement)
+// setStatementFrom builds the routing OTTL statement, or the request condition, of a route from the
+// provided routing table entry configuration, according to its context.
+func (r *router[C]) setStatementFrom(route *routingItem[C], item RoutingTableItem) error {
+	var err error
+	route.statementContext = item.Context
+	switch item.Context {
+	case "", resourceContext:
+		route.statementContext = resourceContext
+		route.statement, err = r.parser.ParseStatement(item.Statement)
+	case requestContext:
+		route.request, err = parseRequestCondition(item.Statement)
+	case spanContext:
+		route.spanStatement, err = r.spanParser.ParseStatement(item.Statement)
+	case metricContext:
+		route.metricStatement, err = r.metricParser.ParseStatement(item.Statement)
+	case dataPointContext:
+		route.dataPointStatement, err = r.dataPointParser.ParseStatement(item.Statement)
+	case logContext:
+		route.logStatement, err = r.logParser.ParseStatement(item.Statement)
+	default:
+		err = fmt.Errorf("%w: %q", errInvalidContext, item.Context)
+	}
+	return err
+}
+
+// checkContexts returns an error if a route is in a record context which isn't one of the given ones
+func (r *router[C]) checkContexts(recordContexts ...string) error {
+	for _, route := range r.routeSlice {
+		switch route.statementContext {
+		case resourceContext, requestContext:
+		default:
+			if !slices.Contains(recordContexts, route.statementContext) {
+				return fmt.Errorf("%w: %q", errInvalidContext, route.statementContext)
+			}
+		}
+	}
+	return nil
+}
+
+// hasRouteIn returns whether one of the routes is in the given context
+func (r *router[C]) hasRouteIn(statementContext string) bool {
+	for _, route := range r.routeSlice {
+		if route.statementContext == statementContext {
+			return true
+		}
+	}
+	return false
+}
+
+// matchRecord evaluates the routes for a single record with match, calling group with the consumer of
+// each matching route, or with the default consumer if none matches.
+func (r *router[C]) matchRecord(
+	match func(routingItem[C]) (bool, error),
+	errorMode ottl.ErrorMode,
+	matchOnce bool,
+	group func(C),
+) error {
+	noRoutesMatch := true
+	for _, route := range r.routeSlice {
+		isMatch, err := match(route)
 		if err != nil {
-			return statement, err
+			if errorMode == ottl.PropagateError {
+				return err
+			}
+			group(r.defaultConsumer)
+			continue
+		}
+		if isMatch {
+			noRoutesMatch = false
+			group(route.consumer)
+			if matchOnce {
+				break
+			}
 		}
 	}
-	return statement, nil
+
+	if noRoutesMatch {
+		group(r.defaultConsumer)
+	}
+	return nil
 }
 
 func key(entry RoutingTableItem) string {
-	return entry.Statement
+	if entry.Context == "" || entry.Context == resourceContext {
+		return entry.Statement
+	}
+	return entry.Context + ": " + entry.Statement
 }
@@ -15,6 +15,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 )
 
 type tracesConnector struct {
@@ -24,6 +25,8 @@ type tracesConnector struct {
 	logger *zap.Logger
 	config *Config
 	router *router[consumer.Traces]
+	// bySpan is set when a route is in the span context, the spans being routed one by one
+	bySpan bool
 }
 
 func newTracesConnector(
@@ -47,11 +50,15 @@ func newTracesConnector(
 	if err != nil {
 		return nil, err
 	}
+	if err = r.checkContexts(spanContext); err != nil {
+		return nil, err
+	}
 
 	return &tracesConnector{
 		logger: set.TelemetrySettings.Logger,
 		config: cfg,
 		router: r,
+		bySpan: r.hasRouteIn(spanContext),
 	}, nil
 }
 
@@ -60,6 +67,10 @@ func (*tracesConnector) Capabilities() consumer.Capabilities {
 }
 
 func (c *tracesConnector) ConsumeTraces(ctx context.Context, t ptrace.Traces) error {
+	if c.bySpan {
+		return c.consumeBySpan(ctx, t)
+	}
+
 	// groups is used to group ptrace.ResourceSpans that are routed to
 	// the same set of pipelines. This way we're not ending up with all the
 	// spans split up which would cause higher CPU usage.
@@ -72,7 +83,7 @@ func (c *tracesConnector) ConsumeTraces(ctx context.Context, t ptrace.Traces) er
 
 		noRoutesMatch := true
 		for _, route := range c.router.routeSlice {
-			_, isMatch, err := route.statement.Execute(ctx, rtx)
+			isMatch, err := route.matchResource(ctx, rtx)
 			if err != nil {
 				if c.config.ErrorMode == ottl.PropagateError {
 					return err
@@ -117,3 +128,80 @@ func (c *tracesConnector) group(
 	spans.CopyTo(group.ResourceSpans().AppendEmpty())
 	groups[consumer] = group
 }
+
+// consumeBySpan routes the spans one by one, grouping the spans routed to the same set of pipelines
+// under copies of their resource and scope.
+func (c *tracesConnector) consumeBySpan(ctx context.Context, t ptrace.Traces) error {
+	groups := make(map[consumer.Traces]*spanGroup)
+
+	for i := 0; i < t.ResourceSpans().Len(); i++ {
+		rspans := t.ResourceSpans().At(i)
+		rtx := ottlresource.NewTransformContext(rspans.Resource())
+		for j := 0; j < rspans.ScopeSpans().Len(); j++ {
+			sspans := rspans.ScopeSpans().At(j)
+			for k := 0; k < sspans.Spans().Len(); k++ {
+				span := sspans.Spans().At(k)
+				stx := ottlspan.NewTransformContext(span, sspans.Scope(), rspans.Resource())
+				err := c.router.matchRecord(func(route routingItem[consumer.Traces]) (bool, error) {
+					if route.spanStatement == nil {
+						return route.matchResource(ctx, rtx)
+					}
+					_, isMatch, err := route.spanStatement.Execute(ctx, stx)
+					return isMatch, err
+				}, c.config.ErrorMode, c.config.MatchOnce, func(consumer consumer.Traces) {
+					groupSpan(groups, consumer, i, j, rspans, sspans, span)
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	var errs error
+	for consumer, group := range groups {
+		errs = errors.Join(errs, consumer.ConsumeTraces(ctx, group.traces))
+	}
+	return errs
+}
+
+// spanGroup holds the spans routed to a consumer. resource and scope are the indexes of the resource
+// and scope the last span came from, the spans being grouped in order.
+type spanGroup struct {
+	traces   ptrace.Traces
+	resource int
+	scope    int
+}
+
+func groupSpan(
+	groups map[consumer.Traces]*spanGroup,
+	consumer consumer.Traces,
+	resource, scope int,
+	rspans ptrace.ResourceSpans,
+	sspans ptrace.ScopeSpans,
+	span ptrace.Span,
+) {
+	if consumer == nil {
+		return
+	}
+	group, ok := groups[consumer]
+	if !ok {
+		group = &spanGroup{traces: ptrace.NewTraces(), resource: -1, scope: -1}
+		groups[consumer] = group
+	}
+	if group.resource != resource {
+		rs := group.traces.ResourceSpans().AppendEmpty()
+		rspans.Resource().CopyTo(rs.Resource())
+		rs.SetSchemaUrl(rspans.SchemaUrl())
+		group.resource, group.scope = resource, -1
+	}
+	rs := group.traces.ResourceSpans().At(group.traces.ResourceSpans().Len() - 1)
+	if group.scope != scope {
+		ss := rs.ScopeSpans().AppendEmpty()
+		sspans.Scope().CopyTo(ss.Scope())
+		ss.SetSchemaUrl(sspans.SchemaUrl())
+		group.scope = scope
+	}
+	ss := rs.ScopeSpans().At(rs.ScopeSpans().Len() - 1)
+	span.CopyTo(ss.Spans().AppendEmpty())
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/connector"
@@ -392,6 +393,142 @@ func TestTracesResourceAttributeDroppedByOTTL(t *testing.T) {
 	)
 }
 
+func TestTracesRoutedPerRequestMetadata(t *testing.T) {
+	tracesDefault := component.NewIDWithName(component.DataTypeTraces, "default")
+	traces0 := component.NewIDWithName(component.DataTypeTraces, "0")
+
+	cfg := &Config{
+		DefaultPipelines: []component.ID{tracesDefault},
+		Table: []RoutingTableItem{
+			{
+				Context:   requestContext,
+				Statement: `route() where request["X-Tenant"] == "acme"`,
+				Pipelines: []component.ID{traces0},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	var defaultSink, sink0 consumertest.TracesSink
+	router := connector.NewTracesRouter(map[component.ID]consumer.Traces{
+		tracesDefault: &defaultSink,
+		traces0:       &sink0,
+	})
+
+	conn, err := NewFactory().CreateTracesToTraces(context.Background(),
+		connectortest.NewNopSettings(), cfg, router.(consumer.Traces))
+	require.NoError(t, err)
+
+	tr := ptrace.NewTraces()
+	tr.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+
+	acme := client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"X-Tenant": {"acme"}}),
+	})
+	require.NoError(t, conn.ConsumeTraces(acme, tr))
+	assert.Len(t, sink0.AllTraces(), 1)
+	assert.Len(t, defaultSink.AllTraces(), 0)
+
+	require.NoError(t, conn.ConsumeTraces(context.Background(), tr))
+	assert.Len(t, sink0.AllTraces(), 1)
+	assert.Len(t, defaultSink.AllTraces(), 1)
+}
+
+func TestTracesSplitPerSpan(t *testing.T) {
+	tracesDefault := component.NewIDWithName(component.DataTypeTraces, "default")
+	traces0 := component.NewIDWithName(component.DataTypeTraces, "0")
+	traces1 := component.NewIDWithName(component.DataTypeTraces, "1")
+
+	cfg := &Config{
+		DefaultPipelines: []component.ID{tracesDefault},
+		Table: []RoutingTableItem{
+			{
+				Context:   spanContext,
+				Statement: `route() where attributes["http.status_code"] >= 500`,
+				Pipelines: []component.ID{traces0},
+			},
+			{
+				Statement: `route() where attributes["X-Tenant"] == "acme"`,
+				Pipelines: []component.ID{traces1},
+			},
+		},
+		MatchOnce: true,
+	}
+	require.NoError(t, cfg.Validate())
+
+	var defaultSink, sink0, sink1 consumertest.TracesSink
+	router := connector.NewTracesRouter(map[component.ID]consumer.Traces{
+		tracesDefault: &defaultSink,
+		traces0:       &sink0,
+		traces1:       &sink1,
+	})
+
+	conn, err := NewFactory().CreateTracesToTraces(context.Background(),
+		connectortest.NewNopSettings(), cfg, router.(consumer.Traces))
+	require.NoError(t, err)
+
+	tr := ptrace.NewTraces()
+	for _, tenant := range []string{"acme", "globex"} {
+		rs := tr.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("X-Tenant", tenant)
+		ss := rs.ScopeSpans().AppendEmpty()
+		ss.Scope().SetName("scope")
+		for _, code := range []int64{200, 503, 200} {
+			span := ss.Spans().AppendEmpty()
+			span.SetName(tenant)
+			span.Attributes().PutInt("http.status_code", code)
+		}
+	}
+
+	require.NoError(t, conn.ConsumeTraces(context.Background(), tr))
+
+	// the failed spans of both tenants, under copies of their resource and scope
+	require.Len(t, sink0.AllTraces(), 1)
+	failed := sink0.AllTraces()[0]
+	require.Equal(t, 2, failed.ResourceSpans().Len())
+	for i, tenant := range []string{"acme", "globex"} {
+		rs := failed.ResourceSpans().At(i)
+		value, _ := rs.Resource().Attributes().Get("X-Tenant")
+		assert.Equal(t, tenant, value.Str())
+		require.Equal(t, 1, rs.ScopeSpans().Len())
+		assert.Equal(t, "scope", rs.ScopeSpans().At(0).Scope().Name())
+		require.Equal(t, 1, rs.ScopeSpans().At(0).Spans().Len())
+		assert.Equal(t, tenant, rs.ScopeSpans().At(0).Spans().At(0).Name())
+	}
+
+	// the other spans of acme, grouped under a single resource and scope
+	require.Len(t, sink1.AllTraces(), 1)
+	assert.Equal(t, 1, sink1.AllTraces()[0].ResourceSpans().Len())
+	assert.Equal(t, 2, sink1.AllTraces()[0].SpanCount())
+
+	require.Len(t, defaultSink.AllTraces(), 1)
+	assert.Equal(t, 2, defaultSink.AllTraces()[0].SpanCount())
+	assert.Equal(t, "globex", defaultSink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+}
+
+func TestTracesUnsupportedContext(t *testing.T) {
+	traces0 := component.NewIDWithName(component.DataTypeTraces, "0")
+
+	cfg := &Config{
+		Table: []RoutingTableItem{
+			{
+				Context:   logContext,
+				Statement: `route() where severity_number > 0`,
+				Pipelines: []component.ID{traces0},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	router := connector.NewTracesRouter(map[component.ID]consumer.Traces{
+		traces0: consumertest.NewNop(),
+	})
+
+	_, err := NewFactory().CreateTracesToTraces(context.Background(),
+		connectortest.NewNopSettings(), cfg, router.(consumer.Traces))
+	assert.ErrorIs(t, err, errInvalidContext)
+}
+
 func TestTraceConnectorCapabilities(t *testing.T) {
 	tracesDefault := component.NewIDWithName(component.DataTypeTraces, "default")
 	tracesOther := component.NewIDWithName(component.DataTypeTraces, "0")
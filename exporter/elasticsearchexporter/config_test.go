@@ -57,11 +57,12 @@ func TestConfig(t *testing.T) {
 					NumConsumers: exporterhelper.NewDefaultQueueSettings().NumConsumers,
 					QueueSize:    exporterhelper.NewDefaultQueueSettings().QueueSize,
 				},
-				Endpoints:   []string{"https://elastic.example.com:9200"},
-				Index:       "",
-				LogsIndex:   "logs-generic-default",
-				TracesIndex: "trace_index",
-				Pipeline:    "mypipeline",
+				Endpoints:    []string{"https://elastic.example.com:9200"},
+				Index:        "",
+				LogsIndex:    "logs-generic-default",
+				TracesIndex:  "trace_index",
+				MetricsIndex: "metrics-generic-default",
+				Pipeline:     "mypipeline",
 				ClientConfig: confighttp.ClientConfig{
 					Timeout:         2 * time.Minute,
 					MaxIdleConns:    &defaultMaxIdleConns,
@@ -109,11 +110,12 @@ func TestConfig(t *testing.T) {
 					NumConsumers: exporterhelper.NewDefaultQueueSettings().NumConsumers,
 					QueueSize:    exporterhelper.NewDefaultQueueSettings().QueueSize,
 				},
-				Endpoints:   []string{"http://localhost:9200"},
-				Index:       "",
-				LogsIndex:   "my_log_index",
-				TracesIndex: "traces-generic-default",
-				Pipeline:    "mypipeline",
+				Endpoints:    []string{"http://localhost:9200"},
+				Index:        "",
+				LogsIndex:    "my_log_index",
+				TracesIndex:  "traces-generic-default",
+				MetricsIndex: "metrics-generic-default",
+				Pipeline:     "mypipeline",
 				ClientConfig: confighttp.ClientConfig{
 					Timeout:         2 * time.Minute,
 					MaxIdleConns:    &defaultMaxIdleConns,
@@ -152,6 +154,15 @@ func TestConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id:         component.NewIDWithName(metadata.Type, "metric"),
+			configFile: "config.yaml",
+			expected: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoints = []string{"http://localhost:9200"}
+				cfg.MetricsIndex = "my_metric_index"
+				cfg.MetricsDynamicIndex.Enabled = true
+			}),
+		},
 		{
 			id:         component.NewIDWithName(metadata.Type, "logstash_format"),
 			configFile: "config.yaml",
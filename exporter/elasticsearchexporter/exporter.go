@@ -14,7 +14,11 @@ import (
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter/internal/objmodel"
 )
 
 type elasticsearchExporter struct {
@@ -113,7 +117,9 @@ func (e *elasticsearchExporter) pushLogsData(ctx context.Context, ld plog.Logs)
 	return errors.Join(errs...)
 }
 
-func (e *elasticsearchExporter) pushLogRecord(ctx context.Context, resource pcommon.Resource, record plog.LogRecord, scope pcommon.InstrumentationScope) error {
+// getIndex returns the index the record should be published to, applying the dynamic index
+// and logstash format settings.
+func (e *elasticsearchExporter) getIndex(resource, scope, record attrGetter) (string, error) {
 	fIndex := e.index
 	if e.dynamicIndex {
 		prefix := getFromAttributes(indexPrefix, resource, scope, record)
@@ -125,10 +131,18 @@ func (e *elasticsearchExporter) pushLogRecord(ctx context.Context, resource pcom
 	if e.logstashFormat.Enabled {
 		formattedIndex, err := generateIndexWithLogstashFormat(fIndex, &e.logstashFormat, time.Now())
 		if err != nil {
-			return err
+			return "", err
 		}
 		fIndex = formattedIndex
 	}
+	return fIndex, nil
+}
+
+func (e *elasticsearchExporter) pushLogRecord(ctx context.Context, resource pcommon.Resource, record plog.LogRecord, scope pcommon.InstrumentationScope) error {
+	fIndex, err := e.getIndex(resource, scope, record)
+	if err != nil {
+		return err
+	}
 
 	document, err := e.model.encodeLog(resource, record, scope)
 	if err != nil {
@@ -167,20 +181,9 @@ func (e *elasticsearchExporter) pushTraceData(
 }
 
 func (e *elasticsearchExporter) pushTraceRecord(ctx context.Context, resource pcommon.Resource, span ptrace.Span, scope pcommon.InstrumentationScope) error {
-	fIndex := e.index
-	if e.dynamicIndex {
-		prefix := getFromAttributes(indexPrefix, resource, scope, span)
-		suffix := getFromAttributes(indexSuffix, resource, scope, span)
-
-		fIndex = fmt.Sprintf("%s%s%s", prefix, fIndex, suffix)
-	}
-
-	if e.logstashFormat.Enabled {
-		formattedIndex, err := generateIndexWithLogstashFormat(fIndex, &e.logstashFormat, time.Now())
-		if err != nil {
-			return err
-		}
-		fIndex = formattedIndex
+	fIndex, err := e.getIndex(resource, scope, span)
+	if err != nil {
+		return err
 	}
 
 	document, err := e.model.encodeSpan(resource, span, scope)
@@ -189,3 +192,109 @@ func (e *elasticsearchExporter) pushTraceRecord(ctx context.Context, resource pc
 	}
 	return pushDocuments(ctx, fIndex, document, e.bulkIndexer)
 }
+
+func (e *elasticsearchExporter) pushMetricsData(
+	ctx context.Context,
+	metrics pmetric.Metrics,
+) error {
+	var errs []error
+	resourceMetrics := metrics.ResourceMetrics()
+	for i := 0; i < resourceMetrics.Len(); i++ {
+		resourceMetric := resourceMetrics.At(i)
+		resource := resourceMetric.Resource()
+		scopeMetrics := resourceMetric.ScopeMetrics()
+
+		// the data points of the resource sharing a timestamp and attributes are grouped in a
+		// single document, which holds the values of all their metrics
+		resourceDocs := make(map[string]map[uint64]objmodel.Document)
+		upsertDataPoint := func(scope pcommon.InstrumentationScope, metric pmetric.Metric, dp dataPoint, value pcommon.Value) error {
+			fIndex, err := e.getIndex(resource, scope, dp)
+			if err != nil {
+				return err
+			}
+			if _, ok := resourceDocs[fIndex]; !ok {
+				resourceDocs[fIndex] = make(map[uint64]objmodel.Document)
+			}
+			e.model.upsertMetricDataPoint(resourceDocs[fIndex], resource, metric, dp, value)
+			return nil
+		}
+		upsertNumberDataPoints := func(scope pcommon.InstrumentationScope, metric pmetric.Metric, dps pmetric.NumberDataPointSlice) {
+			for l := 0; l < dps.Len(); l++ {
+				dp := dps.At(l)
+				if dp.Flags().NoRecordedValue() {
+					continue
+				}
+				value, err := numberToValue(dp)
+				if err == nil {
+					err = upsertDataPoint(scope, metric, dp, value)
+				}
+				if err != nil {
+					errs = append(errs, fmt.Errorf("metric %q: %w", metric.Name(), err))
+				}
+			}
+		}
+
+		for j := 0; j < scopeMetrics.Len(); j++ {
+			scopeMetric := scopeMetrics.At(j)
+			scope := scopeMetric.Scope()
+			metricSlice := scopeMetric.Metrics()
+			for k := 0; k < metricSlice.Len(); k++ {
+				metric := metricSlice.At(k)
+				switch metric.Type() {
+				case pmetric.MetricTypeGauge:
+					upsertNumberDataPoints(scope, metric, metric.Gauge().DataPoints())
+				case pmetric.MetricTypeSum:
+					upsertNumberDataPoints(scope, metric, metric.Sum().DataPoints())
+				case pmetric.MetricTypeHistogram:
+					dps := metric.Histogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dp := dps.At(l)
+						if dp.Flags().NoRecordedValue() {
+							continue
+						}
+						value, err := histogramToValue(dp)
+						if err == nil {
+							err = upsertDataPoint(scope, metric, dp, value)
+						}
+						if err != nil {
+							errs = append(errs, fmt.Errorf("metric %q: %w", metric.Name(), err))
+						}
+					}
+				case pmetric.MetricTypeExponentialHistogram:
+					dps := metric.ExponentialHistogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dp := dps.At(l)
+						if dp.Flags().NoRecordedValue() {
+							continue
+						}
+						if err := upsertDataPoint(scope, metric, dp, exponentialHistogramToValue(dp)); err != nil {
+							errs = append(errs, fmt.Errorf("metric %q: %w", metric.Name(), err))
+						}
+					}
+				default:
+					e.Logger.Debug("Dropping metric of unsupported type",
+						zap.String("name", metric.Name()),
+						zap.Stringer("type", metric.Type()))
+				}
+			}
+		}
+
+		for fIndex, docs := range resourceDocs {
+			for _, doc := range docs {
+				document, err := e.model.encodeDocument(doc)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("failed to encode metric document: %w", err))
+					continue
+				}
+				if err := pushDocuments(ctx, fIndex, document, e.bulkIndexer); err != nil {
+					if cerr := ctx.Err(); cerr != nil {
+						return cerr
+					}
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -25,7 +25,9 @@ import (
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/extension/auth/authtest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
@@ -586,6 +588,151 @@ func TestExporterTraces(t *testing.T) {
 	})
 }
 
+func TestExporterMetrics(t *testing.T) {
+	t.Run("publish with success", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestMetricsExporter(t, server.URL)
+		dp := pmetric.NewNumberDataPoint()
+		dp.SetDoubleValue(123.456)
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+		mustSendMetricSumDataPoints(t, exporter, dp)
+		mustSendMetricGaugeDataPoints(t, exporter, dp)
+
+		rec.WaitItems(2)
+	})
+
+	t.Run("publish with dynamic index", func(t *testing.T) {
+		rec := newBulkRecorder()
+		var (
+			prefix = "resprefix-"
+			suffix = "-attrsuffix"
+			index  = "someindex"
+		)
+
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+
+			data, err := docs[0].Action.MarshalJSON()
+			assert.NoError(t, err)
+
+			jsonVal := map[string]any{}
+			err = json.Unmarshal(data, &jsonVal)
+			assert.NoError(t, err)
+
+			create := jsonVal["create"].(map[string]any)
+
+			expected := fmt.Sprintf("%s%s%s", prefix, index, suffix)
+			assert.Equal(t, expected, create["_index"].(string))
+
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestMetricsExporter(t, server.URL, func(cfg *Config) {
+			cfg.MetricsIndex = index
+			cfg.MetricsDynamicIndex.Enabled = true
+		})
+		metrics := newMetricsWithAttributeAndResourceMap(
+			map[string]string{
+				indexSuffix: "-data.point.suffix",
+			},
+			map[string]string{
+				indexPrefix: prefix,
+				indexSuffix: suffix,
+			},
+		)
+
+		mustSendMetrics(t, exporter, metrics)
+
+		rec.WaitItems(1)
+	})
+
+	t.Run("publish with metrics grouping", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestMetricsExporter(t, server.URL, func(cfg *Config) {
+			cfg.MetricsIndex = "metrics.index"
+		})
+
+		addToMetricSlice := func(metricSlice pmetric.MetricSlice) {
+			fooMetric := metricSlice.AppendEmpty()
+			fooMetric.SetName("metric.foo")
+			fooDps := fooMetric.SetEmptyGauge().DataPoints()
+			fooDp := fooDps.AppendEmpty()
+			fooDp.SetIntValue(1)
+			fooOtherDp := fooDps.AppendEmpty()
+			fooOtherDp.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(3600, 0)))
+			fooOtherDp.SetIntValue(2)
+
+			barMetric := metricSlice.AppendEmpty()
+			barMetric.SetName("metric.bar")
+			barDps := barMetric.SetEmptyGauge().DataPoints()
+			barDp := barDps.AppendEmpty()
+			barDp.SetDoubleValue(1.0)
+			barOtherDp := barDps.AppendEmpty()
+			barOtherDp.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(3600, 0)))
+			barOtherDp.SetDoubleValue(1.0)
+			barOtherIndexDp := barDps.AppendEmpty()
+			barOtherIndexDp.Attributes().PutStr("dp.attribute", "dp.attribute.value")
+			barOtherIndexDp.SetDoubleValue(1.0)
+		}
+
+		metrics := pmetric.NewMetrics()
+		resourceMetrics := metrics.ResourceMetrics().AppendEmpty()
+		scopeA := resourceMetrics.ScopeMetrics().AppendEmpty()
+		addToMetricSlice(scopeA.Metrics())
+
+		scopeB := resourceMetrics.ScopeMetrics().AppendEmpty()
+		fooBarMetric := scopeB.Metrics().AppendEmpty()
+		fooBarMetric.SetName("metric.foobar")
+		fooBarMetric.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+
+		mustSendMetrics(t, exporter, metrics)
+
+		// the data points are grouped by timestamp and attributes, regardless of their scope
+		rec.WaitItems(3)
+		assert.ElementsMatch(t, []string{
+			`{"@timestamp":"1970-01-01T00:00:00.000000000Z","metric":{"bar":1,"foo":1,"foobar":1}}`,
+			`{"@timestamp":"1970-01-01T01:00:00.000000000Z","metric":{"bar":1,"foo":2}}`,
+			`{"@timestamp":"1970-01-01T00:00:00.000000000Z","Attributes":{"dp":{"attribute":"dp.attribute.value"}},"metric":{"bar":1}}`,
+		}, documentsToStrings(rec.Items()))
+	})
+
+	t.Run("publish histogram", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestMetricsExporter(t, server.URL)
+
+		metrics := pmetric.NewMetrics()
+		metric := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		metric.SetName("metric.histogram")
+		dp := metric.SetEmptyHistogram().DataPoints().AppendEmpty()
+		dp.BucketCounts().FromRaw([]uint64{1, 2, 3})
+		dp.ExplicitBounds().FromRaw([]float64{1, 2})
+		// summaries are not supported, and dropped
+		metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().AppendEmpty().SetEmptySummary().DataPoints().AppendEmpty()
+
+		mustSendMetrics(t, exporter, metrics)
+
+		rec.WaitItems(1)
+		assert.Equal(t, []string{
+			`{"@timestamp":"1970-01-01T00:00:00.000000000Z","metric":{"histogram":{"counts":[1,2,3],"values":[0.5,1.5,2]}}}`,
+		}, documentsToStrings(rec.Items()))
+	})
+}
+
 // TestExporterAuth verifies that the Elasticsearch exporter supports
 // confighttp.ClientConfig.Auth.
 func TestExporterAuth(t *testing.T) {
@@ -634,6 +781,24 @@ func newTestTracesExporter(t *testing.T, url string, fns ...func(*Config)) expor
 	return exp
 }
 
+func newTestMetricsExporter(t *testing.T, url string, fns ...func(*Config)) exporter.Metrics {
+	f := NewFactory()
+	cfg := withDefaultConfig(append([]func(*Config){func(cfg *Config) {
+		cfg.Endpoints = []string{url}
+		cfg.NumWorkers = 1
+		cfg.Flush.Interval = 10 * time.Millisecond
+	}}, fns...)...)
+	exp, err := f.CreateMetricsExporter(context.Background(), exportertest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+
+	err = exp.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, exp.Shutdown(context.Background()))
+	})
+	return exp
+}
+
 func newTestLogsExporter(t *testing.T, url string, fns ...func(*Config)) exporter.Logs {
 	exp := newUnstartedTestLogsExporter(t, url, fns...)
 	err := exp.Start(context.Background(), componenttest.NewNopHost())
@@ -686,6 +851,41 @@ func mustSendTraces(t *testing.T, exporter exporter.Traces, traces ptrace.Traces
 	require.NoError(t, err)
 }
 
+func mustSendMetricSumDataPoints(t *testing.T, exporter exporter.Metrics, dataPoints ...pmetric.NumberDataPoint) {
+	metrics := pmetric.NewMetrics()
+	scopeMetrics := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	for _, dataPoint := range dataPoints {
+		metric := scopeMetrics.Metrics().AppendEmpty()
+		metric.SetName("sum")
+		dataPoint.CopyTo(metric.SetEmptySum().DataPoints().AppendEmpty())
+	}
+	mustSendMetrics(t, exporter, metrics)
+}
+
+func mustSendMetricGaugeDataPoints(t *testing.T, exporter exporter.Metrics, dataPoints ...pmetric.NumberDataPoint) {
+	metrics := pmetric.NewMetrics()
+	scopeMetrics := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	for _, dataPoint := range dataPoints {
+		metric := scopeMetrics.Metrics().AppendEmpty()
+		metric.SetName("gauge")
+		dataPoint.CopyTo(metric.SetEmptyGauge().DataPoints().AppendEmpty())
+	}
+	mustSendMetrics(t, exporter, metrics)
+}
+
+func mustSendMetrics(t *testing.T, exporter exporter.Metrics, metrics pmetric.Metrics) {
+	err := exporter.ConsumeMetrics(context.Background(), metrics)
+	require.NoError(t, err)
+}
+
+func documentsToStrings(items []itemRequest) []string {
+	docs := make([]string, 0, len(items))
+	for _, item := range items {
+		docs = append(docs, string(item.Document))
+	}
+	return docs
+}
+
 type mockHost struct {
 	extensions map[component.ID]component.Component
 }
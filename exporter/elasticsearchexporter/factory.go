@@ -21,8 +21,9 @@ import (
 
 const (
 	// The value of "type" key in configuration.
-	defaultLogsIndex   = "logs-generic-default"
-	defaultTracesIndex = "traces-generic-default"
+	defaultLogsIndex    = "logs-generic-default"
+	defaultTracesIndex  = "traces-generic-default"
+	defaultMetricsIndex = "metrics-generic-default"
 )
 
 // NewFactory creates a factory for Elastic exporter.
@@ -32,6 +33,7 @@ func NewFactory() exporter.Factory {
 		createDefaultConfig,
 		exporter.WithLogs(createLogsExporter, metadata.LogsStability),
 		exporter.WithTraces(createTracesExporter, metadata.TracesStability),
+		exporter.WithMetrics(createMetricsExporter, metadata.MetricsStability),
 	)
 }
 
@@ -48,6 +50,7 @@ func createDefaultConfig() component.Config {
 		Index:         "",
 		LogsIndex:     defaultLogsIndex,
 		TracesIndex:   defaultTracesIndex,
+		MetricsIndex:  defaultMetricsIndex,
 		Retry: RetrySettings{
 			Enabled:         true,
 			MaxRequests:     3,
@@ -126,3 +129,29 @@ func createTracesExporter(ctx context.Context,
 		exporterhelper.WithQueue(cf.QueueSettings),
 	)
 }
+
+// createMetricsExporter creates a new exporter for metrics.
+//
+// Data points sharing the same timestamp and dimensions are grouped into a single document,
+// as expected by time series data streams.
+func createMetricsExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Metrics, error) {
+	cf := cfg.(*Config)
+
+	exporter, err := newExporter(cf, set, cf.MetricsIndex, cf.MetricsDynamicIndex.Enabled)
+	if err != nil {
+		return nil, fmt.Errorf("cannot configure Elasticsearch exporter: %w", err)
+	}
+	return exporterhelper.NewMetricsExporter(
+		ctx,
+		set,
+		cfg,
+		exporter.pushMetricsData,
+		exporterhelper.WithStart(exporter.Start),
+		exporterhelper.WithShutdown(exporter.Shutdown),
+		exporterhelper.WithQueue(cf.QueueSettings),
+	)
+}
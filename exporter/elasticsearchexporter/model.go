@@ -5,12 +5,18 @@ package elasticsearchexporter // import "github.com/open-telemetry/opentelemetry
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"hash/fnv"
+	"math"
+	"sort"
 	"time"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	semconv "go.opentelemetry.io/collector/semconv/v1.22.0"
 
@@ -52,6 +58,14 @@ var resourceAttrsConversionMap = map[string]string{
 type mappingModel interface {
 	encodeLog(pcommon.Resource, plog.LogRecord, pcommon.InstrumentationScope) ([]byte, error)
 	encodeSpan(pcommon.Resource, ptrace.Span, pcommon.InstrumentationScope) ([]byte, error)
+	upsertMetricDataPoint(map[uint64]objmodel.Document, pcommon.Resource, pmetric.Metric, dataPoint, pcommon.Value)
+	encodeDocument(objmodel.Document) ([]byte, error)
+}
+
+// dataPoint is the common interface of the metric data points.
+type dataPoint interface {
+	Timestamp() pcommon.Timestamp
+	Attributes() pcommon.Map
 }
 
 // encodeModel tries to keep the event as close to the original open telemetry semantics as is.
@@ -66,6 +80,11 @@ type encodeModel struct {
 	mode  MappingMode
 }
 
+var (
+	errInvalidNumberDataPoint    = errors.New("invalid number data point")
+	errInvalidHistogramDataPoint = errors.New("invalid histogram data point")
+)
+
 const (
 	traceIDField   = "traceID"
 	spanIDField    = "spanID"
@@ -81,14 +100,7 @@ func (m *encodeModel) encodeLog(resource pcommon.Resource, record plog.LogRecord
 		document = m.encodeLogDefaultMode(resource, record, scope)
 	}
 
-	var buf bytes.Buffer
-	if m.dedup {
-		document.Dedup()
-	} else if m.dedot {
-		document.Sort()
-	}
-	err := document.Serialize(&buf, m.dedot)
-	return buf.Bytes(), err
+	return m.encodeDocument(document)
 }
 
 func (m *encodeModel) encodeLogDefaultMode(resource pcommon.Resource, record plog.LogRecord, scope pcommon.InstrumentationScope) objmodel.Document {
@@ -173,6 +185,33 @@ func (m *encodeModel) encodeSpan(resource pcommon.Resource, span ptrace.Span, sc
 	document.AddInt("Duration", durationAsMicroseconds(span.StartTimestamp().AsTime(), span.EndTimestamp().AsTime())) // unit is microseconds
 	document.AddAttributes("Scope", scopeToAttributes(scope))
 
+	return m.encodeDocument(document)
+}
+
+// upsertMetricDataPoint adds the value of the data point to the document of the given documents
+// sharing its timestamp and attributes, creating the document if needed. The documents must all
+// belong to the given resource.
+func (m *encodeModel) upsertMetricDataPoint(documents map[uint64]objmodel.Document, resource pcommon.Resource, metric pmetric.Metric, dp dataPoint, value pcommon.Value) {
+	hash := metricHash(dp.Timestamp(), dp.Attributes())
+	document, ok := documents[hash]
+	if !ok {
+		document.AddTimestamp("@timestamp", dp.Timestamp())
+		switch m.mode {
+		case MappingECS:
+			encodeLogAttributesECSMode(&document, resource.Attributes(), resourceAttrsConversionMap)
+			document.AddAttributes("", dp.Attributes())
+		default:
+			document.AddAttributes("Resource", resource.Attributes())
+			m.encodeAttributes(&document, dp.Attributes())
+		}
+	}
+
+	// histograms are added as objects, which must not be flattened to be mapped to the histogram field type
+	document.Add(metric.Name(), objmodel.ValueFromAttribute(value))
+	documents[hash] = document
+}
+
+func (m *encodeModel) encodeDocument(document objmodel.Document) ([]byte, error) {
 	if m.dedup {
 		document.Dedup()
 	} else if m.dedot {
@@ -200,6 +239,127 @@ func (m *encodeModel) encodeEvents(document *objmodel.Document, events ptrace.Sp
 	document.AddEvents(key, events)
 }
 
+// metricHash identifies the document holding the data points with the given timestamp and attributes.
+func metricHash(timestamp pcommon.Timestamp, attributes pcommon.Map) uint64 {
+	hasher := fnv.New64a()
+
+	timestampBuf := make([]byte, 8)
+	binary.LittleEndian.PutUint64(timestampBuf, uint64(timestamp))
+	hasher.Write(timestampBuf)
+
+	keys := make([]string, 0, attributes.Len())
+	attributes.Range(func(k string, _ pcommon.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	sort.Strings(keys)
+	for _, k := range keys {
+		v, _ := attributes.Get(k)
+		hasher.Write([]byte(k))
+		hasher.Write([]byte{0, byte(v.Type())})
+		hasher.Write([]byte(v.AsString()))
+		hasher.Write([]byte{0})
+	}
+
+	return hasher.Sum64()
+}
+
+func numberToValue(dp pmetric.NumberDataPoint) (pcommon.Value, error) {
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeDouble:
+		return pcommon.NewValueDouble(dp.DoubleValue()), nil
+	case pmetric.NumberDataPointValueTypeInt:
+		return pcommon.NewValueInt(dp.IntValue()), nil
+	}
+	return pcommon.Value{}, errInvalidNumberDataPoint
+}
+
+// histogramToValue converts the histogram data point to the value of an Elasticsearch histogram
+// field, where the count of each bucket is reported at the midpoint of its bounds.
+func histogramToValue(dp pmetric.HistogramDataPoint) (pcommon.Value, error) {
+	bucketCounts := dp.BucketCounts()
+	explicitBounds := dp.ExplicitBounds()
+	if bucketCounts.Len() == 0 && explicitBounds.Len() == 0 {
+		// a histogram without buckets only reports its count and sum, which make a single bucket
+		bucketCounts = pcommon.NewUInt64Slice()
+		bucketCounts.Append(dp.Count())
+	}
+	if bucketCounts.Len() != explicitBounds.Len()+1 {
+		return pcommon.Value{}, errInvalidHistogramDataPoint
+	}
+
+	value := pcommon.NewValueMap()
+	counts := value.Map().PutEmptySlice("counts")
+	values := value.Map().PutEmptySlice("values")
+	for i := 0; i < bucketCounts.Len(); i++ {
+		count := bucketCounts.At(i)
+		if count == 0 {
+			continue
+		}
+
+		var midpoint float64
+		switch {
+		case explicitBounds.Len() == 0:
+			// a single bucket holding all the values, whose mean is the best estimate
+			if dp.HasSum() {
+				midpoint = dp.Sum() / float64(count)
+			}
+		case i == 0:
+			// the first bucket is unbounded below, assume that its values are positive if it allows it
+			midpoint = explicitBounds.At(0)
+			if midpoint > 0 {
+				midpoint /= 2
+			}
+		case i == bucketCounts.Len()-1:
+			// the last bucket is unbounded above
+			midpoint = explicitBounds.At(i - 1)
+		default:
+			midpoint = (explicitBounds.At(i-1) + explicitBounds.At(i)) / 2
+		}
+
+		counts.AppendEmpty().SetInt(int64(count))
+		values.AppendEmpty().SetDouble(midpoint)
+	}
+	return value, nil
+}
+
+// exponentialHistogramToValue converts the exponential histogram data point to the value of an
+// Elasticsearch histogram field, where the count of each bucket is reported at the midpoint of
+// its bounds, in increasing order.
+func exponentialHistogramToValue(dp pmetric.ExponentialHistogramDataPoint) pcommon.Value {
+	base := math.Pow(2, math.Pow(2, -float64(dp.Scale())))
+	midpoint := func(index int) float64 {
+		lower := math.Pow(base, float64(index))
+		return (lower + lower*base) / 2
+	}
+
+	value := pcommon.NewValueMap()
+	counts := value.Map().PutEmptySlice("counts")
+	values := value.Map().PutEmptySlice("values")
+
+	negative := dp.Negative()
+	for i := negative.BucketCounts().Len() - 1; i >= 0; i-- {
+		if count := negative.BucketCounts().At(i); count != 0 {
+			counts.AppendEmpty().SetInt(int64(count))
+			values.AppendEmpty().SetDouble(-midpoint(int(negative.Offset()) + i))
+		}
+	}
+
+	if zeroCount := dp.ZeroCount(); zeroCount != 0 {
+		counts.AppendEmpty().SetInt(int64(zeroCount))
+		values.AppendEmpty().SetDouble(0)
+	}
+
+	positive := dp.Positive()
+	for i := 0; i < positive.BucketCounts().Len(); i++ {
+		if count := positive.BucketCounts().At(i); count != 0 {
+			counts.AppendEmpty().SetInt(int64(count))
+			values.AppendEmpty().SetDouble(midpoint(int(positive.Offset()) + i))
+		}
+	}
+	return value
+}
+
 func spanLinksToString(spanLinkSlice ptrace.SpanLinkSlice) string {
 	linkArray := make([]map[string]any, 0, spanLinkSlice.Len())
 	for i := 0; i < spanLinkSlice.Len(); i++ {
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	semconv "go.opentelemetry.io/collector/semconv/v1.22.0"
 
@@ -61,6 +62,193 @@ func TestEncodeLog(t *testing.T) {
 	})
 }
 
+func TestEncodeMetric(t *testing.T) {
+	metrics := pmetric.NewMetrics()
+	resource := metrics.ResourceMetrics().AppendEmpty().Resource()
+	resource.Attributes().PutStr("host.name", "host1")
+	resource.Attributes().PutStr("telemetry.sdk.name", "opentelemetry")
+	timestamp := pcommon.NewTimestampFromTime(time.Date(2023, 4, 19, 3, 4, 5, 6, time.UTC))
+
+	newDataPoint := func(state string) pmetric.NumberDataPoint {
+		dp := pmetric.NewNumberDataPoint()
+		dp.SetTimestamp(timestamp)
+		dp.Attributes().PutStr("state", state)
+		return dp
+	}
+	cpuTime := pmetric.NewMetric()
+	cpuTime.SetName("system.cpu.time")
+	cpuUtilization := pmetric.NewMetric()
+	cpuUtilization.SetName("system.cpu.utilization")
+
+	tests := []struct {
+		name     string
+		mode     MappingMode
+		expected []string
+	}{
+		{
+			name: "none",
+			mode: MappingNone,
+			expected: []string{
+				`{"@timestamp":"2023-04-19T03:04:05.000000006Z","Attributes.state":"idle","Resource.host.name":"host1","Resource.telemetry.sdk.name":"opentelemetry","system.cpu.time":440.8,"system.cpu.utilization":0.8}`,
+				`{"@timestamp":"2023-04-19T03:04:05.000000006Z","Attributes.state":"user","Resource.host.name":"host1","Resource.telemetry.sdk.name":"opentelemetry","system.cpu.time":110,"system.cpu.utilization":0.2}`,
+			},
+		},
+		{
+			name: "raw",
+			mode: MappingRaw,
+			expected: []string{
+				`{"@timestamp":"2023-04-19T03:04:05.000000006Z","Resource.host.name":"host1","Resource.telemetry.sdk.name":"opentelemetry","state":"idle","system.cpu.time":440.8,"system.cpu.utilization":0.8}`,
+				`{"@timestamp":"2023-04-19T03:04:05.000000006Z","Resource.host.name":"host1","Resource.telemetry.sdk.name":"opentelemetry","state":"user","system.cpu.time":110,"system.cpu.utilization":0.2}`,
+			},
+		},
+		{
+			name: "ecs",
+			mode: MappingECS,
+			expected: []string{
+				`{"@timestamp":"2023-04-19T03:04:05.000000006Z","host.hostname":"host1","state":"idle","system.cpu.time":440.8,"system.cpu.utilization":0.8}`,
+				`{"@timestamp":"2023-04-19T03:04:05.000000006Z","host.hostname":"host1","state":"user","system.cpu.time":110,"system.cpu.utilization":0.2}`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			model := &encodeModel{dedup: true, dedot: false, mode: tc.mode}
+			documents := make(map[uint64]objmodel.Document)
+			model.upsertMetricDataPoint(documents, resource, cpuTime, newDataPoint("idle"), pcommon.NewValueDouble(440.8))
+			model.upsertMetricDataPoint(documents, resource, cpuTime, newDataPoint("user"), pcommon.NewValueInt(110))
+			model.upsertMetricDataPoint(documents, resource, cpuUtilization, newDataPoint("idle"), pcommon.NewValueDouble(0.8))
+			model.upsertMetricDataPoint(documents, resource, cpuUtilization, newDataPoint("user"), pcommon.NewValueDouble(0.2))
+
+			var actual []string
+			for _, document := range documents {
+				docBytes, err := model.encodeDocument(document)
+				require.NoError(t, err)
+				actual = append(actual, string(docBytes))
+			}
+			assert.ElementsMatch(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestEncodeMetricHistogram(t *testing.T) {
+	model := &encodeModel{dedup: true, dedot: true}
+	metric := pmetric.NewMetric()
+	metric.SetName("http.server.duration")
+	dp := pmetric.NewHistogramDataPoint()
+	dp.BucketCounts().FromRaw([]uint64{1, 2})
+	dp.ExplicitBounds().FromRaw([]float64{10})
+	value, err := histogramToValue(dp)
+	require.NoError(t, err)
+
+	documents := make(map[uint64]objmodel.Document)
+	model.upsertMetricDataPoint(documents, pcommon.NewResource(), metric, dp, value)
+	require.Len(t, documents, 1)
+	for _, document := range documents {
+		docBytes, err := model.encodeDocument(document)
+		require.NoError(t, err)
+		assert.Equal(t, `{"@timestamp":"1970-01-01T00:00:00.000000000Z","http":{"server":{"duration":{"counts":[1,2],"values":[5,10]}}}}`, string(docBytes))
+	}
+}
+
+func TestHistogramToValue(t *testing.T) {
+	tests := []struct {
+		name           string
+		counts         []uint64
+		bounds         []float64
+		count          uint64
+		sum            float64
+		expectedCounts []any
+		expectedValues []any
+		expectedErr    error
+	}{
+		{
+			name:           "midpoints",
+			counts:         []uint64{1, 2, 0, 4},
+			bounds:         []float64{10, 20, 40},
+			expectedCounts: []any{int64(1), int64(2), int64(4)},
+			expectedValues: []any{5.0, 15.0, 40.0},
+		},
+		{
+			name:           "negative first bound",
+			counts:         []uint64{1, 2, 3},
+			bounds:         []float64{-10, 10},
+			expectedCounts: []any{int64(1), int64(2), int64(3)},
+			expectedValues: []any{-10.0, 0.0, 10.0},
+		},
+		{
+			name:           "single bucket",
+			counts:         []uint64{4},
+			sum:            10,
+			expectedCounts: []any{int64(4)},
+			expectedValues: []any{2.5},
+		},
+		{
+			name:           "no buckets",
+			count:          4,
+			sum:            10,
+			expectedCounts: []any{int64(4)},
+			expectedValues: []any{2.5},
+		},
+		{
+			name:           "no buckets nor values",
+			expectedCounts: []any{},
+			expectedValues: []any{},
+		},
+		{
+			name:        "bounds without buckets",
+			bounds:      []float64{10},
+			expectedErr: errInvalidHistogramDataPoint,
+		},
+		{
+			name:        "mismatching bounds",
+			counts:      []uint64{1, 2},
+			bounds:      []float64{10, 20},
+			expectedErr: errInvalidHistogramDataPoint,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dp := pmetric.NewHistogramDataPoint()
+			dp.BucketCounts().FromRaw(tc.counts)
+			dp.ExplicitBounds().FromRaw(tc.bounds)
+			dp.SetCount(tc.count)
+			if tc.sum != 0 {
+				dp.SetSum(tc.sum)
+			}
+
+			value, err := histogramToValue(dp)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, map[string]any{
+				"counts": tc.expectedCounts,
+				"values": tc.expectedValues,
+			}, value.Map().AsRaw())
+		})
+	}
+}
+
+func TestExponentialHistogramToValue(t *testing.T) {
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	// with a scale of 0, the bucket of index i holds the values in (2^i, 2^(i+1)]
+	dp.SetScale(0)
+	dp.SetZeroCount(3)
+	dp.Negative().SetOffset(1)
+	dp.Negative().BucketCounts().FromRaw([]uint64{1, 0, 2})
+	dp.Positive().SetOffset(-1)
+	dp.Positive().BucketCounts().FromRaw([]uint64{4, 5})
+
+	value := exponentialHistogramToValue(dp)
+	assert.Equal(t, map[string]any{
+		"counts": []any{int64(2), int64(1), int64(3), int64(4), int64(5)},
+		"values": []any{-12.0, -3.0, 0.0, 0.75, 1.5},
+	}, value.Map().AsRaw())
+}
+
 func mockResourceSpans() ptrace.Traces {
 	traces := ptrace.NewTraces()
 
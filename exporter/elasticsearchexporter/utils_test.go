@@ -18,6 +18,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
@@ -250,6 +251,23 @@ func newTracesWithAttributeAndResourceMap(attrMp map[string]string, resMp map[st
 	return traces
 }
 
+func newMetricsWithAttributeAndResourceMap(attrMp map[string]string, resMp map[string]string) pmetric.Metrics {
+	metrics := pmetric.NewMetrics()
+	resourceMetrics := metrics.ResourceMetrics()
+	rm := resourceMetrics.AppendEmpty()
+
+	metric := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("sum")
+	dataPoint := metric.SetEmptySum().DataPoints().AppendEmpty()
+	dataPoint.SetIntValue(1)
+	fillResourceAttributeMap(dataPoint.Attributes(), attrMp)
+
+	resAttr := rm.Resource().Attributes()
+	fillResourceAttributeMap(resAttr, resMp)
+
+	return metrics
+}
+
 func fillResourceAttributeMap(attrs pcommon.Map, mp map[string]string) {
 	attrs.EnsureCapacity(len(mp))
 	for k, v := range mp {
@@ -11,6 +11,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka"
 )
@@ -53,6 +54,9 @@ type Config struct {
 
 	PartitionMetricsByResourceAttributes bool `mapstructure:"partition_metrics_by_resource_attributes"`
 
+	// Expressions defines OTTL value expressions computing the topic, key and headers of the messages.
+	Expressions MessageExpressions `mapstructure:"expressions"`
+
 	// Metadata is the namespace for metadata management properties used by the
 	// Client, and shared by the Producer/Consumer.
 	Metadata Metadata `mapstructure:"metadata"`
@@ -64,6 +68,31 @@ type Config struct {
 	Authentication kafka.Authentication `mapstructure:"auth"`
 }
 
+// MessageExpressions defines the OTTL value expressions evaluated for each resource or record to
+// compute the topic, key and headers of its message. Data is split so that each message contains
+// the resources or records which share the same topic, key and headers.
+type MessageExpressions struct {
+	// Context is the OTTL context the expressions are evaluated in, either "resource" (default)
+	// or "record", which is the span, metric or log context depending on the signal.
+	Context string `mapstructure:"context"`
+
+	// Topic computes the topic of the messages. When it evaluates to an empty value, the topic is
+	// chosen as if no expression was set.
+	Topic string `mapstructure:"topic"`
+
+	// Key computes the message key. When it evaluates to an empty value, the key is the one set by
+	// the encoding, if any.
+	Key string `mapstructure:"key"`
+
+	// Headers maps the names of the Kafka headers to add to the messages to the expressions computing
+	// their values. Headers whose value is empty are not added.
+	Headers map[string]string `mapstructure:"headers"`
+}
+
+func (e MessageExpressions) enabled() bool {
+	return e.Topic != "" || e.Key != "" || len(e.Headers) > 0
+}
+
 // Metadata defines configuration for retrieving metadata from the broker.
 type Metadata struct {
 	// Whether to maintain a full set of metadata for all topics, or just
@@ -125,9 +154,27 @@ func (cfg *Config) Validate() error {
 		return err
 	}
 
+	if err := validateMessageExpressions(cfg.Expressions); err != nil {
+		return err
+	}
+
 	return validateSASLConfig(cfg.Authentication.SASL)
 }
 
+func validateMessageExpressions(e MessageExpressions) error {
+	switch e.Context {
+	case "", expressionContextResource:
+		// The expressions of the record context depend on the signal, they are parsed when the exporter is created.
+		if _, err := newResourceExpressions(e, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
+			return fmt.Errorf("expressions: %w", err)
+		}
+	case expressionContextRecord:
+	default:
+		return fmt.Errorf("expressions.context should be one of 'resource' or 'record'. configured value %v", e.Context)
+	}
+	return nil
+}
+
 func validateSASLConfig(c *kafka.SASLConfig) error {
 	if c == nil {
 		return nil
@@ -61,6 +61,11 @@ func TestLoadConfig(t *testing.T) {
 				PartitionMetricsByResourceAttributes: true,
 				Brokers:                              []string{"foo:123", "bar:456"},
 				ClientID:                             "test_client_id",
+				Expressions: MessageExpressions{
+					Context: "record",
+					Key:     `attributes["tenant"]`,
+					Headers: map[string]string{"service": `resource.attributes["service.name"]`},
+				},
 				Authentication: kafka.Authentication{
 					PlainText: &kafka.PlainTextConfig{
 						Username: "jdoe",
@@ -116,6 +121,11 @@ func TestLoadConfig(t *testing.T) {
 				PartitionMetricsByResourceAttributes: true,
 				Brokers:                              []string{"foo:123", "bar:456"},
 				ClientID:                             "test_client_id",
+				Expressions: MessageExpressions{
+					Context: "record",
+					Key:     `attributes["tenant"]`,
+					Headers: map[string]string{"service": `resource.attributes["service.name"]`},
+				},
 				Authentication: kafka.Authentication{
 					PlainText: &kafka.PlainTextConfig{
 						Username: "jdoe",
@@ -170,6 +180,11 @@ func TestLoadConfig(t *testing.T) {
 				PartitionMetricsByResourceAttributes: true,
 				Brokers:                              []string{"foo:123", "bar:456"},
 				ClientID:                             "test_client_id",
+				Expressions: MessageExpressions{
+					Context: "record",
+					Key:     `attributes["tenant"]`,
+					Headers: map[string]string{"service": `resource.attributes["service.name"]`},
+				},
 				ResolveCanonicalBootstrapServersOnly: true,
 				Authentication: kafka.Authentication{
 					PlainText: &kafka.PlainTextConfig{
@@ -291,6 +306,35 @@ func TestValidate_sasl_version(t *testing.T) {
 	assert.EqualError(t, err, "auth.sasl.version has to be either 0 or 1. configured value 42")
 }
 
+func TestValidate_expressions_context(t *testing.T) {
+	config := &Config{
+		Producer: Producer{
+			Compression: "none",
+		},
+		Expressions: MessageExpressions{
+			Context: "span",
+			Topic:   `name`,
+		},
+	}
+
+	err := config.Validate()
+	assert.EqualError(t, err, "expressions.context should be one of 'resource' or 'record'. configured value span")
+}
+
+func TestValidate_expressions_resource(t *testing.T) {
+	config := &Config{
+		Producer: Producer{
+			Compression: "none",
+		},
+		Expressions: MessageExpressions{
+			Key: `body`,
+		},
+	}
+
+	err := config.Validate()
+	assert.ErrorContains(t, err, "expressions: invalid key expression")
+}
+
 func Test_saramaProducerCompressionCodec(t *testing.T) {
 	tests := map[string]struct {
 		compression         string
@@ -0,0 +1,404 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkaexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/IBM/sarama"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+const (
+	expressionContextResource = "resource"
+	expressionContextRecord   = "record"
+)
+
+// messageRouting is the topic, key and headers computed for a resource or a record.
+type messageRouting struct {
+	topic   string
+	key     string
+	headers []sarama.RecordHeader
+}
+
+// id identifies the routings of the data that can be sent in the same messages.
+func (r messageRouting) id() string {
+	var sb strings.Builder
+	sb.WriteString(r.topic)
+	sb.WriteByte(0)
+	sb.WriteString(r.key)
+	for _, header := range r.headers {
+		sb.WriteByte(0)
+		sb.Write(header.Key)
+		sb.WriteByte('=')
+		sb.Write(header.Value)
+	}
+	return sb.String()
+}
+
+// apply sets the key and headers of the messages marshaled for the routing. The key set by
+// the marshaler is kept when the key expression evaluates to an empty value.
+func (r messageRouting) apply(messages []*sarama.ProducerMessage) {
+	for _, msg := range messages {
+		if r.key != "" {
+			msg.Key = sarama.StringEncoder(r.key)
+		}
+		msg.Headers = append(msg.Headers, r.headers...)
+	}
+}
+
+// messageExpressions are the parsed expressions of MessageExpressions for the OTTL context K.
+type messageExpressions[K any] struct {
+	topic   *ottl.ValueExpression[K]
+	key     *ottl.ValueExpression[K]
+	headers []headerExpression[K]
+}
+
+type headerExpression[K any] struct {
+	name  string
+	value *ottl.ValueExpression[K]
+}
+
+func newMessageExpressions[K any](parser ottl.Parser[K], cfg MessageExpressions) (*messageExpressions[K], error) {
+	var err error
+	expressions := &messageExpressions[K]{}
+	if cfg.Topic != "" {
+		if expressions.topic, err = parser.ParseValueExpression(cfg.Topic); err != nil {
+			return nil, fmt.Errorf("invalid topic expression: %w", err)
+		}
+	}
+	if cfg.Key != "" {
+		if expressions.key, err = parser.ParseValueExpression(cfg.Key); err != nil {
+			return nil, fmt.Errorf("invalid key expression: %w", err)
+		}
+	}
+	names := make([]string, 0, len(cfg.Headers))
+	for name := range cfg.Headers {
+		names = append(names, name)
+	}
+	// headers are sorted so that equal routings always get the same id
+	sort.Strings(names)
+	for _, name := range names {
+		value, err := parser.ParseValueExpression(cfg.Headers[name])
+		if err != nil {
+			return nil, fmt.Errorf("invalid expression of header %q: %w", name, err)
+		}
+		expressions.headers = append(expressions.headers, headerExpression[K]{name: name, value: value})
+	}
+	return expressions, nil
+}
+
+func (e *messageExpressions[K]) eval(ctx context.Context, tCtx K) (messageRouting, error) {
+	var routing messageRouting
+	var err error
+	if routing.topic, err = evalString(ctx, e.topic, tCtx); err != nil {
+		return routing, fmt.Errorf("failed to evaluate topic expression: %w", err)
+	}
+	if routing.key, err = evalString(ctx, e.key, tCtx); err != nil {
+		return routing, fmt.Errorf("failed to evaluate key expression: %w", err)
+	}
+	for _, header := range e.headers {
+		value, err := evalString(ctx, header.value, tCtx)
+		if err != nil {
+			return routing, fmt.Errorf("failed to evaluate expression of header %q: %w", header.name, err)
+		}
+		if value == "" {
+			continue
+		}
+		routing.headers = append(routing.headers, sarama.RecordHeader{Key: []byte(header.name), Value: []byte(value)})
+	}
+	return routing, nil
+}
+
+// evalString evaluates an expression, if any, and converts its value to a string.
+func evalString[K any](ctx context.Context, expression *ottl.ValueExpression[K], tCtx K) (string, error) {
+	if expression == nil {
+		return "", nil
+	}
+	value, err := expression.Eval(ctx, tCtx)
+	if err != nil {
+		return "", err
+	}
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case pcommon.Value:
+		return v.AsString(), nil
+	case pcommon.Map:
+		mapValue := pcommon.NewValueEmpty()
+		v.CopyTo(mapValue.SetEmptyMap())
+		return mapValue.AsString(), nil
+	case pcommon.Slice:
+		sliceValue := pcommon.NewValueEmpty()
+		v.CopyTo(sliceValue.SetEmptySlice())
+		return sliceValue.AsString(), nil
+	case pcommon.TraceID:
+		return v.String(), nil
+	case pcommon.SpanID:
+		return v.String(), nil
+	}
+	raw := pcommon.NewValueEmpty()
+	if err := raw.FromRaw(value); err != nil {
+		return "", fmt.Errorf("unsupported value of type %T", value)
+	}
+	return raw.AsString(), nil
+}
+
+// batch holds the data sharing the same routing.
+type batch[T any] struct {
+	routing messageRouting
+	data    T
+	// resource and scope are the indexes of the source resource and scope last copied to data,
+	// so that the records of a resource and scope are appended to the same copies.
+	resource, scope int
+}
+
+// batches groups data by routing, in order of first appearance.
+type batches[T any] struct {
+	newData func() T
+	ids     map[string]int
+	items   []*batch[T]
+}
+
+func newBatches[T any](newData func() T) *batches[T] {
+	return &batches[T]{newData: newData, ids: map[string]int{}}
+}
+
+func (b *batches[T]) get(routing messageRouting) *batch[T] {
+	id := routing.id()
+	if i, ok := b.ids[id]; ok {
+		return b.items[i]
+	}
+	b.ids[id] = len(b.items)
+	item := &batch[T]{routing: routing, data: b.newData(), resource: -1, scope: -1}
+	b.items = append(b.items, item)
+	return item
+}
+
+func newResourceExpressions(cfg MessageExpressions, set component.TelemetrySettings) (*messageExpressions[ottlresource.TransformContext], error) {
+	parser, err := ottlresource.NewParser(ottlfuncs.StandardConverters[ottlresource.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+	return newMessageExpressions(parser, cfg)
+}
+
+// tracesExpressions splits traces by the routing computed for each resource or span.
+type tracesExpressions struct {
+	resource *messageExpressions[ottlresource.TransformContext]
+	span     *messageExpressions[ottlspan.TransformContext]
+}
+
+func newTracesExpressions(cfg MessageExpressions, set component.TelemetrySettings) (*tracesExpressions, error) {
+	if cfg.Context != expressionContextRecord {
+		resource, err := newResourceExpressions(cfg, set)
+		if err != nil {
+			return nil, err
+		}
+		return &tracesExpressions{resource: resource}, nil
+	}
+	parser, err := ottlspan.NewParser(ottlfuncs.StandardConverters[ottlspan.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+	span, err := newMessageExpressions(parser, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &tracesExpressions{span: span}, nil
+}
+
+func (e *tracesExpressions) split(ctx context.Context, td ptrace.Traces) ([]*batch[ptrace.Traces], error) {
+	batches := newBatches(ptrace.NewTraces)
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		if e.resource != nil {
+			routing, err := e.resource.eval(ctx, ottlresource.NewTransformContext(rs.Resource()))
+			if err != nil {
+				return nil, err
+			}
+			rs.CopyTo(batches.get(routing).data.ResourceSpans().AppendEmpty())
+			continue
+		}
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			for k := 0; k < ss.Spans().Len(); k++ {
+				span := ss.Spans().At(k)
+				routing, err := e.span.eval(ctx, ottlspan.NewTransformContext(span, ss.Scope(), rs.Resource()))
+				if err != nil {
+					return nil, err
+				}
+				b := batches.get(routing)
+				if b.resource != i {
+					dest := b.data.ResourceSpans().AppendEmpty()
+					rs.Resource().CopyTo(dest.Resource())
+					dest.SetSchemaUrl(rs.SchemaUrl())
+					b.resource, b.scope = i, -1
+				}
+				resourceSpans := b.data.ResourceSpans().At(b.data.ResourceSpans().Len() - 1)
+				if b.scope != j {
+					dest := resourceSpans.ScopeSpans().AppendEmpty()
+					ss.Scope().CopyTo(dest.Scope())
+					dest.SetSchemaUrl(ss.SchemaUrl())
+					b.scope = j
+				}
+				scopeSpans := resourceSpans.ScopeSpans().At(resourceSpans.ScopeSpans().Len() - 1)
+				span.CopyTo(scopeSpans.Spans().AppendEmpty())
+			}
+		}
+	}
+	return batches.items, nil
+}
+
+// metricsExpressions splits metrics by the routing computed for each resource or metric.
+type metricsExpressions struct {
+	resource *messageExpressions[ottlresource.TransformContext]
+	metric   *messageExpressions[ottlmetric.TransformContext]
+}
+
+func newMetricsExpressions(cfg MessageExpressions, set component.TelemetrySettings) (*metricsExpressions, error) {
+	if cfg.Context != expressionContextRecord {
+		resource, err := newResourceExpressions(cfg, set)
+		if err != nil {
+			return nil, err
+		}
+		return &metricsExpressions{resource: resource}, nil
+	}
+	parser, err := ottlmetric.NewParser(ottlfuncs.StandardConverters[ottlmetric.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+	metric, err := newMessageExpressions(parser, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &metricsExpressions{metric: metric}, nil
+}
+
+func (e *metricsExpressions) split(ctx context.Context, md pmetric.Metrics) ([]*batch[pmetric.Metrics], error) {
+	batches := newBatches(pmetric.NewMetrics)
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		if e.resource != nil {
+			routing, err := e.resource.eval(ctx, ottlresource.NewTransformContext(rm.Resource()))
+			if err != nil {
+				return nil, err
+			}
+			rm.CopyTo(batches.get(routing).data.ResourceMetrics().AppendEmpty())
+			continue
+		}
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				metric := sm.Metrics().At(k)
+				routing, err := e.metric.eval(ctx, ottlmetric.NewTransformContext(metric, sm.Metrics(), sm.Scope(), rm.Resource()))
+				if err != nil {
+					return nil, err
+				}
+				b := batches.get(routing)
+				if b.resource != i {
+					dest := b.data.ResourceMetrics().AppendEmpty()
+					rm.Resource().CopyTo(dest.Resource())
+					dest.SetSchemaUrl(rm.SchemaUrl())
+					b.resource, b.scope = i, -1
+				}
+				resourceMetrics := b.data.ResourceMetrics().At(b.data.ResourceMetrics().Len() - 1)
+				if b.scope != j {
+					dest := resourceMetrics.ScopeMetrics().AppendEmpty()
+					sm.Scope().CopyTo(dest.Scope())
+					dest.SetSchemaUrl(sm.SchemaUrl())
+					b.scope = j
+				}
+				scopeMetrics := resourceMetrics.ScopeMetrics().At(resourceMetrics.ScopeMetrics().Len() - 1)
+				metric.CopyTo(scopeMetrics.Metrics().AppendEmpty())
+			}
+		}
+	}
+	return batches.items, nil
+}
+
+// logsExpressions splits logs by the routing computed for each resource or log record.
+type logsExpressions struct {
+	resource *messageExpressions[ottlresource.TransformContext]
+	log      *messageExpressions[ottllog.TransformContext]
+}
+
+func newLogsExpressions(cfg MessageExpressions, set component.TelemetrySettings) (*logsExpressions, error) {
+	if cfg.Context != expressionContextRecord {
+		resource, err := newResourceExpressions(cfg, set)
+		if err != nil {
+			return nil, err
+		}
+		return &logsExpressions{resource: resource}, nil
+	}
+	parser, err := ottllog.NewParser(ottlfuncs.StandardConverters[ottllog.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+	log, err := newMessageExpressions(parser, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &logsExpressions{log: log}, nil
+}
+
+func (e *logsExpressions) split(ctx context.Context, ld plog.Logs) ([]*batch[plog.Logs], error) {
+	batches := newBatches(plog.NewLogs)
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		if e.resource != nil {
+			routing, err := e.resource.eval(ctx, ottlresource.NewTransformContext(rl.Resource()))
+			if err != nil {
+				return nil, err
+			}
+			rl.CopyTo(batches.get(routing).data.ResourceLogs().AppendEmpty())
+			continue
+		}
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				logRecord := sl.LogRecords().At(k)
+				routing, err := e.log.eval(ctx, ottllog.NewTransformContext(logRecord, sl.Scope(), rl.Resource()))
+				if err != nil {
+					return nil, err
+				}
+				b := batches.get(routing)
+				if b.resource != i {
+					dest := b.data.ResourceLogs().AppendEmpty()
+					rl.Resource().CopyTo(dest.Resource())
+					dest.SetSchemaUrl(rl.SchemaUrl())
+					b.resource, b.scope = i, -1
+				}
+				resourceLogs := b.data.ResourceLogs().At(b.data.ResourceLogs().Len() - 1)
+				if b.scope != j {
+					dest := resourceLogs.ScopeLogs().AppendEmpty()
+					sl.Scope().CopyTo(dest.Scope())
+					dest.SetSchemaUrl(sl.SchemaUrl())
+					b.scope = j
+				}
+				scopeLogs := resourceLogs.ScopeLogs().At(resourceLogs.ScopeLogs().Len() - 1)
+				logRecord.CopyTo(scopeLogs.LogRecords().AppendEmpty())
+			}
+		}
+	}
+	return batches.items, nil
+}
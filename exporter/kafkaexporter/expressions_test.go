@@ -0,0 +1,202 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkaexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func newTestLogs() plog.Logs {
+	logs := plog.NewLogs()
+	for _, tenant := range []string{"acme", "globex", "acme"} {
+		rl := logs.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("tenant", tenant)
+		sl := rl.ScopeLogs().AppendEmpty()
+		sl.Scope().SetName("scope")
+		for _, service := range []string{"api", "db"} {
+			lr := sl.LogRecords().AppendEmpty()
+			lr.Attributes().PutStr("service", service)
+			lr.Body().SetStr(tenant + "-" + service)
+		}
+	}
+	return logs
+}
+
+func TestLogsExpressionsResourceContext(t *testing.T) {
+	expressions, err := newLogsExpressions(MessageExpressions{
+		Topic:   `Concat(["logs", attributes["tenant"]], "-")`,
+		Key:     `attributes["tenant"]`,
+		Headers: map[string]string{"tenant": `attributes["tenant"]`, "missing": `attributes["missing"]`},
+	}, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	batches, err := expressions.split(context.Background(), newTestLogs())
+	require.NoError(t, err)
+	require.Len(t, batches, 2)
+
+	assert.Equal(t, messageRouting{
+		topic:   "logs-acme",
+		key:     "acme",
+		headers: []sarama.RecordHeader{{Key: []byte("tenant"), Value: []byte("acme")}},
+	}, batches[0].routing)
+	assert.Equal(t, 2, batches[0].data.ResourceLogs().Len())
+	assert.Equal(t, 4, batches[0].data.LogRecordCount())
+
+	assert.Equal(t, "logs-globex", batches[1].routing.topic)
+	assert.Equal(t, 1, batches[1].data.ResourceLogs().Len())
+	assert.Equal(t, 2, batches[1].data.LogRecordCount())
+}
+
+func TestLogsExpressionsRecordContext(t *testing.T) {
+	expressions, err := newLogsExpressions(MessageExpressions{
+		Context: expressionContextRecord,
+		Key:     `Concat([resource.attributes["tenant"], attributes["service"]], "/")`,
+	}, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	batches, err := expressions.split(context.Background(), newTestLogs())
+	require.NoError(t, err)
+	require.Len(t, batches, 4)
+
+	var keys []string
+	for _, b := range batches {
+		keys = append(keys, b.routing.key)
+	}
+	assert.Equal(t, []string{"acme/api", "acme/db", "globex/api", "globex/db"}, keys)
+
+	// the records of the two "acme" resources are kept under copies of their own resource and scope
+	acmeAPI := batches[0].data
+	require.Equal(t, 2, acmeAPI.ResourceLogs().Len())
+	for i := 0; i < acmeAPI.ResourceLogs().Len(); i++ {
+		rl := acmeAPI.ResourceLogs().At(i)
+		tenant, _ := rl.Resource().Attributes().Get("tenant")
+		assert.Equal(t, "acme", tenant.Str())
+		require.Equal(t, 1, rl.ScopeLogs().Len())
+		assert.Equal(t, "scope", rl.ScopeLogs().At(0).Scope().Name())
+		require.Equal(t, 1, rl.ScopeLogs().At(0).LogRecords().Len())
+		assert.Equal(t, "acme-api", rl.ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+	}
+}
+
+func TestTracesExpressionsRecordContext(t *testing.T) {
+	traces := ptrace.NewTraces()
+	ss := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	for _, id := range []byte{1, 2, 1} {
+		span := ss.Spans().AppendEmpty()
+		span.SetTraceID(pcommon.TraceID{id})
+	}
+
+	expressions, err := newTracesExpressions(MessageExpressions{
+		Context: expressionContextRecord,
+		Key:     `trace_id`,
+	}, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	batches, err := expressions.split(context.Background(), traces)
+	require.NoError(t, err)
+	require.Len(t, batches, 2)
+	assert.Equal(t, pcommon.TraceID{1}.String(), batches[0].routing.key)
+	assert.Equal(t, 2, batches[0].data.SpanCount())
+	assert.Equal(t, 1, batches[0].data.ResourceSpans().Len())
+	assert.Equal(t, pcommon.TraceID{2}.String(), batches[1].routing.key)
+	assert.Equal(t, 1, batches[1].data.SpanCount())
+}
+
+func TestMetricsExpressionsRecordContext(t *testing.T) {
+	metrics := pmetric.NewMetrics()
+	sm := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	for _, name := range []string{"cpu", "memory", "cpu"} {
+		sm.Metrics().AppendEmpty().SetName(name)
+	}
+
+	expressions, err := newMetricsExpressions(MessageExpressions{
+		Context: expressionContextRecord,
+		Topic:   `Concat(["metrics", name], "_")`,
+	}, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	batches, err := expressions.split(context.Background(), metrics)
+	require.NoError(t, err)
+	require.Len(t, batches, 2)
+	assert.Equal(t, "metrics_cpu", batches[0].routing.topic)
+	assert.Equal(t, 2, batches[0].data.MetricCount())
+	assert.Equal(t, "metrics_memory", batches[1].routing.topic)
+	assert.Equal(t, 1, batches[1].data.MetricCount())
+}
+
+func TestNewExpressionsErrors(t *testing.T) {
+	_, err := newLogsExpressions(MessageExpressions{Topic: `attributes[`}, componenttest.NewNopTelemetrySettings())
+	assert.ErrorContains(t, err, "invalid topic expression")
+
+	_, err = newTracesExpressions(MessageExpressions{Context: expressionContextRecord, Key: `body`}, componenttest.NewNopTelemetrySettings())
+	assert.ErrorContains(t, err, "invalid key expression")
+
+	_, err = newMetricsExpressions(MessageExpressions{Headers: map[string]string{"name": `Unknown()`}}, componenttest.NewNopTelemetrySettings())
+	assert.ErrorContains(t, err, `invalid expression of header "name"`)
+}
+
+func TestLogsDataPusher_expressions(t *testing.T) {
+	producer := mocks.NewSyncProducer(t, sarama.NewConfig())
+	for _, tenant := range []string{"acme", "globex"} {
+		tenant := tenant
+		producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+			assert.Equal(t, "logs-"+tenant, msg.Topic)
+			assert.Equal(t, sarama.StringEncoder(tenant), msg.Key)
+			assert.Equal(t, []sarama.RecordHeader{{Key: []byte("tenant"), Value: []byte(tenant)}}, msg.Headers)
+			return nil
+		})
+	}
+
+	cfg := Config{
+		Topic:    "otlp_logs",
+		Encoding: defaultEncoding,
+		Expressions: MessageExpressions{
+			Topic:   `Concat(["logs", attributes["tenant"]], "-")`,
+			Key:     `attributes["tenant"]`,
+			Headers: map[string]string{"tenant": `attributes["tenant"]`},
+		},
+	}
+	p, err := newLogsExporter(cfg, exportertest.NewNopSettings(), logsMarshalers())
+	require.NoError(t, err)
+	p.producer = producer
+	t.Cleanup(func() {
+		require.NoError(t, p.Close(context.Background()))
+	})
+	require.NoError(t, p.logsDataPusher(context.Background(), newTestLogs()))
+}
+
+func TestLogsDataPusher_expressions_fallback_topic(t *testing.T) {
+	producer := mocks.NewSyncProducer(t, sarama.NewConfig())
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+		assert.Equal(t, "otlp_logs", msg.Topic)
+		return nil
+	})
+
+	cfg := Config{
+		Topic:    "otlp_logs",
+		Encoding: defaultEncoding,
+		Expressions: MessageExpressions{
+			Topic: `attributes["missing"]`,
+		},
+	}
+	p, err := newLogsExporter(cfg, exportertest.NewNopSettings(), logsMarshalers())
+	require.NoError(t, err)
+	p.producer = producer
+	t.Cleanup(func() {
+		require.NoError(t, p.Close(context.Background()))
+	})
+	require.NoError(t, p.logsDataPusher(context.Background(), newTestLogs()))
+}
@@ -25,10 +25,11 @@ var errUnrecognizedEncoding = fmt.Errorf("unrecognized encoding")
 
 // kafkaTracesProducer uses sarama to produce trace messages to Kafka.
 type kafkaTracesProducer struct {
-	cfg       Config
-	producer  sarama.SyncProducer
-	marshaler TracesMarshaler
-	logger    *zap.Logger
+	cfg         Config
+	producer    sarama.SyncProducer
+	marshaler   TracesMarshaler
+	expressions *tracesExpressions
+	logger      *zap.Logger
 }
 
 type kafkaErrors struct {
@@ -40,8 +41,8 @@ func (ke kafkaErrors) Error() string {
 	return fmt.Sprintf("Failed to deliver %d messages due to %s", ke.count, ke.err)
 }
 
-func (e *kafkaTracesProducer) tracesPusher(_ context.Context, td ptrace.Traces) error {
-	messages, err := e.marshaler.Marshal(td, getTopic(&e.cfg, td.ResourceSpans()))
+func (e *kafkaTracesProducer) tracesPusher(ctx context.Context, td ptrace.Traces) error {
+	messages, err := e.messages(ctx, td)
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
@@ -58,6 +59,31 @@ func (e *kafkaTracesProducer) tracesPusher(_ context.Context, td ptrace.Traces)
 	return nil
 }
 
+// messages marshals the traces into messages, split by the routing computed by the expressions, if any.
+func (e *kafkaTracesProducer) messages(ctx context.Context, td ptrace.Traces) ([]*sarama.ProducerMessage, error) {
+	if e.expressions == nil {
+		return e.marshaler.Marshal(td, getTopic(&e.cfg, td.ResourceSpans()))
+	}
+	batches, err := e.expressions.split(ctx, td)
+	if err != nil {
+		return nil, err
+	}
+	var messages []*sarama.ProducerMessage
+	for _, b := range batches {
+		topic := b.routing.topic
+		if topic == "" {
+			topic = getTopic(&e.cfg, b.data.ResourceSpans())
+		}
+		batchMessages, err := e.marshaler.Marshal(b.data, topic)
+		if err != nil {
+			return nil, err
+		}
+		b.routing.apply(batchMessages)
+		messages = append(messages, batchMessages...)
+	}
+	return messages, nil
+}
+
 func (e *kafkaTracesProducer) Close(context.Context) error {
 	if e.producer == nil {
 		return nil
@@ -76,14 +102,15 @@ func (e *kafkaTracesProducer) start(_ context.Context, _ component.Host) error {
 
 // kafkaMetricsProducer uses sarama to produce metrics messages to kafka
 type kafkaMetricsProducer struct {
-	cfg       Config
-	producer  sarama.SyncProducer
-	marshaler MetricsMarshaler
-	logger    *zap.Logger
+	cfg         Config
+	producer    sarama.SyncProducer
+	marshaler   MetricsMarshaler
+	expressions *metricsExpressions
+	logger      *zap.Logger
 }
 
-func (e *kafkaMetricsProducer) metricsDataPusher(_ context.Context, md pmetric.Metrics) error {
-	messages, err := e.marshaler.Marshal(md, getTopic(&e.cfg, md.ResourceMetrics()))
+func (e *kafkaMetricsProducer) metricsDataPusher(ctx context.Context, md pmetric.Metrics) error {
+	messages, err := e.messages(ctx, md)
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
@@ -100,6 +127,31 @@ func (e *kafkaMetricsProducer) metricsDataPusher(_ context.Context, md pmetric.M
 	return nil
 }
 
+// messages marshals the metrics into messages, split by the routing computed by the expressions, if any.
+func (e *kafkaMetricsProducer) messages(ctx context.Context, md pmetric.Metrics) ([]*sarama.ProducerMessage, error) {
+	if e.expressions == nil {
+		return e.marshaler.Marshal(md, getTopic(&e.cfg, md.ResourceMetrics()))
+	}
+	batches, err := e.expressions.split(ctx, md)
+	if err != nil {
+		return nil, err
+	}
+	var messages []*sarama.ProducerMessage
+	for _, b := range batches {
+		topic := b.routing.topic
+		if topic == "" {
+			topic = getTopic(&e.cfg, b.data.ResourceMetrics())
+		}
+		batchMessages, err := e.marshaler.Marshal(b.data, topic)
+		if err != nil {
+			return nil, err
+		}
+		b.routing.apply(batchMessages)
+		messages = append(messages, batchMessages...)
+	}
+	return messages, nil
+}
+
 func (e *kafkaMetricsProducer) Close(context.Context) error {
 	if e.producer == nil {
 		return nil
@@ -118,14 +170,15 @@ func (e *kafkaMetricsProducer) start(_ context.Context, _ component.Host) error
 
 // kafkaLogsProducer uses sarama to produce logs messages to kafka
 type kafkaLogsProducer struct {
-	cfg       Config
-	producer  sarama.SyncProducer
-	marshaler LogsMarshaler
-	logger    *zap.Logger
+	cfg         Config
+	producer    sarama.SyncProducer
+	marshaler   LogsMarshaler
+	expressions *logsExpressions
+	logger      *zap.Logger
 }
 
-func (e *kafkaLogsProducer) logsDataPusher(_ context.Context, ld plog.Logs) error {
-	messages, err := e.marshaler.Marshal(ld, getTopic(&e.cfg, ld.ResourceLogs()))
+func (e *kafkaLogsProducer) logsDataPusher(ctx context.Context, ld plog.Logs) error {
+	messages, err := e.messages(ctx, ld)
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
@@ -142,6 +195,31 @@ func (e *kafkaLogsProducer) logsDataPusher(_ context.Context, ld plog.Logs) erro
 	return nil
 }
 
+// messages marshals the logs into messages, split by the routing computed by the expressions, if any.
+func (e *kafkaLogsProducer) messages(ctx context.Context, ld plog.Logs) ([]*sarama.ProducerMessage, error) {
+	if e.expressions == nil {
+		return e.marshaler.Marshal(ld, getTopic(&e.cfg, ld.ResourceLogs()))
+	}
+	batches, err := e.expressions.split(ctx, ld)
+	if err != nil {
+		return nil, err
+	}
+	var messages []*sarama.ProducerMessage
+	for _, b := range batches {
+		topic := b.routing.topic
+		if topic == "" {
+			topic = getTopic(&e.cfg, b.data.ResourceLogs())
+		}
+		batchMessages, err := e.marshaler.Marshal(b.data, topic)
+		if err != nil {
+			return nil, err
+		}
+		b.routing.apply(batchMessages)
+		messages = append(messages, batchMessages...)
+	}
+	return messages, nil
+}
+
 func (e *kafkaLogsProducer) Close(context.Context) error {
 	if e.producer == nil {
 		return nil
@@ -215,11 +293,19 @@ func newMetricsExporter(config Config, set exporter.Settings, marshalers map[str
 		}
 	}
 
-	return &kafkaMetricsProducer{
+	producer := &kafkaMetricsProducer{
 		cfg:       config,
 		marshaler: marshaler,
 		logger:    set.Logger,
-	}, nil
+	}
+	if config.Expressions.enabled() {
+		expressions, err := newMetricsExpressions(config.Expressions, set.TelemetrySettings)
+		if err != nil {
+			return nil, err
+		}
+		producer.expressions = expressions
+	}
+	return producer, nil
 
 }
 
@@ -235,11 +321,19 @@ func newTracesExporter(config Config, set exporter.Settings, marshalers map[stri
 		}
 	}
 
-	return &kafkaTracesProducer{
+	producer := &kafkaTracesProducer{
 		cfg:       config,
 		marshaler: marshaler,
 		logger:    set.Logger,
-	}, nil
+	}
+	if config.Expressions.enabled() {
+		expressions, err := newTracesExpressions(config.Expressions, set.TelemetrySettings)
+		if err != nil {
+			return nil, err
+		}
+		producer.expressions = expressions
+	}
+	return producer, nil
 }
 
 func newLogsExporter(config Config, set exporter.Settings, marshalers map[string]LogsMarshaler) (*kafkaLogsProducer, error) {
@@ -248,11 +342,19 @@ func newLogsExporter(config Config, set exporter.Settings, marshalers map[string
 		return nil, errUnrecognizedEncoding
 	}
 
-	return &kafkaLogsProducer{
+	producer := &kafkaLogsProducer{
 		cfg:       config,
 		marshaler: marshaler,
 		logger:    set.Logger,
-	}, nil
+	}
+	if config.Expressions.enabled() {
+		expressions, err := newLogsExpressions(config.Expressions, set.TelemetrySettings)
+		if err != nil {
+			return nil, err
+		}
+		producer.expressions = expressions
+	}
+	return producer, nil
 
 }
 
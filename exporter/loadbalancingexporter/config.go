@@ -4,6 +4,8 @@
 package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/servicediscovery/types"
@@ -24,6 +26,59 @@ type Config struct {
 	Protocol   Protocol         `mapstructure:"protocol"`
 	Resolver   ResolverSettings `mapstructure:"resolver"`
 	RoutingKey string           `mapstructure:"routing_key"`
+
+	ConsistentHashing ConsistentHashingSettings `mapstructure:"consistent_hashing"`
+}
+
+// ConsistentHashingSettings defines how the routing keys are distributed among the backends
+type ConsistentHashingSettings struct {
+	// LoadFactor bounds the number of active routing keys of each backend to LoadFactor times its fair share,
+	// sending the keys of a full backend to the next one in the ring. Zero disables bounded loads.
+	LoadFactor float64 `mapstructure:"load_factor"`
+
+	// DrainPeriod is how long a backend removed by the resolver keeps receiving the routing keys assigned to it.
+	// Zero disables draining.
+	DrainPeriod time.Duration `mapstructure:"drain_period"`
+
+	// KeyTTL is how long a routing key stays assigned to its backend after it was last seen, when bounded
+	// loads or draining are enabled. Keys stay on their backend when backends are added.
+	KeyTTL time.Duration `mapstructure:"key_ttl"`
+
+	// MaxKeys bounds the number of routing keys kept on their backend, when bounded loads or draining are
+	// enabled. Once reached, new keys are still assigned a backend but may move when the backends change.
+	MaxKeys int `mapstructure:"max_keys"`
+
+	// Weights maps backends to their weight, the default weight being 100. A backend gets a share of the
+	// routing keys proportional to its weight.
+	Weights map[string]int `mapstructure:"weights"`
+}
+
+// stickyKeys returns whether the routing keys are assigned to their backends for the key TTL
+func (s ConsistentHashingSettings) stickyKeys() bool {
+	return s.LoadFactor > 0 || s.DrainPeriod > 0
+}
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	ch := cfg.ConsistentHashing
+	if ch.LoadFactor != 0 && ch.LoadFactor <= 1 {
+		return errors.New("consistent_hashing.load_factor must be greater than 1")
+	}
+	if ch.DrainPeriod < 0 {
+		return errors.New("consistent_hashing.drain_period must not be negative")
+	}
+	if ch.stickyKeys() && ch.KeyTTL <= 0 {
+		return errors.New("consistent_hashing.key_ttl must be positive when bounded loads or draining are enabled")
+	}
+	if ch.stickyKeys() && ch.MaxKeys <= 0 {
+		return errors.New("consistent_hashing.max_keys must be positive when bounded loads or draining are enabled")
+	}
+	for endpoint, weight := range ch.Weights {
+		if weight <= 0 {
+			return fmt.Errorf("consistent_hashing.weights: the weight of %q must be positive", endpoint)
+		}
+	}
+	return nil
 }
 
 // Protocol holds the individual protocol-specific settings. Only OTLP is supported at the moment.
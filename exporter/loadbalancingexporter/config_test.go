@@ -6,7 +6,9 @@ package loadbalancingexporter
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
@@ -25,3 +27,55 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, sub.Unmarshal(cfg))
 	require.NotNil(t, cfg)
 }
+
+func TestValidateConsistentHashing(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		settings    ConsistentHashingSettings
+		expectedErr string
+	}{
+		{
+			name:     "defaults",
+			settings: ConsistentHashingSettings{KeyTTL: defaultKeyTTL},
+		},
+		{
+			name:     "bounded loads and draining",
+			settings: ConsistentHashingSettings{LoadFactor: 1.25, DrainPeriod: time.Minute, KeyTTL: defaultKeyTTL, MaxKeys: defaultMaxKeys, Weights: map[string]int{"endpoint-1": 200}},
+		},
+		{
+			name:        "load factor too low",
+			settings:    ConsistentHashingSettings{LoadFactor: 0.5, KeyTTL: defaultKeyTTL, MaxKeys: defaultMaxKeys},
+			expectedErr: "consistent_hashing.load_factor must be greater than 1",
+		},
+		{
+			name:        "negative drain period",
+			settings:    ConsistentHashingSettings{DrainPeriod: -time.Second, KeyTTL: defaultKeyTTL},
+			expectedErr: "consistent_hashing.drain_period must not be negative",
+		},
+		{
+			name:        "no key TTL",
+			settings:    ConsistentHashingSettings{DrainPeriod: time.Minute},
+			expectedErr: "consistent_hashing.key_ttl must be positive when bounded loads or draining are enabled",
+		},
+		{
+			name:        "no max keys",
+			settings:    ConsistentHashingSettings{LoadFactor: 1.25, KeyTTL: defaultKeyTTL},
+			expectedErr: "consistent_hashing.max_keys must be positive when bounded loads or draining are enabled",
+		},
+		{
+			name:        "invalid weight",
+			settings:    ConsistentHashingSettings{Weights: map[string]int{"endpoint-1": 0}},
+			expectedErr: `consistent_hashing.weights: the weight of "endpoint-1" must be positive`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{ConsistentHashing: tt.settings}
+			err := cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
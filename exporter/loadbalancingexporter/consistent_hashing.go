@@ -4,6 +4,7 @@
 package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
 
 import (
+	"encoding/binary"
 	"hash/crc32"
 	"sort"
 )
@@ -25,13 +26,31 @@ type ringItem struct {
 type hashRing struct {
 	// ringItems holds all the positions, used for the lookup the position for the closest next ring item
 	items []ringItem
+
+	// weights holds the weight of each endpoint of the ring
+	weights map[string]int
 }
 
 // newHashRing builds a new immutable consistent hash ring based on the given endpoints.
 func newHashRing(endpoints []string) *hashRing {
-	items := positionsForEndpoints(endpoints, defaultWeight)
+	return newWeightedHashRing(endpoints, nil)
+}
+
+// newWeightedHashRing builds a new immutable consistent hash ring based on the given endpoints, each
+// of them having as many positions in the ring as its weight. Endpoints without weight get the default one.
+func newWeightedHashRing(endpoints []string, weights map[string]int) *hashRing {
+	endpointWeights := make(map[string]int, len(endpoints))
+	for _, endpoint := range endpoints {
+		weight, ok := weights[endpointWithPort(endpoint)]
+		if !ok {
+			weight = defaultWeight
+		}
+		endpointWeights[endpoint] = weight
+	}
+	items := positionsForWeightedEndpoints(endpoints, endpointWeights)
 	return &hashRing{
-		items: items,
+		items:   items,
+		weights: endpointWeights,
 	}
 }
 
@@ -41,12 +60,47 @@ func (h *hashRing) endpointFor(identifier []byte) string {
 		// perhaps the ring itself couldn't get initialized yet?
 		return ""
 	}
+	return h.findEndpoint(positionForIdentifier(identifier))
+}
+
+// positionForIdentifier calculates the position of the given identifier in the ring
+func positionForIdentifier(identifier []byte) position {
 	hasher := crc32.NewIEEE()
 	hasher.Write(identifier)
 	hash := hasher.Sum32()
-	pos := hash % maxPositions
+	return position(hash % maxPositions)
+}
+
+// walk calls fn with each distinct endpoint of the ring, in the order they are found starting from the
+// position of the given identifier, until fn returns false.
+func (h *hashRing) walk(identifier []byte, fn func(endpoint string) bool) {
+	if h == nil || len(h.items) == 0 {
+		return
+	}
+	pos := positionForIdentifier(identifier)
+	start := sort.Search(len(h.items), func(i int) bool {
+		return h.items[i].pos >= pos
+	})
+	visited := make(map[string]bool, len(h.weights))
+	for i := 0; i < len(h.items) && len(visited) < len(h.weights); i++ {
+		endpoint := h.items[(start+i)%len(h.items)].endpoint
+		if visited[endpoint] {
+			continue
+		}
+		visited[endpoint] = true
+		if !fn(endpoint) {
+			return
+		}
+	}
+}
 
-	return h.findEndpoint(position(pos))
+// contains returns whether the given endpoint is part of the ring
+func (h *hashRing) contains(endpoint string) bool {
+	if h == nil {
+		return false
+	}
+	_, ok := h.weights[endpoint]
+	return ok
 }
 
 // findEndpoint returns the "next" endpoint starting from the given position, or an empty string in case no endpoints are available
@@ -104,7 +158,13 @@ func positionsFor(endpoint string, numPoints int) []position {
 	for i := 0; i < numPoints; i++ {
 		h := crc32.NewIEEE()
 		h.Write([]byte(endpoint))
-		h.Write([]byte{byte(i)})
+		if i < 256 {
+			h.Write([]byte{byte(i)})
+		} else {
+			// the positions of the points beyond the 256th would otherwise repeat the previous ones.
+			// Varints of these points are at least two bytes long, so they never hash like the first ones.
+			h.Write(binary.AppendUvarint(nil, uint64(i)))
+		}
 		hash := h.Sum32()
 		pos := hash % maxPositions
 		res = append(res, position(pos))
@@ -115,11 +175,20 @@ func positionsFor(endpoint string, numPoints int) []position {
 
 // positionsForEndpoints calculates all the positions for all the given endpoints
 func positionsForEndpoints(endpoints []string, weight int) []ringItem {
+	weights := make(map[string]int, len(endpoints))
+	for _, endpoint := range endpoints {
+		weights[endpoint] = weight
+	}
+	return positionsForWeightedEndpoints(endpoints, weights)
+}
+
+// positionsForWeightedEndpoints calculates all the positions for all the given endpoints, using the weight of each endpoint
+// as its number of positions
+func positionsForWeightedEndpoints(endpoints []string, weights map[string]int) []ringItem {
 	var items []ringItem
 	positions := map[position]bool{} // tracking the used positions
 	for _, endpoint := range endpoints {
-		// for this initial implementation, we don't allow endpoints to have custom weights
-		for _, pos := range positionsFor(endpoint, weight) {
+		for _, pos := range positionsFor(endpoint, weights[endpoint]) {
 			// if this position is occupied already, skip this item
 			if _, found := positions[pos]; found {
 				continue
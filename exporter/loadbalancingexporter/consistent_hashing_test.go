@@ -156,7 +156,7 @@ func TestPositionsForEndpoints(t *testing.T) {
 
 func TestEqual(t *testing.T) {
 	original := &hashRing{
-		[]ringItem{
+		items: []ringItem{
 			{pos: position(123), endpoint: "endpoint-1"},
 		},
 	}
@@ -168,7 +168,7 @@ func TestEqual(t *testing.T) {
 	}{
 		{
 			"empty",
-			&hashRing{[]ringItem{}},
+			&hashRing{items: []ringItem{}},
 			false,
 		},
 		{
@@ -179,7 +179,7 @@ func TestEqual(t *testing.T) {
 		{
 			"equal",
 			&hashRing{
-				[]ringItem{
+				items: []ringItem{
 					{pos: position(123), endpoint: "endpoint-1"},
 				},
 			},
@@ -188,7 +188,7 @@ func TestEqual(t *testing.T) {
 		{
 			"different length",
 			&hashRing{
-				[]ringItem{
+				items: []ringItem{
 					{pos: position(123), endpoint: "endpoint-1"},
 					{pos: position(124), endpoint: "endpoint-2"},
 				},
@@ -198,7 +198,7 @@ func TestEqual(t *testing.T) {
 		{
 			"different position",
 			&hashRing{
-				[]ringItem{
+				items: []ringItem{
 					{pos: position(124), endpoint: "endpoint-1"},
 				},
 			},
@@ -207,7 +207,7 @@ func TestEqual(t *testing.T) {
 		{
 			"different endpoint",
 			&hashRing{
-				[]ringItem{
+				items: []ringItem{
 					{pos: position(123), endpoint: "endpoint-2"},
 				},
 			},
@@ -219,3 +219,74 @@ func TestEqual(t *testing.T) {
 		})
 	}
 }
+
+func TestNewWeightedHashRing(t *testing.T) {
+	// prepare
+	endpoints := []string{"endpoint-1", "endpoint-2:55690"}
+	weights := map[string]int{
+		"endpoint-1:4317":  300,
+		"endpoint-2:55690": 50,
+	}
+
+	// test
+	ring := newWeightedHashRing(endpoints, weights)
+
+	// verify
+	assert.Equal(t, map[string]int{"endpoint-1": 300, "endpoint-2:55690": 50}, ring.weights)
+	counts := map[string]int{}
+	for _, item := range ring.items {
+		counts[item.endpoint]++
+	}
+	// a few positions may be taken already, by the other endpoint or by another point of the same one
+	assert.InDelta(t, 300, counts["endpoint-1"], 10)
+	assert.InDelta(t, 50, counts["endpoint-2:55690"], 10)
+}
+
+func TestPositionsForMoreThan256Points(t *testing.T) {
+	// test
+	positions := positionsFor("endpoint-1", 1000)
+
+	// verify
+	distinct := map[position]bool{}
+	for _, pos := range positions {
+		distinct[pos] = true
+	}
+	// with 36000 possible positions, only a few collisions are expected
+	assert.Greater(t, len(distinct), 950)
+}
+
+func TestWalk(t *testing.T) {
+	// prepare
+	ring := newHashRing([]string{"endpoint-1", "endpoint-2", "endpoint-3"})
+	identifier := []byte("ad-service-7")
+
+	// test
+	var visited []string
+	ring.walk(identifier, func(endpoint string) bool {
+		visited = append(visited, endpoint)
+		return true
+	})
+
+	// verify
+	assert.ElementsMatch(t, []string{"endpoint-1", "endpoint-2", "endpoint-3"}, visited)
+	assert.Equal(t, ring.endpointFor(identifier), visited[0])
+
+	// test
+	visited = nil
+	ring.walk(identifier, func(endpoint string) bool {
+		visited = append(visited, endpoint)
+		return false
+	})
+
+	// verify
+	assert.Len(t, visited, 1)
+}
+
+func TestContains(t *testing.T) {
+	ring := newHashRing([]string{"endpoint-1"})
+	assert.True(t, ring.contains("endpoint-1"))
+	assert.False(t, ring.contains("endpoint-2"))
+
+	var nilRing *hashRing
+	assert.False(t, nilRing.contains("endpoint-1"))
+}
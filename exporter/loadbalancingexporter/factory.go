@@ -38,6 +38,10 @@ func createDefaultConfig() component.Config {
 		Protocol: Protocol{
 			OTLP: *otlpDefaultCfg,
 		},
+		ConsistentHashing: ConsistentHashingSettings{
+			KeyTTL:  defaultKeyTTL,
+			MaxKeys: defaultMaxKeys,
+		},
 	}
 }
 
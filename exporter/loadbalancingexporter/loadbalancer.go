@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/exporter"
@@ -33,13 +34,27 @@ type loadBalancer struct {
 	res  resolver
 	ring *hashRing
 
+	// weights holds the weights of the backends, by endpoint with port
+	weights map[string]int
+	// table holds the assignments of the routing keys when bounded loads or draining are enabled
+	table       *routingTable
+	drainPeriod time.Duration
+
 	componentFactory componentFactory
 	exporters        map[string]*wrappedExporter
+	// draining holds the exporters of the backends removed by the resolver, until their drain period ends
+	draining map[string]*drainingExporter
 
 	stopped    bool
 	updateLock sync.RWMutex
 }
 
+// drainingExporter is the exporter of a backend removed by the resolver, shut down when its timer fires
+type drainingExporter struct {
+	exporter *wrappedExporter
+	timer    *time.Timer
+}
+
 // Create new load balancer
 func newLoadBalancer(params exporter.Settings, cfg component.Config, factory componentFactory) (*loadBalancer, error) {
 	oCfg := cfg.(*Config)
@@ -104,11 +119,25 @@ func newLoadBalancer(params exporter.Settings, cfg component.Config, factory com
 		return nil, errNoResolver
 	}
 
+	weights := make(map[string]int, len(oCfg.ConsistentHashing.Weights))
+	for endpoint, weight := range oCfg.ConsistentHashing.Weights {
+		weights[endpointWithPort(endpoint)] = weight
+	}
+
+	var table *routingTable
+	if oCfg.ConsistentHashing.stickyKeys() {
+		table = newRoutingTable(oCfg.ConsistentHashing.LoadFactor, oCfg.ConsistentHashing.KeyTTL, oCfg.ConsistentHashing.MaxKeys)
+	}
+
 	return &loadBalancer{
 		logger:           params.Logger,
 		res:              res,
+		weights:          weights,
+		table:            table,
+		drainPeriod:      oCfg.ConsistentHashing.DrainPeriod,
 		componentFactory: factory,
 		exporters:        map[string]*wrappedExporter{},
+		draining:         map[string]*drainingExporter{},
 	}, nil
 }
 
@@ -119,7 +148,7 @@ func (lb *loadBalancer) Start(ctx context.Context, host component.Host) error {
 }
 
 func (lb *loadBalancer) onBackendChanges(resolved []string) {
-	newRing := newHashRing(resolved)
+	newRing := newWeightedHashRing(resolved, lb.weights)
 
 	if !newRing.equal(lb.ring) {
 		lb.updateLock.Lock()
@@ -141,6 +170,13 @@ func (lb *loadBalancer) addMissingExporters(ctx context.Context, endpoints []str
 		endpoint = endpointWithPort(endpoint)
 
 		if _, exists := lb.exporters[endpoint]; !exists {
+			if drained, ok := lb.draining[endpoint]; ok {
+				// the backend came back before the end of its drain period
+				drained.timer.Stop()
+				delete(lb.draining, endpoint)
+				lb.exporters[endpoint] = drained.exporter
+				continue
+			}
 			exp, err := lb.componentFactory(ctx, endpoint)
 			if err != nil {
 				lb.logger.Error("failed to create new exporter for endpoint", zap.String("endpoint", endpoint), zap.Error(err))
@@ -171,15 +207,41 @@ func (lb *loadBalancer) removeExtraExporters(ctx context.Context, endpoints []st
 	for existing := range lb.exporters {
 		if !endpointFound(existing, endpointsWithPort) {
 			exp := lb.exporters[existing]
+			delete(lb.exporters, existing)
+			if lb.drainPeriod > 0 {
+				lb.drain(ctx, existing, exp)
+				continue
+			}
 			// Shutdown the exporter asynchronously to avoid blocking the resolver
 			go func() {
 				_ = exp.Shutdown(ctx)
 			}()
-			delete(lb.exporters, existing)
 		}
 	}
 }
 
+// drain keeps the exporter of a removed backend for the drain period, so that the routing keys assigned to
+// the backend keep being sent to it. The exporter is shut down and the keys are reassigned afterwards.
+func (lb *loadBalancer) drain(ctx context.Context, endpoint string, exp *wrappedExporter) {
+	drained := &drainingExporter{exporter: exp}
+	drained.timer = time.AfterFunc(lb.drainPeriod, func() {
+		lb.updateLock.Lock()
+		defer lb.updateLock.Unlock()
+		if lb.draining[endpoint] != drained {
+			// the backend came back in the meantime
+			return
+		}
+		delete(lb.draining, endpoint)
+		if lb.table != nil {
+			lb.table.releaseEndpoint(endpoint)
+		}
+		go func() {
+			_ = exp.Shutdown(ctx)
+		}()
+	})
+	lb.draining[endpoint] = drained
+}
+
 func endpointFound(endpoint string, endpoints []string) bool {
 	for _, candidate := range endpoints {
 		if candidate == endpoint {
@@ -193,9 +255,35 @@ func endpointFound(endpoint string, endpoints []string) bool {
 func (lb *loadBalancer) Shutdown(ctx context.Context) error {
 	err := lb.res.shutdown(ctx)
 	lb.stopped = true
+
+	lb.updateLock.Lock()
+	defer lb.updateLock.Unlock()
+	for endpoint, drained := range lb.draining {
+		drained.timer.Stop()
+		delete(lb.draining, endpoint)
+		go func(exp *wrappedExporter) {
+			_ = exp.Shutdown(ctx)
+		}(drained.exporter)
+	}
 	return err
 }
 
+// endpointFor returns the endpoint for the given identifier, keeping the routing keys on their
+// backend while it is available when the routing table is enabled. The update lock must be held.
+func (lb *loadBalancer) endpointFor(identifier []byte) string {
+	if lb.table == nil {
+		return lb.ring.endpointFor(identifier)
+	}
+	return lb.table.endpointFor(lb.ring, identifier, func(endpoint string) bool {
+		endpoint = endpointWithPort(endpoint)
+		if _, ok := lb.exporters[endpoint]; ok {
+			return true
+		}
+		_, ok := lb.draining[endpoint]
+		return ok
+	})
+}
+
 // exporterAndEndpoint returns the exporter and the endpoint for the given identifier.
 func (lb *loadBalancer) exporterAndEndpoint(identifier []byte) (*wrappedExporter, string, error) {
 	// NOTE: make rolling updates of next tier of collectors work. currently, this may cause
@@ -203,8 +291,14 @@ func (lb *loadBalancer) exporterAndEndpoint(identifier []byte) (*wrappedExporter
 	// for details: https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/1690
 	lb.updateLock.RLock()
 	defer lb.updateLock.RUnlock()
-	endpoint := lb.ring.endpointFor(identifier)
+	endpoint := lb.endpointFor(identifier)
 	exp, found := lb.exporters[endpointWithPort(endpoint)]
+	if !found {
+		var drained *drainingExporter
+		if drained, found = lb.draining[endpointWithPort(endpoint)]; found {
+			exp = drained.exporter
+		}
+	}
 	if !found {
 		// something is really wrong... how come we couldn't find the exporter??
 		return nil, "", fmt.Errorf("couldn't find the exporter for the endpoint %q", endpoint)
@@ -6,7 +6,9 @@ package loadbalancingexporter
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -427,6 +429,149 @@ func TestNewLoadBalancerInvalidServiceAwsResolver(t *testing.T) {
 	assert.True(t, clientcmd.IsConfigurationInvalid(err) || errors.Is(err, errNoServiceName))
 }
 
+func TestDrainRemovedBackend(t *testing.T) {
+	// prepare
+	cfg := simpleConfig()
+	cfg.ConsistentHashing = ConsistentHashingSettings{
+		DrainPeriod: 100 * time.Millisecond,
+		KeyTTL:      time.Minute,
+		MaxKeys:     defaultMaxKeys,
+	}
+	componentFactory := func(_ context.Context, _ string) (component.Component, error) {
+		return newNopMockExporter(), nil
+	}
+	p, err := newLoadBalancer(exportertest.NewNopSettings(), cfg, componentFactory)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	p.onBackendChanges([]string{"endpoint-1", "endpoint-2"})
+	drained := p.exporters[endpointWithPort("endpoint-2")]
+	// this trace ID reaches the endpoint-2 -- see the consistent hashing tests for more info
+	inflight := []byte{128, 128, 0, 0}
+	_, endpoint, err := p.exporterAndEndpoint(inflight)
+	require.NoError(t, err)
+	require.Equal(t, "endpoint-2", endpoint)
+
+	// test
+	p.onBackendChanges([]string{"endpoint-1"})
+
+	// verify
+	assert.NotContains(t, p.exporters, endpointWithPort("endpoint-2"))
+	exp, endpoint, err := p.exporterAndEndpoint(inflight)
+	require.NoError(t, err)
+	assert.Equal(t, "endpoint-2", endpoint)
+	assert.Same(t, drained, exp)
+
+	// new keys don't go to the draining backend
+	_, endpoint, err = p.exporterAndEndpoint([]byte("get-recommendations-1"))
+	require.NoError(t, err)
+	assert.Equal(t, "endpoint-1", endpoint)
+
+	// once drained, the keys of the backend are reassigned
+	assert.Eventually(t, func() bool {
+		p.updateLock.RLock()
+		defer p.updateLock.RUnlock()
+		return len(p.draining) == 0
+	}, time.Second, 10*time.Millisecond)
+	_, endpoint, err = p.exporterAndEndpoint(inflight)
+	require.NoError(t, err)
+	assert.Equal(t, "endpoint-1", endpoint)
+}
+
+func TestDrainedBackendComesBack(t *testing.T) {
+	// prepare
+	cfg := simpleConfig()
+	cfg.ConsistentHashing = ConsistentHashingSettings{
+		DrainPeriod: time.Hour,
+		KeyTTL:      time.Minute,
+		MaxKeys:     defaultMaxKeys,
+	}
+	componentFactory := func(_ context.Context, _ string) (component.Component, error) {
+		return newNopMockExporter(), nil
+	}
+	p, err := newLoadBalancer(exportertest.NewNopSettings(), cfg, componentFactory)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	p.onBackendChanges([]string{"endpoint-1", "endpoint-2"})
+	drained := p.exporters[endpointWithPort("endpoint-2")]
+	p.onBackendChanges([]string{"endpoint-1"})
+	require.Contains(t, p.draining, endpointWithPort("endpoint-2"))
+
+	// test
+	p.onBackendChanges([]string{"endpoint-1", "endpoint-2"})
+
+	// verify
+	assert.Empty(t, p.draining)
+	assert.Same(t, drained, p.exporters[endpointWithPort("endpoint-2")])
+}
+
+func TestShutdownDrainingBackends(t *testing.T) {
+	// prepare
+	cfg := simpleConfig()
+	cfg.ConsistentHashing = ConsistentHashingSettings{
+		DrainPeriod: time.Hour,
+		KeyTTL:      time.Minute,
+		MaxKeys:     defaultMaxKeys,
+	}
+	componentFactory := func(_ context.Context, _ string) (component.Component, error) {
+		return newNopMockExporter(), nil
+	}
+	p, err := newLoadBalancer(exportertest.NewNopSettings(), cfg, componentFactory)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	p.onBackendChanges([]string{"endpoint-2"})
+	require.Contains(t, p.draining, endpointWithPort("endpoint-1"))
+
+	// test
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	// verify
+	assert.Empty(t, p.draining)
+}
+
+func TestBoundedLoadsAndStickyKeys(t *testing.T) {
+	// prepare
+	cfg := simpleConfig()
+	cfg.ConsistentHashing = ConsistentHashingSettings{
+		LoadFactor: 1.25,
+		KeyTTL:     time.Minute,
+		MaxKeys:    defaultMaxKeys,
+	}
+	componentFactory := func(_ context.Context, _ string) (component.Component, error) {
+		return newNopMockExporter(), nil
+	}
+	p, err := newLoadBalancer(exportertest.NewNopSettings(), cfg, componentFactory)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+	p.onBackendChanges([]string{"endpoint-1", "endpoint-2"})
+
+	// test
+	assigned := map[string]string{}
+	loads := map[string]int{}
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		_, endpoint, err := p.exporterAndEndpoint([]byte(key))
+		require.NoError(t, err)
+		assigned[key] = endpoint
+		loads[endpoint]++
+	}
+
+	// verify
+	for endpoint, load := range loads {
+		assert.LessOrEqual(t, load, 63, endpoint)
+	}
+
+	// the keys stay on their backend when the ring changes
+	p.onBackendChanges([]string{"endpoint-1", "endpoint-2", "endpoint-3"})
+	for key, endpoint := range assigned {
+		_, actual, err := p.exporterAndEndpoint([]byte(key))
+		require.NoError(t, err)
+		assert.Equal(t, endpoint, actual, key)
+	}
+}
+
 func newNopMockExporter() *wrappedExporter {
 	return newWrappedExporter(mockComponent{})
 }
@@ -0,0 +1,203 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"hash/maphash"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultKeyTTL  = 30 * time.Second
+	defaultMaxKeys = 100000
+
+	// routingTableShards is the number of shards of the routing table, each one locked independently so that
+	// concurrent exports don't contend on a single lock
+	routingTableShards = 32
+)
+
+// keyAssignment is the backend a routing key was assigned to
+type keyAssignment struct {
+	endpoint string
+	lastSeen time.Time
+}
+
+// routingTableShard holds the assignments of the routing keys hashed to it
+type routingTableShard struct {
+	mu   sync.Mutex
+	keys map[string]*keyAssignment
+}
+
+// routingTable assigns routing keys to backends with consistent hashing and bounded loads, following
+// Mirrokni et al. A key stays on its backend for as long as it is seen within the key TTL and its backend
+// is available, so that changes of the ring only move new keys. Once the table holds its maximum number of
+// keys, new keys are still assigned a backend but aren't kept on it.
+//
+// The keys are spread over shards, so the loads are only approximately bounded under concurrent exports.
+type routingTable struct {
+	loadFactor      float64
+	keyTTL          time.Duration
+	maxKeysPerShard int
+	now             func() time.Time
+
+	seed   maphash.Seed
+	shards [routingTableShards]routingTableShard
+	// numKeys is the number of keys assigned in all the shards
+	numKeys atomic.Int64
+	// lastSweep is the time of the last sweep of the expired keys, in nanoseconds since the epoch
+	lastSweep atomic.Int64
+
+	// loadsMu protects the loads map, while the loads themselves are updated atomically
+	loadsMu sync.RWMutex
+	loads   map[string]*atomic.Int64
+}
+
+func newRoutingTable(loadFactor float64, keyTTL time.Duration, maxKeys int) *routingTable {
+	t := &routingTable{
+		loadFactor:      loadFactor,
+		keyTTL:          keyTTL,
+		maxKeysPerShard: max(1, (maxKeys+routingTableShards-1)/routingTableShards),
+		now:             time.Now,
+		seed:            maphash.MakeSeed(),
+		loads:           map[string]*atomic.Int64{},
+	}
+	for i := range t.shards {
+		t.shards[i].keys = map[string]*keyAssignment{}
+	}
+	return t
+}
+
+// endpointFor returns the backend of the given routing key, assigning it to a backend of the ring if it
+// is new, expired or if its backend is not available anymore.
+func (t *routingTable) endpointFor(ring *hashRing, identifier []byte, available func(endpoint string) bool) string {
+	now := t.now()
+	t.sweepIfDue(now)
+
+	s := &t.shards[maphash.Bytes(t.seed, identifier)%routingTableShards]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if assignment, ok := s.keys[string(identifier)]; ok {
+		if now.Sub(assignment.lastSeen) < t.keyTTL && available(assignment.endpoint) {
+			assignment.lastSeen = now
+			return assignment.endpoint
+		}
+		t.release(s, string(identifier), assignment)
+	}
+
+	endpoint := t.assign(ring, identifier)
+	if endpoint != "" && len(s.keys) < t.maxKeysPerShard {
+		s.keys[string(identifier)] = &keyAssignment{endpoint: endpoint, lastSeen: now}
+		t.numKeys.Add(1)
+		t.load(endpoint).Add(1)
+	}
+	return endpoint
+}
+
+// assign returns the first backend in the ring, starting from the position of the key, whose load is
+// under its capacity. Without load factor, this is the backend the ring is pointing to.
+func (t *routingTable) assign(ring *hashRing, identifier []byte) string {
+	if t.loadFactor == 0 {
+		return ring.endpointFor(identifier)
+	}
+
+	totalWeight := 0
+	for _, weight := range ring.weights {
+		totalWeight += weight
+	}
+	// the capacities are computed as if the new key was already assigned, ensuring that at least one of
+	// the backends has room for it
+	totalLoad := t.numKeys.Load() + 1
+
+	var endpoint string
+	ring.walk(identifier, func(candidate string) bool {
+		share := float64(ring.weights[candidate]) / float64(totalWeight)
+		capacity := int64(math.Ceil(t.loadFactor * share * float64(totalLoad)))
+		if t.currentLoad(candidate) < capacity {
+			endpoint = candidate
+			return false
+		}
+		return true
+	})
+	return endpoint
+}
+
+// releaseEndpoint unassigns the keys of the backend with the given endpoint, including its port
+func (t *routingTable) releaseEndpoint(endpoint string) {
+	for i := range t.shards {
+		s := &t.shards[i]
+		s.mu.Lock()
+		for key, assignment := range s.keys {
+			if endpointWithPort(assignment.endpoint) == endpoint {
+				t.release(s, key, assignment)
+			}
+		}
+		s.mu.Unlock()
+	}
+
+	t.loadsMu.Lock()
+	defer t.loadsMu.Unlock()
+	for assigned, load := range t.loads {
+		if endpointWithPort(assigned) == endpoint && load.Load() <= 0 {
+			delete(t.loads, assigned)
+		}
+	}
+}
+
+// sweepIfDue unassigns the keys which were not seen within the key TTL, at most once per key TTL. The
+// shards are swept one after the other, so that only the exports of the swept shard wait for it.
+func (t *routingTable) sweepIfDue(now time.Time) {
+	last := t.lastSweep.Load()
+	if now.Sub(time.Unix(0, last)) < t.keyTTL || !t.lastSweep.CompareAndSwap(last, now.UnixNano()) {
+		return
+	}
+	for i := range t.shards {
+		s := &t.shards[i]
+		s.mu.Lock()
+		for key, assignment := range s.keys {
+			if now.Sub(assignment.lastSeen) >= t.keyTTL {
+				t.release(s, key, assignment)
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+// release unassigns the key, the lock of its shard must be held
+func (t *routingTable) release(s *routingTableShard, key string, assignment *keyAssignment) {
+	delete(s.keys, key)
+	t.numKeys.Add(-1)
+	t.load(assignment.endpoint).Add(-1)
+}
+
+// load returns the counter of the keys assigned to the endpoint, creating it if needed
+func (t *routingTable) load(endpoint string) *atomic.Int64 {
+	t.loadsMu.RLock()
+	load, ok := t.loads[endpoint]
+	t.loadsMu.RUnlock()
+	if ok {
+		return load
+	}
+
+	t.loadsMu.Lock()
+	defer t.loadsMu.Unlock()
+	if load, ok = t.loads[endpoint]; !ok {
+		load = &atomic.Int64{}
+		t.loads[endpoint] = load
+	}
+	return load
+}
+
+// currentLoad returns the number of keys assigned to the endpoint
+func (t *routingTable) currentLoad(endpoint string) int64 {
+	t.loadsMu.RLock()
+	defer t.loadsMu.RUnlock()
+	if load, ok := t.loads[endpoint]; ok {
+		return load.Load()
+	}
+	return 0
+}
@@ -0,0 +1,230 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func alwaysAvailable(string) bool {
+	return true
+}
+
+// tableKeys returns the assignments of all the shards of the table
+func tableKeys(table *routingTable) map[string]*keyAssignment {
+	keys := map[string]*keyAssignment{}
+	for i := range table.shards {
+		for key, assignment := range table.shards[i].keys {
+			keys[key] = assignment
+		}
+	}
+	return keys
+}
+
+// tableLoads returns the number of keys of each endpoint with keys assigned
+func tableLoads(table *routingTable) map[string]int {
+	loads := map[string]int{}
+	for endpoint, load := range table.loads {
+		if load.Load() > 0 {
+			loads[endpoint] = int(load.Load())
+		}
+	}
+	return loads
+}
+
+func TestRoutingTableWithoutLoadFactor(t *testing.T) {
+	// prepare
+	ring := newHashRing([]string{"endpoint-1", "endpoint-2"})
+	table := newRoutingTable(0, time.Minute, defaultMaxKeys)
+
+	for _, id := range [][]byte{{1, 2, 0, 0}, {128, 128, 0, 0}, []byte("ad-service-7")} {
+		// test and verify
+		assert.Equal(t, ring.endpointFor(id), table.endpointFor(ring, id, alwaysAvailable))
+	}
+}
+
+func TestRoutingTableBoundedLoads(t *testing.T) {
+	// prepare
+	endpoints := []string{"endpoint-1", "endpoint-2", "endpoint-3"}
+	ring := newHashRing(endpoints)
+	table := newRoutingTable(1.25, time.Minute, defaultMaxKeys)
+
+	// test
+	loads := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		loads[table.endpointFor(ring, []byte(fmt.Sprintf("key-%d", i)), alwaysAvailable)]++
+	}
+
+	// verify
+	for _, endpoint := range endpoints {
+		assert.LessOrEqual(t, loads[endpoint], 417, "endpoint %s is overloaded", endpoint)
+	}
+	assert.Equal(t, loads, tableLoads(table))
+	assert.EqualValues(t, 1000, table.numKeys.Load())
+}
+
+func TestRoutingTableWeightedBoundedLoads(t *testing.T) {
+	// prepare
+	ring := newWeightedHashRing([]string{"endpoint-1", "endpoint-2"}, map[string]int{"endpoint-1:4317": 300})
+	table := newRoutingTable(1.1, time.Minute, defaultMaxKeys)
+
+	// test
+	loads := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		loads[table.endpointFor(ring, []byte(fmt.Sprintf("key-%d", i)), alwaysAvailable)]++
+	}
+
+	// verify
+	assert.LessOrEqual(t, loads["endpoint-1"], 825)
+	assert.LessOrEqual(t, loads["endpoint-2"], 275)
+	assert.Greater(t, loads["endpoint-1"], loads["endpoint-2"])
+}
+
+func TestRoutingTableStickyKeys(t *testing.T) {
+	// prepare
+	now := time.Now()
+	table := newRoutingTable(0, time.Minute, defaultMaxKeys)
+	table.now = func() time.Time { return now }
+	identifier := []byte("key-2")
+	ring := newHashRing([]string{"endpoint-1"})
+	assert.Equal(t, "endpoint-1", table.endpointFor(ring, identifier, alwaysAvailable))
+
+	// test
+	// the key stays on its backend while it's seen within the TTL, even though the ring now points to another one
+	ring = newHashRing([]string{"endpoint-1", "endpoint-2", "endpoint-3", "endpoint-4"})
+	assert.Equal(t, "endpoint-3", ring.endpointFor(identifier))
+	for i := 0; i < 3; i++ {
+		now = now.Add(50 * time.Second)
+		assert.Equal(t, "endpoint-1", table.endpointFor(ring, identifier, alwaysAvailable))
+	}
+
+	// the key is reassigned once it expires
+	now = now.Add(2 * time.Minute)
+	assert.Equal(t, "endpoint-3", table.endpointFor(ring, identifier, alwaysAvailable))
+}
+
+func TestRoutingTableUnavailableEndpoint(t *testing.T) {
+	// prepare
+	table := newRoutingTable(0, time.Minute, defaultMaxKeys)
+	identifier := []byte("ad-service-7")
+	table.endpointFor(newHashRing([]string{"endpoint-1"}), identifier, alwaysAvailable)
+
+	// test
+	ring := newHashRing([]string{"endpoint-2"})
+	endpoint := table.endpointFor(ring, identifier, ring.contains)
+
+	// verify
+	assert.Equal(t, "endpoint-2", endpoint)
+	assert.Equal(t, map[string]int{"endpoint-2": 1}, tableLoads(table))
+}
+
+func TestRoutingTableSweep(t *testing.T) {
+	// prepare
+	now := time.Now()
+	table := newRoutingTable(0, time.Minute, defaultMaxKeys)
+	table.now = func() time.Time { return now }
+	ring := newHashRing([]string{"endpoint-1"})
+	for i := 0; i < 10; i++ {
+		table.endpointFor(ring, []byte(fmt.Sprintf("key-%d", i)), alwaysAvailable)
+	}
+
+	// test
+	now = now.Add(2 * time.Minute)
+	table.endpointFor(ring, []byte("new-key"), alwaysAvailable)
+
+	// verify
+	assert.Len(t, tableKeys(table), 1)
+	assert.Equal(t, map[string]int{"endpoint-1": 1}, tableLoads(table))
+}
+
+func TestRoutingTableReleaseEndpoint(t *testing.T) {
+	// prepare
+	table := newRoutingTable(0, time.Minute, defaultMaxKeys)
+	ring := newHashRing([]string{"endpoint-1", "endpoint-2:55690"})
+	for i := 0; i < 100; i++ {
+		table.endpointFor(ring, []byte(fmt.Sprintf("key-%d", i)), alwaysAvailable)
+	}
+
+	// test
+	table.releaseEndpoint("endpoint-1:4317")
+
+	// verify
+	assert.NotContains(t, table.loads, "endpoint-1")
+	for _, assignment := range tableKeys(table) {
+		assert.Equal(t, "endpoint-2:55690", assignment.endpoint)
+	}
+}
+
+func TestRoutingTableMaxKeys(t *testing.T) {
+	// prepare
+	table := newRoutingTable(0, time.Minute, 64)
+	ring := newHashRing([]string{"endpoint-1", "endpoint-2"})
+
+	// test
+	for i := 0; i < 1000; i++ {
+		identifier := []byte(fmt.Sprintf("key-%d", i))
+		// verify
+		assert.Equal(t, ring.endpointFor(identifier), table.endpointFor(ring, identifier, alwaysAvailable), "keys over the limit should still be routed")
+	}
+
+	// verify
+	assert.LessOrEqual(t, len(tableKeys(table)), 64)
+	assert.EqualValues(t, len(tableKeys(table)), table.numKeys.Load())
+	total := 0
+	for _, load := range tableLoads(table) {
+		total += load
+	}
+	assert.Equal(t, len(tableKeys(table)), total)
+}
+
+func TestRoutingTableConcurrentKeys(t *testing.T) {
+	// prepare
+	table := newRoutingTable(1.25, time.Minute, defaultMaxKeys)
+	ring := newHashRing([]string{"endpoint-1", "endpoint-2", "endpoint-3"})
+
+	// test
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				assert.NotEmpty(t, table.endpointFor(ring, []byte(fmt.Sprintf("key-%d", i)), alwaysAvailable))
+			}
+		}()
+	}
+	wg.Wait()
+
+	// verify
+	assert.Len(t, tableKeys(table), 1000)
+	assert.EqualValues(t, 1000, table.numKeys.Load())
+	total := 0
+	for _, load := range tableLoads(table) {
+		total += load
+	}
+	assert.Equal(t, 1000, total)
+}
+
+func BenchmarkRoutingTableEndpointFor(b *testing.B) {
+	table := newRoutingTable(1.25, time.Minute, defaultMaxKeys)
+	ring := newHashRing([]string{"endpoint-1", "endpoint-2", "endpoint-3"})
+	identifiers := make([][]byte, 1000)
+	for i := range identifiers {
+		identifiers[i] = []byte(fmt.Sprintf("key-%d", i))
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			table.endpointFor(ring, identifiers[i%len(identifiers)], alwaysAvailable)
+			i++
+		}
+	})
+}
@@ -37,10 +37,17 @@ func createDefaultConfig() component.Config {
 	}
 }
 
-func createExtension(_ context.Context, _ extension.Settings, cfg component.Config) (extension.Extension, error) {
-	if cfg.(*Config).StorageID == nil {
-		return newInMemoryAckExtension(cfg.(*Config)), nil
+func createExtension(_ context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
+	oCfg := cfg.(*Config)
+	var store ackStore
+	if oCfg.StorageID == nil {
+		store = newInMemoryAckExtension(oCfg)
+	} else {
+		store = newStorageAckExtension(oCfg, set)
 	}
 
-	return nil, nil
+	if oCfg.HTTP != nil {
+		store = newHTTPAckExtension(store, oCfg, set)
+	}
+	return newQueueCheckingExtension(store, set.ID), nil
 }
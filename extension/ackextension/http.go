@@ -0,0 +1,203 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ackextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+)
+
+const (
+	// PartitionHeader is the header of the requests holding the partition their ack ID is generated for.
+	PartitionHeader = "X-Ack-Partition"
+	// AckIDHeader is the header of the responses holding the ack ID generated for the request.
+	AckIDHeader = "X-Ack-Id"
+
+	// queryPath is the path of the ack query API
+	queryPath = "/acks"
+)
+
+// ackStore is an AckExtension implementation, the HTTP API of the extension is served on top of it
+type ackStore interface {
+	extension.Extension
+	AckExtension
+}
+
+// ackRequest is the body of a query of the ack API
+type ackRequest struct {
+	Partition string   `json:"partition"`
+	Acks      []uint64 `json:"acks"`
+}
+
+// ackResponse is the body of the response to a query of the ack API
+type ackResponse struct {
+	Acks map[uint64]bool `json:"acks"`
+}
+
+// httpAckExtension serves the ack query API over HTTP
+type httpAckExtension struct {
+	ackStore
+	config     *Config
+	settings   extension.Settings
+	server     *http.Server
+	shutdownWG sync.WaitGroup
+}
+
+func newHTTPAckExtension(store ackStore, conf *Config, set extension.Settings) *httpAckExtension {
+	return &httpAckExtension{
+		ackStore: store,
+		config:   conf,
+		settings: set,
+	}
+}
+
+// Start starts the ack store, then the HTTP server
+func (h *httpAckExtension) Start(ctx context.Context, host component.Host) error {
+	if err := h.ackStore.Start(ctx, host); err != nil {
+		return err
+	}
+
+	ln, err := h.config.HTTP.ToListener(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", h.config.HTTP.Endpoint, err)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(queryPath, h.handleQuery)
+	h.server, err = h.config.HTTP.ToServer(ctx, host, h.settings.TelemetrySettings, mux)
+	if err != nil {
+		return err
+	}
+
+	h.shutdownWG.Add(1)
+	go func() {
+		defer h.shutdownWG.Done()
+		if errHTTP := h.server.Serve(ln); !errors.Is(errHTTP, http.ErrServerClosed) && errHTTP != nil {
+			h.settings.TelemetrySettings.ReportStatus(component.NewFatalErrorEvent(errHTTP))
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the HTTP server, then the ack store
+func (h *httpAckExtension) Shutdown(ctx context.Context) error {
+	var err error
+	if h.server != nil {
+		err = h.server.Close()
+		h.shutdownWG.Wait()
+	}
+	return errors.Join(err, h.ackStore.Shutdown(ctx))
+}
+
+// handleQuery returns the statuses of the queried ack IDs. The partition and the ack IDs are read from the JSON
+// body of POST requests, or from the partition and ack query parameters of GET requests.
+func (h *httpAckExtension) handleQuery(w http.ResponseWriter, r *http.Request) {
+	var req ackRequest
+	switch r.Method {
+	case http.MethodGet:
+		query := r.URL.Query()
+		req.Partition = query.Get("partition")
+		for _, raw := range query["ack"] {
+			ackID, err := strconv.ParseUint(raw, 10, 64)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("invalid ack ID %q", raw), http.StatusBadRequest)
+				return
+			}
+			req.Acks = append(req.Acks, ackID)
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if req.Partition == "" {
+		req.Partition = r.Header.Get(PartitionHeader)
+	}
+	if req.Partition == "" {
+		http.Error(w, "missing partition", http.StatusBadRequest)
+		return
+	}
+	if len(req.Acks) == 0 {
+		http.Error(w, "at least one ack ID must be queried", http.StatusBadRequest)
+		return
+	}
+
+	body, err := json.Marshal(ackResponse{Acks: h.QueryAcks(req.Partition, req.Acks)})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
+
+// NewHandler wraps the handler of an HTTP receiver to provide end-to-end acknowledgement. Requests holding the
+// PartitionHeader are given an ack ID, returned in the AckIDHeader of every response, so that the client can query
+// its status later. The ack ID is only acknowledged once next responded with a 2xx status code, that is once the
+// pipeline consumed the data. The extension rejects the configurations where an exporter of the data has a sending
+// queue enabled, provided the receiver references it through its ack::extension setting.
+func NewHandler(ext AckExtension, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		partitionID := r.Header.Get(PartitionHeader)
+		if partitionID == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		ackID := ext.ProcessEvent(partitionID)
+		w.Header().Set(AckIDHeader, strconv.FormatUint(ackID, 10))
+		aw := &ackResponseWriter{
+			ResponseWriter: w,
+			ext:            ext,
+			partitionID:    partitionID,
+			ackID:          ackID,
+		}
+		next.ServeHTTP(aw, r)
+		if !aw.wroteHeader {
+			// the response is implicitly successful
+			aw.WriteHeader(http.StatusOK)
+		}
+	})
+}
+
+// ackResponseWriter acknowledges the ack ID of the request when the response status is successful
+type ackResponseWriter struct {
+	http.ResponseWriter
+	ext         AckExtension
+	partitionID string
+	ackID       uint64
+	wroteHeader bool
+}
+
+func (a *ackResponseWriter) WriteHeader(statusCode int) {
+	if !a.wroteHeader {
+		a.wroteHeader = true
+		if statusCode >= 200 && statusCode < 300 {
+			a.ext.Ack(a.partitionID, a.ackID)
+		}
+	}
+	a.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (a *ackResponseWriter) Write(b []byte) (int, error) {
+	if !a.wroteHeader {
+		a.WriteHeader(http.StatusOK)
+	}
+	return a.ResponseWriter.Write(b)
+}
+
+func (a *ackResponseWriter) Unwrap() http.ResponseWriter {
+	return a.ResponseWriter
+}
@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ackextension
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+)
+
+func newTestHTTPAckExtension() *httpAckExtension {
+	conf := &Config{
+		MaxNumPartition:               defaultMaxNumPartition,
+		MaxNumPendingAcksPerPartition: defaultMaxNumPendingAcksPerPartition,
+		HTTP: &confighttp.ServerConfig{
+			Endpoint: "localhost:0",
+		},
+	}
+	return newHTTPAckExtension(newInMemoryAckExtension(conf), conf, extensiontest.NewNopSettings())
+}
+
+func TestHTTPAckExtensionQuery(t *testing.T) {
+	ext := newTestHTTPAckExtension()
+	ext.ProcessEvent("partition")
+	ext.ProcessEvent("partition")
+	ext.Ack("partition", 2)
+
+	tests := []struct {
+		name         string
+		req          *http.Request
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "get",
+			req:          httptest.NewRequest(http.MethodGet, "/acks?partition=partition&ack=1&ack=2", nil),
+			expectedCode: http.StatusOK,
+			expectedBody: `{"acks":{"1":false,"2":true}}`,
+		},
+		{
+			name:         "post",
+			req:          httptest.NewRequest(http.MethodPost, "/acks", strings.NewReader(`{"partition":"partition","acks":[1]}`)),
+			expectedCode: http.StatusOK,
+			expectedBody: `{"acks":{"1":false}}`,
+		},
+		{
+			name:         "invalid_ack_id",
+			req:          httptest.NewRequest(http.MethodGet, "/acks?partition=partition&ack=foo", nil),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid_body",
+			req:          httptest.NewRequest(http.MethodPost, "/acks", strings.NewReader(`{`)),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "missing_partition",
+			req:          httptest.NewRequest(http.MethodGet, "/acks?ack=1", nil),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "missing_acks",
+			req:          httptest.NewRequest(http.MethodGet, "/acks?partition=partition", nil),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid_method",
+			req:          httptest.NewRequest(http.MethodPut, "/acks", nil),
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ext.handleQuery(w, tt.req)
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHTTPAckExtensionPartitionHeader(t *testing.T) {
+	ext := newTestHTTPAckExtension()
+	ackID := ext.ProcessEvent("partition")
+	ext.Ack("partition", ackID)
+
+	req := httptest.NewRequest(http.MethodPost, "/acks", strings.NewReader(`{"acks":[1]}`))
+	req.Header.Set(PartitionHeader, "partition")
+	w := httptest.NewRecorder()
+	ext.handleQuery(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp ackResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, map[uint64]bool{1: true}, resp.Acks)
+}
+
+func TestHTTPAckExtensionLifecycle(t *testing.T) {
+	ext := newTestHTTPAckExtension()
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
+
+func TestNewHandler(t *testing.T) {
+	ext := newInMemoryAckExtension(&Config{
+		MaxNumPartition:               defaultMaxNumPartition,
+		MaxNumPendingAcksPerPartition: defaultMaxNumPendingAcksPerPartition,
+	})
+	status := http.StatusOK
+	handler := NewHandler(ext, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+	}))
+
+	// requests without a partition aren't given an ack ID
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/logs", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get(AckIDHeader))
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/logs", nil)
+	req.Header.Set(PartitionHeader, "partition")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, "1", w.Header().Get(AckIDHeader))
+	assert.Equal(t, map[uint64]bool{1: true}, ext.QueryAcks("partition", []uint64{1}))
+
+	// the ack ID of a failed request is returned, but not acknowledged
+	status = http.StatusServiceUnavailable
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "2", w.Header().Get(AckIDHeader))
+	assert.Equal(t, map[uint64]bool{2: false}, ext.QueryAcks("partition", []uint64{2}))
+}
+
+func TestNewHandlerNoResponse(t *testing.T) {
+	ext := newInMemoryAckExtension(&Config{
+		MaxNumPartition:               defaultMaxNumPartition,
+		MaxNumPendingAcksPerPartition: defaultMaxNumPendingAcksPerPartition,
+	})
+	handler := NewHandler(ext, http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/logs", nil)
+	req.Header.Set(PartitionHeader, "partition")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "1", w.Header().Get(AckIDHeader))
+	assert.Equal(t, map[uint64]bool{1: true}, ext.QueryAcks("partition", []uint64{1}))
+}
+
+func TestNewHandlerImplicitStatus(t *testing.T) {
+	ext := newInMemoryAckExtension(&Config{
+		MaxNumPartition:               defaultMaxNumPartition,
+		MaxNumPendingAcksPerPartition: defaultMaxNumPendingAcksPerPartition,
+	})
+	handler := NewHandler(ext, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("{}"))
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/logs", nil)
+	req.Header.Set(PartitionHeader, "partition")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, "1", w.Header().Get(AckIDHeader))
+	assert.Equal(t, map[uint64]bool{1: true}, ext.QueryAcks("partition", []uint64{1}))
+}
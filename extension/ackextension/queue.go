@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ackextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// queueCheckingExtension rejects the configurations where the data of a receiver acknowledging through the extension
+// is enqueued by an exporter: the data would be acknowledged once enqueued, while the ack IDs must only be
+// acknowledged once the data was exported.
+type queueCheckingExtension struct {
+	ackStore
+	id   component.ID
+	host component.Host
+}
+
+func newQueueCheckingExtension(store ackStore, id component.ID) *queueCheckingExtension {
+	return &queueCheckingExtension{
+		ackStore: store,
+		id:       id,
+	}
+}
+
+// Start keeps the host to get the factories of the exporters from, then starts the extension
+func (q *queueCheckingExtension) Start(ctx context.Context, host component.Host) error {
+	q.host = host
+	return q.ackStore.Start(ctx, host)
+}
+
+// NotifyConfig checks the sending queue of the exporters of the pipelines the extension is used in, before the
+// pipelines are started. The pipelines receiving the data from these ones through a connector are checked too.
+func (q *queueCheckingExtension) NotifyConfig(_ context.Context, conf *confmap.Conf) error {
+	pipelines, err := conf.Sub("service::pipelines")
+	if err != nil {
+		return err
+	}
+	checked := map[string]bool{}
+	for changed := true; changed; {
+		changed = false
+		for pipelineID := range pipelines.ToStringMap() {
+			receivers, _ := pipelines.Get(pipelineID + "::receivers").([]any)
+			if checked[pipelineID] || !q.acknowledges(conf, checked, pipelines, receivers) {
+				continue
+			}
+			checked[pipelineID] = true
+			changed = true
+			exporters, _ := pipelines.Get(pipelineID + "::exporters").([]any)
+			for _, exporterID := range exporters {
+				if conf.IsSet(fmt.Sprintf("connectors::%v", exporterID)) {
+					continue
+				}
+				if err = q.checkExporter(conf, fmt.Sprint(exporterID)); err != nil {
+					return fmt.Errorf("pipeline %q: %w", pipelineID, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// acknowledges returns whether one of the receivers references the extension through its ack::extension setting, or
+// is a connector exporting the data of an already checked pipeline
+func (q *queueCheckingExtension) acknowledges(conf *confmap.Conf, checked map[string]bool, pipelines *confmap.Conf, receivers []any) bool {
+	for _, receiverID := range receivers {
+		if ackID := conf.Get(fmt.Sprintf("receivers::%v::ack::extension", receiverID)); ackID != nil && fmt.Sprint(ackID) == q.id.String() {
+			return true
+		}
+		for pipelineID := range checked {
+			exporters, _ := pipelines.Get(pipelineID + "::exporters").([]any)
+			for _, exporterID := range exporters {
+				if exporterID == receiverID {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// checkExporter returns an error if the exporter has a sending queue enabled, explicitly or by default
+func (q *queueCheckingExtension) checkExporter(conf *confmap.Conf, rawID string) error {
+	var id component.ID
+	if err := id.UnmarshalText([]byte(rawID)); err != nil {
+		return err
+	}
+	factory := q.host.GetFactory(component.KindExporter, id.Type())
+	if factory == nil {
+		return fmt.Errorf("exporter %q: factory not available", id)
+	}
+	cfg := factory.CreateDefaultConfig()
+	exporterConf, err := conf.Sub("exporters::" + rawID)
+	if err != nil {
+		return fmt.Errorf("exporter %q: %w", id, err)
+	}
+	if err = exporterConf.Unmarshal(cfg); err != nil {
+		return fmt.Errorf("exporter %q: %w", id, err)
+	}
+	effectiveConf := confmap.New()
+	if err = effectiveConf.Marshal(cfg); err != nil {
+		return fmt.Errorf("exporter %q: %w", id, err)
+	}
+	if enabled, _ := effectiveConf.Get("sending_queue::enabled").(bool); enabled {
+		return fmt.Errorf("exporter %q has a sending queue enabled, the data would be acknowledged by the extension %q before being exported: set sending_queue::enabled to false", id, q.id)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ackextension
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension"
+)
+
+type queueConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+type exporterConfig struct {
+	Endpoint     string      `mapstructure:"endpoint"`
+	SendingQueue queueConfig `mapstructure:"sending_queue"`
+}
+
+// factoryHost returns a factory creating configs with a sending queue enabled by default for every exporter type
+type factoryHost struct {
+	component.Host
+}
+
+func (factoryHost) GetFactory(kind component.Kind, componentType component.Type) component.Factory {
+	if kind != component.KindExporter {
+		return nil
+	}
+	return extension.NewFactory(componentType, func() component.Config {
+		return &exporterConfig{SendingQueue: queueConfig{Enabled: true}}
+	}, nil, component.StabilityLevelDevelopment)
+}
+
+func TestNotifyConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		conf        map[string]any
+		expectedErr string
+	}{
+		{
+			name: "default_queue",
+			conf: map[string]any{
+				"receivers": map[string]any{"webhookevent": map[string]any{"ack": map[string]any{"extension": "ack"}}},
+				"exporters": map[string]any{"otlphttp": map[string]any{"endpoint": "localhost:4318"}},
+				"service": map[string]any{"pipelines": map[string]any{
+					"logs": map[string]any{"receivers": []any{"webhookevent"}, "exporters": []any{"otlphttp"}},
+				}},
+			},
+			expectedErr: `pipeline "logs": exporter "otlphttp" has a sending queue enabled`,
+		},
+		{
+			name: "disabled_queue",
+			conf: map[string]any{
+				"receivers": map[string]any{"webhookevent": map[string]any{"ack": map[string]any{"extension": "ack"}}},
+				"exporters": map[string]any{"otlphttp": map[string]any{"sending_queue": map[string]any{"enabled": false}}},
+				"service": map[string]any{"pipelines": map[string]any{
+					"logs": map[string]any{"receivers": []any{"webhookevent"}, "exporters": []any{"otlphttp"}},
+				}},
+			},
+		},
+		{
+			name: "other_extension",
+			conf: map[string]any{
+				"receivers": map[string]any{"webhookevent": map[string]any{"ack": map[string]any{"extension": "ack/other"}}},
+				"exporters": map[string]any{"otlphttp": nil},
+				"service": map[string]any{"pipelines": map[string]any{
+					"logs": map[string]any{"receivers": []any{"webhookevent"}, "exporters": []any{"otlphttp"}},
+				}},
+			},
+		},
+		{
+			name: "connected_pipeline",
+			conf: map[string]any{
+				"receivers":  map[string]any{"webhookevent": map[string]any{"ack": map[string]any{"extension": "ack"}}},
+				"connectors": map[string]any{"forward": nil},
+				"exporters":  map[string]any{"otlphttp/queued": nil},
+				"service": map[string]any{"pipelines": map[string]any{
+					"logs/in":  map[string]any{"receivers": []any{"webhookevent"}, "exporters": []any{"forward"}},
+					"logs/out": map[string]any{"receivers": []any{"forward"}, "exporters": []any{"otlphttp/queued"}},
+				}},
+			},
+			expectedErr: `pipeline "logs/out": exporter "otlphttp/queued" has a sending queue enabled`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ext := newQueueCheckingExtension(newInMemoryAckExtension(createDefaultConfig().(*Config)), component.MustNewID("ack"))
+			require.NoError(t, ext.Start(context.Background(), factoryHost{Host: componenttest.NewNopHost()}))
+			err := ext.NotifyConfig(context.Background(), confmap.NewFromStringMap(tt.conf))
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.expectedErr)
+			}
+			require.NoError(t, ext.Shutdown(context.Background()))
+		})
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ackextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+)
+
+const (
+	ackPending byte = iota
+	ackAcked
+)
+
+// storageAckExtension is the implementation of the AckExtension persisting the acks through a storage extension,
+// so that the ack IDs handed out before a restart of the collector can still be queried after it.
+// Each partition is stored as the last ack ID generated for it, and one key per pending ack ID. When
+// MaxNumPendingAcksPerPartition is reached, the oldest ack of the partition is evicted. When MaxNumPartition is
+// reached, the keys of the least recently used partition are deleted. The list of the partitions is persisted too,
+// so that the partitions created before a restart are still evicted after it.
+type storageAckExtension struct {
+	storageID                     component.ID
+	componentID                   component.ID
+	logger                        *zap.Logger
+	maxNumPendingAcksPerPartition uint64
+
+	client storage.Client
+	// lock serializes the generation of the ack IDs, and the reads and writes of the acks
+	lock sync.Mutex
+	// lastIDs holds the last ack ID generated for the MaxNumPartition most recently used partitions
+	lastIDs *lru.Cache[string, uint64]
+	// evictionOps are the deletions of the keys of the partitions evicted from lastIDs, not persisted yet
+	evictionOps []storage.Operation
+}
+
+func newStorageAckExtension(conf *Config, set extension.Settings) *storageAckExtension {
+	s := &storageAckExtension{
+		storageID:                     *conf.StorageID,
+		componentID:                   set.ID,
+		logger:                        set.Logger,
+		maxNumPendingAcksPerPartition: conf.MaxNumPendingAcksPerPartition,
+	}
+	s.lastIDs, _ = lru.NewWithEvict[string, uint64](int(conf.MaxNumPartition), s.onEvict)
+	return s
+}
+
+const partitionsKey = "partitions"
+
+func lastIDKey(partitionID string) string {
+	return "last_id/" + partitionID
+}
+
+func ackKey(partitionID string, ackID uint64) string {
+	return "ack/" + partitionID + "/" + strconv.FormatUint(ackID, 10)
+}
+
+// Start gets the client of the configured storage extension
+func (s *storageAckExtension) Start(ctx context.Context, host component.Host) error {
+	ext, ok := host.GetExtensions()[s.storageID]
+	if !ok {
+		return fmt.Errorf("storage extension '%s' not found", s.storageID)
+	}
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return fmt.Errorf("non-storage extension '%s' found", s.storageID)
+	}
+	client, err := storageExt.GetClient(ctx, component.KindExtension, s.componentID, "")
+	if err != nil {
+		return err
+	}
+	s.client = client
+	return s.loadPartitions(ctx)
+}
+
+// loadPartitions restores the partitions persisted before a restart, from the least to the most recently created
+func (s *storageAckExtension) loadPartitions(ctx context.Context) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	value, err := s.client.Get(ctx, partitionsKey)
+	if err != nil || value == nil {
+		return err
+	}
+	var partitionIDs []string
+	if err = json.Unmarshal(value, &partitionIDs); err != nil {
+		return fmt.Errorf("invalid partitions: %w", err)
+	}
+	for _, partitionID := range partitionIDs {
+		var lastID uint64
+		lastID, err = s.storedLastID(ctx, partitionID)
+		if err != nil {
+			return err
+		}
+		s.lastIDs.Add(partitionID, lastID)
+	}
+	// the number of partitions is over the limit if MaxNumPartition was lowered
+	if len(s.evictionOps) > 0 {
+		return s.client.Batch(ctx, s.takeEvictionOps()...)
+	}
+	return nil
+}
+
+// onEvict schedules the deletion of the keys of a partition evicted from lastIDs, run with the next batch of operations
+func (s *storageAckExtension) onEvict(partitionID string, lastID uint64) {
+	s.evictionOps = append(s.evictionOps, storage.DeleteOperation(lastIDKey(partitionID)))
+	// the pending acks of the partition are the last maxNumPendingAcksPerPartition ones at most
+	firstID := uint64(1)
+	if lastID > s.maxNumPendingAcksPerPartition {
+		firstID = lastID - s.maxNumPendingAcksPerPartition + 1
+	}
+	for ackID := firstID; ackID <= lastID; ackID++ {
+		s.evictionOps = append(s.evictionOps, storage.DeleteOperation(ackKey(partitionID, ackID)))
+	}
+}
+
+// takeEvictionOps returns the pending deletions of the evicted partitions, followed by the update of the list of the
+// partitions
+func (s *storageAckExtension) takeEvictionOps() []storage.Operation {
+	ops := append(s.evictionOps, s.partitionsOp())
+	s.evictionOps = nil
+	return ops
+}
+
+// partitionsOp persists the list of the partitions, from the least to the most recently used
+func (s *storageAckExtension) partitionsOp() storage.Operation {
+	value, _ := json.Marshal(s.lastIDs.Keys())
+	return storage.SetOperation(partitionsKey, value)
+}
+
+// Shutdown closes the storage client
+func (s *storageAckExtension) Shutdown(ctx context.Context) error {
+	if s.client == nil {
+		return nil
+	}
+	return s.client.Close(ctx)
+}
+
+// ProcessEvent marks the beginning of processing an event. It generates an ack ID for the associated partition ID.
+func (s *storageAckExtension) ProcessEvent(partitionID string) (ackID uint64) {
+	ctx := context.Background()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	lastID, known := s.lastIDs.Get(partitionID)
+	ackID = lastID + 1
+	s.lastIDs.Add(partitionID, ackID)
+
+	lastIDValue := make([]byte, 8)
+	binary.BigEndian.PutUint64(lastIDValue, ackID)
+	ops := []storage.Operation{
+		storage.SetOperation(lastIDKey(partitionID), lastIDValue),
+		storage.SetOperation(ackKey(partitionID, ackID), []byte{ackPending}),
+	}
+	if ackID > s.maxNumPendingAcksPerPartition {
+		ops = append(ops, storage.DeleteOperation(ackKey(partitionID, ackID-s.maxNumPendingAcksPerPartition)))
+	}
+	if !known {
+		// the list of the partitions only changes when a partition is created, possibly evicting another one
+		ops = append(ops, s.takeEvictionOps()...)
+	}
+	if err := s.client.Batch(ctx, ops...); err != nil {
+		s.logger.Warn("Failed to persist ack ID", zap.String("partition", partitionID), zap.Uint64("ack_id", ackID), zap.Error(err))
+	}
+	return ackID
+}
+
+// Ack acknowledges an event has been processed.
+func (s *storageAckExtension) Ack(partitionID string, ackID uint64) {
+	ctx := context.Background()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := ackKey(partitionID, ackID)
+	value, err := s.client.Get(ctx, key)
+	if err != nil {
+		s.logger.Warn("Failed to read ack from storage", zap.String("partition", partitionID), zap.Uint64("ack_id", ackID), zap.Error(err))
+		return
+	}
+	if value == nil {
+		// the ack ID was never generated, or was evicted
+		return
+	}
+	if err = s.client.Set(ctx, key, []byte{ackAcked}); err != nil {
+		s.logger.Warn("Failed to persist ack", zap.String("partition", partitionID), zap.Uint64("ack_id", ackID), zap.Error(err))
+	}
+}
+
+// QueryAcks checks the statuses of given ackIDs for a partition.
+// ackIDs that are not generated from ProcessEvent or have been removed as a result of previous calls to QueryAcks will return false.
+func (s *storageAckExtension) QueryAcks(partitionID string, ackIDs []uint64) map[uint64]bool {
+	ctx := context.Background()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	result := make(map[uint64]bool, len(ackIDs))
+	var ops []storage.Operation
+	for _, ackID := range ackIDs {
+		key := ackKey(partitionID, ackID)
+		value, err := s.client.Get(ctx, key)
+		if err != nil {
+			s.logger.Warn("Failed to read ack from storage", zap.String("partition", partitionID), zap.Uint64("ack_id", ackID), zap.Error(err))
+		}
+		isAcked := len(value) == 1 && value[0] == ackAcked
+		result[ackID] = isAcked
+		if isAcked {
+			ops = append(ops, storage.DeleteOperation(key))
+		}
+	}
+	if len(ops) > 0 {
+		if err := s.client.Batch(ctx, ops...); err != nil {
+			s.logger.Warn("Failed to remove queried acks from storage", zap.String("partition", partitionID), zap.Error(err))
+		}
+	}
+	return result
+}
+
+// storedLastID returns the last ack ID generated for the partition from storage
+func (s *storageAckExtension) storedLastID(ctx context.Context, partitionID string) (uint64, error) {
+	value, err := s.client.Get(ctx, lastIDKey(partitionID))
+	if err != nil || value == nil {
+		return 0, err
+	}
+	if len(value) != 8 {
+		return 0, errors.New("invalid last ack ID")
+	}
+	return binary.BigEndian.Uint64(value), nil
+}
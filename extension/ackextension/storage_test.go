@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ackextension
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func newTestStorageAckExtension(t *testing.T, host component.Host, maxPendingAcks uint64) *storageAckExtension {
+	return newTestStorageAckExtensionWithPartitions(t, host, defaultMaxNumPartition, maxPendingAcks)
+}
+
+func newTestStorageAckExtensionWithPartitions(t *testing.T, host component.Host, maxPartitions, maxPendingAcks uint64) *storageAckExtension {
+	storageID := storagetest.NewStorageID("test")
+	// the ID is fixed, so that the extension gets the same storage client after a restart
+	set := extensiontest.NewNopSettings()
+	set.ID = component.MustNewID("ack")
+	ext := newStorageAckExtension(&Config{
+		StorageID:                     &storageID,
+		MaxNumPartition:               maxPartitions,
+		MaxNumPendingAcksPerPartition: maxPendingAcks,
+	}, set)
+	require.NoError(t, ext.Start(context.Background(), host))
+	return ext
+}
+
+func TestStorageAckExtension(t *testing.T) {
+	host := storagetest.NewStorageHost().WithInMemoryStorageExtension("test")
+	ext := newTestStorageAckExtension(t, host, defaultMaxNumPendingAcksPerPartition)
+	defer func() {
+		require.NoError(t, ext.Shutdown(context.Background()))
+	}()
+
+	require.Equal(t, uint64(1), ext.ProcessEvent("partition1"))
+	require.Equal(t, uint64(2), ext.ProcessEvent("partition1"))
+	require.Equal(t, uint64(1), ext.ProcessEvent("partition2"))
+
+	ext.Ack("partition1", 2)
+	ext.Ack("partition2", 1)
+	// acking an ack ID that was never generated has no effect
+	ext.Ack("partition1", 5)
+
+	require.Equal(t, map[uint64]bool{1: false, 2: true, 5: false}, ext.QueryAcks("partition1", []uint64{1, 2, 5}))
+	// acked IDs are removed once queried
+	require.Equal(t, map[uint64]bool{2: false}, ext.QueryAcks("partition1", []uint64{2}))
+	require.Equal(t, map[uint64]bool{1: true}, ext.QueryAcks("partition2", []uint64{1}))
+	require.Equal(t, map[uint64]bool{1: false}, ext.QueryAcks("partition3", []uint64{1}))
+}
+
+func TestStorageAckExtensionRestart(t *testing.T) {
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	ext := newTestStorageAckExtension(t, host, defaultMaxNumPendingAcksPerPartition)
+	require.Equal(t, uint64(1), ext.ProcessEvent("partition"))
+	require.Equal(t, uint64(2), ext.ProcessEvent("partition"))
+	ext.Ack("partition", 1)
+	require.NoError(t, ext.Shutdown(context.Background()))
+
+	// the acks and the ack IDs of the partitions survive a restart
+	ext = newTestStorageAckExtension(t, host, defaultMaxNumPendingAcksPerPartition)
+	require.Equal(t, uint64(3), ext.ProcessEvent("partition"))
+	ext.Ack("partition", 2)
+	require.Equal(t, map[uint64]bool{1: true, 2: true, 3: false}, ext.QueryAcks("partition", []uint64{1, 2, 3}))
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
+
+func TestStorageAckExtensionMaxPendingAcks(t *testing.T) {
+	host := storagetest.NewStorageHost().WithInMemoryStorageExtension("test")
+	ext := newTestStorageAckExtension(t, host, 2)
+	defer func() {
+		require.NoError(t, ext.Shutdown(context.Background()))
+	}()
+
+	for i := 0; i < 3; i++ {
+		ext.ProcessEvent("partition")
+	}
+	// the oldest ack ID was evicted
+	ext.Ack("partition", 1)
+	ext.Ack("partition", 3)
+	require.Equal(t, map[uint64]bool{1: false, 3: true}, ext.QueryAcks("partition", []uint64{1, 3}))
+}
+
+func TestStorageAckExtensionMaxPartitions(t *testing.T) {
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	ext := newTestStorageAckExtensionWithPartitions(t, host, 2, 2)
+	for i := 0; i < 3; i++ {
+		ext.ProcessEvent("partition1")
+	}
+	ext.Ack("partition1", 3)
+	ext.ProcessEvent("partition2")
+	// the least recently used partition is evicted along with its keys
+	ext.ProcessEvent("partition3")
+	for _, key := range []string{lastIDKey("partition1"), ackKey("partition1", 2), ackKey("partition1", 3)} {
+		value, err := ext.client.Get(context.Background(), key)
+		require.NoError(t, err)
+		require.Nil(t, value, key)
+	}
+	require.Equal(t, map[uint64]bool{3: false}, ext.QueryAcks("partition1", []uint64{3}))
+	require.NoError(t, ext.Shutdown(context.Background()))
+
+	// the partitions created before a restart are still evicted after it
+	ext = newTestStorageAckExtensionWithPartitions(t, host, 2, 2)
+	ext.Ack("partition2", 1)
+	ext.ProcessEvent("partition4")
+	require.Equal(t, map[uint64]bool{1: false}, ext.QueryAcks("partition2", []uint64{1}))
+	require.Equal(t, uint64(2), ext.ProcessEvent("partition3"))
+	require.Equal(t, uint64(1), ext.ProcessEvent("partition1"))
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
+
+func TestStorageAckExtensionMissingStorage(t *testing.T) {
+	storageID := storagetest.NewStorageID("missing")
+	ext := newStorageAckExtension(&Config{StorageID: &storageID}, extensiontest.NewNopSettings())
+	require.ErrorContains(t, ext.Start(context.Background(), storagetest.NewStorageHost()), "storage extension 'test_storage/missing' not found")
+
+	nonStorageID := storagetest.NewNonStorageID("non")
+	ext = newStorageAckExtension(&Config{StorageID: &nonStorageID}, extensiontest.NewNopSettings())
+	host := storagetest.NewStorageHost().WithNonStorageExtension("non")
+	require.ErrorContains(t, ext.Start(context.Background(), host), "non-storage extension")
+}
@@ -25,6 +25,7 @@ var (
 	errGRPCEndpointRequired = errors.New("grpc endpoint required")
 	errHTTPEndpointRequired = errors.New("http endpoint required")
 	errInvalidPath          = errors.New("path must start with /")
+	errNegativeDuration     = errors.New("recovery_duration and startup_grace_period must not be negative")
 )
 
 // Config has the configuration for the extension enabling the health check
@@ -77,6 +78,11 @@ func (c *Config) Validate() error {
 		return errGRPCEndpointRequired
 	}
 
+	if c.ComponentHealthConfig != nil &&
+		(c.ComponentHealthConfig.RecoveryDuration < 0 || c.ComponentHealthConfig.StartupGracePeriod < 0) {
+		return errNegativeDuration
+	}
+
 	return nil
 }
 
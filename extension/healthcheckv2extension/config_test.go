@@ -110,6 +110,7 @@ func TestLoadConfig(t *testing.T) {
 					IncludePermanent:   true,
 					IncludeRecoverable: true,
 					RecoveryDuration:   5 * time.Minute,
+					StartupGracePeriod: 30 * time.Second,
 				},
 			},
 		},
@@ -170,6 +171,10 @@ func TestLoadConfig(t *testing.T) {
 			id:          component.NewIDWithName(metadata.Type, "v2noprotocols"),
 			expectedErr: errMissingProtocol,
 		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "v2negativegraceperiod"),
+			expectedErr: errNegativeDuration,
+		},
 	}
 
 	for _, tt := range tests {
@@ -5,38 +5,167 @@ package healthcheckv2extension // import "github.com/open-telemetry/opentelemetr
 
 import (
 	"context"
+	"errors"
+	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/extension"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckv2extension/internal/common"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckv2extension/internal/grpc"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckv2extension/internal/http"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckv2extension/internal/status"
 )
 
 type healthCheckExtension struct {
-	config    Config
-	telemetry component.TelemetrySettings
+	config        Config
+	telemetry     component.TelemetrySettings
+	aggregator    *status.Aggregator
+	evaluator     *common.HealthEvaluator
+	subcomponents []component.Component
+
+	// mu protects done, status events received after shutdown are discarded
+	mu   sync.Mutex
+	done bool
 }
 
-var _ component.Component = (*healthCheckExtension)(nil)
+var (
+	_ component.Component       = (*healthCheckExtension)(nil)
+	_ extension.ConfigWatcher   = (*healthCheckExtension)(nil)
+	_ extension.PipelineWatcher = (*healthCheckExtension)(nil)
+	_ extension.StatusWatcher   = (*healthCheckExtension)(nil)
+)
 
 func newExtension(
 	_ context.Context,
 	config Config,
 	set extension.Settings,
 ) *healthCheckExtension {
+	var comps []component.Component
+
+	errPriority := status.PriorityPermanent
+	if config.ComponentHealthConfig != nil &&
+		config.ComponentHealthConfig.IncludeRecoverable &&
+		!config.ComponentHealthConfig.IncludePermanent {
+		errPriority = status.PriorityRecoverable
+	}
+
+	aggregator := status.NewAggregator(errPriority)
+	// the startup grace period is restarted when the extension is started
+	evaluator := common.NewHealthEvaluator(config.ComponentHealthConfig, time.Now())
+
+	if config.UseV2 && config.GRPCConfig != nil {
+		grpcServer := grpc.NewServer(
+			config.GRPCConfig,
+			evaluator,
+			set.TelemetrySettings,
+			aggregator,
+		)
+		comps = append(comps, grpcServer)
+	}
+
+	if !config.UseV2 || config.HTTPConfig != nil {
+		httpServer := http.NewServer(
+			config.HTTPConfig,
+			config.LegacyConfig,
+			evaluator,
+			set.TelemetrySettings,
+			aggregator,
+		)
+		comps = append(comps, httpServer)
+	}
+
 	return &healthCheckExtension{
-		config:    config,
-		telemetry: set.TelemetrySettings,
+		config:        config,
+		subcomponents: comps,
+		telemetry:     set.TelemetrySettings,
+		aggregator:    aggregator,
+		evaluator:     evaluator,
 	}
 }
 
 // Start implements the component.Component interface.
-func (hc *healthCheckExtension) Start(context.Context, component.Host) error {
+func (hc *healthCheckExtension) Start(ctx context.Context, host component.Host) error {
 	hc.telemetry.Logger.Debug("Starting health check extension V2", zap.Any("config", hc.config))
 
+	// the collector can take a while to create the components, which mustn't shorten the grace period
+	hc.evaluator.SetStartTime(time.Now())
+
+	for _, comp := range hc.subcomponents {
+		if err := comp.Start(ctx, host); err != nil {
+			return errors.Join(err, hc.Shutdown(ctx))
+		}
+	}
+
 	return nil
 }
 
 // Shutdown implements the component.Component interface.
-func (hc *healthCheckExtension) Shutdown(context.Context) error {
-	return nil
+func (hc *healthCheckExtension) Shutdown(ctx context.Context) error {
+	hc.mu.Lock()
+	if !hc.done {
+		hc.done = true
+		// closing the aggregator ends the gRPC Watch streams
+		hc.aggregator.Close()
+	}
+	hc.mu.Unlock()
+
+	var err error
+	for _, comp := range hc.subcomponents {
+		err = errors.Join(err, comp.Shutdown(ctx))
+	}
+	return err
+}
+
+// ComponentStatusChanged implements the extension.StatusWatcher interface.
+func (hc *healthCheckExtension) ComponentStatusChanged(
+	source *component.InstanceID,
+	event *component.StatusEvent,
+) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	// There can be late arriving events after shutdown, the subscriptions to the aggregator are
+	// closed by then
+	if hc.done {
+		hc.telemetry.Logger.Debug("discarding event received after shutdown", zap.Stringer("source", source.ID))
+		return
+	}
+	hc.aggregator.RecordStatus(source, event)
+}
+
+// NotifyConfig implements the extension.ConfigWatcher interface.
+func (hc *healthCheckExtension) NotifyConfig(ctx context.Context, conf *confmap.Conf) error {
+	var err error
+	for _, comp := range hc.subcomponents {
+		if cw, ok := comp.(extension.ConfigWatcher); ok {
+			err = errors.Join(err, cw.NotifyConfig(ctx, conf))
+		}
+	}
+	return err
+}
+
+// Ready implements the extension.PipelineWatcher interface.
+func (hc *healthCheckExtension) Ready() error {
+	var err error
+	for _, comp := range hc.subcomponents {
+		if pw, ok := comp.(extension.PipelineWatcher); ok {
+			err = errors.Join(err, pw.Ready())
+		}
+	}
+	return err
+}
+
+// NotReady implements the extension.PipelineWatcher interface.
+func (hc *healthCheckExtension) NotReady() error {
+	var err error
+	for _, comp := range hc.subcomponents {
+		if pw, ok := comp.(extension.PipelineWatcher); ok {
+			err = errors.Join(err, pw.NotReady())
+		}
+	}
+	return err
 }
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheckv2extension
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckv2extension/internal/common"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckv2extension/internal/testhelpers"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+)
+
+func getStatusCode(t *testing.T, url string) int {
+	t.Helper()
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	return resp.StatusCode
+}
+
+func TestComponentStatus(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.UseV2 = true
+	cfg.HTTPConfig.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.GRPCConfig.NetAddr.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.ComponentHealthConfig = &common.ComponentHealthConfig{IncludePermanent: true}
+
+	ext := newExtension(context.Background(), *cfg, extensiontest.NewNopSettings())
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+
+	statusURL := fmt.Sprintf("http://%s/status", cfg.HTTPConfig.Endpoint)
+	traces := testhelpers.NewPipelineMetadata("traces")
+
+	for _, id := range traces.InstanceIDs() {
+		ext.ComponentStatusChanged(id, component.NewStatusEvent(component.StatusStarting))
+	}
+	assert.Equal(t, http.StatusServiceUnavailable, getStatusCode(t, statusURL))
+
+	for _, id := range traces.InstanceIDs() {
+		ext.ComponentStatusChanged(id, component.NewStatusEvent(component.StatusOK))
+	}
+	assert.Equal(t, http.StatusOK, getStatusCode(t, statusURL))
+	assert.Equal(t, http.StatusOK, getStatusCode(t, statusURL+"?pipeline=traces"))
+
+	ext.ComponentStatusChanged(traces.ExporterID, component.NewPermanentErrorEvent(assert.AnError))
+	assert.Equal(t, http.StatusInternalServerError, getStatusCode(t, statusURL+"?pipeline=traces"))
+
+	require.NoError(t, ext.Shutdown(context.Background()))
+
+	// late events are discarded
+	ext.ComponentStatusChanged(traces.ExporterID, component.NewStatusEvent(component.StatusStopped))
+}
+
+func TestStartupGracePeriodStartsOnStart(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.UseV2 = true
+	cfg.HTTPConfig.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.GRPCConfig = nil
+	cfg.ComponentHealthConfig = &common.ComponentHealthConfig{
+		IncludePermanent:   true,
+		StartupGracePeriod: time.Minute,
+	}
+
+	ext := newExtension(context.Background(), *cfg, extensiontest.NewNopSettings())
+	time.Sleep(10 * time.Millisecond)
+	start := time.Now()
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, ext.Shutdown(context.Background())) })
+
+	unhealthyAt, ok := ext.evaluator.UnhealthyAt(component.NewPermanentErrorEvent(assert.AnError))
+	require.True(t, ok)
+	assert.False(t, unhealthyAt.Before(start.Add(time.Minute)), "the grace period should start when the extension is started")
+}
+
+func TestLegacyReadiness(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+
+	ext := newExtension(context.Background(), *cfg, extensiontest.NewNopSettings())
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, ext.Shutdown(context.Background()))
+	}()
+
+	url := fmt.Sprintf("http://%s/", cfg.Endpoint)
+	assert.Equal(t, http.StatusServiceUnavailable, getStatusCode(t, url))
+
+	require.NoError(t, ext.Ready())
+	assert.Equal(t, http.StatusOK, getStatusCode(t, url))
+
+	require.NoError(t, ext.NotReady())
+	assert.Equal(t, http.StatusServiceUnavailable, getStatusCode(t, url))
+}
@@ -9,6 +9,9 @@ type ComponentHealthConfig struct {
 	IncludePermanent   bool          `mapstructure:"include_permanent_errors"`
 	IncludeRecoverable bool          `mapstructure:"include_recoverable_errors"`
 	RecoveryDuration   time.Duration `mapstructure:"recovery_duration"`
+	// StartupGracePeriod is the time after the start of the extension during which
+	// errors are not considered unhealthy.
+	StartupGracePeriod time.Duration `mapstructure:"startup_grace_period"`
 }
 
 func (c ComponentHealthConfig) Enabled() bool {
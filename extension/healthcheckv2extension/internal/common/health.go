@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package common // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckv2extension/internal/common"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckv2extension/internal/status"
+)
+
+// HealthEvaluator decides whether a status event is healthy according to the ComponentHealthConfig.
+// It is shared by the HTTP and gRPC services so that both report the same health.
+type HealthEvaluator struct {
+	config   ComponentHealthConfig
+	graceEnd time.Time
+}
+
+// NewHealthEvaluator returns a *HealthEvaluator for the config. Errors are not considered unhealthy
+// before the StartupGracePeriod, counted from startTime, has elapsed. A nil config only considers
+// FatalError events unhealthy.
+func NewHealthEvaluator(config *ComponentHealthConfig, startTime time.Time) *HealthEvaluator {
+	h := &HealthEvaluator{}
+	if config != nil {
+		h.config = *config
+	}
+	h.SetStartTime(startTime)
+	return h
+}
+
+// SetStartTime restarts the StartupGracePeriod from startTime. It must be called before the evaluator
+// is used by the services.
+func (h *HealthEvaluator) SetStartTime(startTime time.Time) {
+	h.graceEnd = startTime.Add(h.config.StartupGracePeriod)
+}
+
+// IsHealthy returns whether the event is healthy at the given time.
+func (h *HealthEvaluator) IsHealthy(ev status.Event, now time.Time) bool {
+	unhealthyAt, ok := h.UnhealthyAt(ev)
+	return !ok || now.Before(unhealthyAt)
+}
+
+// UnhealthyAt returns the time from which the event is considered unhealthy, as long as it is not
+// superseded by another event. The boolean return value is false if the event is always healthy.
+func (h *HealthEvaluator) UnhealthyAt(ev status.Event) (time.Time, bool) {
+	switch ev.Status() {
+	case component.StatusFatalError:
+		return time.Time{}, true
+	case component.StatusPermanentError:
+		if h.config.IncludePermanent {
+			return h.graceEnd, true
+		}
+	case component.StatusRecoverableError:
+		if h.config.IncludeRecoverable {
+			recoveryEnd := ev.Timestamp().Add(h.config.RecoveryDuration)
+			if recoveryEnd.Before(h.graceEnd) {
+				return h.graceEnd, true
+			}
+			return recoveryEnd, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component"
+)
+
+type testEvent struct {
+	status    component.Status
+	timestamp time.Time
+}
+
+func (e *testEvent) Status() component.Status { return e.status }
+func (e *testEvent) Err() error               { return nil }
+func (e *testEvent) Timestamp() time.Time     { return e.timestamp }
+
+func TestHealthEvaluator(t *testing.T) {
+	start := time.Now()
+
+	tests := []struct {
+		name      string
+		config    *ComponentHealthConfig
+		event     *testEvent
+		healthyAt map[time.Duration]bool
+	}{
+		{
+			name:      "nil config ignores errors",
+			event:     &testEvent{status: component.StatusPermanentError, timestamp: start},
+			healthyAt: map[time.Duration]bool{0: true, time.Hour: true},
+		},
+		{
+			name:      "fatal error is always unhealthy",
+			config:    &ComponentHealthConfig{StartupGracePeriod: time.Minute},
+			event:     &testEvent{status: component.StatusFatalError, timestamp: start},
+			healthyAt: map[time.Duration]bool{0: false, time.Hour: false},
+		},
+		{
+			name:      "starting is healthy",
+			config:    &ComponentHealthConfig{IncludePermanent: true, IncludeRecoverable: true},
+			event:     &testEvent{status: component.StatusStarting, timestamp: start},
+			healthyAt: map[time.Duration]bool{0: true, time.Hour: true},
+		},
+		{
+			name:      "permanent error",
+			config:    &ComponentHealthConfig{IncludePermanent: true},
+			event:     &testEvent{status: component.StatusPermanentError, timestamp: start},
+			healthyAt: map[time.Duration]bool{0: false},
+		},
+		{
+			name:      "permanent error during startup grace period",
+			config:    &ComponentHealthConfig{IncludePermanent: true, StartupGracePeriod: time.Minute},
+			event:     &testEvent{status: component.StatusPermanentError, timestamp: start},
+			healthyAt: map[time.Duration]bool{0: true, 59 * time.Second: true, time.Minute: false},
+		},
+		{
+			name:      "recoverable error not included",
+			config:    &ComponentHealthConfig{IncludePermanent: true, RecoveryDuration: time.Second},
+			event:     &testEvent{status: component.StatusRecoverableError, timestamp: start},
+			healthyAt: map[time.Duration]bool{0: true, time.Hour: true},
+		},
+		{
+			name:      "recoverable error within recovery duration",
+			config:    &ComponentHealthConfig{IncludeRecoverable: true, RecoveryDuration: time.Minute},
+			event:     &testEvent{status: component.StatusRecoverableError, timestamp: start.Add(time.Hour)},
+			healthyAt: map[time.Duration]bool{time.Hour: true, time.Hour + time.Minute: false},
+		},
+		{
+			name: "recoverable error during startup grace period",
+			config: &ComponentHealthConfig{
+				IncludeRecoverable: true,
+				RecoveryDuration:   time.Second,
+				StartupGracePeriod: time.Minute,
+			},
+			event:     &testEvent{status: component.StatusRecoverableError, timestamp: start},
+			healthyAt: map[time.Duration]bool{30 * time.Second: true, time.Minute: false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evaluator := NewHealthEvaluator(tt.config, start)
+			for offset, healthy := range tt.healthyAt {
+				assert.Equal(t, healthy, evaluator.IsHealthy(tt.event, start.Add(offset)), "at %s", offset)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckv2extension/internal/grpc"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	grpcstatus "google.golang.org/grpc/status"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckv2extension/internal/status"
+)
+
+var (
+	errNotFound     = grpcstatus.Error(codes.NotFound, "Service not found.")
+	errShuttingDown = grpcstatus.Error(codes.Canceled, "Server shutting down.")
+	errStreamEnded  = grpcstatus.Error(codes.Canceled, "Stream has ended.")
+)
+
+var servingStatuses = map[component.Status]healthpb.HealthCheckResponse_ServingStatus{
+	component.StatusNone:             healthpb.HealthCheckResponse_NOT_SERVING,
+	component.StatusStarting:         healthpb.HealthCheckResponse_NOT_SERVING,
+	component.StatusOK:               healthpb.HealthCheckResponse_SERVING,
+	component.StatusRecoverableError: healthpb.HealthCheckResponse_SERVING,
+	component.StatusPermanentError:   healthpb.HealthCheckResponse_SERVING,
+	component.StatusFatalError:       healthpb.HealthCheckResponse_NOT_SERVING,
+	component.StatusStopping:         healthpb.HealthCheckResponse_NOT_SERVING,
+	component.StatusStopped:          healthpb.HealthCheckResponse_NOT_SERVING,
+}
+
+// Check returns the serving status of the collector for the empty service name, or of the pipeline
+// named by the service.
+func (s *Server) Check(
+	_ context.Context,
+	req *healthpb.HealthCheckRequest,
+) (*healthpb.HealthCheckResponse, error) {
+	st, ok := s.aggregator.AggregateStatus(status.Scope(req.Service), status.Concise)
+	if !ok {
+		return nil, errNotFound
+	}
+
+	return &healthpb.HealthCheckResponse{
+		Status: s.toServingStatus(st.Event, time.Now()),
+	}, nil
+}
+
+// Watch streams the changes of the serving status of the collector or of a pipeline. An unknown
+// service is reported as SERVICE_UNKNOWN until it starts reporting. An error that becomes unhealthy
+// once its recovery duration or the startup grace period elapses is re-evaluated at that time.
+func (s *Server) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	sub, unsub := s.aggregator.Subscribe(status.Scope(req.Service), status.Concise)
+	defer unsub()
+
+	var lastServingStatus healthpb.HealthCheckResponse_ServingStatus = -1
+	var lastEvent status.Event
+	var timer *time.Timer
+	var timerCh <-chan time.Time
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+
+	for {
+		select {
+		case st, ok := <-sub:
+			if !ok {
+				return errShuttingDown
+			}
+			if timer != nil {
+				timer.Stop()
+				timer, timerCh = nil, nil
+			}
+			lastEvent = nil
+			if st != nil {
+				lastEvent = st.Event
+			}
+		case <-timerCh:
+			timer, timerCh = nil, nil
+		case <-stream.Context().Done():
+			return errStreamEnded
+		}
+
+		now := time.Now()
+		sst := healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+		if lastEvent != nil {
+			sst = s.toServingStatus(lastEvent, now)
+			if unhealthyAt, ok := s.evaluator.UnhealthyAt(lastEvent); ok && now.Before(unhealthyAt) {
+				timer = time.NewTimer(unhealthyAt.Sub(now))
+				timerCh = timer.C
+			}
+		}
+
+		if sst == lastServingStatus {
+			continue
+		}
+		lastServingStatus = sst
+
+		if err := stream.Send(&healthpb.HealthCheckResponse{Status: sst}); err != nil {
+			return errStreamEnded
+		}
+	}
+}
+
+func (s *Server) toServingStatus(ev status.Event, now time.Time) healthpb.HealthCheckResponse_ServingStatus {
+	if !s.evaluator.IsHealthy(ev, now) {
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	return servingStatuses[ev.Status()]
+}
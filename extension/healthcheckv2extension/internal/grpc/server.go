@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckv2extension/internal/grpc"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckv2extension/internal/common"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckv2extension/internal/status"
+)
+
+// Server is the gRPC health check service, an implementation of the grpc_health_v1 service
+// serving the aggregate statuses of the collector and its pipelines.
+type Server struct {
+	healthpb.UnimplementedHealthServer
+	grpcServer *grpc.Server
+	aggregator *status.Aggregator
+	config     *Config
+	evaluator  *common.HealthEvaluator
+	telemetry  component.TelemetrySettings
+	doneCh     chan struct{}
+}
+
+var (
+	_ component.Component   = (*Server)(nil)
+	_ healthpb.HealthServer = (*Server)(nil)
+)
+
+// NewServer returns a *Server.
+func NewServer(
+	config *Config,
+	evaluator *common.HealthEvaluator,
+	telemetry component.TelemetrySettings,
+	aggregator *status.Aggregator,
+) *Server {
+	return &Server{
+		config:     config,
+		evaluator:  evaluator,
+		telemetry:  telemetry,
+		aggregator: aggregator,
+		doneCh:     make(chan struct{}),
+	}
+}
+
+// Start implements the component.Component interface.
+func (s *Server) Start(ctx context.Context, host component.Host) error {
+	grpcServer, err := s.config.ToServer(ctx, host, s.telemetry)
+	if err != nil {
+		return err
+	}
+
+	healthpb.RegisterHealthServer(grpcServer, s)
+	ln, err := s.config.NetAddr.Listen(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the server is only set once it is served, so that Shutdown does not wait for a server that never ran
+	s.grpcServer = grpcServer
+	go func() {
+		defer close(s.doneCh)
+
+		if err = s.grpcServer.Serve(ln); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			s.telemetry.ReportStatus(component.NewPermanentErrorEvent(err))
+		}
+	}()
+
+	return nil
+}
+
+// Shutdown implements the component.Component interface. The aggregator must be closed first so
+// that the Watch streams end.
+func (s *Server) Shutdown(context.Context) error {
+	if s.grpcServer == nil {
+		return nil
+	}
+	s.grpcServer.GracefulStop()
+	<-s.doneCh
+	return nil
+}
@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confignet"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	grpcstatus "google.golang.org/grpc/status"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckv2extension/internal/common"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckv2extension/internal/status"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckv2extension/internal/testhelpers"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+)
+
+func startTestServer(
+	t *testing.T,
+	componentHealthConfig *common.ComponentHealthConfig,
+) (healthpb.HealthClient, *status.Aggregator) {
+	t.Helper()
+	config := &Config{
+		ServerConfig: configgrpc.ServerConfig{
+			NetAddr: confignet.AddrConfig{
+				Endpoint:  testutil.GetAvailableLocalAddress(t),
+				Transport: "tcp",
+			},
+		},
+	}
+	aggregator := status.NewAggregator(testhelpers.ErrPriority(componentHealthConfig))
+	srv := NewServer(
+		config,
+		common.NewHealthEvaluator(componentHealthConfig, time.Now()),
+		componenttest.NewNopTelemetrySettings(),
+		aggregator,
+	)
+	require.NoError(t, srv.Start(context.Background(), componenttest.NewNopHost()))
+
+	conn, err := grpc.NewClient(config.NetAddr.Endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, conn.Close())
+		aggregator.Close()
+		require.NoError(t, srv.Shutdown(context.Background()))
+	})
+	return healthpb.NewHealthClient(conn), aggregator
+}
+
+func TestCheck(t *testing.T) {
+	client, aggregator := startTestServer(t, &common.ComponentHealthConfig{IncludePermanent: true})
+	traces := testhelpers.NewPipelineMetadata("traces")
+	ctx := context.Background()
+
+	check := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		return resp.Status
+	}
+
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check(""))
+
+	_, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "traces"})
+	assert.Equal(t, codes.NotFound, grpcstatus.Code(err))
+
+	testhelpers.SeedAggregator(aggregator, traces.InstanceIDs(), component.StatusStarting)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check("traces"))
+
+	testhelpers.SeedAggregator(aggregator, traces.InstanceIDs(), component.StatusOK)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check(""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check("traces"))
+
+	aggregator.RecordStatus(traces.ExporterID, component.NewRecoverableErrorEvent(assert.AnError))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check("traces"))
+
+	aggregator.RecordStatus(traces.ExporterID, component.NewPermanentErrorEvent(assert.AnError))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check("traces"))
+}
+
+func TestWatch(t *testing.T) {
+	client, aggregator := startTestServer(t, &common.ComponentHealthConfig{
+		IncludeRecoverable: true,
+		RecoveryDuration:   50 * time.Millisecond,
+	})
+	traces := testhelpers.NewPipelineMetadata("traces")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "traces"})
+	require.NoError(t, err)
+
+	recv := func() healthpb.HealthCheckResponse_ServingStatus {
+		resp, recvErr := stream.Recv()
+		require.NoError(t, recvErr)
+		return resp.Status
+	}
+
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVICE_UNKNOWN, recv())
+
+	testhelpers.SeedAggregator(aggregator, traces.InstanceIDs(), component.StatusOK)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, recv())
+
+	// the recoverable error becomes unhealthy once the recovery duration elapses
+	aggregator.RecordStatus(traces.ExporterID, component.NewRecoverableErrorEvent(assert.AnError))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, recv())
+
+	aggregator.RecordStatus(traces.ExporterID, component.NewStatusEvent(component.StatusOK))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, recv())
+
+	aggregator.RecordStatus(traces.ExporterID, component.NewFatalErrorEvent(assert.AnError))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, recv())
+}
+
+func TestShutdownAfterFailedStart(t *testing.T) {
+	config := &Config{
+		ServerConfig: configgrpc.ServerConfig{
+			NetAddr: confignet.AddrConfig{
+				Endpoint:  "invalid:address:port",
+				Transport: "tcp",
+			},
+		},
+	}
+	aggregator := status.NewAggregator(status.PriorityPermanent)
+	srv := NewServer(
+		config,
+		common.NewHealthEvaluator(&common.ComponentHealthConfig{}, time.Now()),
+		componenttest.NewNopTelemetrySettings(),
+		aggregator,
+	)
+	require.Error(t, srv.Start(context.Background(), componenttest.NewNopHost()))
+
+	aggregator.Close()
+	require.NoError(t, srv.Shutdown(context.Background()))
+}
+
+func TestWatchAfterClose(t *testing.T) {
+	client, aggregator := startTestServer(t, &common.ComponentHealthConfig{})
+	aggregator.Close()
+
+	stream, err := client.Watch(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Canceled, grpcstatus.Code(err))
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package http // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckv2extension/internal/http"
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckv2extension/internal/status"
+)
+
+const (
+	pipelineParam = "pipeline"
+	verboseParam  = "verbose"
+)
+
+var responseCodes = map[component.Status]int{
+	component.StatusNone:             http.StatusServiceUnavailable,
+	component.StatusStarting:         http.StatusServiceUnavailable,
+	component.StatusOK:               http.StatusOK,
+	component.StatusRecoverableError: http.StatusOK,
+	component.StatusPermanentError:   http.StatusOK,
+	component.StatusFatalError:       http.StatusInternalServerError,
+	component.StatusStopping:         http.StatusServiceUnavailable,
+	component.StatusStopped:          http.StatusServiceUnavailable,
+}
+
+// statusHandler responds with the aggregate status of the collector, or of the pipeline passed as the
+// pipeline query parameter. The verbose query parameter includes the statuses of the subtrees.
+func (s *Server) statusHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		verbosity := status.Verbosity(query.Has(verboseParam) && query.Get(verboseParam) != "false")
+
+		st, ok := s.aggregator.AggregateStatus(status.Scope(query.Get(pipelineParam)), verbosity)
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		now := time.Now()
+		code := http.StatusInternalServerError
+		if s.evaluator.IsHealthy(st.Event, now) {
+			code = responseCodes[st.Status()]
+		}
+
+		sst := toSerializableStatus(st, s.evaluator, now)
+		sst.StartTimestamp = &s.startTimestamp
+		body, err := json.Marshal(sst)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_, _ = w.Write(body)
+	})
+}
+
+// configHandler responds with the collector configuration, once the collector notified it.
+func (s *Server) configHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		conf, _ := s.colconf.Load().([]byte)
+		if conf == nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(conf)
+	})
+}
+
+// legacyHandler responds as the original health check extension: available once the collector is
+// ready, not available otherwise.
+func (s *Server) legacyHandler() http.Handler {
+	type healthCheckResponse struct {
+		StatusMsg string    `json:"status"`
+		UpSince   time.Time `json:"upSince"`
+		Uptime    string    `json:"uptime"`
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		code, msg := http.StatusServiceUnavailable, "Server not available"
+		if s.ready.Load() {
+			code, msg = http.StatusOK, "Server available"
+		}
+
+		var body []byte
+		if rb := s.legacyConfig.ResponseBody; rb != nil {
+			body = []byte(rb.Unhealthy)
+			if code == http.StatusOK {
+				body = []byte(rb.Healthy)
+			}
+		} else {
+			var err error
+			body, err = json.Marshal(&healthCheckResponse{
+				StatusMsg: msg,
+				UpSince:   s.startTimestamp,
+				Uptime:    time.Since(s.startTimestamp).String(),
+			})
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+		}
+
+		w.WriteHeader(code)
+		_, _ = w.Write(body)
+	})
+}
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package http // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckv2extension/internal/http"
+
+import (
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckv2extension/internal/common"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckv2extension/internal/status"
+)
+
+// serializableStatus is the JSON representation of an *status.AggregateStatus
+type serializableStatus struct {
+	StartTimestamp *time.Time `json:"start_time,omitempty"`
+	*SerializableEvent
+	ComponentStatuses map[string]*serializableStatus `json:"components,omitempty"`
+}
+
+// SerializableEvent is the JSON representation of a status.Event
+type SerializableEvent struct {
+	Healthy      bool      `json:"healthy"`
+	StatusString string    `json:"status"`
+	Error        string    `json:"error,omitempty"`
+	Timestamp    time.Time `json:"status_time"`
+}
+
+func toSerializableEvent(ev status.Event, evaluator *common.HealthEvaluator, now time.Time) *SerializableEvent {
+	se := &SerializableEvent{
+		Healthy:      evaluator.IsHealthy(ev, now),
+		StatusString: ev.Status().String(),
+		Timestamp:    ev.Timestamp(),
+	}
+	if ev.Err() != nil {
+		se.Error = ev.Err().Error()
+	}
+	return se
+}
+
+func toSerializableStatus(
+	st *status.AggregateStatus,
+	evaluator *common.HealthEvaluator,
+	now time.Time,
+) *serializableStatus {
+	s := &serializableStatus{
+		SerializableEvent: toSerializableEvent(st.Event, evaluator, now),
+	}
+	if len(st.ComponentStatusMap) > 0 {
+		s.ComponentStatuses = make(map[string]*serializableStatus, len(st.ComponentStatusMap))
+		for k, cs := range st.ComponentStatusMap {
+			s.ComponentStatuses[k] = toSerializableStatus(cs, evaluator, now)
+		}
+	}
+	return s
+}
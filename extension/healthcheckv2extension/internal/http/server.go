@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package http // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckv2extension/internal/http"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckv2extension/internal/common"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckv2extension/internal/status"
+)
+
+// Server is the HTTP health check service. With the v2 config it serves the aggregate statuses of
+// the collector and its pipelines, and optionally the collector config. With the legacy config it
+// serves the readiness of the collector, as the original health check extension.
+type Server struct {
+	telemetry      component.TelemetrySettings
+	serverConfig   confighttp.ServerConfig
+	legacyConfig   LegacyConfig
+	mux            *http.ServeMux
+	httpServer     *http.Server
+	aggregator     *status.Aggregator
+	evaluator      *common.HealthEvaluator
+	startTimestamp time.Time
+	colconf        atomic.Value
+	ready          atomic.Bool
+	doneWg         sync.WaitGroup
+}
+
+var (
+	_ component.Component       = (*Server)(nil)
+	_ extension.ConfigWatcher   = (*Server)(nil)
+	_ extension.PipelineWatcher = (*Server)(nil)
+)
+
+// NewServer returns a *Server. The v2 config is ignored unless legacyConfig.UseV2 is set.
+func NewServer(
+	config *Config,
+	legacyConfig LegacyConfig,
+	evaluator *common.HealthEvaluator,
+	telemetry component.TelemetrySettings,
+	aggregator *status.Aggregator,
+) *Server {
+	srv := &Server{
+		telemetry:      telemetry,
+		legacyConfig:   legacyConfig,
+		mux:            http.NewServeMux(),
+		aggregator:     aggregator,
+		evaluator:      evaluator,
+		startTimestamp: time.Now(),
+	}
+
+	if !legacyConfig.UseV2 {
+		srv.serverConfig = legacyConfig.ServerConfig
+		srv.mux.Handle(legacyConfig.Path, srv.legacyHandler())
+		return srv
+	}
+
+	srv.serverConfig = config.ServerConfig
+	if config.Status.Enabled {
+		srv.mux.Handle(config.Status.Path, srv.statusHandler())
+	}
+	if config.Config.Enabled {
+		srv.mux.Handle(config.Config.Path, srv.configHandler())
+	}
+	return srv
+}
+
+// Start implements the component.Component interface.
+func (s *Server) Start(ctx context.Context, host component.Host) error {
+	var err error
+	s.startTimestamp = time.Now()
+
+	s.httpServer, err = s.serverConfig.ToServer(ctx, host, s.telemetry, s.mux)
+	if err != nil {
+		return err
+	}
+
+	ln, err := s.serverConfig.ToListener(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", s.serverConfig.Endpoint, err)
+	}
+
+	s.doneWg.Add(1)
+	go func() {
+		defer s.doneWg.Done()
+		if err = s.httpServer.Serve(ln); !errors.Is(err, http.ErrServerClosed) && err != nil {
+			s.telemetry.ReportStatus(component.NewPermanentErrorEvent(err))
+		}
+	}()
+
+	return nil
+}
+
+// Shutdown implements the component.Component interface.
+func (s *Server) Shutdown(context.Context) error {
+	if s.httpServer == nil {
+		return nil
+	}
+	err := s.httpServer.Close()
+	s.doneWg.Wait()
+	return err
+}
+
+// NotifyConfig implements the extension.ConfigWatcher interface.
+func (s *Server) NotifyConfig(_ context.Context, conf *confmap.Conf) error {
+	confBytes, err := json.Marshal(conf.ToStringMap())
+	if err != nil {
+		s.telemetry.Logger.Warn("could not marshal config", zap.Error(err))
+		return err
+	}
+	s.colconf.Store(confBytes)
+	return nil
+}
+
+// Ready implements the extension.PipelineWatcher interface.
+func (s *Server) Ready() error {
+	s.ready.Store(true)
+	return nil
+}
+
+// NotReady implements the extension.PipelineWatcher interface.
+func (s *Server) NotReady() error {
+	s.ready.Store(false)
+	return nil
+}
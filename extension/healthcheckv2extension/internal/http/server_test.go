@@ -0,0 +1,212 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckv2extension/internal/common"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckv2extension/internal/status"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckv2extension/internal/testhelpers"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+)
+
+func newTestServer(
+	t *testing.T,
+	legacyConfig LegacyConfig,
+	componentHealthConfig *common.ComponentHealthConfig,
+) (*Server, *status.Aggregator) {
+	t.Helper()
+	aggregator := status.NewAggregator(testhelpers.ErrPriority(componentHealthConfig))
+	t.Cleanup(aggregator.Close)
+	config := &Config{
+		ServerConfig: confighttp.ServerConfig{Endpoint: testutil.GetAvailableLocalAddress(t)},
+		Status:       PathConfig{Enabled: true, Path: "/status"},
+		Config:       PathConfig{Enabled: true, Path: "/config"},
+	}
+	srv := NewServer(
+		config,
+		legacyConfig,
+		common.NewHealthEvaluator(componentHealthConfig, time.Now()),
+		componenttest.NewNopTelemetrySettings(),
+		aggregator,
+	)
+	return srv, aggregator
+}
+
+func get(t *testing.T, srv *Server, target string) (int, []byte) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	srv.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+	resp := w.Result()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, body
+}
+
+func getStatus(t *testing.T, srv *Server, target string) (int, *serializableStatus) {
+	t.Helper()
+	code, body := get(t, srv, target)
+	if code == http.StatusNotFound {
+		return code, nil
+	}
+	st := &serializableStatus{}
+	require.NoError(t, json.Unmarshal(body, st))
+	return code, st
+}
+
+func TestStatus(t *testing.T) {
+	srv, aggregator := newTestServer(t, LegacyConfig{UseV2: true}, nil)
+	traces := testhelpers.NewPipelineMetadata("traces")
+	metrics := testhelpers.NewPipelineMetadata("metrics")
+
+	code, st := getStatus(t, srv, "/status")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, component.StatusNone.String(), st.StatusString)
+
+	testhelpers.SeedAggregator(aggregator, traces.InstanceIDs(), component.StatusStarting)
+	code, st = getStatus(t, srv, "/status")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, component.StatusStarting.String(), st.StatusString)
+	assert.NotNil(t, st.StartTimestamp)
+
+	testhelpers.SeedAggregator(aggregator, traces.InstanceIDs(), component.StatusOK)
+	testhelpers.SeedAggregator(aggregator, metrics.InstanceIDs(), component.StatusOK)
+	code, st = getStatus(t, srv, "/status")
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, st.Healthy)
+	assert.Empty(t, st.ComponentStatuses)
+
+	code, st = getStatus(t, srv, "/status?verbose")
+	assert.Equal(t, http.StatusOK, code)
+	require.Len(t, st.ComponentStatuses, 2)
+	assert.Len(t, st.ComponentStatuses["pipeline:traces"].ComponentStatuses, 3)
+
+	// errors are healthy by default
+	aggregator.RecordStatus(metrics.ExporterID, component.NewPermanentErrorEvent(assert.AnError))
+	code, st = getStatus(t, srv, "/status?pipeline=metrics&verbose")
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, st.Healthy)
+	assert.Equal(t, component.StatusPermanentError.String(), st.StatusString)
+	assert.Equal(t, assert.AnError.Error(), st.Error)
+	assert.Equal(t, assert.AnError.Error(), st.ComponentStatuses["exporter:metrics/out"].Error)
+
+	code, st = getStatus(t, srv, "/status?pipeline=traces")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, component.StatusOK.String(), st.StatusString)
+
+	code, _ = getStatus(t, srv, "/status?pipeline=logs")
+	assert.Equal(t, http.StatusNotFound, code)
+
+	aggregator.RecordStatus(traces.ReceiverID, component.NewFatalErrorEvent(assert.AnError))
+	code, st = getStatus(t, srv, "/status")
+	assert.Equal(t, http.StatusInternalServerError, code)
+	assert.False(t, st.Healthy)
+}
+
+func TestStatusComponentHealth(t *testing.T) {
+	srv, aggregator := newTestServer(t, LegacyConfig{UseV2: true}, &common.ComponentHealthConfig{
+		IncludePermanent:   true,
+		IncludeRecoverable: true,
+		RecoveryDuration:   time.Millisecond,
+	})
+	traces := testhelpers.NewPipelineMetadata("traces")
+	testhelpers.SeedAggregator(aggregator, traces.InstanceIDs(), component.StatusOK)
+
+	aggregator.RecordStatus(traces.ExporterID, component.NewRecoverableErrorEvent(assert.AnError))
+	assert.Eventually(t, func() bool {
+		code, st := getStatus(t, srv, "/status?pipeline=traces")
+		return code == http.StatusInternalServerError && !st.Healthy
+	}, time.Second, 5*time.Millisecond)
+
+	aggregator.RecordStatus(traces.ExporterID, component.NewStatusEvent(component.StatusOK))
+	code, _ := getStatus(t, srv, "/status?pipeline=traces")
+	assert.Equal(t, http.StatusOK, code)
+
+	aggregator.RecordStatus(traces.ExporterID, component.NewPermanentErrorEvent(assert.AnError))
+	code, st := getStatus(t, srv, "/status?pipeline=traces")
+	assert.Equal(t, http.StatusInternalServerError, code)
+	assert.False(t, st.Healthy)
+}
+
+func TestStatusStartupGracePeriod(t *testing.T) {
+	srv, aggregator := newTestServer(t, LegacyConfig{UseV2: true}, &common.ComponentHealthConfig{
+		IncludePermanent:   true,
+		StartupGracePeriod: time.Hour,
+	})
+	traces := testhelpers.NewPipelineMetadata("traces")
+	testhelpers.SeedAggregator(aggregator, traces.InstanceIDs(), component.StatusOK)
+	aggregator.RecordStatus(traces.ExporterID, component.NewPermanentErrorEvent(assert.AnError))
+
+	code, st := getStatus(t, srv, "/status")
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, st.Healthy)
+}
+
+func TestConfig(t *testing.T) {
+	srv, _ := newTestServer(t, LegacyConfig{UseV2: true}, nil)
+
+	code, _ := get(t, srv, "/config")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+
+	conf := map[string]any{"exporters": map[string]any{"debug": nil}}
+	require.NoError(t, srv.NotifyConfig(context.Background(), confmap.NewFromStringMap(conf)))
+
+	code, body := get(t, srv, "/config")
+	assert.Equal(t, http.StatusOK, code)
+	assert.JSONEq(t, `{"exporters":{"debug":null}}`, string(body))
+}
+
+func TestLegacy(t *testing.T) {
+	srv, _ := newTestServer(t, LegacyConfig{Path: "/"}, nil)
+
+	code, body := get(t, srv, "/")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Contains(t, string(body), "Server not available")
+
+	require.NoError(t, srv.Ready())
+	code, body = get(t, srv, "/")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Contains(t, string(body), "Server available")
+
+	srv, _ = newTestServer(t, LegacyConfig{
+		Path:         "/health",
+		ResponseBody: &ResponseBodyConfig{Healthy: "I'm OK", Unhealthy: "I'm not well"},
+	}, nil)
+	code, body = get(t, srv, "/health")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "I'm not well", string(body))
+
+	require.NoError(t, srv.Ready())
+	require.NoError(t, srv.NotReady())
+	require.NoError(t, srv.Ready())
+	code, body = get(t, srv, "/health")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "I'm OK", string(body))
+}
+
+func TestStartShutdown(t *testing.T) {
+	srv, _ := newTestServer(t, LegacyConfig{UseV2: true}, nil)
+	require.NoError(t, srv.Start(context.Background(), componenttest.NewNopHost()))
+
+	resp, err := http.Get("http://" + srv.serverConfig.Endpoint + "/status")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	require.NoError(t, srv.Shutdown(context.Background()))
+}
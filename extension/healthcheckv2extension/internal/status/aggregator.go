@@ -93,6 +93,7 @@ type Aggregator struct {
 	aggregateStatus *AggregateStatus
 	subscriptions   map[string]*list.List
 	aggregationFunc aggregationFunc
+	closed          bool
 }
 
 // NewAggregator returns a *status.Aggregator.
@@ -168,11 +169,18 @@ func (a *Aggregator) RecordStatus(source *component.InstanceID, event *component
 // It is possible to subscribe to a pipeline that has not yet reported. An initial nil
 // will be sent on the channel and events will start streaming if and when it starts reporting.
 // A `Verbose` verbosity specifies that subtrees should be returned with the *AggregateStatus.
-// To unsubscribe, call the returned UnsubscribeFunc.
+// To unsubscribe, call the returned UnsubscribeFunc. The channel of a subscription made after the
+// aggregator is closed is already closed.
 func (a *Aggregator) Subscribe(scope Scope, verbosity Verbosity) (<-chan *AggregateStatus, UnsubscribeFunc) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
+	if a.closed {
+		statusCh := make(chan *AggregateStatus)
+		close(statusCh)
+		return statusCh, func() {}
+	}
+
 	key := scope.toKey()
 	st := a.aggregateStatus
 	if scope != ScopeAll {
@@ -193,6 +201,9 @@ func (a *Aggregator) Subscribe(scope Scope, verbosity Verbosity) (<-chan *Aggreg
 	el := subList.PushBack(sub)
 
 	unsubFunc := func() {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+
 		subList.Remove(el)
 		if subList.Front() == nil {
 			delete(a.subscriptions, key)
@@ -204,17 +215,23 @@ func (a *Aggregator) Subscribe(scope Scope, verbosity Verbosity) (<-chan *Aggreg
 	return sub.statusCh, unsubFunc
 }
 
-// Close terminates all existing subscriptions.
+// Close terminates all existing subscriptions and the ones made afterwards.
 func (a *Aggregator) Close() {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
+	if a.closed {
+		return
+	}
+	a.closed = true
+
 	for _, subList := range a.subscriptions {
 		for el := subList.Front(); el != nil; el = el.Next() {
 			sub := el.Value.(*subscription)
 			close(sub.statusCh)
 		}
 	}
+	a.subscriptions = make(map[string]*list.List)
 }
 
 func (a *Aggregator) notifySubscribers(scope Scope, status *AggregateStatus) {
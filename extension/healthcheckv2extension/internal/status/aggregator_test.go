@@ -462,6 +462,30 @@ func TestUnsubscribe(t *testing.T) {
 	assertNoEventsRecvd(t, traceEvents, allEvents)
 }
 
+func TestSubscribeAfterClose(t *testing.T) {
+	agg := status.NewAggregator(status.PriorityPermanent)
+	traces := testhelpers.NewPipelineMetadata("traces")
+
+	allEvents, allUnsub := agg.Subscribe(status.ScopeAll, status.Concise)
+	assert.NotNil(t, <-allEvents)
+
+	agg.Close()
+	agg.Close()
+
+	_, ok := <-allEvents
+	assert.False(t, ok)
+
+	traceEvents, traceUnsub := agg.Subscribe(status.Scope(traces.PipelineID.String()), status.Concise)
+	_, ok = <-traceEvents
+	assert.False(t, ok)
+
+	// late events are not sent to the closed subscriptions
+	testhelpers.SeedAggregator(agg, traces.InstanceIDs(), component.StatusOK)
+
+	traceUnsub()
+	allUnsub()
+}
+
 // assertEventMatches ensures one or more events share the expected status and are
 // otherwise equal, ignoring timestamp.
 func assertEventsMatch(
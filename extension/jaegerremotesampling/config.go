@@ -10,12 +10,28 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal"
 )
 
 var (
-	errTooManySources     = errors.New("too many sources specified, has to be either 'file' or 'remote'")
-	errNoSources          = errors.New("no sources specified, has to be either 'file' or 'remote'")
+	errTooManySources     = errors.New("too many sources specified, has to be either 'file', 'remote' or 'adaptive'")
+	errNoSources          = errors.New("no sources specified, has to be either 'file', 'remote' or 'adaptive'")
 	errAtLeastOneProtocol = errors.New("no protocols selected to serve the strategies, use 'grpc', 'http', or both")
+	errInvalidTarget      = errors.New("'target_samples_per_second' must not be negative")
+	errInvalidProbability = errors.New("'initial_sampling_probability' and 'min_sampling_probability' must be between 0 and 1")
+	errNegativeAdaptive   = errors.New("'delta_tolerance', 'min_samples_per_second', 'calculation_interval', 'aggregation_buckets' and 'operation_ttl' must not be negative")
+)
+
+const (
+	defaultTargetSamplesPerSecond     = 1
+	defaultDeltaTolerance             = 0.3
+	defaultInitialSamplingProbability = 0.001
+	defaultMinSamplingProbability     = 1e-5
+	defaultMinSamplesPerSecond        = 1.0 / 60
+	defaultCalculationInterval        = time.Minute
+	defaultAggregationBuckets         = 10
+	defaultOperationTTL               = 24 * time.Hour
 )
 
 // Config has the configuration for the extension enabling the health check
@@ -24,7 +40,7 @@ type Config struct {
 	HTTPServerConfig *confighttp.ServerConfig `mapstructure:"http"`
 	GRPCServerConfig *configgrpc.ServerConfig `mapstructure:"grpc"`
 
-	// Source configures the source for the strategies file. One of `remote` or `file` has to be specified.
+	// Source configures the source for the strategies file. One of `remote`, `file` or `adaptive` has to be specified.
 	Source Source `mapstructure:"source"`
 }
 
@@ -37,6 +53,97 @@ type Source struct {
 
 	// ReloadInterval determines the periodicity to refresh the strategies
 	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+
+	// Adaptive computes the strategies from the observed throughput of the services
+	Adaptive *AdaptiveConfig `mapstructure:"adaptive"`
+}
+
+// AdaptiveConfig configures the adaptive source, which computes the sampling probability of each operation of each
+// service from its observed throughput, so that each operation is sampled at a target number of traces per second.
+// The unset fields take their default value.
+type AdaptiveConfig struct {
+	// TargetSamplesPerSecond is the number of traces to sample per second for each operation. Default is 1.
+	TargetSamplesPerSecond float64 `mapstructure:"target_samples_per_second"`
+
+	// DeltaTolerance is the relative difference between the observed and the target samples per second under which
+	// the probability of an operation is left unchanged. Default is 0.3.
+	DeltaTolerance float64 `mapstructure:"delta_tolerance"`
+
+	// InitialSamplingProbability is the probability of the operations without observed throughput. Default is 0.001.
+	InitialSamplingProbability float64 `mapstructure:"initial_sampling_probability"`
+
+	// MinSamplingProbability is the lowest probability of an operation. Default is 0.00001.
+	MinSamplingProbability float64 `mapstructure:"min_sampling_probability"`
+
+	// MinSamplesPerSecond is the lower bound of traces per second sampled for each operation by the SDKs,
+	// regardless of its probability. Default is 1/60, one trace per minute.
+	MinSamplesPerSecond float64 `mapstructure:"min_samples_per_second"`
+
+	// CalculationInterval determines the periodicity to recalculate the probabilities. Default is 1m.
+	CalculationInterval time.Duration `mapstructure:"calculation_interval"`
+
+	// AggregationBuckets is the number of calculation intervals the throughput is aggregated over. Default is 10.
+	AggregationBuckets int `mapstructure:"aggregation_buckets"`
+
+	// OperationTTL is the duration without throughput after which an operation is forgotten, and sampled with the
+	// initial sampling probability again. Default is 24h.
+	OperationTTL time.Duration `mapstructure:"operation_ttl"`
+
+	// StorageID is the ID of a storage extension the probabilities are persisted to, so that they survive restarts.
+	StorageID *component.ID `mapstructure:"storage"`
+}
+
+func (cfg *AdaptiveConfig) validate() error {
+	if cfg.TargetSamplesPerSecond < 0 {
+		return errInvalidTarget
+	}
+	if cfg.InitialSamplingProbability < 0 || cfg.InitialSamplingProbability > 1 ||
+		cfg.MinSamplingProbability < 0 || cfg.MinSamplingProbability > 1 {
+		return errInvalidProbability
+	}
+	if cfg.DeltaTolerance < 0 || cfg.MinSamplesPerSecond < 0 || cfg.CalculationInterval < 0 || cfg.AggregationBuckets < 0 || cfg.OperationTTL < 0 {
+		return errNegativeAdaptive
+	}
+	return nil
+}
+
+// settings returns the settings of the adaptive strategy store, with the defaults applied to the unset fields
+func (cfg *AdaptiveConfig) settings() internal.AdaptiveSettings {
+	s := internal.AdaptiveSettings{
+		TargetSamplesPerSecond:     cfg.TargetSamplesPerSecond,
+		DeltaTolerance:             cfg.DeltaTolerance,
+		InitialSamplingProbability: cfg.InitialSamplingProbability,
+		MinSamplingProbability:     cfg.MinSamplingProbability,
+		MinSamplesPerSecond:        cfg.MinSamplesPerSecond,
+		CalculationInterval:        cfg.CalculationInterval,
+		AggregationBuckets:         cfg.AggregationBuckets,
+		OperationTTL:               cfg.OperationTTL,
+	}
+	if s.TargetSamplesPerSecond == 0 {
+		s.TargetSamplesPerSecond = defaultTargetSamplesPerSecond
+	}
+	if s.DeltaTolerance == 0 {
+		s.DeltaTolerance = defaultDeltaTolerance
+	}
+	if s.InitialSamplingProbability == 0 {
+		s.InitialSamplingProbability = defaultInitialSamplingProbability
+	}
+	if s.MinSamplingProbability == 0 {
+		s.MinSamplingProbability = defaultMinSamplingProbability
+	}
+	if s.MinSamplesPerSecond == 0 {
+		s.MinSamplesPerSecond = defaultMinSamplesPerSecond
+	}
+	if s.CalculationInterval == 0 {
+		s.CalculationInterval = defaultCalculationInterval
+	}
+	if s.AggregationBuckets == 0 {
+		s.AggregationBuckets = defaultAggregationBuckets
+	}
+	if s.OperationTTL == 0 {
+		s.OperationTTL = defaultOperationTTL
+	}
+	return s
 }
 
 var _ component.Config = (*Config)(nil)
@@ -47,13 +154,28 @@ func (cfg *Config) Validate() error {
 		return errAtLeastOneProtocol
 	}
 
-	if cfg.Source.File != "" && cfg.Source.Remote != nil {
+	sources := 0
+	if cfg.Source.File != "" {
+		sources++
+	}
+	if cfg.Source.Remote != nil {
+		sources++
+	}
+	if cfg.Source.Adaptive != nil {
+		sources++
+	}
+
+	if sources > 1 {
 		return errTooManySources
 	}
 
-	if cfg.Source.File == "" && cfg.Source.Remote == nil {
+	if sources == 0 {
 		return errNoSources
 	}
 
+	if cfg.Source.Adaptive != nil {
+		return cfg.Source.Adaptive.validate()
+	}
+
 	return nil
 }
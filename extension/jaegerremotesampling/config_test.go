@@ -16,12 +16,15 @@ import (
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal/metadata"
 )
 
 func TestLoadConfig(t *testing.T) {
 	t.Parallel()
 
+	fileStorageID := component.MustNewID("file_storage")
+
 	tests := []struct {
 		id       component.ID
 		expected component.Config
@@ -55,6 +58,25 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "2"),
+			expected: &Config{
+				HTTPServerConfig: &confighttp.ServerConfig{Endpoint: "0.0.0.0:5778"},
+				GRPCServerConfig: &configgrpc.ServerConfig{NetAddr: confignet.AddrConfig{
+					Endpoint:  "0.0.0.0:14250",
+					Transport: confignet.TransportTypeTCP,
+				}},
+				Source: Source{
+					Adaptive: &AdaptiveConfig{
+						TargetSamplesPerSecond: 2,
+						CalculationInterval:    30 * time.Second,
+						AggregationBuckets:     5,
+						OperationTTL:           time.Hour,
+						StorageID:              &fileStorageID,
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
@@ -101,6 +123,56 @@ func TestValidate(t *testing.T) {
 			},
 			expected: errTooManySources,
 		},
+		{
+			desc: "too many sources with adaptive",
+			cfg: Config{
+				GRPCServerConfig: &configgrpc.ServerConfig{},
+				Source: Source{
+					File:     "/tmp/some-file",
+					Adaptive: &AdaptiveConfig{},
+				},
+			},
+			expected: errTooManySources,
+		},
+		{
+			desc: "adaptive with defaults",
+			cfg: Config{
+				GRPCServerConfig: &configgrpc.ServerConfig{},
+				Source: Source{
+					Adaptive: &AdaptiveConfig{},
+				},
+			},
+		},
+		{
+			desc: "adaptive with negative target",
+			cfg: Config{
+				GRPCServerConfig: &configgrpc.ServerConfig{},
+				Source: Source{
+					Adaptive: &AdaptiveConfig{TargetSamplesPerSecond: -1},
+				},
+			},
+			expected: errInvalidTarget,
+		},
+		{
+			desc: "adaptive with invalid probability",
+			cfg: Config{
+				GRPCServerConfig: &configgrpc.ServerConfig{},
+				Source: Source{
+					Adaptive: &AdaptiveConfig{InitialSamplingProbability: 1.5},
+				},
+			},
+			expected: errInvalidProbability,
+		},
+		{
+			desc: "adaptive with negative calculation interval",
+			cfg: Config{
+				GRPCServerConfig: &configgrpc.ServerConfig{},
+				Source: Source{
+					Adaptive: &AdaptiveConfig{CalculationInterval: -time.Second},
+				},
+			},
+			expected: errNegativeAdaptive,
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
@@ -109,3 +181,17 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestAdaptiveSettings(t *testing.T) {
+	cfg := &AdaptiveConfig{TargetSamplesPerSecond: 2, AggregationBuckets: 5}
+	assert.Equal(t, internal.AdaptiveSettings{
+		TargetSamplesPerSecond:     2,
+		DeltaTolerance:             defaultDeltaTolerance,
+		InitialSamplingProbability: defaultInitialSamplingProbability,
+		MinSamplingProbability:     defaultMinSamplingProbability,
+		MinSamplesPerSecond:        defaultMinSamplesPerSecond,
+		CalculationInterval:        defaultCalculationInterval,
+		AggregationBuckets:         5,
+		OperationTTL:               defaultOperationTTL,
+	}, cfg.settings())
+}
@@ -11,28 +11,38 @@ import (
 	"github.com/jaegertracing/jaeger/plugin/sampling/strategystore/static"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal"
 )
 
-var _ extension.Extension = (*jrsExtension)(nil)
+var (
+	_ extension.Extension = (*jrsExtension)(nil)
+	_ ThroughputRecorder  = (*jrsExtension)(nil)
+)
 
 type jrsExtension struct {
+	id        component.ID
 	cfg       *Config
 	telemetry component.TelemetrySettings
 
 	httpServer    component.Component
 	grpcServer    component.Component
 	samplingStore strategystore.StrategyStore
+	adaptiveStore *internal.AdaptiveStrategyStore
 
 	closers []func() error
 }
 
-func newExtension(cfg *Config, telemetry component.TelemetrySettings) *jrsExtension {
+func newExtension(cfg *Config, set extension.Settings) *jrsExtension {
 	jrse := &jrsExtension{
+		id:        set.ID,
 		cfg:       cfg,
-		telemetry: telemetry,
+		telemetry: set.TelemetrySettings,
+	}
+	if cfg.Source.Adaptive != nil {
+		jrse.adaptiveStore = internal.NewAdaptiveStrategyStore(cfg.Source.Adaptive.settings(), set.Logger)
 	}
 	return jrse
 }
@@ -42,6 +52,7 @@ func (jrse *jrsExtension) Start(ctx context.Context, host component.Host) error
 	// source of the sampling config:
 	// - remote (gRPC)
 	// - local file
+	// - adaptive
 	// we can then use a simplified logic here to assign the appropriate store
 	if jrse.cfg.Source.File != "" {
 		opts := static.Options{
@@ -73,6 +84,25 @@ func (jrse *jrsExtension) Start(ctx context.Context, host component.Host) error
 		jrse.samplingStore = remoteStore
 	}
 
+	if jrse.adaptiveStore != nil {
+		var client storage.Client
+		if storageID := jrse.cfg.Source.Adaptive.StorageID; storageID != nil {
+			var err error
+			client, err = jrse.storageClient(ctx, host, *storageID)
+			if err != nil {
+				return err
+			}
+		}
+		if err := jrse.adaptiveStore.Start(ctx, client); err != nil {
+			if client != nil {
+				_ = client.Close(ctx)
+			}
+			return fmt.Errorf("failed to start the adaptive strategy store: %w", err)
+		}
+		jrse.closers = append(jrse.closers, jrse.adaptiveStore.Close)
+		jrse.samplingStore = jrse.adaptiveStore
+	}
+
 	if jrse.cfg.HTTPServerConfig != nil {
 		httpServer, err := internal.NewHTTP(jrse.telemetry, *jrse.cfg.HTTPServerConfig, jrse.samplingStore)
 		if err != nil {
@@ -100,6 +130,25 @@ func (jrse *jrsExtension) Start(ctx context.Context, host component.Host) error
 	return nil
 }
 
+// RecordThroughput implements ThroughputRecorder. The throughput is ignored unless the source is adaptive.
+func (jrse *jrsExtension) RecordThroughput(service, operation string, count int64) {
+	if jrse.adaptiveStore != nil {
+		jrse.adaptiveStore.RecordThroughput(service, operation, count)
+	}
+}
+
+func (jrse *jrsExtension) storageClient(ctx context.Context, host component.Host, storageID component.ID) (storage.Client, error) {
+	ext, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+	return storageExt.GetClient(ctx, component.KindExtension, jrse.id, "adaptive")
+}
+
 func (jrse *jrsExtension) Shutdown(ctx context.Context) error {
 	// we probably don't want to break whenever an error occurs, we want to continue and close the other resources
 	if jrse.httpServer != nil {
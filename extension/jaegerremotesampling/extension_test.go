@@ -15,10 +15,12 @@ import (
 	"github.com/jaegertracing/jaeger/proto-gen/api_v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/extension/extensiontest"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 )
@@ -27,7 +29,7 @@ func TestNewExtension(t *testing.T) {
 	// test
 	cfg := testConfig()
 	cfg.Source.File = filepath.Join("testdata", "strategy.json")
-	e := newExtension(cfg, componenttest.NewNopTelemetrySettings())
+	e := newExtension(cfg, extensiontest.NewNopSettings())
 
 	// verify
 	assert.NotNil(t, e)
@@ -38,7 +40,7 @@ func TestStartAndShutdownLocalFile(t *testing.T) {
 	cfg := testConfig()
 	cfg.Source.File = filepath.Join("testdata", "strategy.json")
 
-	e := newExtension(cfg, componenttest.NewNopTelemetrySettings())
+	e := newExtension(cfg, extensiontest.NewNopSettings())
 	require.NotNil(t, e)
 	require.NoError(t, e.Start(context.Background(), componenttest.NewNopHost()))
 
@@ -46,6 +48,40 @@ func TestStartAndShutdownLocalFile(t *testing.T) {
 	assert.NoError(t, e.Shutdown(context.Background()))
 }
 
+func TestStartAndShutdownAdaptive(t *testing.T) {
+	// prepare
+	cfg := testConfig()
+	cfg.Source.Adaptive = &AdaptiveConfig{}
+
+	e := newExtension(cfg, extensiontest.NewNopSettings())
+	require.NotNil(t, e)
+	require.NoError(t, e.Start(context.Background(), componenttest.NewNopHost()))
+
+	// test
+	e.RecordThroughput("foo", "GET /", 10)
+	resp, err := e.samplingStore.GetSamplingStrategy(context.Background(), "foo")
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, api_v2.SamplingStrategyType_PROBABILISTIC, resp.StrategyType)
+	assert.Equal(t, defaultInitialSamplingProbability, resp.OperationSampling.DefaultSamplingProbability)
+	assert.NoError(t, e.Shutdown(context.Background()))
+}
+
+func TestStartAdaptiveMissingStorage(t *testing.T) {
+	// prepare
+	storageID := component.MustNewID("file_storage")
+	cfg := testConfig()
+	cfg.Source.Adaptive = &AdaptiveConfig{StorageID: &storageID}
+
+	e := newExtension(cfg, extensiontest.NewNopSettings())
+	require.NotNil(t, e)
+
+	// test and verify
+	assert.EqualError(t, e.Start(context.Background(), componenttest.NewNopHost()), "storage extension 'file_storage' not found")
+	assert.NoError(t, e.Shutdown(context.Background()))
+}
+
 func TestRemote(t *testing.T) {
 	for _, tc := range []struct {
 		name                          string
@@ -110,7 +146,7 @@ func TestRemote(t *testing.T) {
 			}
 
 			// create the extension
-			e := newExtension(cfg, componenttest.NewNopTelemetrySettings())
+			e := newExtension(cfg, extensiontest.NewNopSettings())
 			require.NotNil(t, e)
 
 			// start the server
@@ -64,5 +64,5 @@ var protoGate = featuregate.GlobalRegistry().MustRegister(
 
 func createExtension(_ context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
 	logDeprecation(set.Logger)
-	return newExtension(cfg.(*Config), set.TelemetrySettings), nil
+	return newExtension(cfg.(*Config), set), nil
 }
@@ -0,0 +1,275 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal"
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jaegertracing/jaeger/proto-gen/api_v2"
+	"github.com/tilinna/clock"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+)
+
+// probabilitiesKey is the storage key the sampling probabilities are persisted under
+const probabilitiesKey = "probabilities"
+
+// maxProbabilityIncrease caps the relative increase of a probability at each calculation, so that a short drop of
+// the throughput doesn't make the probability overshoot. Decreases are not capped.
+const maxProbabilityIncrease = 0.5
+
+// AdaptiveSettings are the settings of the adaptive strategy store, with the defaults applied.
+type AdaptiveSettings struct {
+	TargetSamplesPerSecond     float64
+	DeltaTolerance             float64
+	InitialSamplingProbability float64
+	MinSamplingProbability     float64
+	MinSamplesPerSecond        float64
+	CalculationInterval        time.Duration
+	AggregationBuckets         int
+	OperationTTL               time.Duration
+}
+
+// serviceOperationCounts holds a count of traces per operation per service
+type serviceOperationCounts map[string]map[string]int64
+
+// serviceOperationProbabilities holds a sampling probability per operation per service
+type serviceOperationProbabilities map[string]map[string]float64
+
+// AdaptiveStrategyStore is a StrategyStore computing the sampling probabilities of the operations of each service
+// from their observed throughput, so that each operation is sampled at TargetSamplesPerSecond, the way the
+// adaptive sampling of the Jaeger collector does. The throughput is recorded through RecordThroughput, and
+// aggregated over the last AggregationBuckets calculation intervals. The operations without throughput for
+// OperationTTL are forgotten, and sampled with the initial sampling probability again.
+type AdaptiveStrategyStore struct {
+	settings AdaptiveSettings
+	logger   *zap.Logger
+
+	// mu protects current, buckets, probabilities, idleIntervals and client
+	mu            sync.Mutex
+	current       serviceOperationCounts
+	buckets       []serviceOperationCounts
+	probabilities serviceOperationProbabilities
+	// idleIntervals counts the consecutive calculation intervals without throughput of the operations
+	idleIntervals serviceOperationCounts
+	client        storage.Client
+
+	stopCh chan struct{}
+	doneWg sync.WaitGroup
+}
+
+// NewAdaptiveStrategyStore returns an *AdaptiveStrategyStore. Start must be called for the probabilities to be
+// calculated.
+func NewAdaptiveStrategyStore(settings AdaptiveSettings, logger *zap.Logger) *AdaptiveStrategyStore {
+	return &AdaptiveStrategyStore{
+		settings:      settings,
+		logger:        logger,
+		current:       serviceOperationCounts{},
+		probabilities: serviceOperationProbabilities{},
+		idleIntervals: serviceOperationCounts{},
+		stopCh:        make(chan struct{}),
+	}
+}
+
+// Start loads the probabilities persisted in the storage client, if any, and starts calculating the probabilities
+// every calculation interval. The client is closed by Close.
+func (a *AdaptiveStrategyStore) Start(ctx context.Context, client storage.Client) error {
+	if client != nil {
+		value, err := client.Get(ctx, probabilitiesKey)
+		if err != nil {
+			return err
+		}
+		if value != nil {
+			probabilities := serviceOperationProbabilities{}
+			if err = json.Unmarshal(value, &probabilities); err != nil {
+				a.logger.Warn("Discarding the invalid sampling probabilities found in storage", zap.Error(err))
+			} else {
+				a.probabilities = probabilities
+			}
+		}
+		a.client = client
+	}
+
+	ticker := clock.NewTicker(ctx, a.settings.CalculationInterval)
+	a.doneWg.Add(1)
+	go a.periodicallyCalculate(ticker)
+	return nil
+}
+
+// Close stops the calculation of the probabilities and closes the storage client.
+func (a *AdaptiveStrategyStore) Close() error {
+	close(a.stopCh)
+	a.doneWg.Wait()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.client == nil {
+		return nil
+	}
+	return a.client.Close(context.Background())
+}
+
+// RecordThroughput records count traces sampled for the operation of the service.
+func (a *AdaptiveStrategyStore) RecordThroughput(service, operation string, count int64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	operations, ok := a.current[service]
+	if !ok {
+		operations = map[string]int64{}
+		a.current[service] = operations
+	}
+	operations[operation] += count
+}
+
+// GetSamplingStrategy returns the per operation sampling strategy of the service. The operations without a
+// calculated probability are sampled with the initial sampling probability.
+func (a *AdaptiveStrategyStore) GetSamplingStrategy(_ context.Context, serviceName string) (*api_v2.SamplingStrategyResponse, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	operations := a.probabilities[serviceName]
+	strategies := make([]*api_v2.OperationSamplingStrategy, 0, len(operations))
+	for operation, probability := range operations {
+		strategies = append(strategies, &api_v2.OperationSamplingStrategy{
+			Operation: operation,
+			ProbabilisticSampling: &api_v2.ProbabilisticSamplingStrategy{
+				SamplingRate: probability,
+			},
+		})
+	}
+	sort.Slice(strategies, func(i, j int) bool {
+		return strategies[i].Operation < strategies[j].Operation
+	})
+
+	return &api_v2.SamplingStrategyResponse{
+		StrategyType: api_v2.SamplingStrategyType_PROBABILISTIC,
+		OperationSampling: &api_v2.PerOperationSamplingStrategies{
+			DefaultSamplingProbability:       a.settings.InitialSamplingProbability,
+			DefaultLowerBoundTracesPerSecond: a.settings.MinSamplesPerSecond,
+			PerOperationStrategies:           strategies,
+		},
+	}, nil
+}
+
+func (a *AdaptiveStrategyStore) periodicallyCalculate(ticker *clock.Ticker) {
+	defer a.doneWg.Done()
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			a.calculate()
+		case <-a.stopCh:
+			return
+		}
+	}
+}
+
+// calculate closes the current throughput bucket, updates the probabilities of the operations that were
+// sampled in the aggregated buckets, and expires the operations idle for OperationTTL
+func (a *AdaptiveStrategyStore) calculate() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	latest := a.current
+	a.expire(latest)
+
+	a.buckets = append(a.buckets, latest)
+	if len(a.buckets) > a.settings.AggregationBuckets {
+		a.buckets = a.buckets[len(a.buckets)-a.settings.AggregationBuckets:]
+	}
+	a.current = serviceOperationCounts{}
+
+	totals := serviceOperationCounts{}
+	for _, bucket := range a.buckets {
+		for service, operations := range bucket {
+			if _, ok := totals[service]; !ok {
+				totals[service] = map[string]int64{}
+			}
+			for operation, count := range operations {
+				totals[service][operation] += count
+			}
+		}
+	}
+
+	seconds := float64(len(a.buckets)) * a.settings.CalculationInterval.Seconds()
+	for service, operations := range totals {
+		for operation, count := range operations {
+			probability, ok := a.probabilities[service][operation]
+			if !ok {
+				// an expired operation is only tracked again once it has throughput in the latest bucket
+				if latest[service][operation] == 0 {
+					continue
+				}
+				probability = a.settings.InitialSamplingProbability
+			}
+			if _, ok = a.probabilities[service]; !ok {
+				a.probabilities[service] = map[string]float64{}
+			}
+			a.probabilities[service][operation] = a.newProbability(probability, float64(count)/seconds)
+		}
+	}
+
+	a.persist()
+}
+
+// expire forgets the probabilities of the operations without throughput in the current bucket for OperationTTL
+func (a *AdaptiveStrategyStore) expire(current serviceOperationCounts) {
+	for service, operations := range a.probabilities {
+		for operation := range operations {
+			if current[service][operation] > 0 {
+				delete(a.idleIntervals[service], operation)
+				continue
+			}
+			if _, ok := a.idleIntervals[service]; !ok {
+				a.idleIntervals[service] = map[string]int64{}
+			}
+			a.idleIntervals[service][operation]++
+			if time.Duration(a.idleIntervals[service][operation])*a.settings.CalculationInterval >= a.settings.OperationTTL {
+				delete(operations, operation)
+				delete(a.idleIntervals[service], operation)
+			}
+		}
+		if len(operations) == 0 {
+			delete(a.probabilities, service)
+		}
+		if len(a.idleIntervals[service]) == 0 {
+			delete(a.idleIntervals, service)
+		}
+	}
+}
+
+// newProbability returns the probability bringing the observed samples per second to the target, unless the
+// throughput is already within the tolerance of the target
+func (a *AdaptiveStrategyStore) newProbability(probability, samplesPerSecond float64) float64 {
+	target := a.settings.TargetSamplesPerSecond
+	if math.Abs(samplesPerSecond-target)/target <= a.settings.DeltaTolerance {
+		return probability
+	}
+
+	newProbability := probability * (1 + maxProbabilityIncrease)
+	if samplesPerSecond > 0 {
+		newProbability = math.Min(probability*target/samplesPerSecond, newProbability)
+	}
+	return math.Max(a.settings.MinSamplingProbability, math.Min(1, newProbability))
+}
+
+func (a *AdaptiveStrategyStore) persist() {
+	if a.client == nil {
+		return
+	}
+	value, err := json.Marshal(a.probabilities)
+	if err != nil {
+		a.logger.Warn("Failed to marshal the sampling probabilities", zap.Error(err))
+		return
+	}
+	if err = a.client.Set(context.Background(), probabilitiesKey, value); err != nil {
+		a.logger.Warn("Failed to persist the sampling probabilities", zap.Error(err))
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jaegertracing/jaeger/proto-gen/api_v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tilinna/clock"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+)
+
+var testAdaptiveSettings = AdaptiveSettings{
+	TargetSamplesPerSecond:     1,
+	DeltaTolerance:             0.3,
+	InitialSamplingProbability: 0.001,
+	MinSamplingProbability:     1e-5,
+	MinSamplesPerSecond:        1.0 / 60,
+	CalculationInterval:        time.Minute,
+	AggregationBuckets:         2,
+	OperationTTL:               3 * time.Minute,
+}
+
+// mapClient is an in-memory storage.Client
+type mapClient struct {
+	mu     sync.Mutex
+	values map[string][]byte
+	closed bool
+}
+
+func newMapClient() *mapClient {
+	return &mapClient{values: map[string][]byte{}}
+}
+
+func (m *mapClient) Get(_ context.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.values[key], nil
+}
+
+func (m *mapClient) Set(_ context.Context, key string, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.values[key] = value
+	return nil
+}
+
+func (m *mapClient) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.values, key)
+	return nil
+}
+
+func (m *mapClient) Batch(ctx context.Context, ops ...storage.Operation) error {
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			op.Value, _ = m.Get(ctx, op.Key)
+		case storage.Set:
+			_ = m.Set(ctx, op.Key, op.Value)
+		case storage.Delete:
+			_ = m.Delete(ctx, op.Key)
+		}
+	}
+	return nil
+}
+
+func (m *mapClient) Close(context.Context) error {
+	m.closed = true
+	return nil
+}
+
+func probabilityOf(t *testing.T, store *AdaptiveStrategyStore, service, operation string) float64 {
+	t.Helper()
+	resp, err := store.GetSamplingStrategy(context.Background(), service)
+	require.NoError(t, err)
+	for _, strategy := range resp.OperationSampling.PerOperationStrategies {
+		if strategy.Operation == operation {
+			return strategy.ProbabilisticSampling.SamplingRate
+		}
+	}
+	return resp.OperationSampling.DefaultSamplingProbability
+}
+
+func TestAdaptiveStrategyStore_DefaultStrategy(t *testing.T) {
+	store := NewAdaptiveStrategyStore(testAdaptiveSettings, zap.NewNop())
+
+	resp, err := store.GetSamplingStrategy(context.Background(), "foo")
+	require.NoError(t, err)
+	assert.Equal(t, &api_v2.SamplingStrategyResponse{
+		StrategyType: api_v2.SamplingStrategyType_PROBABILISTIC,
+		OperationSampling: &api_v2.PerOperationSamplingStrategies{
+			DefaultSamplingProbability:       0.001,
+			DefaultLowerBoundTracesPerSecond: 1.0 / 60,
+			PerOperationStrategies:           []*api_v2.OperationSamplingStrategy{},
+		},
+	}, resp)
+}
+
+func TestAdaptiveStrategyStore_Calculate(t *testing.T) {
+	store := NewAdaptiveStrategyStore(testAdaptiveSettings, zap.NewNop())
+
+	// 600 traces per minute is 10 traces per second, the probability is divided by 10
+	store.RecordThroughput("foo", "GET /", 600)
+	// 50 traces per minute is within the tolerance of the target, the probability is left unchanged
+	store.RecordThroughput("foo", "GET /health", 50)
+	// 6 traces per minute is 0.1 traces per second, the increase of the probability is capped
+	store.RecordThroughput("bar", "POST /", 6)
+	store.calculate()
+
+	assert.InDelta(t, 0.0001, probabilityOf(t, store, "foo", "GET /"), 1e-12)
+	assert.InDelta(t, 0.001, probabilityOf(t, store, "foo", "GET /health"), 1e-12)
+	assert.InDelta(t, 0.0015, probabilityOf(t, store, "bar", "POST /"), 1e-12)
+
+	resp, err := store.GetSamplingStrategy(context.Background(), "foo")
+	require.NoError(t, err)
+	require.Len(t, resp.OperationSampling.PerOperationStrategies, 2)
+	assert.Equal(t, "GET /", resp.OperationSampling.PerOperationStrategies[0].Operation)
+	assert.Equal(t, "GET /health", resp.OperationSampling.PerOperationStrategies[1].Operation)
+
+	// the throughput is aggregated over the last two buckets: (600 + 0) / 120s is 5 traces per second
+	store.calculate()
+	assert.InDelta(t, 0.00002, probabilityOf(t, store, "foo", "GET /"), 1e-12)
+
+	// the first bucket is dropped, without throughput the probability is left unchanged
+	store.calculate()
+	assert.InDelta(t, 0.00002, probabilityOf(t, store, "foo", "GET /"), 1e-12)
+}
+
+func TestAdaptiveStrategyStore_OperationTTL(t *testing.T) {
+	store := NewAdaptiveStrategyStore(testAdaptiveSettings, zap.NewNop())
+
+	store.RecordThroughput("foo", "GET /", 600)
+	store.RecordThroughput("foo", "GET /health", 60)
+	store.calculate()
+	for i := 0; i < 2; i++ {
+		store.RecordThroughput("foo", "GET /health", 60)
+		store.calculate()
+	}
+	assert.Contains(t, store.probabilities["foo"], "GET /")
+
+	// the operation is forgotten after three calculation intervals without throughput
+	store.RecordThroughput("foo", "GET /health", 60)
+	store.calculate()
+	assert.NotContains(t, store.probabilities["foo"], "GET /")
+	assert.Contains(t, store.probabilities["foo"], "GET /health")
+	assert.InDelta(t, 0.001, probabilityOf(t, store, "foo", "GET /"), 1e-12)
+
+	// the service is forgotten along with its last operation
+	for i := 0; i < 3; i++ {
+		store.calculate()
+	}
+	assert.Empty(t, store.probabilities)
+	assert.Empty(t, store.idleIntervals)
+
+	// an expired operation is tracked again once it has throughput
+	store.RecordThroughput("foo", "GET /", 60)
+	store.calculate()
+	assert.Contains(t, store.probabilities["foo"], "GET /")
+}
+
+func TestAdaptiveStrategyStore_ProbabilityBounds(t *testing.T) {
+	settings := testAdaptiveSettings
+	// the operations are kept for the duration of the test
+	settings.OperationTTL = time.Hour
+	store := NewAdaptiveStrategyStore(settings, zap.NewNop())
+
+	store.RecordThroughput("foo", "flood", 60_000_000)
+	store.RecordThroughput("foo", "rare", 1)
+	for i := 0; i < 30; i++ {
+		store.calculate()
+	}
+
+	assert.InDelta(t, 1e-5, probabilityOf(t, store, "foo", "flood"), 1e-12)
+
+	for i := 0; i < 30; i++ {
+		store.RecordThroughput("foo", "rare", 1)
+		store.calculate()
+	}
+	assert.InDelta(t, 1, probabilityOf(t, store, "foo", "rare"), 1e-12)
+}
+
+func TestAdaptiveStrategyStore_Persistence(t *testing.T) {
+	client := newMapClient()
+	store := NewAdaptiveStrategyStore(testAdaptiveSettings, zap.NewNop())
+	require.NoError(t, store.Start(context.Background(), client))
+
+	store.RecordThroughput("foo", "GET /", 600)
+	store.calculate()
+	require.NoError(t, store.Close())
+	assert.True(t, client.closed)
+	assert.NotEmpty(t, client.values[probabilitiesKey])
+
+	// the probabilities are loaded from the storage on start
+	store = NewAdaptiveStrategyStore(testAdaptiveSettings, zap.NewNop())
+	require.NoError(t, store.Start(context.Background(), client))
+	defer func() {
+		assert.NoError(t, store.Close())
+	}()
+	assert.InDelta(t, 0.0001, probabilityOf(t, store, "foo", "GET /"), 1e-12)
+}
+
+func TestAdaptiveStrategyStore_PeriodicCalculation(t *testing.T) {
+	startTime := time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)
+	mock := clock.NewMock(startTime)
+	ctx := clock.Context(context.Background(), mock)
+
+	store := NewAdaptiveStrategyStore(testAdaptiveSettings, zap.NewNop())
+	require.NoError(t, store.Start(ctx, nil))
+	defer func() {
+		assert.NoError(t, store.Close())
+	}()
+
+	store.RecordThroughput("foo", "GET /", 600)
+	mock.Add(time.Minute)
+
+	assert.Eventually(t, func() bool {
+		return probabilityOf(t, store, "foo", "GET /") < 0.001
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jaegerremotesampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling"
+
+import "go.opentelemetry.io/collector/pdata/ptrace"
+
+const serviceNameAttribute = "service.name"
+
+// ThroughputRecorder is implemented by the extension. When its source is adaptive, the sampling probabilities are
+// computed from the throughput recorded through it, which the samplingthroughput processor feeds by looking the
+// extension up in the host extensions.
+type ThroughputRecorder interface {
+	// RecordThroughput records count traces sampled for the operation of the service.
+	RecordThroughput(service, operation string, count int64)
+}
+
+// RecordTraces records the throughput of the root spans of td, the operation of a trace being the name of its root
+// span and its service the service.name of the resource of the root span.
+func RecordTraces(recorder ThroughputRecorder, td ptrace.Traces) {
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		serviceName, ok := rs.Resource().Attributes().Get(serviceNameAttribute)
+		if !ok {
+			continue
+		}
+		counts := map[string]int64{}
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			spans := rs.ScopeSpans().At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				if span := spans.At(k); span.ParentSpanID().IsEmpty() {
+					counts[span.Name()]++
+				}
+			}
+		}
+		for operation, count := range counts {
+			recorder.RecordThroughput(serviceName.Str(), operation, count)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jaegerremotesampling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+type mapRecorder map[string]map[string]int64
+
+func (m mapRecorder) RecordThroughput(service, operation string, count int64) {
+	if _, ok := m[service]; !ok {
+		m[service] = map[string]int64{}
+	}
+	m[service][operation] += count
+}
+
+func TestRecordTraces(t *testing.T) {
+	td := ptrace.NewTraces()
+
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "foo")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	spans.AppendEmpty().SetName("GET /")
+	spans.AppendEmpty().SetName("GET /")
+	spans.AppendEmpty().SetName("POST /")
+	// child spans are not counted
+	child := spans.AppendEmpty()
+	child.SetName("SELECT")
+	child.SetParentSpanID(pcommon.SpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+
+	rs = td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "bar")
+	rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("GET /")
+
+	// spans without service are not counted
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("GET /")
+
+	recorder := mapRecorder{}
+	RecordTraces(recorder, td)
+	assert.Equal(t, mapRecorder{
+		"foo": {"GET /": 2, "POST /": 1},
+		"bar": {"GET /": 1},
+	}, recorder)
+}
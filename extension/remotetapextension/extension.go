@@ -25,7 +25,7 @@ type remoteObserverExtension struct {
 
 func (s *remoteObserverExtension) Start(ctx context.Context, host component.Host) error {
 
-	htmlContent, err := fs.Sub(httpFS, "html")
+	htmlContent, err := fs.Sub(httpFS, "http")
 	if err != nil {
 		return err
 	}
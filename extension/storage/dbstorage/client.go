@@ -8,91 +8,149 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	// Postgres driver
 	_ "github.com/jackc/pgx/v5/stdlib"
 	// SQLite driver
 	_ "github.com/mattn/go-sqlite3"
 	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage/internal/metadata"
 )
 
 const (
-	createTable     = "create table if not exists %s (key text primary key, value blob)"
-	getQueryText    = "select value from %s where key=?"
-	setQueryText    = "insert into %s(key, value) values(?,?) on conflict(key) do update set value=?"
+	getQueryText    = "select value from %s where key=? and (expires_at is null or expires_at > ?)"
+	setQueryText    = "insert into %s(key, value, updated_at, expires_at) values(?,?,?,?) on conflict(key) do update set value=excluded.value, updated_at=excluded.updated_at, expires_at=excluded.expires_at"
 	deleteQueryText = "delete from %s where key=?"
+	expireQueryText = "delete from %s where expires_at is not null and expires_at <= ?"
+	sizeQueryText   = "select count(*), coalesce(sum(length(value)), 0) from %s"
+	// evictQueryText deletes the least recently set keys, which exceed the maximum size when
+	// the sizes of all keys are summed up starting from the most recently set one
+	evictQueryText = "delete from %[1]s where key in (select key from (select key, sum(length(value)) over (order by updated_at desc, key desc) as total from %[1]s) as sized where total > ?)"
 )
 
+type clientOptions struct {
+	dialect   dialect
+	ttl       time.Duration
+	maxSize   int64
+	cleanup   time.Duration
+	telemetry *metadata.TelemetryBuilder
+	logger    *zap.Logger
+}
+
 type dbStorageClient struct {
 	db          *sql.DB
+	ttl         time.Duration
+	maxSize     int64
 	getQuery    *sql.Stmt
 	setQuery    *sql.Stmt
 	deleteQuery *sql.Stmt
+	expireQuery *sql.Stmt
+	sizeQuery   *sql.Stmt
+	evictQuery  *sql.Stmt
+
+	logger    *zap.Logger
+	telemetry *metadata.TelemetryBuilder
+	attrs     metric.MeasurementOption
+	// last reported table entries and size, to report changes
+	entries, size int64
+
+	// sizeMu guards sizeBound, an upper bound of the table size: the last measured size plus the sizes
+	// of the values set since then. The table is only measured and evicted when it exceeds the max size.
+	sizeMu    sync.Mutex
+	sizeBound int64
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
 }
 
-func newClient(ctx context.Context, db *sql.DB, tableName string) (*dbStorageClient, error) {
-	var err error
-	_, err = db.ExecContext(ctx, fmt.Sprintf(createTable, tableName))
-	if err != nil {
+// now is the current time, overridden in tests
+var now = time.Now
+
+func newClient(ctx context.Context, db *sql.DB, tableName string, opts clientOptions) (*dbStorageClient, error) {
+	if err := migrate(ctx, db, opts.dialect, tableName); err != nil {
 		return nil, err
 	}
 
-	selectQuery, err := db.PrepareContext(ctx, fmt.Sprintf(getQueryText, tableName))
-	if err != nil {
-		return nil, err
+	c := &dbStorageClient{
+		db:        db,
+		ttl:       opts.ttl,
+		maxSize:   opts.maxSize,
+		logger:    opts.logger,
+		telemetry: opts.telemetry,
+		attrs:     metric.WithAttributes(attribute.String("table", tableName)),
 	}
-	setQuery, err := db.PrepareContext(ctx, fmt.Sprintf(setQueryText, tableName))
-	if err != nil {
-		return nil, err
+	queries := []struct {
+		stmt **sql.Stmt
+		text string
+	}{
+		{&c.getQuery, getQueryText},
+		{&c.setQuery, setQueryText},
+		{&c.deleteQuery, deleteQueryText},
+		{&c.expireQuery, expireQueryText},
+		{&c.sizeQuery, sizeQueryText},
+		{&c.evictQuery, evictQueryText},
 	}
-	deleteQuery, err := db.PrepareContext(ctx, fmt.Sprintf(deleteQueryText, tableName))
-	if err != nil {
-		return nil, err
+	for _, q := range queries {
+		stmt, err := db.PrepareContext(ctx, opts.dialect.rebind(fmt.Sprintf(q.text, tableName)))
+		if err != nil {
+			_ = c.closeQueries()
+			return nil, err
+		}
+		*q.stmt = stmt
 	}
-	return &dbStorageClient{db, selectQuery, setQuery, deleteQuery}, nil
+
+	loopCtx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.cleanup(ctx)
+	c.wg.Add(1)
+	go c.cleanupLoop(loopCtx, opts.cleanup)
+	return c, nil
 }
 
 // Get will retrieve data from storage that corresponds to the specified key
 func (c *dbStorageClient) Get(ctx context.Context, key string) ([]byte, error) {
-	rows, err := c.getQuery.QueryContext(ctx, key)
-	if err != nil {
+	op := storage.GetOperation(key)
+	if err := c.Batch(ctx, op); err != nil {
 		return nil, err
 	}
-	if !rows.Next() {
-		return nil, nil
-	}
-	var result []byte
-	err = rows.Scan(&result)
-	if err != nil {
-		return result, err
-	}
-	err = rows.Close()
-	return result, err
+	return op.Value, nil
 }
 
 // Set will store data. The data can be retrieved using the same key
 func (c *dbStorageClient) Set(ctx context.Context, key string, value []byte) error {
-	_, err := c.setQuery.ExecContext(ctx, key, value, value)
-	return err
+	return c.Batch(ctx, storage.SetOperation(key, value))
 }
 
 // Delete will delete data associated with the specified key
 func (c *dbStorageClient) Delete(ctx context.Context, key string) error {
-	_, err := c.deleteQuery.ExecContext(ctx, key)
-	return err
+	return c.Batch(ctx, storage.DeleteOperation(key))
 }
 
 // Batch executes the specified operations in order. Get operation results are updated in place
 func (c *dbStorageClient) Batch(ctx context.Context, ops ...storage.Operation) error {
+	ts := now()
+	var expiresAt sql.NullInt64
+	if c.ttl > 0 {
+		expiresAt = sql.NullInt64{Int64: ts.Add(c.ttl).UnixNano(), Valid: true}
+	}
+
 	var err error
+	var written int64
 	for _, op := range ops {
 		switch op.Type {
 		case storage.Get:
-			op.Value, err = c.Get(ctx, op.Key)
+			op.Value, err = c.get(ctx, op.Key, ts)
 		case storage.Set:
-			err = c.Set(ctx, op.Key, op.Value)
+			_, err = c.setQuery.ExecContext(ctx, op.Key, op.Value, ts.UnixNano(), expiresAt)
+			written += int64(len(op.Value))
 		case storage.Delete:
-			err = c.Delete(ctx, op.Key)
+			_, err = c.deleteQuery.ExecContext(ctx, op.Key)
 		default:
 			return errors.New("wrong operation type")
 		}
@@ -101,16 +159,118 @@ func (c *dbStorageClient) Batch(ctx context.Context, ops ...storage.Operation) e
 			return err
 		}
 	}
-	return err
+
+	if written > 0 && c.maxSize > 0 {
+		return c.evict(ctx, written)
+	}
+	return nil
 }
 
-// Close will close the database
-func (c *dbStorageClient) Close(_ context.Context) error {
-	if err := c.setQuery.Close(); err != nil {
+func (c *dbStorageClient) get(ctx context.Context, key string, ts time.Time) ([]byte, error) {
+	var result []byte
+	err := c.getQuery.QueryRowContext(ctx, key, ts.UnixNano()).Scan(&result)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return result, err
+}
+
+// evict removes the least recently set keys while the table exceeds its maximum size. The table is
+// only measured once the written bytes may have made it exceed the maximum size.
+func (c *dbStorageClient) evict(ctx context.Context, written int64) error {
+	c.sizeMu.Lock()
+	defer c.sizeMu.Unlock()
+	c.sizeBound += written
+	if c.sizeBound <= c.maxSize {
+		return nil
+	}
+
+	size, err := c.measure(ctx)
+	if err != nil || size <= c.maxSize {
 		return err
 	}
-	if err := c.deleteQuery.Close(); err != nil {
+	res, err := c.evictQuery.ExecContext(ctx, c.maxSize)
+	if err != nil {
 		return err
 	}
-	return c.getQuery.Close()
+	if n, err := res.RowsAffected(); err == nil && n > 0 {
+		c.telemetry.DbstorageEntriesEvicted.Add(ctx, n, c.attrs)
+	}
+	_, err = c.measure(ctx)
+	return err
+}
+
+// measure returns the size of the table and resets the size bound to it. The size lock must be held.
+func (c *dbStorageClient) measure(ctx context.Context) (int64, error) {
+	var entries, size int64
+	if err := c.sizeQuery.QueryRowContext(ctx).Scan(&entries, &size); err != nil {
+		return 0, err
+	}
+	c.sizeBound = size
+	return size, nil
+}
+
+func (c *dbStorageClient) cleanupLoop(ctx context.Context, interval time.Duration) {
+	defer c.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.cleanup(ctx)
+		}
+	}
+}
+
+// cleanup removes expired keys and reports the table size
+func (c *dbStorageClient) cleanup(ctx context.Context) {
+	if c.ttl > 0 {
+		res, err := c.expireQuery.ExecContext(ctx, now().UnixNano())
+		if err != nil {
+			c.logger.Warn("failed to remove expired keys", zap.Error(err))
+		} else if n, err := res.RowsAffected(); err == nil && n > 0 {
+			c.telemetry.DbstorageEntriesExpired.Add(ctx, n, c.attrs)
+		}
+	}
+
+	var entries, size int64
+	c.sizeMu.Lock()
+	err := c.sizeQuery.QueryRowContext(ctx).Scan(&entries, &size)
+	if err == nil {
+		c.sizeBound = size
+	}
+	c.sizeMu.Unlock()
+	if err != nil {
+		c.logger.Warn("failed to measure table size", zap.Error(err))
+		return
+	}
+	c.report(ctx, entries, size)
+}
+
+// report records the changes since the last reported table entries and size
+func (c *dbStorageClient) report(ctx context.Context, entries, size int64) {
+	c.telemetry.DbstorageTableEntries.Add(ctx, entries-c.entries, c.attrs)
+	c.telemetry.DbstorageTableSize.Add(ctx, size-c.size, c.attrs)
+	c.entries, c.size = entries, size
+}
+
+// Close will close the database
+func (c *dbStorageClient) Close(_ context.Context) error {
+	c.cancel()
+	c.wg.Wait()
+	// the table is no longer reported by this client
+	c.report(context.Background(), 0, 0)
+	return c.closeQueries()
+}
+
+func (c *dbStorageClient) closeQueries() error {
+	var errs []error
+	for _, stmt := range []*sql.Stmt{c.getQuery, c.setQuery, c.deleteQuery, c.expireQuery, c.sizeQuery, c.evictQuery} {
+		if stmt != nil {
+			errs = append(errs, stmt.Close())
+		}
+	}
+	return errors.Join(errs...)
 }
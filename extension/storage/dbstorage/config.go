@@ -5,12 +5,22 @@ package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-c
 
 import (
 	"errors"
+	"time"
 )
 
 // Config defines configuration for dbstorage extension.
 type Config struct {
 	DriverName string `mapstructure:"driver,omitempty"`
 	DataSource string `mapstructure:"datasource,omitempty"`
+
+	// TTL specifies the duration after which a key expires, counted from when it was last set.
+	// Zero disables expiry.
+	TTL time.Duration `mapstructure:"ttl,omitempty"`
+	// MaxSizeBytes specifies the maximum total size of the values stored by each component.
+	// When exceeded, the least recently set keys are evicted. Zero disables the limit.
+	MaxSizeBytes int64 `mapstructure:"max_size_bytes,omitempty"`
+	// CleanupInterval specifies the frequency at which expired keys are removed and table sizes are reported
+	CleanupInterval time.Duration `mapstructure:"cleanup_interval,omitempty"`
 }
 
 func (cfg *Config) Validate() error {
@@ -20,6 +30,15 @@ func (cfg *Config) Validate() error {
 	if cfg.DriverName == "" {
 		return errors.New("missing driver name")
 	}
+	if cfg.TTL < 0 {
+		return errors.New("ttl cannot be negative")
+	}
+	if cfg.MaxSizeBytes < 0 {
+		return errors.New("max size cannot be negative")
+	}
+	if cfg.CleanupInterval <= 0 {
+		return errors.New("cleanup interval must be positive")
+	}
 
 	return nil
 }
@@ -6,6 +6,7 @@ package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-c
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -27,8 +28,28 @@ func TestConfigValidate(t *testing.T) {
 			errors.New("missing datasource"),
 		},
 		{
-			"valid",
+			"Negative ttl",
+			Config{DriverName: "foo", DataSource: "bar", TTL: -time.Second, CleanupInterval: time.Minute},
+			errors.New("ttl cannot be negative"),
+		},
+		{
+			"Negative max size",
+			Config{DriverName: "foo", DataSource: "bar", MaxSizeBytes: -1, CleanupInterval: time.Minute},
+			errors.New("max size cannot be negative"),
+		},
+		{
+			"Missing cleanup interval",
 			Config{DriverName: "foo", DataSource: "bar"},
+			errors.New("cleanup interval must be positive"),
+		},
+		{
+			"valid",
+			Config{DriverName: "foo", DataSource: "bar", CleanupInterval: time.Minute},
+			nil,
+		},
+		{
+			"valid with ttl and max size",
+			Config{DriverName: "foo", DataSource: "bar", TTL: time.Hour, MaxSizeBytes: 1024, CleanupInterval: time.Minute},
 			nil,
 		},
 	}
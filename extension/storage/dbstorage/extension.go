@@ -8,11 +8,14 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
 	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage/internal/metadata"
 )
 
 type databaseStorage struct {
@@ -20,16 +23,30 @@ type databaseStorage struct {
 	datasourceName string
 	logger         *zap.Logger
 	db             *sql.DB
+	dialect        dialect
+	ttl            time.Duration
+	maxSize        int64
+	cleanup        time.Duration
+	telemetry      *metadata.TelemetryBuilder
 }
 
 // Ensure this storage extension implements the appropriate interface
 var _ storage.Extension = (*databaseStorage)(nil)
 
-func newDBStorage(logger *zap.Logger, config *Config) (extension.Extension, error) {
+func newDBStorage(set component.TelemetrySettings, config *Config) (extension.Extension, error) {
+	telemetry, err := metadata.NewTelemetryBuilder(set)
+	if err != nil {
+		return nil, err
+	}
 	return &databaseStorage{
 		driverName:     config.DriverName,
 		datasourceName: config.DataSource,
-		logger:         logger,
+		logger:         set.Logger,
+		dialect:        newDialect(config.DriverName),
+		ttl:            config.TTL,
+		maxSize:        config.MaxSizeBytes,
+		cleanup:        config.CleanupInterval,
+		telemetry:      telemetry,
 	}, nil
 }
 
@@ -64,7 +81,14 @@ func (ds *databaseStorage) GetClient(ctx context.Context, kind component.Kind, e
 		fullName = fmt.Sprintf("%s_%s_%s_%s", kindString(kind), ent.Type(), ent.Name(), name)
 	}
 	fullName = strings.ReplaceAll(fullName, " ", "")
-	return newClient(ctx, ds.db, fullName)
+	return newClient(ctx, ds.db, fullName, clientOptions{
+		dialect:   ds.dialect,
+		ttl:       ds.ttl,
+		maxSize:   ds.maxSize,
+		cleanup:   ds.cleanup,
+		telemetry: ds.telemetry,
+		logger:    ds.logger,
+	})
 }
 
 func kindString(k component.Kind) string {
@@ -5,9 +5,11 @@ package dbstorage
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -15,6 +17,12 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage/internal/metadata"
 )
 
 func TestExtensionIntegrity(t *testing.T) {
@@ -115,3 +123,226 @@ func newTestExtension(t *testing.T) storage.Extension {
 func newTestEntity(name string) component.ID {
 	return component.MustNewIDWithName("nop", name)
 }
+
+func newTestClient(t *testing.T, db *sql.DB, opts clientOptions) *dbStorageClient {
+	if opts.telemetry == nil {
+		telemetry, err := metadata.NewTelemetryBuilder(componenttest.NewNopTelemetrySettings())
+		require.NoError(t, err)
+		opts.telemetry = telemetry
+	}
+	if opts.cleanup == 0 {
+		opts.cleanup = time.Hour
+	}
+	opts.dialect = newDialect("sqlite3")
+	opts.logger = zap.NewNop()
+
+	client, err := newClient(context.Background(), db, "test_table", opts)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(context.Background()))
+	})
+	return client
+}
+
+func newTestDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s/foo.db?_busy_timeout=10000&_journal=WAL&_sync=NORMAL", t.TempDir()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+	return db
+}
+
+func setNow(t *testing.T, ts time.Time) {
+	now = func() time.Time { return ts }
+	t.Cleanup(func() {
+		now = time.Now
+	})
+}
+
+func TestClientTTL(t *testing.T) {
+	ctx := context.Background()
+	start := time.Unix(1000, 0)
+	setNow(t, start)
+
+	client := newTestClient(t, newTestDB(t), clientOptions{ttl: time.Minute})
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+
+	setNow(t, start.Add(30*time.Second))
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+
+	// setting a key again extends its expiry
+	require.NoError(t, client.Set(ctx, "key", []byte("updated")))
+	require.NoError(t, client.Set(ctx, "other", []byte("value")))
+
+	setNow(t, start.Add(80*time.Second))
+	value, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("updated"), value)
+
+	setNow(t, start.Add(2*time.Minute))
+	value, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	client.cleanup(ctx)
+	var count int
+	require.NoError(t, client.db.QueryRowContext(ctx, "select count(*) from test_table").Scan(&count))
+	require.Equal(t, 0, count)
+}
+
+func TestClientMaxSize(t *testing.T) {
+	ctx := context.Background()
+	start := time.Unix(1000, 0)
+	client := newTestClient(t, newTestDB(t), clientOptions{maxSize: 25})
+
+	for i := 0; i < 5; i++ {
+		setNow(t, start.Add(time.Duration(i)*time.Second))
+		require.NoError(t, client.Set(ctx, fmt.Sprintf("key%d", i), []byte("0123456789")))
+	}
+
+	// only the two most recently set keys fit
+	for i := 0; i < 5; i++ {
+		value, err := client.Get(ctx, fmt.Sprintf("key%d", i))
+		require.NoError(t, err)
+		if i < 3 {
+			require.Nil(t, value)
+		} else {
+			require.Equal(t, []byte("0123456789"), value)
+		}
+	}
+
+	// setting an existing key makes it the most recent
+	setNow(t, start.Add(10*time.Second))
+	require.NoError(t, client.Set(ctx, "key3", []byte("0123456789")))
+	require.NoError(t, client.Set(ctx, "key5", []byte("0123456789")))
+	value, err := client.Get(ctx, "key4")
+	require.NoError(t, err)
+	require.Nil(t, value)
+	value, err = client.Get(ctx, "key3")
+	require.NoError(t, err)
+	require.Equal(t, []byte("0123456789"), value)
+}
+
+func TestClientMaxSizeBound(t *testing.T) {
+	ctx := context.Background()
+	start := time.Unix(1000, 0)
+	setNow(t, start)
+	client := newTestClient(t, newTestDB(t), clientOptions{maxSize: 25})
+
+	// the table is not measured while the values set stay under the max size
+	require.NoError(t, client.Set(ctx, "key1", []byte("0123456789")))
+	require.NoError(t, client.Set(ctx, "key2", []byte("0123456789")))
+	require.Equal(t, int64(20), client.sizeBound)
+
+	// overwriting a key exceeds the bound, but not the measured size
+	require.NoError(t, client.Set(ctx, "key2", []byte("0123456789")))
+	require.Equal(t, int64(20), client.sizeBound)
+
+	// the cleanup accounts for the deleted keys
+	require.NoError(t, client.Delete(ctx, "key1"))
+	client.cleanup(ctx)
+	require.Equal(t, int64(10), client.sizeBound)
+
+	setNow(t, start.Add(time.Second))
+	require.NoError(t, client.Set(ctx, "key3", []byte("0123456789")))
+	setNow(t, start.Add(2*time.Second))
+	require.NoError(t, client.Set(ctx, "key4", []byte("0123456789")))
+	require.Equal(t, int64(20), client.sizeBound)
+	value, err := client.Get(ctx, "key2")
+	require.NoError(t, err)
+	require.Nil(t, value)
+}
+
+func TestClientMigrateLegacyTable(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	// tables created before schema versioning only have a key and a value
+	_, err := db.ExecContext(ctx, "create table test_table (key text primary key, value blob)")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "insert into test_table(key, value) values(?, ?)", "key", []byte("value"))
+	require.NoError(t, err)
+
+	client := newTestClient(t, db, clientOptions{ttl: time.Hour})
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+
+	var version int
+	require.NoError(t, db.QueryRowContext(ctx, "select version from dbstorage_schema where table_name=?", "test_table").Scan(&version))
+	require.Equal(t, len(migrations), version)
+
+	// migrating again is a no-op
+	require.NoError(t, migrate(ctx, db, newDialect("sqlite3"), "test_table"))
+}
+
+func TestClientMigrateNewerSchema(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	require.NoError(t, migrate(ctx, db, newDialect("sqlite3"), "test_table"))
+	_, err := db.ExecContext(ctx, "update dbstorage_schema set version=? where table_name=?", len(migrations)+1, "test_table")
+	require.NoError(t, err)
+
+	err = migrate(ctx, db, newDialect("sqlite3"), "test_table")
+	require.ErrorContains(t, err, "newer than the supported version")
+}
+
+func TestClientMetrics(t *testing.T) {
+	ctx := context.Background()
+	reader := sdkmetric.NewManualReader()
+	set := componenttest.NewNopTelemetrySettings()
+	set.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	telemetry, err := metadata.NewTelemetryBuilder(set)
+	require.NoError(t, err)
+
+	start := time.Unix(1000, 0)
+	setNow(t, start)
+	client := newTestClient(t, newTestDB(t), clientOptions{ttl: time.Minute, maxSize: 10, telemetry: telemetry})
+
+	require.NoError(t, client.Set(ctx, "key1", []byte("0123456789")))
+	setNow(t, start.Add(time.Second))
+	require.NoError(t, client.Set(ctx, "key2", []byte("01234")))
+	client.cleanup(ctx)
+	require.Equal(t, map[string]int64{
+		"dbstorage_entries_evicted": 1,
+		"dbstorage_table_entries":   1,
+		"dbstorage_table_size":      5,
+	}, collectMetrics(t, reader))
+
+	setNow(t, start.Add(time.Hour))
+	client.cleanup(ctx)
+	require.Equal(t, map[string]int64{
+		"dbstorage_entries_evicted": 1,
+		"dbstorage_entries_expired": 1,
+		"dbstorage_table_entries":   0,
+		"dbstorage_table_size":      0,
+	}, collectMetrics(t, reader))
+}
+
+// collectMetrics returns the value of each metric reported for the test table
+func collectMetrics(t *testing.T, reader sdkmetric.Reader) map[string]int64 {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	values := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			require.True(t, ok, m.Name)
+			for _, dp := range sum.DataPoints {
+				table, _ := dp.Attributes.Value(attribute.Key("table"))
+				require.Equal(t, "test_table", table.AsString())
+				values[m.Name] = dp.Value
+			}
+		}
+	}
+	return values
+}
+
+func TestDialectRebind(t *testing.T) {
+	query := "select value from t where key=? and expires_at > ?"
+	assert.Equal(t, query, newDialect("sqlite3").rebind(query))
+	assert.Equal(t, "select value from t where key=$1 and expires_at > $2", newDialect("pgx").rebind(query))
+}
@@ -7,6 +7,7 @@ package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-c
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
@@ -24,8 +25,12 @@ func NewFactory() extension.Factory {
 	)
 }
 
+const defaultCleanupInterval = time.Minute
+
 func createDefaultConfig() component.Config {
-	return &Config{}
+	return &Config{
+		CleanupInterval: defaultCleanupInterval,
+	}
 }
 
 func createExtension(
@@ -33,5 +38,5 @@ func createExtension(
 	params extension.Settings,
 	cfg component.Config,
 ) (extension.Extension, error) {
-	return newDBStorage(params.Logger, cfg.(*Config))
+	return newDBStorage(params.TelemetrySettings, cfg.(*Config))
 }
@@ -3,9 +3,14 @@
 package metadata
 
 import (
-	"go.opentelemetry.io/collector/component"
+	"errors"
+
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
 	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtelemetry"
 )
 
 func Meter(settings component.TelemetrySettings) metric.Meter {
@@ -15,3 +20,64 @@ func Meter(settings component.TelemetrySettings) metric.Meter {
 func Tracer(settings component.TelemetrySettings) trace.Tracer {
 	return settings.TracerProvider.Tracer("otelcol/dbstorage")
 }
+
+// TelemetryBuilder provides an interface for components to report telemetry
+// as defined in metadata and user config.
+type TelemetryBuilder struct {
+	meter                   metric.Meter
+	DbstorageEntriesEvicted metric.Int64Counter
+	DbstorageEntriesExpired metric.Int64Counter
+	DbstorageTableEntries   metric.Int64UpDownCounter
+	DbstorageTableSize      metric.Int64UpDownCounter
+	level                   configtelemetry.Level
+}
+
+// telemetryBuilderOption applies changes to default builder.
+type telemetryBuilderOption func(*TelemetryBuilder)
+
+// WithLevel sets the current telemetry level for the component.
+func WithLevel(lvl configtelemetry.Level) telemetryBuilderOption {
+	return func(builder *TelemetryBuilder) {
+		builder.level = lvl
+	}
+}
+
+// NewTelemetryBuilder provides a struct with methods to update all internal telemetry
+// for a component
+func NewTelemetryBuilder(settings component.TelemetrySettings, options ...telemetryBuilderOption) (*TelemetryBuilder, error) {
+	builder := TelemetryBuilder{level: configtelemetry.LevelBasic}
+	for _, op := range options {
+		op(&builder)
+	}
+	var err, errs error
+	if builder.level >= configtelemetry.LevelBasic {
+		builder.meter = Meter(settings)
+	} else {
+		builder.meter = noop.Meter{}
+	}
+	builder.DbstorageEntriesEvicted, err = builder.meter.Int64Counter(
+		"dbstorage_entries_evicted",
+		metric.WithDescription("Number of entries evicted from a table because it exceeded its maximum size"),
+		metric.WithUnit("{entries}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.DbstorageEntriesExpired, err = builder.meter.Int64Counter(
+		"dbstorage_entries_expired",
+		metric.WithDescription("Number of expired entries removed from a table"),
+		metric.WithUnit("{entries}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.DbstorageTableEntries, err = builder.meter.Int64UpDownCounter(
+		"dbstorage_table_entries",
+		metric.WithDescription("Number of entries stored in a table"),
+		metric.WithUnit("{entries}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.DbstorageTableSize, err = builder.meter.Int64UpDownCounter(
+		"dbstorage_table_size",
+		metric.WithDescription("Size of the values stored in a table"),
+		metric.WithUnit("By"),
+	)
+	errs = errors.Join(errs, err)
+	return &builder, errs
+}
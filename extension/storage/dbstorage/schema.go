@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage"
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const driverPostgres = "pgx"
+
+// dialect holds the differences between the supported databases
+type dialect struct {
+	blobType string
+	// positional reports whether placeholders are numbered ($1, $2, ...) instead of ?
+	positional bool
+}
+
+// newDialect returns the dialect of the driver. Drivers other than SQLite and
+// Postgres are assumed to accept ? placeholders and the blob type.
+func newDialect(driverName string) dialect {
+	if driverName == driverPostgres {
+		return dialect{blobType: "bytea", positional: true}
+	}
+	return dialect{blobType: "blob"}
+}
+
+// rebind rewrites the ? placeholders of q as required by the dialect
+func (d dialect) rebind(q string) string {
+	if !d.positional {
+		return q
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range q {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+const (
+	// schemaTable records the schema version of each component table
+	createSchemaTable = "create table if not exists dbstorage_schema (table_name text primary key, version integer not null)"
+	// claiming the row first takes a write lock, so concurrent collectors migrate a table one at a time
+	claimSchemaText = "insert into dbstorage_schema(table_name, version) values(?, 0) on conflict(table_name) do nothing"
+	getSchemaText   = "select version from dbstorage_schema where table_name=?"
+	setSchemaText   = "update dbstorage_schema set version=? where table_name=?"
+)
+
+// migrations upgrade a component table, each to the version matching its position.
+// Statements are formatted with the table name and the blob type of the dialect.
+// Tables created before schema versioning have no version, and are upgraded from
+// the first migration on, which is a no-op for them.
+var migrations = [][]string{
+	// 1: plain key-value table
+	{
+		"create table if not exists %[1]s (key text primary key, value %[2]s)",
+	},
+	// 2: track when keys were set and when they expire
+	{
+		"alter table %[1]s add column updated_at bigint not null default 0",
+		"alter table %[1]s add column expires_at bigint",
+		"create index if not exists %[1]s_updated_at on %[1]s (updated_at)",
+		"create index if not exists %[1]s_expires_at on %[1]s (expires_at)",
+	},
+}
+
+// migrate upgrades the table to the latest schema version
+func migrate(ctx context.Context, db *sql.DB, d dialect, table string) error {
+	if _, err := db.ExecContext(ctx, createSchemaTable); err != nil {
+		return err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err = tx.ExecContext(ctx, d.rebind(claimSchemaText), table); err != nil {
+		return err
+	}
+	var version int
+	if err = tx.QueryRowContext(ctx, d.rebind(getSchemaText), table).Scan(&version); err != nil {
+		return err
+	}
+	if version > len(migrations) {
+		return fmt.Errorf("table %s has schema version %d, which is newer than the supported version %d", table, version, len(migrations))
+	}
+	if version == len(migrations) {
+		return nil
+	}
+
+	for _, migration := range migrations[version:] {
+		for _, stmt := range migration {
+			if _, err = tx.ExecContext(ctx, fmt.Sprintf(stmt, table, d.blobType)); err != nil {
+				return fmt.Errorf("migrating table %s to schema version %d: %w", table, version+1, err)
+			}
+		}
+		version++
+	}
+	if _, err = tx.ExecContext(ctx, d.rebind(setSchemaText), version, table); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
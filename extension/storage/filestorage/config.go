@@ -9,6 +9,8 @@ import (
 	"io/fs"
 	"os"
 	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage/internal/wal"
 )
 
 // Config defines configuration for file storage extension.
@@ -20,6 +22,28 @@ type Config struct {
 
 	// FSync specifies that fsync should be called after each database write
 	FSync bool `mapstructure:"fsync,omitempty"`
+
+	// Backend specifies the storage implementation, either "bbolt" or "wal"
+	Backend string `mapstructure:"backend,omitempty"`
+
+	// WAL configures the write-ahead log backend
+	WAL *WALConfig `mapstructure:"wal,omitempty"`
+}
+
+// WALConfig defines configuration for the write-ahead log backend, which appends
+// all writes to segment files and reclaims segments in the background.
+type WALConfig struct {
+	// FSyncPolicy specifies when writes are flushed to disk: "always", "interval" or "never"
+	FSyncPolicy string `mapstructure:"fsync_policy,omitempty"`
+	// FSyncInterval specifies the frequency of flushes with the "interval" policy
+	FSyncInterval time.Duration `mapstructure:"fsync_interval,omitempty"`
+	// MaxSegmentSizeMiB specifies the size after which a segment is sealed and a new one started
+	MaxSegmentSizeMiB int64 `mapstructure:"max_segment_size_mib,omitempty"`
+	// ReclaimInterval specifies the frequency at which sealed segments are checked for reclamation
+	ReclaimInterval time.Duration `mapstructure:"reclaim_interval,omitempty"`
+	// ReclaimThreshold specifies the fraction of live data below which a sealed segment is reclaimed.
+	// Its live data is rewritten to the active segment before it is removed.
+	ReclaimThreshold float64 `mapstructure:"reclaim_threshold,omitempty"`
 }
 
 // CompactionConfig defines configuration for optional file storage compaction.
@@ -83,5 +107,62 @@ func (cfg *Config) Validate() error {
 		return errors.New("compaction check interval must be positive when rebound compaction is set")
 	}
 
+	switch cfg.Backend {
+	case backendBBolt:
+	case backendWAL:
+		if cfg.WAL == nil {
+			return errors.New("wal settings must be set when the wal backend is set")
+		}
+		if err := cfg.validateUnsupportedByWAL(); err != nil {
+			return err
+		}
+		return cfg.WAL.validate()
+	default:
+		return fmt.Errorf("unknown backend %q, must be one of %q or %q", cfg.Backend, backendBBolt, backendWAL)
+	}
+
+	return nil
+}
+
+// validateUnsupportedByWAL rejects the bbolt settings which the write-ahead log backend would otherwise ignore
+func (cfg *Config) validateUnsupportedByWAL() error {
+	if cfg.Timeout != defaultTimeout {
+		return errors.New("timeout is not supported by the wal backend, which does not lock files")
+	}
+
+	if cfg.FSync {
+		return errors.New("fsync is not supported by the wal backend, use wal::fsync_policy instead")
+	}
+
+	if cfg.Compaction.OnStart || cfg.Compaction.OnRebound || cfg.Compaction.CleanupOnStart {
+		return errors.New("compaction is not supported by the wal backend, which reclaims segments according to wal::reclaim_interval and wal::reclaim_threshold")
+	}
+
+	return nil
+}
+
+func (cfg *WALConfig) validate() error {
+	switch wal.FSyncPolicy(cfg.FSyncPolicy) {
+	case wal.FSyncAlways, wal.FSyncNever:
+	case wal.FSyncInterval:
+		if cfg.FSyncInterval <= 0 {
+			return errors.New("wal fsync interval must be positive when the interval fsync policy is set")
+		}
+	default:
+		return fmt.Errorf("unknown wal fsync policy %q", cfg.FSyncPolicy)
+	}
+
+	if cfg.MaxSegmentSizeMiB <= 0 {
+		return errors.New("wal max segment size must be positive")
+	}
+
+	if cfg.ReclaimInterval <= 0 {
+		return errors.New("wal reclaim interval must be positive")
+	}
+
+	if cfg.ReclaimThreshold < 0 || cfg.ReclaimThreshold > 1 {
+		return errors.New("wal reclaim threshold must be between 0 and 1")
+	}
+
 	return nil
 }
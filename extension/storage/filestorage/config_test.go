@@ -49,8 +49,32 @@ func TestLoadConfig(t *testing.T) {
 				},
 				Timeout: 2 * time.Second,
 				FSync:   true,
+				Backend: backendBBolt,
+				WAL: &WALConfig{
+					FSyncPolicy:       defaultWALFSyncPolicy,
+					FSyncInterval:     defaultWALFSyncInterval,
+					MaxSegmentSizeMiB: defaultWALMaxSegmentSizeMib,
+					ReclaimInterval:   defaultWALReclaimInterval,
+					ReclaimThreshold:  defaultWALReclaimThreshold,
+				},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "wal"),
+			expected: func() component.Config {
+				ret := NewFactory().CreateDefaultConfig().(*Config)
+				ret.Directory = "."
+				ret.Backend = backendWAL
+				ret.WAL = &WALConfig{
+					FSyncPolicy:       "always",
+					FSyncInterval:     defaultWALFSyncInterval,
+					MaxSegmentSizeMiB: 16,
+					ReclaimInterval:   10 * time.Second,
+					ReclaimThreshold:  0.25,
+				}
+				return ret
+			}(),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
@@ -95,3 +119,77 @@ func TestHandleProvidingFilePathAsDirWithAnError(t *testing.T) {
 	require.Error(t, err)
 	require.EqualError(t, err, file.Name()+" is not a directory")
 }
+
+func TestWALConfigErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		expect string
+	}{
+		{
+			name:   "unknown_backend",
+			modify: func(cfg *Config) { cfg.Backend = "sqlite" },
+			expect: `unknown backend "sqlite", must be one of "bbolt" or "wal"`,
+		},
+		{
+			name:   "missing_wal",
+			modify: func(cfg *Config) { cfg.WAL = nil },
+			expect: "wal settings must be set when the wal backend is set",
+		},
+		{
+			name:   "timeout",
+			modify: func(cfg *Config) { cfg.Timeout = 2 * time.Second },
+			expect: "timeout is not supported by the wal backend, which does not lock files",
+		},
+		{
+			name:   "fsync",
+			modify: func(cfg *Config) { cfg.FSync = true },
+			expect: "fsync is not supported by the wal backend, use wal::fsync_policy instead",
+		},
+		{
+			name:   "compaction_on_start",
+			modify: func(cfg *Config) { cfg.Compaction.OnStart = true },
+			expect: "compaction is not supported by the wal backend, which reclaims segments according to wal::reclaim_interval and wal::reclaim_threshold",
+		},
+		{
+			name:   "compaction_on_rebound",
+			modify: func(cfg *Config) { cfg.Compaction.OnRebound = true },
+			expect: "compaction is not supported by the wal backend, which reclaims segments according to wal::reclaim_interval and wal::reclaim_threshold",
+		},
+		{
+			name:   "unknown_fsync_policy",
+			modify: func(cfg *Config) { cfg.WAL.FSyncPolicy = "sometimes" },
+			expect: `unknown wal fsync policy "sometimes"`,
+		},
+		{
+			name:   "fsync_interval",
+			modify: func(cfg *Config) { cfg.WAL.FSyncInterval = 0 },
+			expect: "wal fsync interval must be positive when the interval fsync policy is set",
+		},
+		{
+			name:   "max_segment_size",
+			modify: func(cfg *Config) { cfg.WAL.MaxSegmentSizeMiB = 0 },
+			expect: "wal max segment size must be positive",
+		},
+		{
+			name:   "reclaim_interval",
+			modify: func(cfg *Config) { cfg.WAL.ReclaimInterval = 0 },
+			expect: "wal reclaim interval must be positive",
+		},
+		{
+			name:   "reclaim_threshold",
+			modify: func(cfg *Config) { cfg.WAL.ReclaimThreshold = 1.5 },
+			expect: "wal reclaim threshold must be between 0 and 1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.Directory = t.TempDir()
+			cfg.Compaction.Directory = cfg.Directory
+			cfg.Backend = backendWAL
+			tt.modify(cfg)
+			require.EqualError(t, component.ValidateConfig(cfg), tt.expect)
+		})
+	}
+}
@@ -15,8 +15,12 @@ import (
 	"go.opentelemetry.io/collector/extension"
 	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage/internal/wal"
 )
 
+const walDirectorySuffix = ".wal"
+
 type localFileStorage struct {
 	cfg    *Config
 	logger *zap.Logger
@@ -58,6 +62,12 @@ func (lfs *localFileStorage) GetClient(_ context.Context, kind component.Kind, e
 
 	rawName = sanitize(rawName)
 	absoluteName := filepath.Join(lfs.cfg.Directory, rawName)
+
+	if lfs.cfg.Backend == backendWAL {
+		// the write-ahead log keeps its segments in a directory next to where the bbolt file would be
+		return wal.Open(lfs.logger, absoluteName+walDirectorySuffix, walOptions(lfs.cfg.WAL))
+	}
+
 	client, err := newClient(lfs.logger, absoluteName, lfs.cfg.Timeout, lfs.cfg.Compaction, !lfs.cfg.FSync)
 
 	if err != nil {
@@ -75,6 +85,16 @@ func (lfs *localFileStorage) GetClient(_ context.Context, kind component.Kind, e
 	return client, nil
 }
 
+func walOptions(cfg *WALConfig) wal.Options {
+	return wal.Options{
+		FSync:            wal.FSyncPolicy(cfg.FSyncPolicy),
+		FSyncInterval:    cfg.FSyncInterval,
+		MaxSegmentSize:   cfg.MaxSegmentSizeMiB * oneMiB,
+		ReclaimInterval:  cfg.ReclaimInterval,
+		ReclaimThreshold: cfg.ReclaimThreshold,
+	}
+}
+
 func kindString(k component.Kind) string {
 	switch k {
 	case component.KindReceiver:
@@ -143,6 +143,38 @@ func TestClientHandlesSimpleCases(t *testing.T) {
 
 }
 
+func TestWALBackend(t *testing.T) {
+	ctx := context.Background()
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	cfg.Backend = backendWAL
+	require.NoError(t, component.ValidateConfig(cfg))
+
+	extension, err := f.CreateExtension(ctx, extensiontest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+	se, ok := extension.(storage.Extension)
+	require.True(t, ok)
+
+	client, err := se.GetClient(ctx, component.KindExporter, newTestEntity("my_component"), "")
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	require.NoError(t, client.Close(ctx))
+
+	// the segments are kept in a directory named after the component
+	require.DirExists(t, filepath.Join(cfg.Directory, "exporter_nop_my_component.wal"))
+
+	// data is available to a new client for the same component
+	client, err = se.GetClient(ctx, component.KindExporter, newTestEntity("my_component"), "")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(ctx))
+	})
+	data, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), data)
+}
+
 func TestTwoClientsWithDifferentNames(t *testing.T) {
 	ctx := context.Background()
 	se := newTestExtension(t)
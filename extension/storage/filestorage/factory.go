@@ -22,6 +22,16 @@ const (
 	defaultReboundTriggerThresholdMib = 10
 	defaultReboundNeededThresholdMib  = 100
 	defaultCompactionInterval         = time.Second * 5
+	defaultTimeout                    = time.Second
+
+	backendBBolt = "bbolt"
+	backendWAL   = "wal"
+
+	defaultWALFSyncPolicy       = "interval"
+	defaultWALFSyncInterval     = time.Second
+	defaultWALMaxSegmentSizeMib = 64
+	defaultWALReclaimInterval   = time.Second * 5
+	defaultWALReclaimThreshold  = 0.5
 )
 
 // NewFactory creates a factory for HostObserver extension.
@@ -47,8 +57,16 @@ func createDefaultConfig() component.Config {
 			CheckInterval:              defaultCompactionInterval,
 			CleanupOnStart:             false,
 		},
-		Timeout: time.Second,
+		Timeout: defaultTimeout,
 		FSync:   false,
+		Backend: backendBBolt,
+		WAL: &WALConfig{
+			FSyncPolicy:       defaultWALFSyncPolicy,
+			FSyncInterval:     defaultWALFSyncInterval,
+			MaxSegmentSizeMiB: defaultWALMaxSegmentSizeMib,
+			ReclaimInterval:   defaultWALReclaimInterval,
+			ReclaimThreshold:  defaultWALReclaimThreshold,
+		},
 	}
 }
 
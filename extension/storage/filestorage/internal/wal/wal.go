@@ -0,0 +1,566 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package wal implements a storage client backed by a segmented, append-only
+// write-ahead log.
+//
+// Each call to Batch appends a single checksummed record holding all of its
+// Set and Delete operations to the active segment, so a batch is either
+// replayed completely or not at all. Values are not kept in memory; an index
+// maps every key to the location of its latest value on disk. Once the active
+// segment exceeds its maximum size, it is sealed and a new one is started.
+// Sealed segments are reclaimed oldest first, by rewriting their remaining
+// live values to the active segment and removing the segment file. This suits
+// persistent queues, where the oldest entries are removed first.
+package wal // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage/internal/wal"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+)
+
+// FSyncPolicy determines when writes are flushed to disk.
+type FSyncPolicy string
+
+const (
+	// FSyncAlways flushes every batch before it is acknowledged.
+	FSyncAlways FSyncPolicy = "always"
+	// FSyncInterval flushes pending writes periodically.
+	FSyncInterval FSyncPolicy = "interval"
+	// FSyncNever leaves flushing to the operating system.
+	FSyncNever FSyncPolicy = "never"
+)
+
+const (
+	segmentExt = ".wal"
+	headerSize = 8
+
+	opSet    byte = 1
+	opDelete byte = 2
+)
+
+var (
+	crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+	errClosed  = errors.New("wal: client is closed")
+	errCorrupt = errors.New("wal: corrupt record")
+)
+
+// Options configure a Client.
+type Options struct {
+	FSync         FSyncPolicy
+	FSyncInterval time.Duration
+	// MaxSegmentSize is the size in bytes after which the active segment is sealed.
+	MaxSegmentSize int64
+	// ReclaimInterval is the frequency at which sealed segments are checked for reclamation.
+	ReclaimInterval time.Duration
+	// ReclaimThreshold is the fraction of live data below which a sealed segment is reclaimed.
+	ReclaimThreshold float64
+}
+
+type segment struct {
+	id   uint64
+	file *os.File
+	// size is the number of bytes written to the segment
+	size int64
+	// live is the number of bytes of keys and values that are still current
+	live int64
+}
+
+type location struct {
+	seg    *segment
+	offset int64
+	length int64
+}
+
+// Client is a storage.Client which persists data in a write-ahead log.
+type Client struct {
+	logger *zap.Logger
+	dir    string
+	opts   Options
+
+	mu       sync.Mutex
+	index    map[string]location
+	segments []*segment
+	dirty    bool
+	closed   bool
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+var _ storage.Client = (*Client)(nil)
+
+// Open opens the write-ahead log in dir, creating it if needed, and replays
+// its segments to rebuild the index.
+func Open(logger *zap.Logger, dir string, opts Options) (*Client, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		logger: logger,
+		dir:    dir,
+		opts:   opts,
+		index:  make(map[string]location),
+	}
+	if err := c.load(); err != nil {
+		_ = c.closeFiles()
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	if opts.FSync == FSyncInterval && opts.FSyncInterval > 0 {
+		c.startLoop(ctx, opts.FSyncInterval, c.sync)
+	}
+	if opts.ReclaimInterval > 0 {
+		c.startLoop(ctx, opts.ReclaimInterval, c.reclaim)
+	}
+	return c, nil
+}
+
+// Get will retrieve data from storage that corresponds to the specified key
+func (c *Client) Get(ctx context.Context, key string) ([]byte, error) {
+	op := storage.GetOperation(key)
+	if err := c.Batch(ctx, op); err != nil {
+		return nil, err
+	}
+	return op.Value, nil
+}
+
+// Set will store data. The data can be retrieved using the same key
+func (c *Client) Set(ctx context.Context, key string, value []byte) error {
+	return c.Batch(ctx, storage.SetOperation(key, value))
+}
+
+// Delete will delete data associated with the specified key
+func (c *Client) Delete(ctx context.Context, key string) error {
+	return c.Batch(ctx, storage.DeleteOperation(key))
+}
+
+// Batch executes the specified operations in order. Get operation results are updated in place.
+// All Set and Delete operations of a batch are persisted as a single record.
+func (c *Client) Batch(_ context.Context, ops ...storage.Operation) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return errClosed
+	}
+	return c.batch(ops)
+}
+
+// Close stops background work, flushes pending writes and closes all segments
+func (c *Client) Close(_ context.Context) error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	c.mu.Unlock()
+
+	c.cancel()
+	c.wg.Wait()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var err error
+	if c.opts.FSync != FSyncNever {
+		err = c.active().file.Sync()
+	}
+	return errors.Join(err, c.closeFiles())
+}
+
+// pendingWrite is a Set or Delete of the batch being encoded
+type pendingWrite struct {
+	op     byte
+	key    string
+	value  []byte
+	offset int64 // of the value, relative to the start of the payload
+}
+
+func (c *Client) batch(ops []storage.Operation) error {
+	var (
+		payload []byte
+		writes  []pendingWrite
+		pending = make(map[string]int)
+	)
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			if i, ok := pending[op.Key]; ok {
+				op.Value = cloneBytes(writes[i].value)
+				continue
+			}
+			value, err := c.read(op.Key)
+			if err != nil {
+				return err
+			}
+			op.Value = value
+		case storage.Set:
+			payload = append(payload, opSet)
+			payload = appendBytes(payload, []byte(op.Key))
+			payload = binary.AppendUvarint(payload, uint64(len(op.Value)))
+			pending[op.Key] = len(writes)
+			writes = append(writes, pendingWrite{op: opSet, key: op.Key, value: op.Value, offset: int64(len(payload))})
+			payload = append(payload, op.Value...)
+		case storage.Delete:
+			payload = append(payload, opDelete)
+			payload = appendBytes(payload, []byte(op.Key))
+			pending[op.Key] = len(writes)
+			writes = append(writes, pendingWrite{op: opDelete, key: op.Key})
+		default:
+			return errors.New("wrong operation type")
+		}
+	}
+	if len(writes) == 0 {
+		return nil
+	}
+
+	if seg := c.active(); seg.size > 0 && seg.size+headerSize+int64(len(payload)) > c.opts.MaxSegmentSize {
+		if err := c.rotate(); err != nil {
+			return err
+		}
+	}
+
+	seg := c.active()
+	start, err := c.write(seg, payload)
+	if err != nil {
+		return err
+	}
+	for _, w := range writes {
+		if w.op == opSet {
+			c.put(w.key, location{seg: seg, offset: start + w.offset, length: int64(len(w.value))})
+		} else {
+			c.remove(w.key)
+		}
+	}
+	return nil
+}
+
+// write appends a record with the given payload to seg, and returns the offset of the payload
+func (c *Client) write(seg *segment, payload []byte) (int64, error) {
+	buf := make([]byte, headerSize, headerSize+len(payload))
+	binary.LittleEndian.PutUint32(buf[0:4], crc32.Checksum(payload, crcTable))
+	binary.LittleEndian.PutUint32(buf[4:8], uint32(len(payload)))
+	buf = append(buf, payload...)
+
+	if _, err := seg.file.WriteAt(buf, seg.size); err != nil {
+		// drop a partially written record, so the segment stays readable
+		_ = seg.file.Truncate(seg.size)
+		return 0, err
+	}
+	if c.opts.FSync == FSyncAlways {
+		if err := seg.file.Sync(); err != nil {
+			return 0, err
+		}
+	} else {
+		c.dirty = true
+	}
+
+	start := seg.size + headerSize
+	seg.size += int64(len(buf))
+	return start, nil
+}
+
+func (c *Client) read(key string) ([]byte, error) {
+	loc, ok := c.index[key]
+	if !ok {
+		return nil, nil
+	}
+	value := make([]byte, loc.length)
+	if _, err := loc.seg.file.ReadAt(value, loc.offset); err != nil {
+		return nil, fmt.Errorf("wal: reading %q from segment %d: %w", key, loc.seg.id, err)
+	}
+	return value, nil
+}
+
+func (c *Client) put(key string, loc location) {
+	c.remove(key)
+	c.index[key] = loc
+	loc.seg.live += int64(len(key)) + loc.length
+}
+
+func (c *Client) remove(key string) {
+	if old, ok := c.index[key]; ok {
+		old.seg.live -= int64(len(key)) + old.length
+		delete(c.index, key)
+	}
+}
+
+func (c *Client) active() *segment {
+	return c.segments[len(c.segments)-1]
+}
+
+// rotate seals the active segment and starts a new one
+func (c *Client) rotate() error {
+	if c.opts.FSync != FSyncNever {
+		if err := c.active().file.Sync(); err != nil {
+			return err
+		}
+	}
+	seg, err := c.openSegment(c.active().id + 1)
+	if err != nil {
+		return err
+	}
+	c.segments = append(c.segments, seg)
+	c.dirty = false
+	return nil
+}
+
+func (c *Client) sync() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed || !c.dirty {
+		return nil
+	}
+	c.dirty = false
+	return c.active().file.Sync()
+}
+
+// reclaim removes sealed segments, oldest first, as long as their fraction of
+// live data is below the threshold. Live values are rewritten to the active
+// segment before a segment is removed.
+func (c *Client) reclaim() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for !c.closed && len(c.segments) > 1 {
+		seg := c.segments[0]
+		if seg.live > 0 && float64(seg.live)/float64(seg.size) >= c.opts.ReclaimThreshold {
+			return nil
+		}
+
+		var ops []storage.Operation
+		for key, loc := range c.index {
+			if loc.seg != seg {
+				continue
+			}
+			value, err := c.read(key)
+			if err != nil {
+				return err
+			}
+			ops = append(ops, storage.SetOperation(key, value))
+		}
+		if len(ops) > 0 {
+			if err := c.batch(ops); err != nil {
+				return err
+			}
+			// the rewritten values must be durable before the segment is removed
+			if c.opts.FSync != FSyncNever {
+				if err := c.active().file.Sync(); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := seg.file.Close(); err != nil {
+			return err
+		}
+		if err := os.Remove(seg.file.Name()); err != nil {
+			return err
+		}
+		c.segments = c.segments[1:]
+		c.logger.Debug("reclaimed write-ahead log segment",
+			zap.String("segment", seg.file.Name()),
+			zap.Int("rewritten", len(ops)))
+	}
+	return nil
+}
+
+func (c *Client) startLoop(ctx context.Context, interval time.Duration, fn func() error) {
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := fn(); err != nil {
+					c.logger.Error("write-ahead log maintenance failed", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+// load opens and replays all existing segments, in order
+func (c *Client) load() error {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+	var ids []uint64
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, segmentExt) {
+			continue
+		}
+		id, err := strconv.ParseUint(strings.TrimSuffix(name, segmentExt), 16, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		seg, err := c.openSegment(id)
+		if err != nil {
+			return err
+		}
+		c.segments = append(c.segments, seg)
+		if err := c.replay(seg); err != nil {
+			return err
+		}
+	}
+
+	if len(c.segments) == 0 {
+		seg, err := c.openSegment(1)
+		if err != nil {
+			return err
+		}
+		c.segments = append(c.segments, seg)
+	}
+	return nil
+}
+
+func (c *Client) openSegment(id uint64) (*segment, error) {
+	name := filepath.Join(c.dir, fmt.Sprintf("%016x%s", id, segmentExt))
+	file, err := os.OpenFile(name, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &segment{id: id, file: file}, nil
+}
+
+// replay applies all records of seg to the index. A torn or corrupt record,
+// typically left behind by a crash during a write, ends the segment: it is
+// truncated to the last valid record.
+func (c *Client) replay(seg *segment) error {
+	data, err := os.ReadFile(seg.file.Name())
+	if err != nil {
+		return err
+	}
+
+	var offset int64
+	for offset < int64(len(data)) {
+		n, err := c.replayRecord(seg, data[offset:], offset)
+		if err != nil {
+			c.logger.Warn("truncating write-ahead log segment",
+				zap.String("segment", seg.file.Name()),
+				zap.Int64("offset", offset),
+				zap.Error(err))
+			if err := seg.file.Truncate(offset); err != nil {
+				return err
+			}
+			break
+		}
+		offset += n
+	}
+	seg.size = offset
+	return nil
+}
+
+// replayRecord applies the record at the start of data, which is located at
+// offset in seg, and returns its size
+func (c *Client) replayRecord(seg *segment, data []byte, offset int64) (int64, error) {
+	if len(data) < headerSize {
+		return 0, errCorrupt
+	}
+	sum := binary.LittleEndian.Uint32(data[0:4])
+	n := int64(binary.LittleEndian.Uint32(data[4:8]))
+	if n > int64(len(data)-headerSize) {
+		return 0, errCorrupt
+	}
+	payload := data[headerSize : headerSize+n]
+	if crc32.Checksum(payload, crcTable) != sum {
+		return 0, errCorrupt
+	}
+
+	// decode the whole record before applying it, so it is never applied partially
+	var writes []pendingWrite
+	for pos := int64(0); pos < n; {
+		op := payload[pos]
+		pos++
+		key, size := readBytes(payload[pos:])
+		if size <= 0 {
+			return 0, errCorrupt
+		}
+		pos += size
+
+		w := pendingWrite{op: op, key: string(key)}
+		switch op {
+		case opSet:
+			length, size := binary.Uvarint(payload[pos:])
+			if size <= 0 || length > uint64(n-pos-int64(size)) {
+				return 0, errCorrupt
+			}
+			pos += int64(size)
+			w.offset = pos
+			w.value = payload[pos : pos+int64(length)]
+			pos += int64(length)
+		case opDelete:
+		default:
+			return 0, errCorrupt
+		}
+		writes = append(writes, w)
+	}
+
+	for _, w := range writes {
+		if w.op == opSet {
+			c.put(w.key, location{seg: seg, offset: offset + headerSize + w.offset, length: int64(len(w.value))})
+		} else {
+			c.remove(w.key)
+		}
+	}
+	return headerSize + n, nil
+}
+
+func (c *Client) closeFiles() error {
+	var errs []error
+	for _, seg := range c.segments {
+		errs = append(errs, seg.file.Close())
+	}
+	return errors.Join(errs...)
+}
+
+func appendBytes(buf, b []byte) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(b)))
+	return append(buf, b...)
+}
+
+// readBytes reads a length-prefixed byte slice from the front of buf, and
+// returns it along with the number of bytes read, which is not positive on error
+func readBytes(buf []byte) ([]byte, int64) {
+	n, size := binary.Uvarint(buf)
+	if size <= 0 || n > uint64(len(buf)-size) {
+		return nil, 0
+	}
+	end := int64(size) + int64(n)
+	return buf[size:end], end
+}
+
+func cloneBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	return append([]byte{}, b...)
+}
@@ -0,0 +1,241 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package wal
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+)
+
+var testOptions = Options{
+	FSync:            FSyncAlways,
+	MaxSegmentSize:   1024,
+	ReclaimThreshold: 0.5,
+}
+
+func TestClientOperations(t *testing.T) {
+	client, err := Open(zap.NewNop(), t.TempDir(), testOptions)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(context.Background()))
+	})
+
+	ctx := context.Background()
+
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	value, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+
+	require.NoError(t, client.Set(ctx, "key", []byte("other")))
+	value, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("other"), value)
+
+	require.NoError(t, client.Delete(ctx, "key"))
+	value, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	require.Nil(t, value)
+}
+
+func TestClientBatchOperations(t *testing.T) {
+	client, err := Open(zap.NewNop(), t.TempDir(), testOptions)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(context.Background()))
+	})
+
+	ctx := context.Background()
+	require.NoError(t, client.Set(ctx, "deleted", []byte("value")))
+
+	ops := []storage.Operation{
+		storage.SetOperation("key1", []byte("value1")),
+		storage.GetOperation("key1"),
+		storage.SetOperation("key2", []byte("value2")),
+		storage.DeleteOperation("deleted"),
+		storage.GetOperation("deleted"),
+		storage.SetOperation("key1", []byte("updated")),
+		storage.GetOperation("key1"),
+	}
+	require.NoError(t, client.Batch(ctx, ops...))
+	require.Equal(t, []byte("value1"), ops[1].Value)
+	require.Nil(t, ops[4].Value)
+	require.Equal(t, []byte("updated"), ops[6].Value)
+
+	gets := []storage.Operation{
+		storage.GetOperation("key1"),
+		storage.GetOperation("key2"),
+		storage.GetOperation("deleted"),
+	}
+	require.NoError(t, client.Batch(ctx, gets...))
+	require.Equal(t, []byte("updated"), gets[0].Value)
+	require.Equal(t, []byte("value2"), gets[1].Value)
+	require.Nil(t, gets[2].Value)
+}
+
+func TestClientReopen(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	client, err := Open(zap.NewNop(), dir, testOptions)
+	require.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		require.NoError(t, client.Set(ctx, fmt.Sprintf("key%d", i), []byte(fmt.Sprintf("value%d", i))))
+	}
+	for i := 0; i < 100; i += 2 {
+		require.NoError(t, client.Delete(ctx, fmt.Sprintf("key%d", i)))
+	}
+	require.Greater(t, len(client.segments), 1)
+	require.NoError(t, client.Close(ctx))
+
+	client, err = Open(zap.NewNop(), dir, testOptions)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(context.Background()))
+	})
+	for i := 0; i < 100; i++ {
+		value, err := client.Get(ctx, fmt.Sprintf("key%d", i))
+		require.NoError(t, err)
+		if i%2 == 0 {
+			require.Nil(t, value)
+		} else {
+			require.Equal(t, []byte(fmt.Sprintf("value%d", i)), value)
+		}
+	}
+}
+
+func TestClientTornWrite(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	client, err := Open(zap.NewNop(), dir, testOptions)
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "key1", []byte("value1")))
+	require.NoError(t, client.Batch(ctx,
+		storage.SetOperation("key1", []byte("torn")),
+		storage.SetOperation("key2", []byte("torn")),
+	))
+	name := client.active().file.Name()
+	require.NoError(t, client.Close(ctx))
+
+	// cut the last record short, as if the process crashed while writing it
+	info, err := os.Stat(name)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(name, info.Size()-3))
+
+	client, err = Open(zap.NewNop(), dir, testOptions)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(context.Background()))
+	})
+
+	ops := []storage.Operation{storage.GetOperation("key1"), storage.GetOperation("key2")}
+	require.NoError(t, client.Batch(ctx, ops...))
+	require.Equal(t, []byte("value1"), ops[0].Value)
+	require.Nil(t, ops[1].Value)
+
+	// the torn record is dropped, so new writes are readable after reopening
+	require.NoError(t, client.Set(ctx, "key2", []byte("value2")))
+	value, err := client.Get(ctx, "key2")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value2"), value)
+}
+
+func TestClientReclaim(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	client, err := Open(zap.NewNop(), dir, testOptions)
+	require.NoError(t, err)
+
+	// fill several segments, then delete most entries like a queue would
+	value := make([]byte, 100)
+	for i := 0; i < 50; i++ {
+		require.NoError(t, client.Set(ctx, fmt.Sprintf("key%02d", i), value))
+	}
+	for i := 0; i < 45; i++ {
+		require.NoError(t, client.Delete(ctx, fmt.Sprintf("key%02d", i)))
+	}
+	before := segmentFiles(t, dir)
+	require.Greater(t, len(before), 2)
+
+	require.NoError(t, client.reclaim())
+	after := segmentFiles(t, dir)
+	require.Less(t, len(after), len(before))
+
+	for i := 45; i < 50; i++ {
+		got, err := client.Get(ctx, fmt.Sprintf("key%02d", i))
+		require.NoError(t, err)
+		require.Equal(t, value, got)
+	}
+	require.NoError(t, client.Close(ctx))
+
+	client, err = Open(zap.NewNop(), dir, testOptions)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(context.Background()))
+	})
+	for i := 0; i < 50; i++ {
+		got, err := client.Get(ctx, fmt.Sprintf("key%02d", i))
+		require.NoError(t, err)
+		if i < 45 {
+			require.Nil(t, got)
+		} else {
+			require.Equal(t, value, got)
+		}
+	}
+}
+
+func TestClientReclaimInterval(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	opts := testOptions
+	opts.FSync = FSyncInterval
+	opts.FSyncInterval = 10 * time.Millisecond
+	opts.ReclaimInterval = 10 * time.Millisecond
+	client, err := Open(zap.NewNop(), dir, opts)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(context.Background()))
+	})
+
+	value := make([]byte, 100)
+	for i := 0; i < 50; i++ {
+		require.NoError(t, client.Set(ctx, fmt.Sprintf("key%02d", i), value))
+		require.NoError(t, client.Delete(ctx, fmt.Sprintf("key%02d", i)))
+	}
+	require.Eventually(t, func() bool {
+		return len(segmentFiles(t, dir)) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestClientClosed(t *testing.T) {
+	client, err := Open(zap.NewNop(), t.TempDir(), testOptions)
+	require.NoError(t, err)
+	require.NoError(t, client.Close(context.Background()))
+	require.NoError(t, client.Close(context.Background()))
+
+	_, err = client.Get(context.Background(), "key")
+	require.ErrorIs(t, err, errClosed)
+	require.ErrorIs(t, client.Set(context.Background(), "key", nil), errClosed)
+}
+
+func segmentFiles(t *testing.T, dir string) []string {
+	files, err := filepath.Glob(filepath.Join(dir, "*"+segmentExt))
+	require.NoError(t, err)
+	return files
+}
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package identity // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+
+import (
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = Stream{}
+	_ encoding.BinaryUnmarshaler = (*Stream)(nil)
+)
+
+// MarshalBinary encodes the stream identity, so it can be persisted and later
+// be restored using [Stream.UnmarshalBinary].
+func (s Stream) MarshalBinary() ([]byte, error) {
+	var buf []byte
+	buf = append(buf, s.metric.scope.resource.attrs[:]...)
+
+	buf = appendString(buf, s.metric.scope.name)
+	buf = appendString(buf, s.metric.scope.version)
+	buf = append(buf, s.metric.scope.attrs[:]...)
+
+	buf = appendString(buf, s.metric.name)
+	buf = appendString(buf, s.metric.unit)
+	var mono byte
+	if s.metric.monotonic {
+		mono = 1
+	}
+	buf = append(buf, byte(s.metric.ty), mono, byte(s.metric.temporality))
+
+	buf = append(buf, s.attrs[:]...)
+	return buf, nil
+}
+
+// UnmarshalBinary decodes a stream identity previously encoded by [Stream.MarshalBinary]
+func (s *Stream) UnmarshalBinary(data []byte) error {
+	r := reader{buf: data}
+
+	var id Stream
+	r.hash(&id.metric.scope.resource.attrs)
+
+	id.metric.scope.name = r.string()
+	id.metric.scope.version = r.string()
+	r.hash(&id.metric.scope.attrs)
+
+	id.metric.name = r.string()
+	id.metric.unit = r.string()
+	id.metric.ty = pmetric.MetricType(r.byte())
+	id.metric.monotonic = r.byte() == 1
+	id.metric.temporality = pmetric.AggregationTemporality(r.byte())
+
+	r.hash(&id.attrs)
+
+	if r.err != nil {
+		return fmt.Errorf("invalid stream identity: %w", r.err)
+	}
+	if len(r.buf) != 0 {
+		return fmt.Errorf("invalid stream identity: %d trailing bytes", len(r.buf))
+	}
+
+	*s = id
+	return nil
+}
+
+func appendString(buf []byte, s string) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(s)))
+	return append(buf, s...)
+}
+
+var errShort = errors.New("unexpected end of data")
+
+// reader consumes buf from the front. Once an error occurred, all further
+// reads are no-ops, so it only needs to be checked once at the end.
+type reader struct {
+	buf []byte
+	err error
+}
+
+func (r *reader) take(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if len(r.buf) < n {
+		r.err = errShort
+		return nil
+	}
+	b := r.buf[:n]
+	r.buf = r.buf[n:]
+	return b
+}
+
+func (r *reader) hash(into *[16]byte) {
+	copy(into[:], r.take(len(into)))
+}
+
+func (r *reader) byte() byte {
+	b := r.take(1)
+	if b == nil {
+		return 0
+	}
+	return b[0]
+}
+
+func (r *reader) string() string {
+	if r.err != nil {
+		return ""
+	}
+	n, size := binary.Uvarint(r.buf)
+	if size <= 0 || n > uint64(len(r.buf)-size) {
+		r.err = errShort
+		return ""
+	}
+	r.buf = r.buf[size:]
+	return string(r.take(int(n)))
+}
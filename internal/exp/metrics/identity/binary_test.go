@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestStreamBinary(t *testing.T) {
+	res := pcommon.NewResource()
+	res.Attributes().PutStr("service.name", "checkout")
+
+	scope := pcommon.NewInstrumentationScope()
+	scope.SetName("otelhttp")
+	scope.SetVersion("v0.52.0")
+	scope.Attributes().PutStr("aaa", "bbb")
+
+	m := pmetric.NewMetric()
+	m.SetName("http.server.requests")
+	m.SetUnit("{request}")
+	sum := m.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+
+	dp := sum.DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("http.route", "/cart")
+
+	id := OfStream(OfResourceMetric(res, scope, m), dp)
+
+	buf, err := id.MarshalBinary()
+	require.NoError(t, err)
+
+	var got Stream
+	require.NoError(t, got.UnmarshalBinary(buf))
+	require.Equal(t, id, got)
+	require.Equal(t, id.Hash().Sum64(), got.Hash().Sum64())
+
+	for i := 0; i < len(buf); i++ {
+		require.Error(t, new(Stream).UnmarshalBinary(buf[:i]))
+	}
+	require.Error(t, new(Stream).UnmarshalBinary(append(buf, 0)))
+}
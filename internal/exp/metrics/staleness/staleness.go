@@ -51,6 +51,14 @@ func (s *Staleness[T]) Store(id identity.Stream, v T) error {
 	return s.items.Store(id, v)
 }
 
+// Restore stores the given key value pair in the map, using lastSeen as the pair's staleness value instead of "now".
+// This allows re-populating the map from previously persisted state, while still expiring entries that became
+// stale in the meantime
+func (s *Staleness[T]) Restore(id identity.Stream, v T, lastSeen time.Time) error {
+	s.pq.Update(id, lastSeen)
+	return s.items.Store(id, v)
+}
+
 func (s *Staleness[T]) Delete(id identity.Stream) {
 	s.items.Delete(id)
 }
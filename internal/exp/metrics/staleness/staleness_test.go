@@ -130,3 +130,30 @@ func TestEvict(t *testing.T) {
 	require.False(t, ok)
 	require.Equal(t, 1, stale.Len())
 }
+
+func TestRestore(t *testing.T) {
+	now := 0
+	NowFunc = func() time.Time {
+		return time.Unix(int64(now), 0)
+	}
+
+	stale := NewStaleness(1*time.Minute, make(streams.HashMap[int]))
+
+	idA := generateStreamID(t, map[string]any{"aaa": "123"})
+	idB := generateStreamID(t, map[string]any{"bbb": "456"})
+
+	now = 100
+	require.NoError(t, stale.Restore(idA, 1, time.Unix(30, 0)))
+	require.NoError(t, stale.Restore(idB, 2, time.Unix(90, 0)))
+	require.Equal(t, 2, stale.Len())
+
+	// idA was last seen 70s ago, so it already is stale
+	stale.ExpireOldEntries()
+	validateStalenessMapEntries(t, map[identity.Stream]int{idB: 2}, stale)
+
+	// storing refreshes the staleness value to "now"
+	require.NoError(t, stale.Store(idB, 3))
+	now = 155
+	stale.ExpireOldEntries()
+	validateStalenessMapEntries(t, map[identity.Stream]int{idB: 3}, stale)
+}
@@ -10,6 +10,9 @@ import (
 	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
+// profileIDSize is the size of a profile id, which is held in a byte slice by the profiles.
+const profileIDSize = 16
+
 func ParseSpanID(spanIDStr string) (pcommon.SpanID, error) {
 	var id pcommon.SpanID
 	if hex.DecodedLen(len(spanIDStr)) != len(id) {
@@ -33,3 +36,10 @@ func ParseTraceID(traceIDStr string) (pcommon.TraceID, error) {
 	}
 	return id, nil
 }
+
+func ParseProfileID(profileIDStr string) ([]byte, error) {
+	if hex.DecodedLen(len(profileIDStr)) != profileIDSize {
+		return nil, errors.New("profile ids must be 32 hex characters")
+	}
+	return hex.DecodeString(profileIDStr)
+}
@@ -58,3 +58,28 @@ func TestParseTraceIDError(t *testing.T) {
 		})
 	}
 }
+
+func TestParseProfileIDError(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{
+			name:    "incorrect size",
+			input:   "0123456789abcdef0123456789abcde",
+			wantErr: "profile ids must be 32 hex characters",
+		},
+		{
+			name:    "incorrect characters",
+			input:   "0123456789Xbcdef0123456789abcdef",
+			wantErr: "encoding/hex: invalid byte: U+0058 'X'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseProfileID(tt.input)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap/zapcore"
 )
@@ -388,3 +389,37 @@ func (e Exemplar) MarshalLogObject(encoder zapcore.ObjectEncoder) error {
 	}
 	return err
 }
+
+type Profile pprofile.ProfileContainer
+
+func (p Profile) MarshalLogObject(encoder zapcore.ObjectEncoder) error {
+	pc := pprofile.ProfileContainer(p)
+	err := encoder.AddObject("attributes", Map(pc.Attributes()))
+	encoder.AddUint32("dropped_attribute_count", pc.DroppedAttributesCount())
+	encoder.AddUint64("end_time_unix_nano", uint64(pc.EndTime()))
+	encoder.AddInt64("period", pc.Profile().Period())
+	encoder.AddString("profile_id", hex.EncodeToString(pc.ProfileID().AsRaw()))
+	encoder.AddInt("samples_count", pc.Profile().Sample().Len())
+	encoder.AddUint64("start_time_unix_nano", uint64(pc.StartTime()))
+	return err
+}
+
+type Sample pprofile.Sample
+
+func (s Sample) MarshalLogObject(encoder zapcore.ObjectEncoder) error {
+	ss := pprofile.Sample(s)
+	encoder.AddUint64("locations_length", ss.LocationsLength())
+	encoder.AddUint64("locations_start_index", ss.LocationsStartIndex())
+	err := encoder.AddArray("value", Int64Slice(ss.Value()))
+	return err
+}
+
+type Int64Slice pcommon.Int64Slice
+
+func (i Int64Slice) MarshalLogArray(encoder zapcore.ArrayEncoder) error {
+	is := pcommon.Int64Slice(i)
+	for j := 0; j < is.Len(); j++ {
+		encoder.AppendInt64(is.At(j))
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal"
+
+import (
+	"context"
+	"encoding/hex"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+const (
+	ProfileContextName = "Profile"
+)
+
+type ProfileContext interface {
+	GetProfile() pprofile.ProfileContainer
+}
+
+func ProfilePathGetSetter[K ProfileContext](path ottl.Path[K]) (ottl.GetSetter[K], error) {
+	if path == nil {
+		return accessProfile[K](), nil
+	}
+	switch path.Name() {
+	case "profile_id":
+		nextPath := path.Next()
+		if nextPath != nil {
+			if nextPath.Name() == "string" {
+				return accessStringProfileID[K](), nil
+			}
+			return nil, FormatDefaultErrorMessage(nextPath.Name(), nextPath.String(), ProfileContextName, ProfileRef)
+		}
+		return accessProfileID[K](), nil
+	case "start_time_unix_nano":
+		return accessProfileStartTimeUnixNano[K](), nil
+	case "end_time_unix_nano":
+		return accessProfileEndTimeUnixNano[K](), nil
+	case "start_time":
+		return accessProfileStartTime[K](), nil
+	case "end_time":
+		return accessProfileEndTime[K](), nil
+	case "attributes":
+		mapKeys := path.Keys()
+		if mapKeys == nil {
+			return accessProfileAttributes[K](), nil
+		}
+		return accessProfileAttributesKey[K](mapKeys), nil
+	case "dropped_attributes_count":
+		return accessProfileDroppedAttributesCount[K](), nil
+	case "samples":
+		return accessProfileSamples[K](), nil
+	case "locations":
+		return accessProfileLocations[K](), nil
+	case "functions":
+		return accessProfileFunctions[K](), nil
+	case "string_table":
+		return accessProfileStringTable[K](), nil
+	case "period":
+		return accessProfilePeriod[K](), nil
+	default:
+		return nil, FormatDefaultErrorMessage(path.Name(), path.String(), ProfileContextName, ProfileRef)
+	}
+}
+
+func accessProfile[K ProfileContext]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return tCtx.GetProfile(), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if newProfile, ok := val.(pprofile.ProfileContainer); ok {
+				newProfile.CopyTo(tCtx.GetProfile())
+			}
+			return nil
+		},
+	}
+}
+
+func accessProfileID[K ProfileContext]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return tCtx.GetProfile().ProfileID().AsRaw(), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if newProfileID, ok := val.([]byte); ok {
+				tCtx.GetProfile().ProfileID().FromRaw(newProfileID)
+			}
+			return nil
+		},
+	}
+}
+
+func accessStringProfileID[K ProfileContext]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return hex.EncodeToString(tCtx.GetProfile().ProfileID().AsRaw()), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if str, ok := val.(string); ok {
+				id, err := ParseProfileID(str)
+				if err != nil {
+					return err
+				}
+				tCtx.GetProfile().ProfileID().FromRaw(id)
+			}
+			return nil
+		},
+	}
+}
+
+func accessProfileStartTimeUnixNano[K ProfileContext]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return tCtx.GetProfile().StartTime().AsTime().UnixNano(), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if i, ok := val.(int64); ok {
+				tCtx.GetProfile().SetStartTime(pcommon.NewTimestampFromTime(time.Unix(0, i)))
+			}
+			return nil
+		},
+	}
+}
+
+func accessProfileEndTimeUnixNano[K ProfileContext]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return tCtx.GetProfile().EndTime().AsTime().UnixNano(), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if i, ok := val.(int64); ok {
+				tCtx.GetProfile().SetEndTime(pcommon.NewTimestampFromTime(time.Unix(0, i)))
+			}
+			return nil
+		},
+	}
+}
+
+func accessProfileStartTime[K ProfileContext]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return tCtx.GetProfile().StartTime().AsTime(), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if t, ok := val.(time.Time); ok {
+				tCtx.GetProfile().SetStartTime(pcommon.NewTimestampFromTime(t))
+			}
+			return nil
+		},
+	}
+}
+
+func accessProfileEndTime[K ProfileContext]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return tCtx.GetProfile().EndTime().AsTime(), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if t, ok := val.(time.Time); ok {
+				tCtx.GetProfile().SetEndTime(pcommon.NewTimestampFromTime(t))
+			}
+			return nil
+		},
+	}
+}
+
+func accessProfileAttributes[K ProfileContext]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return tCtx.GetProfile().Attributes(), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if attrs, ok := val.(pcommon.Map); ok {
+				attrs.CopyTo(tCtx.GetProfile().Attributes())
+			}
+			return nil
+		},
+	}
+}
+
+func accessProfileAttributesKey[K ProfileContext](keys []ottl.Key[K]) ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(ctx context.Context, tCtx K) (any, error) {
+			return GetMapValue[K](ctx, tCtx, tCtx.GetProfile().Attributes(), keys)
+		},
+		Setter: func(ctx context.Context, tCtx K, val any) error {
+			return SetMapValue[K](ctx, tCtx, tCtx.GetProfile().Attributes(), keys, val)
+		},
+	}
+}
+
+func accessProfileDroppedAttributesCount[K ProfileContext]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return int64(tCtx.GetProfile().DroppedAttributesCount()), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if i, ok := val.(int64); ok {
+				tCtx.GetProfile().SetDroppedAttributesCount(uint32(i))
+			}
+			return nil
+		},
+	}
+}
+
+func accessProfileSamples[K ProfileContext]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return tCtx.GetProfile().Profile().Sample(), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if samples, ok := val.(pprofile.SampleSlice); ok {
+				samples.CopyTo(tCtx.GetProfile().Profile().Sample())
+			}
+			return nil
+		},
+	}
+}
+
+func accessProfileLocations[K ProfileContext]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return tCtx.GetProfile().Profile().Location(), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if locations, ok := val.(pprofile.LocationSlice); ok {
+				locations.CopyTo(tCtx.GetProfile().Profile().Location())
+			}
+			return nil
+		},
+	}
+}
+
+func accessProfileFunctions[K ProfileContext]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return tCtx.GetProfile().Profile().Function(), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if functions, ok := val.(pprofile.FunctionSlice); ok {
+				functions.CopyTo(tCtx.GetProfile().Profile().Function())
+			}
+			return nil
+		},
+	}
+}
+
+func accessProfileStringTable[K ProfileContext]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return tCtx.GetProfile().Profile().StringTable(), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			switch v := val.(type) {
+			case pcommon.StringSlice:
+				v.CopyTo(tCtx.GetProfile().Profile().StringTable())
+			case []string:
+				tCtx.GetProfile().Profile().StringTable().FromRaw(v)
+			}
+			return nil
+		},
+	}
+}
+
+func accessProfilePeriod[K ProfileContext]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return tCtx.GetProfile().Profile().Period(), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if i, ok := val.(int64); ok {
+				tCtx.GetProfile().Profile().SetPeriod(i)
+			}
+			return nil
+		},
+	}
+}
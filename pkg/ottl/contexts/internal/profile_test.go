@@ -0,0 +1,289 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
+)
+
+var (
+	profileID  = [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	profileID2 = [16]byte{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1}
+)
+
+func TestProfilePathGetSetter(t *testing.T) {
+	refProfile := createProfile()
+
+	newAttrs := pcommon.NewMap()
+	newAttrs.PutStr("hello", "world")
+
+	newSamples := pprofile.NewSampleSlice()
+	newSamples.AppendEmpty().Value().FromRaw([]int64{42})
+
+	newStringTable := pcommon.NewStringSlice()
+	newStringTable.FromRaw([]string{"", "new"})
+
+	tests := []struct {
+		name     string
+		path     ottl.Path[*profileContext]
+		orig     any
+		newVal   any
+		modified func(profile pprofile.ProfileContainer)
+	}{
+		{
+			name: "profile",
+			path: nil,
+			orig: refProfile,
+			newVal: func() pprofile.ProfileContainer {
+				profile := pprofile.NewProfileContainer()
+				profile.ProfileID().FromRaw(profileID2[:])
+				return profile
+			}(),
+			modified: func(profile pprofile.ProfileContainer) {
+				newProfile := pprofile.NewProfileContainer()
+				newProfile.ProfileID().FromRaw(profileID2[:])
+				newProfile.CopyTo(profile)
+			},
+		},
+		{
+			name: "profile_id",
+			path: &TestPath[*profileContext]{
+				N: "profile_id",
+			},
+			orig:   profileID[:],
+			newVal: profileID2[:],
+			modified: func(profile pprofile.ProfileContainer) {
+				profile.ProfileID().FromRaw(profileID2[:])
+			},
+		},
+		{
+			name: "profile_id string",
+			path: &TestPath[*profileContext]{
+				N: "profile_id",
+				NextPath: &TestPath[*profileContext]{
+					N: "string",
+				},
+			},
+			orig:   hex.EncodeToString(profileID[:]),
+			newVal: hex.EncodeToString(profileID2[:]),
+			modified: func(profile pprofile.ProfileContainer) {
+				profile.ProfileID().FromRaw(profileID2[:])
+			},
+		},
+		{
+			name: "start_time_unix_nano",
+			path: &TestPath[*profileContext]{
+				N: "start_time_unix_nano",
+			},
+			orig:   int64(100_000_000),
+			newVal: int64(200_000_000),
+			modified: func(profile pprofile.ProfileContainer) {
+				profile.SetStartTime(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
+			},
+		},
+		{
+			name: "end_time_unix_nano",
+			path: &TestPath[*profileContext]{
+				N: "end_time_unix_nano",
+			},
+			orig:   int64(500_000_000),
+			newVal: int64(200_000_000),
+			modified: func(profile pprofile.ProfileContainer) {
+				profile.SetEndTime(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
+			},
+		},
+		{
+			name: "start_time",
+			path: &TestPath[*profileContext]{
+				N: "start_time",
+			},
+			orig:   time.Date(1970, 1, 1, 0, 0, 0, 100000000, time.UTC),
+			newVal: time.Date(1970, 1, 1, 0, 0, 0, 200000000, time.UTC),
+			modified: func(profile pprofile.ProfileContainer) {
+				profile.SetStartTime(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
+			},
+		},
+		{
+			name: "end_time",
+			path: &TestPath[*profileContext]{
+				N: "end_time",
+			},
+			orig:   time.Date(1970, 1, 1, 0, 0, 0, 500000000, time.UTC),
+			newVal: time.Date(1970, 1, 1, 0, 0, 0, 200000000, time.UTC),
+			modified: func(profile pprofile.ProfileContainer) {
+				profile.SetEndTime(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
+			},
+		},
+		{
+			name: "attributes",
+			path: &TestPath[*profileContext]{
+				N: "attributes",
+			},
+			orig:   refProfile.Attributes(),
+			newVal: newAttrs,
+			modified: func(profile pprofile.ProfileContainer) {
+				newAttrs.CopyTo(profile.Attributes())
+			},
+		},
+		{
+			name: "attributes string",
+			path: &TestPath[*profileContext]{
+				N: "attributes",
+				KeySlice: []ottl.Key[*profileContext]{
+					&TestKey[*profileContext]{
+						S: ottltest.Strp("str"),
+					},
+				},
+			},
+			orig:   "val",
+			newVal: "newVal",
+			modified: func(profile pprofile.ProfileContainer) {
+				profile.Attributes().PutStr("str", "newVal")
+			},
+		},
+		{
+			name: "dropped_attributes_count",
+			path: &TestPath[*profileContext]{
+				N: "dropped_attributes_count",
+			},
+			orig:   int64(10),
+			newVal: int64(20),
+			modified: func(profile pprofile.ProfileContainer) {
+				profile.SetDroppedAttributesCount(20)
+			},
+		},
+		{
+			name: "samples",
+			path: &TestPath[*profileContext]{
+				N: "samples",
+			},
+			orig:   refProfile.Profile().Sample(),
+			newVal: newSamples,
+			modified: func(profile pprofile.ProfileContainer) {
+				newSamples.CopyTo(profile.Profile().Sample())
+			},
+		},
+		{
+			name: "string_table",
+			path: &TestPath[*profileContext]{
+				N: "string_table",
+			},
+			orig:   refProfile.Profile().StringTable(),
+			newVal: newStringTable,
+			modified: func(profile pprofile.ProfileContainer) {
+				newStringTable.CopyTo(profile.Profile().StringTable())
+			},
+		},
+		{
+			name: "string_table raw",
+			path: &TestPath[*profileContext]{
+				N: "string_table",
+			},
+			orig:   refProfile.Profile().StringTable(),
+			newVal: []string{"", "new"},
+			modified: func(profile pprofile.ProfileContainer) {
+				profile.Profile().StringTable().FromRaw([]string{"", "new"})
+			},
+		},
+		{
+			name: "period",
+			path: &TestPath[*profileContext]{
+				N: "period",
+			},
+			orig:   int64(10_000_000),
+			newVal: int64(20_000_000),
+			modified: func(profile pprofile.ProfileContainer) {
+				profile.Profile().SetPeriod(20_000_000)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessor, err := ProfilePathGetSetter[*profileContext](tt.path)
+			assert.NoError(t, err)
+
+			profile := createProfile()
+
+			got, err := accessor.Get(context.Background(), newProfileContext(profile))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.orig, got)
+
+			err = accessor.Set(context.Background(), newProfileContext(profile), tt.newVal)
+			assert.NoError(t, err)
+
+			expectedProfile := createProfile()
+			tt.modified(expectedProfile)
+
+			assert.Equal(t, expectedProfile, profile)
+		})
+	}
+}
+
+func TestProfilePathGetSetter_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		path ottl.Path[*profileContext]
+	}{
+		{
+			name: "unknown path",
+			path: &TestPath[*profileContext]{
+				N: "name",
+			},
+		},
+		{
+			name: "unknown profile_id path",
+			path: &TestPath[*profileContext]{
+				N: "profile_id",
+				NextPath: &TestPath[*profileContext]{
+					N: "bytes",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ProfilePathGetSetter[*profileContext](tt.path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func createProfile() pprofile.ProfileContainer {
+	profile := pprofile.NewProfileContainer()
+	profile.ProfileID().FromRaw(profileID[:])
+	profile.SetStartTime(pcommon.NewTimestampFromTime(time.UnixMilli(100)))
+	profile.SetEndTime(pcommon.NewTimestampFromTime(time.UnixMilli(500)))
+	profile.Attributes().PutStr("str", "val")
+	profile.Attributes().PutBool("bool", true)
+	profile.Attributes().PutInt("int", 10)
+	profile.SetDroppedAttributesCount(10)
+
+	profile.Profile().SetPeriod(10_000_000)
+	profile.Profile().StringTable().FromRaw([]string{"", "main", "main.go"})
+	profile.Profile().Sample().AppendEmpty().Value().FromRaw([]int64{1})
+
+	return profile
+}
+
+type profileContext struct {
+	profile pprofile.ProfileContainer
+}
+
+func (r *profileContext) GetProfile() pprofile.ProfileContainer {
+	return r.profile
+}
+
+func newProfileContext(profile pprofile.ProfileContainer) *profileContext {
+	return &profileContext{profile: profile}
+}
@@ -0,0 +1,208 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlprofile
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
+)
+
+var (
+	profileID = [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+)
+
+func Test_newPathGetSetter(t *testing.T) {
+	refProfile, _, _ := createTelemetry()
+
+	newAttrs := pcommon.NewMap()
+	newAttrs.PutStr("hello", "world")
+
+	newCache := pcommon.NewMap()
+	newCache.PutStr("temp", "value")
+
+	tests := []struct {
+		name     string
+		path     ottl.Path[TransformContext]
+		orig     any
+		newVal   any
+		modified func(profile pprofile.ProfileContainer, il pcommon.InstrumentationScope, resource pcommon.Resource, cache pcommon.Map)
+	}{
+		{
+			name: "cache",
+			path: &internal.TestPath[TransformContext]{
+				N: "cache",
+			},
+			orig:   pcommon.NewMap(),
+			newVal: newCache,
+			modified: func(_ pprofile.ProfileContainer, _ pcommon.InstrumentationScope, _ pcommon.Resource, cache pcommon.Map) {
+				newCache.CopyTo(cache)
+			},
+		},
+		{
+			name: "cache access",
+			path: &internal.TestPath[TransformContext]{
+				N: "cache",
+				KeySlice: []ottl.Key[TransformContext]{
+					&internal.TestKey[TransformContext]{
+						S: ottltest.Strp("temp"),
+					},
+				},
+			},
+			orig:   nil,
+			newVal: "new value",
+			modified: func(_ pprofile.ProfileContainer, _ pcommon.InstrumentationScope, _ pcommon.Resource, cache pcommon.Map) {
+				cache.PutStr("temp", "new value")
+			},
+		},
+		{
+			name: "start_time_unix_nano",
+			path: &internal.TestPath[TransformContext]{
+				N: "start_time_unix_nano",
+			},
+			orig:   int64(100_000_000),
+			newVal: int64(200_000_000),
+			modified: func(profile pprofile.ProfileContainer, _ pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				profile.SetStartTime(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
+			},
+		},
+		{
+			name: "attributes",
+			path: &internal.TestPath[TransformContext]{
+				N: "attributes",
+			},
+			orig:   refProfile.Attributes(),
+			newVal: newAttrs,
+			modified: func(profile pprofile.ProfileContainer, _ pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				newAttrs.CopyTo(profile.Attributes())
+			},
+		},
+		{
+			name: "attributes string",
+			path: &internal.TestPath[TransformContext]{
+				N: "attributes",
+				KeySlice: []ottl.Key[TransformContext]{
+					&internal.TestKey[TransformContext]{
+						S: ottltest.Strp("str"),
+					},
+				},
+			},
+			orig:   "val",
+			newVal: "newVal",
+			modified: func(profile pprofile.ProfileContainer, _ pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				profile.Attributes().PutStr("str", "newVal")
+			},
+		},
+		{
+			name: "period",
+			path: &internal.TestPath[TransformContext]{
+				N: "period",
+			},
+			orig:   int64(10_000_000),
+			newVal: int64(20_000_000),
+			modified: func(profile pprofile.ProfileContainer, _ pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				profile.Profile().SetPeriod(20_000_000)
+			},
+		},
+		{
+			name: "resource attributes",
+			path: &internal.TestPath[TransformContext]{
+				N: "resource",
+				NextPath: &internal.TestPath[TransformContext]{
+					N: "attributes",
+				},
+			},
+			orig:   refProfileResource().Attributes(),
+			newVal: newAttrs,
+			modified: func(_ pprofile.ProfileContainer, _ pcommon.InstrumentationScope, resource pcommon.Resource, _ pcommon.Map) {
+				newAttrs.CopyTo(resource.Attributes())
+			},
+		},
+		{
+			name: "instrumentation_scope name",
+			path: &internal.TestPath[TransformContext]{
+				N: "instrumentation_scope",
+				NextPath: &internal.TestPath[TransformContext]{
+					N: "name",
+				},
+			},
+			orig:   "library",
+			newVal: "new library",
+			modified: func(_ pprofile.ProfileContainer, il pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				il.SetName("new library")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pep := pathExpressionParser{}
+			accessor, err := pep.parsePath(tt.path)
+			assert.NoError(t, err)
+
+			profile, il, resource := createTelemetry()
+
+			tCtx := NewTransformContext(profile, il, resource)
+
+			got, err := accessor.Get(context.Background(), tCtx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.orig, got)
+
+			err = accessor.Set(context.Background(), tCtx, tt.newVal)
+			assert.NoError(t, err)
+
+			exProfile, exIl, exRes := createTelemetry()
+			exCache := pcommon.NewMap()
+			tt.modified(exProfile, exIl, exRes, exCache)
+
+			assert.Equal(t, exProfile, profile)
+			assert.Equal(t, exIl, il)
+			assert.Equal(t, exRes, resource)
+			assert.Equal(t, exCache, tCtx.getCache())
+		})
+	}
+}
+
+func Test_newPathGetSetter_Invalid(t *testing.T) {
+	pep := pathExpressionParser{}
+	_, err := pep.parsePath(&internal.TestPath[TransformContext]{
+		N: "name",
+	})
+	assert.Error(t, err)
+}
+
+func Test_ParseEnum_False(t *testing.T) {
+	actual, err := parseEnum((*ottl.EnumSymbol)(ottltest.Strp("SPAN_KIND_SERVER")))
+	assert.Error(t, err)
+	assert.Nil(t, actual)
+}
+
+func refProfileResource() pcommon.Resource {
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("service.name", "profiled")
+	return resource
+}
+
+func createTelemetry() (pprofile.ProfileContainer, pcommon.InstrumentationScope, pcommon.Resource) {
+	profile := pprofile.NewProfileContainer()
+	profile.ProfileID().FromRaw(profileID[:])
+	profile.SetStartTime(pcommon.NewTimestampFromTime(time.UnixMilli(100)))
+	profile.SetEndTime(pcommon.NewTimestampFromTime(time.UnixMilli(500)))
+	profile.Attributes().PutStr("str", "val")
+	profile.Attributes().PutInt("int", 10)
+	profile.Profile().SetPeriod(10_000_000)
+
+	il := pcommon.NewInstrumentationScope()
+	il.SetName("library")
+	il.SetVersion("version")
+
+	return profile, il, refProfileResource()
+}
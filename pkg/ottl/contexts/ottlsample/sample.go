@@ -0,0 +1,288 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlsample // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlsample"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/logging"
+)
+
+var _ internal.ResourceContext = (*TransformContext)(nil)
+var _ internal.InstrumentationScopeContext = (*TransformContext)(nil)
+var _ internal.ProfileContext = (*TransformContext)(nil)
+var _ zapcore.ObjectMarshaler = (*TransformContext)(nil)
+
+type TransformContext struct {
+	sample               pprofile.Sample
+	profile              pprofile.ProfileContainer
+	instrumentationScope pcommon.InstrumentationScope
+	resource             pcommon.Resource
+	cache                pcommon.Map
+}
+
+func (tCtx TransformContext) MarshalLogObject(encoder zapcore.ObjectEncoder) error {
+	err := encoder.AddObject("resource", logging.Resource(tCtx.resource))
+	err = errors.Join(err, encoder.AddObject("scope", logging.InstrumentationScope(tCtx.instrumentationScope)))
+	err = errors.Join(err, encoder.AddObject("profile", logging.Profile(tCtx.profile)))
+	err = errors.Join(err, encoder.AddObject("sample", logging.Sample(tCtx.sample)))
+	err = errors.Join(err, encoder.AddObject("cache", logging.Map(tCtx.cache)))
+	return err
+}
+
+type Option func(*ottl.Parser[TransformContext])
+
+func NewTransformContext(sample pprofile.Sample, profile pprofile.ProfileContainer, instrumentationScope pcommon.InstrumentationScope, resource pcommon.Resource) TransformContext {
+	return TransformContext{
+		sample:               sample,
+		profile:              profile,
+		instrumentationScope: instrumentationScope,
+		resource:             resource,
+		cache:                pcommon.NewMap(),
+	}
+}
+
+func (tCtx TransformContext) GetSample() pprofile.Sample {
+	return tCtx.sample
+}
+
+func (tCtx TransformContext) GetProfile() pprofile.ProfileContainer {
+	return tCtx.profile
+}
+
+func (tCtx TransformContext) GetInstrumentationScope() pcommon.InstrumentationScope {
+	return tCtx.instrumentationScope
+}
+
+func (tCtx TransformContext) GetResource() pcommon.Resource {
+	return tCtx.resource
+}
+
+func (tCtx TransformContext) getCache() pcommon.Map {
+	return tCtx.cache
+}
+
+func NewParser(functions map[string]ottl.Factory[TransformContext], telemetrySettings component.TelemetrySettings, options ...Option) (ottl.Parser[TransformContext], error) {
+	pep := pathExpressionParser{telemetrySettings}
+	p, err := ottl.NewParser[TransformContext](
+		functions,
+		pep.parsePath,
+		telemetrySettings,
+		ottl.WithEnumParser[TransformContext](parseEnum),
+	)
+	if err != nil {
+		return ottl.Parser[TransformContext]{}, err
+	}
+	for _, opt := range options {
+		opt(&p)
+	}
+	return p, nil
+}
+
+type StatementSequenceOption func(*ottl.StatementSequence[TransformContext])
+
+func WithStatementSequenceErrorMode(errorMode ottl.ErrorMode) StatementSequenceOption {
+	return func(s *ottl.StatementSequence[TransformContext]) {
+		ottl.WithStatementSequenceErrorMode[TransformContext](errorMode)(s)
+	}
+}
+
+func NewStatementSequence(statements []*ottl.Statement[TransformContext], telemetrySettings component.TelemetrySettings, options ...StatementSequenceOption) ottl.StatementSequence[TransformContext] {
+	s := ottl.NewStatementSequence(statements, telemetrySettings)
+	for _, op := range options {
+		op(&s)
+	}
+	return s
+}
+
+type ConditionSequenceOption func(*ottl.ConditionSequence[TransformContext])
+
+func WithConditionSequenceErrorMode(errorMode ottl.ErrorMode) ConditionSequenceOption {
+	return func(c *ottl.ConditionSequence[TransformContext]) {
+		ottl.WithConditionSequenceErrorMode[TransformContext](errorMode)(c)
+	}
+}
+
+func NewConditionSequence(conditions []*ottl.Condition[TransformContext], telemetrySettings component.TelemetrySettings, options ...ConditionSequenceOption) ottl.ConditionSequence[TransformContext] {
+	c := ottl.NewConditionSequence(conditions, telemetrySettings)
+	for _, op := range options {
+		op(&c)
+	}
+	return c
+}
+
+func parseEnum(_ *ottl.EnumSymbol) (*ottl.Enum, error) {
+	return nil, fmt.Errorf("sample context does not provide Enum support")
+}
+
+type pathExpressionParser struct {
+	telemetrySettings component.TelemetrySettings
+}
+
+func (pep *pathExpressionParser) parsePath(path ottl.Path[TransformContext]) (ottl.GetSetter[TransformContext], error) {
+	if path == nil {
+		return nil, fmt.Errorf("path cannot be nil")
+	}
+	switch path.Name() {
+	case "cache":
+		if path.Keys() == nil {
+			return accessCache(), nil
+		}
+		return accessCacheKey(path.Keys()), nil
+	case "resource":
+		return internal.ResourcePathGetSetter[TransformContext](path.Next())
+	case "instrumentation_scope":
+		return internal.ScopePathGetSetter[TransformContext](path.Next())
+	case "profile":
+		return internal.ProfilePathGetSetter[TransformContext](path.Next())
+	case "values":
+		return accessValues(), nil
+	case "attributes":
+		if path.Keys() == nil {
+			return accessAttributes(), nil
+		}
+		return accessAttributesKey(path.Keys()), nil
+	case "locations":
+		if path.Keys() == nil {
+			return accessLocations(), nil
+		}
+		return accessLocationsKey(path.Keys()), nil
+	case "functions":
+		if path.Keys() == nil {
+			return accessFunctions(), nil
+		}
+		return accessFunctionsKey(path.Keys()), nil
+	default:
+		return nil, internal.FormatDefaultErrorMessage(path.Name(), path.String(), "Sample", internal.SampleRef)
+	}
+}
+
+func accessCache() ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(_ context.Context, tCtx TransformContext) (any, error) {
+			return tCtx.getCache(), nil
+		},
+		Setter: func(_ context.Context, tCtx TransformContext, val any) error {
+			if m, ok := val.(pcommon.Map); ok {
+				m.CopyTo(tCtx.getCache())
+			}
+			return nil
+		},
+	}
+}
+
+func accessCacheKey(key []ottl.Key[TransformContext]) ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(ctx context.Context, tCtx TransformContext) (any, error) {
+			return internal.GetMapValue[TransformContext](ctx, tCtx, tCtx.getCache(), key)
+		},
+		Setter: func(ctx context.Context, tCtx TransformContext, val any) error {
+			return internal.SetMapValue[TransformContext](ctx, tCtx, tCtx.getCache(), key, val)
+		},
+	}
+}
+
+func accessValues() ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(_ context.Context, tCtx TransformContext) (any, error) {
+			return tCtx.GetSample().Value().AsRaw(), nil
+		},
+		Setter: func(_ context.Context, tCtx TransformContext, val any) error {
+			switch v := val.(type) {
+			case []int64:
+				tCtx.GetSample().Value().FromRaw(v)
+			case []any:
+				values := make([]int64, 0, len(v))
+				for _, item := range v {
+					i, ok := item.(int64)
+					if !ok {
+						return fmt.Errorf("sample values must be int64, got %T", item)
+					}
+					values = append(values, i)
+				}
+				tCtx.GetSample().Value().FromRaw(values)
+			}
+			return nil
+		},
+	}
+}
+
+func accessAttributes() ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(_ context.Context, tCtx TransformContext) (any, error) {
+			return sampleAttributes(tCtx.GetProfile().Profile(), tCtx.GetSample()), nil
+		},
+		Setter: func(_ context.Context, _ TransformContext, _ any) error {
+			return errReadOnly("attributes")
+		},
+	}
+}
+
+func accessAttributesKey(keys []ottl.Key[TransformContext]) ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(ctx context.Context, tCtx TransformContext) (any, error) {
+			return internal.GetMapValue[TransformContext](ctx, tCtx, sampleAttributes(tCtx.GetProfile().Profile(), tCtx.GetSample()), keys)
+		},
+		Setter: func(_ context.Context, _ TransformContext, _ any) error {
+			return errReadOnly("attributes")
+		},
+	}
+}
+
+func accessLocations() ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(_ context.Context, tCtx TransformContext) (any, error) {
+			return stackLocations(tCtx.GetProfile().Profile(), tCtx.GetSample()), nil
+		},
+		Setter: func(_ context.Context, _ TransformContext, _ any) error {
+			return errReadOnly("locations")
+		},
+	}
+}
+
+func accessLocationsKey(keys []ottl.Key[TransformContext]) ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(ctx context.Context, tCtx TransformContext) (any, error) {
+			return internal.GetSliceValue[TransformContext](ctx, tCtx, stackLocations(tCtx.GetProfile().Profile(), tCtx.GetSample()), keys)
+		},
+		Setter: func(_ context.Context, _ TransformContext, _ any) error {
+			return errReadOnly("locations")
+		},
+	}
+}
+
+func accessFunctions() ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(_ context.Context, tCtx TransformContext) (any, error) {
+			return stackFunctions(tCtx.GetProfile().Profile(), tCtx.GetSample()), nil
+		},
+		Setter: func(_ context.Context, _ TransformContext, _ any) error {
+			return errReadOnly("functions")
+		},
+	}
+}
+
+func accessFunctionsKey(keys []ottl.Key[TransformContext]) ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(ctx context.Context, tCtx TransformContext) (any, error) {
+			return internal.GetSliceValue[TransformContext](ctx, tCtx, stackFunctions(tCtx.GetProfile().Profile(), tCtx.GetSample()), keys)
+		},
+		Setter: func(_ context.Context, _ TransformContext, _ any) error {
+			return errReadOnly("functions")
+		},
+	}
+}
+
+func errReadOnly(path string) error {
+	return fmt.Errorf("the %q path of the sample context is resolved from the profile tables and cannot be set", path)
+}
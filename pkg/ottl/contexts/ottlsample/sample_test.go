@@ -0,0 +1,310 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlsample
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
+)
+
+func Test_newPathGetSetter(t *testing.T) {
+	newCache := pcommon.NewMap()
+	newCache.PutStr("temp", "value")
+
+	tests := []struct {
+		name     string
+		path     ottl.Path[TransformContext]
+		orig     any
+		newVal   any
+		modified func(sample pprofile.Sample, profile pprofile.ProfileContainer, il pcommon.InstrumentationScope, resource pcommon.Resource, cache pcommon.Map)
+	}{
+		{
+			name: "cache",
+			path: &internal.TestPath[TransformContext]{
+				N: "cache",
+			},
+			orig:   pcommon.NewMap(),
+			newVal: newCache,
+			modified: func(_ pprofile.Sample, _ pprofile.ProfileContainer, _ pcommon.InstrumentationScope, _ pcommon.Resource, cache pcommon.Map) {
+				newCache.CopyTo(cache)
+			},
+		},
+		{
+			name: "cache access",
+			path: &internal.TestPath[TransformContext]{
+				N: "cache",
+				KeySlice: []ottl.Key[TransformContext]{
+					&internal.TestKey[TransformContext]{
+						S: ottltest.Strp("temp"),
+					},
+				},
+			},
+			orig:   nil,
+			newVal: "new value",
+			modified: func(_ pprofile.Sample, _ pprofile.ProfileContainer, _ pcommon.InstrumentationScope, _ pcommon.Resource, cache pcommon.Map) {
+				cache.PutStr("temp", "new value")
+			},
+		},
+		{
+			name: "values",
+			path: &internal.TestPath[TransformContext]{
+				N: "values",
+			},
+			orig:   []int64{1, 10_000_000},
+			newVal: []int64{7, 8},
+			modified: func(sample pprofile.Sample, _ pprofile.ProfileContainer, _ pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				sample.Value().FromRaw([]int64{7, 8})
+			},
+		},
+		{
+			name: "values list",
+			path: &internal.TestPath[TransformContext]{
+				N: "values",
+			},
+			orig:   []int64{1, 10_000_000},
+			newVal: []any{int64(7), int64(8)},
+			modified: func(sample pprofile.Sample, _ pprofile.ProfileContainer, _ pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				sample.Value().FromRaw([]int64{7, 8})
+			},
+		},
+		{
+			name: "profile period",
+			path: &internal.TestPath[TransformContext]{
+				N: "profile",
+				NextPath: &internal.TestPath[TransformContext]{
+					N: "period",
+				},
+			},
+			orig:   int64(10_000_000),
+			newVal: int64(20_000_000),
+			modified: func(_ pprofile.Sample, profile pprofile.ProfileContainer, _ pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				profile.Profile().SetPeriod(20_000_000)
+			},
+		},
+		{
+			name: "instrumentation_scope name",
+			path: &internal.TestPath[TransformContext]{
+				N: "instrumentation_scope",
+				NextPath: &internal.TestPath[TransformContext]{
+					N: "name",
+				},
+			},
+			orig:   "library",
+			newVal: "new library",
+			modified: func(_ pprofile.Sample, _ pprofile.ProfileContainer, il pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				il.SetName("new library")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pep := pathExpressionParser{}
+			accessor, err := pep.parsePath(tt.path)
+			assert.NoError(t, err)
+
+			sample, profile, il, resource := createTelemetry()
+
+			tCtx := NewTransformContext(sample, profile, il, resource)
+
+			got, err := accessor.Get(context.Background(), tCtx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.orig, got)
+
+			err = accessor.Set(context.Background(), tCtx, tt.newVal)
+			assert.NoError(t, err)
+
+			exSample, exProfile, exIl, exRes := createTelemetry()
+			exCache := pcommon.NewMap()
+			tt.modified(exSample, exProfile, exIl, exRes, exCache)
+
+			assert.Equal(t, exSample, sample)
+			assert.Equal(t, exProfile, profile)
+			assert.Equal(t, exIl, il)
+			assert.Equal(t, exRes, resource)
+			assert.Equal(t, exCache, tCtx.getCache())
+		})
+	}
+}
+
+func Test_newPathGetSetter_ResolvedPaths(t *testing.T) {
+	expectedAttributes := pcommon.NewMap()
+	expectedAttributes.PutStr("thread.name", "worker")
+
+	expectedLocations := pcommon.NewSlice()
+	innermost := expectedLocations.AppendEmpty().SetEmptyMap()
+	innermost.PutInt("address", 0x10)
+	innermostLine := innermost.PutEmptySlice("lines").AppendEmpty().SetEmptyMap()
+	innermostLine.PutStr("function", "main.handle")
+	innermostLine.PutStr("filename", "main.go")
+	innermostLine.PutInt("line", 12)
+	outermost := expectedLocations.AppendEmpty().SetEmptyMap()
+	outermost.PutInt("address", 0x20)
+	outermostLine := outermost.PutEmptySlice("lines").AppendEmpty().SetEmptyMap()
+	outermostLine.PutStr("function", "runtime.goexit")
+	outermostLine.PutStr("filename", "runtime.go")
+	outermostLine.PutInt("line", 30)
+
+	expectedFunctions := pcommon.NewSlice()
+	expectedFunctions.AppendEmpty().SetStr("main.handle")
+	expectedFunctions.AppendEmpty().SetStr("runtime.goexit")
+
+	tests := []struct {
+		name     string
+		path     ottl.Path[TransformContext]
+		expected any
+	}{
+		{
+			name: "attributes",
+			path: &internal.TestPath[TransformContext]{
+				N: "attributes",
+			},
+			expected: expectedAttributes,
+		},
+		{
+			name: "attributes string",
+			path: &internal.TestPath[TransformContext]{
+				N: "attributes",
+				KeySlice: []ottl.Key[TransformContext]{
+					&internal.TestKey[TransformContext]{
+						S: ottltest.Strp("thread.name"),
+					},
+				},
+			},
+			expected: "worker",
+		},
+		{
+			name: "locations",
+			path: &internal.TestPath[TransformContext]{
+				N: "locations",
+			},
+			expected: expectedLocations,
+		},
+		{
+			name: "locations index",
+			path: &internal.TestPath[TransformContext]{
+				N: "locations",
+				KeySlice: []ottl.Key[TransformContext]{
+					&internal.TestKey[TransformContext]{
+						I: ottltest.Intp(1),
+					},
+					&internal.TestKey[TransformContext]{
+						S: ottltest.Strp("address"),
+					},
+				},
+			},
+			expected: int64(0x20),
+		},
+		{
+			name: "functions",
+			path: &internal.TestPath[TransformContext]{
+				N: "functions",
+			},
+			expected: expectedFunctions,
+		},
+		{
+			name: "functions index",
+			path: &internal.TestPath[TransformContext]{
+				N: "functions",
+				KeySlice: []ottl.Key[TransformContext]{
+					&internal.TestKey[TransformContext]{
+						I: ottltest.Intp(0),
+					},
+				},
+			},
+			expected: "main.handle",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pep := pathExpressionParser{}
+			accessor, err := pep.parsePath(tt.path)
+			assert.NoError(t, err)
+
+			sample, profile, il, resource := createTelemetry()
+			tCtx := NewTransformContext(sample, profile, il, resource)
+
+			got, err := accessor.Get(context.Background(), tCtx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+
+			err = accessor.Set(context.Background(), tCtx, "new value")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_sampleLocations_LocationIndex(t *testing.T) {
+	_, profile, _, _ := createTelemetry()
+
+	sample := profile.Profile().Sample().AppendEmpty()
+	sample.LocationIndex().FromRaw([]uint64{1, 5})
+
+	functions := stackFunctions(profile.Profile(), sample)
+	assert.Equal(t, []any{"runtime.goexit"}, functions.AsRaw())
+}
+
+func Test_newPathGetSetter_Invalid(t *testing.T) {
+	pep := pathExpressionParser{}
+	_, err := pep.parsePath(&internal.TestPath[TransformContext]{
+		N: "name",
+	})
+	assert.Error(t, err)
+}
+
+func Test_ParseEnum_False(t *testing.T) {
+	actual, err := parseEnum((*ottl.EnumSymbol)(ottltest.Strp("SPAN_KIND_SERVER")))
+	assert.Error(t, err)
+	assert.Nil(t, actual)
+}
+
+func createTelemetry() (pprofile.Sample, pprofile.ProfileContainer, pcommon.InstrumentationScope, pcommon.Resource) {
+	profile := pprofile.NewProfileContainer()
+	profile.Profile().SetPeriod(10_000_000)
+	profile.Profile().StringTable().FromRaw([]string{"", "main.handle", "main.go", "runtime.goexit", "runtime.go"})
+	profile.Profile().AttributeTable().PutStr("thread.name", "worker")
+	profile.Profile().AttributeTable().PutInt("process.pid", 42)
+
+	handle := profile.Profile().Function().AppendEmpty()
+	handle.SetName(1)
+	handle.SetFilename(2)
+	goexit := profile.Profile().Function().AppendEmpty()
+	goexit.SetName(3)
+	goexit.SetFilename(4)
+
+	inner := profile.Profile().Location().AppendEmpty()
+	inner.SetAddress(0x10)
+	innerLine := inner.Line().AppendEmpty()
+	innerLine.SetFunctionIndex(0)
+	innerLine.SetLine(12)
+	outer := profile.Profile().Location().AppendEmpty()
+	outer.SetAddress(0x20)
+	outerLine := outer.Line().AppendEmpty()
+	outerLine.SetFunctionIndex(1)
+	outerLine.SetLine(30)
+
+	profile.Profile().LocationIndices().FromRaw([]int64{0, 1})
+
+	sample := profile.Profile().Sample().AppendEmpty()
+	sample.SetLocationsStartIndex(0)
+	sample.SetLocationsLength(2)
+	sample.Value().FromRaw([]int64{1, 10_000_000})
+	sample.Attributes().FromRaw([]uint64{0})
+
+	il := pcommon.NewInstrumentationScope()
+	il.SetName("library")
+	il.SetVersion("version")
+
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("service.name", "profiled")
+
+	return sample, profile, il, resource
+}
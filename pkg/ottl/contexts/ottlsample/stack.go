@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlsample // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlsample"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+)
+
+// A sample only references its stack and attributes through indices into the tables of the
+// profile it belongs to. The helpers below resolve those references so statements can work
+// with function names and file names instead of table indices.
+
+// sampleLocations returns the locations of the sample stack trace, innermost frame first.
+// Both the deprecated location_index field and the locations_start_index/locations_length
+// range over the profile location_indices are supported.
+func sampleLocations(profile pprofile.Profile, sample pprofile.Sample) []pprofile.Location {
+	locations := profile.Location()
+
+	if sample.LocationIndex().Len() > 0 {
+		result := make([]pprofile.Location, 0, sample.LocationIndex().Len())
+		for i := 0; i < sample.LocationIndex().Len(); i++ {
+			idx := sample.LocationIndex().At(i)
+			if idx < uint64(locations.Len()) {
+				result = append(result, locations.At(int(idx)))
+			}
+		}
+		return result
+	}
+
+	indices := profile.LocationIndices()
+	start := sample.LocationsStartIndex()
+	end := start + sample.LocationsLength()
+	result := make([]pprofile.Location, 0, sample.LocationsLength())
+	for i := start; i < end && i < uint64(indices.Len()); i++ {
+		idx := indices.At(int(i))
+		if idx >= 0 && idx < int64(locations.Len()) {
+			result = append(result, locations.At(int(idx)))
+		}
+	}
+	return result
+}
+
+// stringAt returns the entry of the profile string table at the given index, or an empty
+// string when the index is out of range.
+func stringAt(profile pprofile.Profile, idx int64) string {
+	if idx < 0 || idx >= int64(profile.StringTable().Len()) {
+		return ""
+	}
+	return profile.StringTable().At(int(idx))
+}
+
+// putLine writes the function, file name and line number of a location line into m.
+func putLine(profile pprofile.Profile, line pprofile.Line, m pcommon.Map) {
+	functions := profile.Function()
+	if line.FunctionIndex() < uint64(functions.Len()) {
+		function := functions.At(int(line.FunctionIndex()))
+		m.PutStr("function", stringAt(profile, function.Name()))
+		m.PutStr("filename", stringAt(profile, function.Filename()))
+	}
+	m.PutInt("line", line.Line())
+}
+
+// stackLocations returns the sample stack trace as a slice of maps, one per location, each
+// holding the location address and its lines. A location has several lines when functions
+// were inlined, the last one being the caller.
+func stackLocations(profile pprofile.Profile, sample pprofile.Sample) pcommon.Slice {
+	result := pcommon.NewSlice()
+	for _, location := range sampleLocations(profile, sample) {
+		m := result.AppendEmpty().SetEmptyMap()
+		m.PutInt("address", int64(location.Address()))
+		lines := m.PutEmptySlice("lines")
+		for i := 0; i < location.Line().Len(); i++ {
+			putLine(profile, location.Line().At(i), lines.AppendEmpty().SetEmptyMap())
+		}
+	}
+	return result
+}
+
+// stackFunctions returns the names of the functions of the sample stack trace, innermost
+// frame first, including inlined functions.
+func stackFunctions(profile pprofile.Profile, sample pprofile.Sample) pcommon.Slice {
+	result := pcommon.NewSlice()
+	functions := profile.Function()
+	for _, location := range sampleLocations(profile, sample) {
+		for i := 0; i < location.Line().Len(); i++ {
+			functionIndex := location.Line().At(i).FunctionIndex()
+			if functionIndex < uint64(functions.Len()) {
+				result.AppendEmpty().SetStr(stringAt(profile, functions.At(int(functionIndex)).Name()))
+			}
+		}
+	}
+	return result
+}
+
+// sampleAttributes resolves the sample attribute indices against the profile attribute table.
+func sampleAttributes(profile pprofile.Profile, sample pprofile.Sample) pcommon.Map {
+	result := pcommon.NewMap()
+	indices := sample.Attributes()
+	if indices.Len() == 0 {
+		return result
+	}
+
+	wanted := make(map[int]struct{}, indices.Len())
+	for i := 0; i < indices.Len(); i++ {
+		wanted[int(indices.At(i))] = struct{}{}
+	}
+
+	idx := 0
+	profile.AttributeTable().Range(func(k string, v pcommon.Value) bool {
+		if _, ok := wanted[idx]; ok {
+			v.CopyTo(result.PutEmpty(k))
+		}
+		idx++
+		return true
+	})
+	return result
+}
@@ -544,6 +544,16 @@ func Test_e2e_converters(t *testing.T) {
 				m.PutStr("k2", "v2__!__v2")
 			},
 		},
+		{
+			statement: `set(attributes["test"], ParseQueryString("?q=otel%20collector&tag=a&tag=b"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				m := tCtx.GetLogRecord().Attributes().PutEmptyMap("test")
+				m.PutStr("q", "otel collector")
+				s := m.PutEmptySlice("tag")
+				s.AppendEmpty().SetStr("a")
+				s.AppendEmpty().SetStr("b")
+			},
+		},
 		{
 			statement: `set(attributes["test"], ParseXML("<Log id=\"1\"><Message>This is a log message!</Message></Log>"))`,
 			want: func(tCtx ottllog.TransformContext) {
@@ -665,6 +675,29 @@ func Test_e2e_converters(t *testing.T) {
 				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
 			},
 		},
+		{
+			statement: `set(attributes["test"], URL("https://www.example.com:8443/index.html?q=otel#top"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				m := tCtx.GetLogRecord().Attributes().PutEmptyMap("test")
+				m.PutStr("url.original", "https://www.example.com:8443/index.html?q=otel#top")
+				m.PutStr("url.scheme", "https")
+				m.PutStr("url.domain", "www.example.com")
+				m.PutInt("url.port", 8443)
+				m.PutStr("url.path", "/index.html")
+				m.PutStr("url.query", "q=otel")
+				m.PutStr("url.fragment", "top")
+				m.PutStr("url.extension", "html")
+			},
+		},
+		{
+			statement: `set(attributes["test"], UserAgent("curl/8.8.0"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				m := tCtx.GetLogRecord().Attributes().PutEmptyMap("test")
+				m.PutStr("user_agent.original", "curl/8.8.0")
+				m.PutStr("user_agent.name", "curl")
+				m.PutStr("user_agent.version", "8.8.0")
+			},
+		},
 		{
 			statement: `set(attributes["test"], "pass") where IsString(UUID())`,
 			want: func(tCtx ottllog.TransformContext) {
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type ParseQueryStringArguments[K any] struct {
+	Target ottl.StringGetter[K]
+}
+
+func NewParseQueryStringFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ParseQueryString", &ParseQueryStringArguments[K]{}, createParseQueryStringFunction[K])
+}
+
+func createParseQueryStringFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ParseQueryStringArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("ParseQueryStringFactory args must be of type *ParseQueryStringArguments[K]")
+	}
+
+	return parseQueryString(args.Target), nil
+}
+
+func parseQueryString[K any](target ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		source, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		values, err := url.ParseQuery(strings.TrimPrefix(source, "?"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse query string %q: %w", source, err)
+		}
+
+		result := pcommon.NewMap()
+		result.EnsureCapacity(len(values))
+		for k, v := range values {
+			if len(v) == 1 {
+				result.PutStr(k, v[0])
+				continue
+			}
+			s := result.PutEmptySlice(k)
+			s.EnsureCapacity(len(v))
+			for _, value := range v {
+				s.AppendEmpty().SetStr(value)
+			}
+		}
+		return result, nil
+	}
+}
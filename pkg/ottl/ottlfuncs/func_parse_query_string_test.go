@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_parseQueryString(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		expected map[string]any
+	}{
+		{
+			name:   "simple",
+			target: "name=ottl&func=query_string",
+			expected: map[string]any{
+				"name": "ottl",
+				"func": "query_string",
+			},
+		},
+		{
+			name:   "leading question mark",
+			target: "?q=otel",
+			expected: map[string]any{
+				"q": "otel",
+			},
+		},
+		{
+			name:   "repeated keys",
+			target: "tag=a&tag=b&tag=c&page=2",
+			expected: map[string]any{
+				"tag":  []any{"a", "b", "c"},
+				"page": "2",
+			},
+		},
+		{
+			name:   "escaped values",
+			target: "q=otel%20collector&path=%2Fvar%2Flog&plus=a+b",
+			expected: map[string]any{
+				"q":    "otel collector",
+				"path": "/var/log",
+				"plus": "a b",
+			},
+		},
+		{
+			name:   "empty values",
+			target: "debug&verbose=",
+			expected: map[string]any{
+				"debug":   "",
+				"verbose": "",
+			},
+		},
+		{
+			name:     "empty target",
+			target:   "",
+			expected: map[string]any{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return tt.target, nil
+				},
+			}
+			result, err := parseQueryString[any](target)(context.Background(), nil)
+			require.NoError(t, err)
+
+			actual, ok := result.(pcommon.Map)
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, actual.AsRaw())
+		})
+	}
+}
+
+func Test_parseQueryString_error(t *testing.T) {
+	tests := []struct {
+		name   string
+		target any
+	}{
+		{
+			name:   "invalid escape",
+			target: "q=%zz",
+		},
+		{
+			name:   "bad target",
+			target: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return tt.target, nil
+				},
+			}
+			_, err := parseQueryString[any](target)(context.Background(), nil)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type URLArguments[K any] struct {
+	URI ottl.StringGetter[K]
+}
+
+func NewURLFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("URL", &URLArguments[K]{}, createURLFunction[K])
+}
+
+func createURLFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*URLArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("URLFactory args must be of type *URLArguments[K]")
+	}
+
+	return parseURL(args.URI), nil
+}
+
+func parseURL[K any](target ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		source, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		if source == "" {
+			return nil, fmt.Errorf("cannot parse from empty target")
+		}
+
+		u, err := url.Parse(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse url %q: %w", source, err)
+		}
+
+		result := pcommon.NewMap()
+		result.PutStr("url.original", source)
+		putNonEmpty(result, "url.scheme", u.Scheme)
+		putNonEmpty(result, "url.domain", u.Hostname())
+		putNonEmpty(result, "url.path", u.EscapedPath())
+		putNonEmpty(result, "url.query", u.RawQuery)
+		putNonEmpty(result, "url.fragment", u.Fragment)
+		if ext := path.Ext(u.Path); len(ext) > 1 {
+			result.PutStr("url.extension", ext[1:])
+		}
+		if u.User != nil {
+			result.PutStr("url.username", u.User.Username())
+			if password, ok := u.User.Password(); ok {
+				result.PutStr("url.password", password)
+			}
+		}
+
+		if p := u.Port(); p != "" {
+			port, err := strconv.ParseInt(p, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse port %q of url %q: %w", p, source, err)
+			}
+			result.PutInt("url.port", port)
+		}
+
+		return result, nil
+	}
+}
+
+func putNonEmpty(m pcommon.Map, key, value string) {
+	if value != "" {
+		m.PutStr(key, value)
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type UserAgentArguments[K any] struct {
+	UserAgent ottl.StringGetter[K]
+}
+
+func NewUserAgentFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("UserAgent", &UserAgentArguments[K]{}, createUserAgentFunction[K])
+}
+
+func createUserAgentFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*UserAgentArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("UserAgentFactory args must be of type *UserAgentArguments[K]")
+	}
+
+	return userAgent(args.UserAgent), nil
+}
+
+func userAgent[K any](target ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		source, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		ua := parseUserAgent(source)
+
+		result := pcommon.NewMap()
+		result.PutStr("user_agent.original", source)
+		putNonEmpty(result, "user_agent.name", ua.name)
+		putNonEmpty(result, "user_agent.version", ua.version)
+		putNonEmpty(result, "os.name", ua.osName)
+		putNonEmpty(result, "os.version", ua.osVersion)
+		putNonEmpty(result, "device.model.name", ua.device)
+		return result, nil
+	}
+}
+
+type userAgentInfo struct {
+	name      string
+	version   string
+	osName    string
+	osVersion string
+	device    string
+}
+
+// userAgentBrowser matches the product token of a browser or client. The browsers are
+// checked in order, since most of them also advertise the tokens of the browsers they derive from.
+type userAgentBrowser struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+var userAgentBrowsers = []userAgentBrowser{
+	{name: "Edge", pattern: regexp.MustCompile(`\b(?:Edge|Edg|EdgA|EdgiOS)/([\d.]+)`)},
+	{name: "Opera", pattern: regexp.MustCompile(`\b(?:OPR|Opera)/([\d.]+)`)},
+	{name: "Samsung Internet", pattern: regexp.MustCompile(`\bSamsungBrowser/([\d.]+)`)},
+	{name: "Chrome", pattern: regexp.MustCompile(`\b(?:Chrome|CriOS)/([\d.]+)`)},
+	{name: "Firefox", pattern: regexp.MustCompile(`\b(?:Firefox|FxiOS)/([\d.]+)`)},
+	{name: "Safari", pattern: regexp.MustCompile(`\bVersion/([\d.]+).*\bSafari/`)},
+	{name: "IE", pattern: regexp.MustCompile(`(?:\bMSIE ([\d.]+)|\bTrident/.*\brv:([\d.]+))`)},
+	{name: "Googlebot", pattern: regexp.MustCompile(`\bGooglebot/([\d.]+)`)},
+	{name: "Bingbot", pattern: regexp.MustCompile(`\bbingbot/([\d.]+)`)},
+	{name: "curl", pattern: regexp.MustCompile(`^curl/([\d.]+)`)},
+	{name: "Wget", pattern: regexp.MustCompile(`^Wget/([\d.]+)`)},
+	{name: "Go-http-client", pattern: regexp.MustCompile(`^Go-http-client/([\d.]+)`)},
+	{name: "Python Requests", pattern: regexp.MustCompile(`^python-requests/([\d.]+)`)},
+}
+
+var (
+	userAgentWindows  = regexp.MustCompile(`\bWindows NT ([\d.]+)`)
+	userAgentIOS      = regexp.MustCompile(`\b(iPhone|iPad|iPod)\b.*\bOS ([\d_]+)`)
+	userAgentMacOS    = regexp.MustCompile(`\bMac OS X ([\d_.]+)`)
+	userAgentAndroid  = regexp.MustCompile(`\bAndroid ([\d.]+)(?:; ([^;)]+))?`)
+	userAgentChromeOS = regexp.MustCompile(`\bCrOS \S+ ([\d.]+)`)
+)
+
+// windowsVersions maps the Windows NT kernel versions to the Windows release names
+var windowsVersions = map[string]string{
+	"10.0": "10",
+	"6.3":  "8.1",
+	"6.2":  "8",
+	"6.1":  "7",
+	"6.0":  "Vista",
+	"5.2":  "XP",
+	"5.1":  "XP",
+}
+
+// parseUserAgent extracts the browser, operating system and device from the most
+// common user agent formats. The fields which cannot be identified are left empty.
+func parseUserAgent(ua string) userAgentInfo {
+	var info userAgentInfo
+	for _, b := range userAgentBrowsers {
+		if m := b.pattern.FindStringSubmatch(ua); m != nil {
+			info.name = b.name
+			for _, v := range m[1:] {
+				if v != "" {
+					info.version = v
+					break
+				}
+			}
+			break
+		}
+	}
+
+	switch {
+	case userAgentWindows.MatchString(ua):
+		info.osName = "Windows"
+		info.osVersion = windowsVersions[userAgentWindows.FindStringSubmatch(ua)[1]]
+	case userAgentIOS.MatchString(ua):
+		m := userAgentIOS.FindStringSubmatch(ua)
+		info.osName = "iOS"
+		info.osVersion = strings.ReplaceAll(m[2], "_", ".")
+		info.device = m[1]
+	case userAgentMacOS.MatchString(ua):
+		info.osName = "macOS"
+		info.osVersion = strings.ReplaceAll(userAgentMacOS.FindStringSubmatch(ua)[1], "_", ".")
+		info.device = "Mac"
+	case userAgentAndroid.MatchString(ua):
+		m := userAgentAndroid.FindStringSubmatch(ua)
+		info.osName = "Android"
+		info.osVersion = m[1]
+		// the model is left out of reduced user agents, which advertise "K" instead
+		if model := strings.TrimSpace(strings.Split(m[2], " Build/")[0]); model != "" && model != "K" {
+			info.device = model
+		}
+	case userAgentChromeOS.MatchString(ua):
+		info.osName = "ChromeOS"
+		info.osVersion = userAgentChromeOS.FindStringSubmatch(ua)[1]
+	case strings.Contains(ua, "Linux"):
+		info.osName = "Linux"
+	}
+	return info
+}
@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_userAgent(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		expected map[string]any
+	}{
+		{
+			name:   "chrome on windows",
+			target: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36",
+			expected: map[string]any{
+				"user_agent.name":    "Chrome",
+				"user_agent.version": "126.0.0.0",
+				"os.name":            "Windows",
+				"os.version":         "10",
+			},
+		},
+		{
+			name:   "edge on windows",
+			target: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36 Edg/126.0.2592.68",
+			expected: map[string]any{
+				"user_agent.name":    "Edge",
+				"user_agent.version": "126.0.2592.68",
+				"os.name":            "Windows",
+				"os.version":         "10",
+			},
+		},
+		{
+			name:   "firefox on linux",
+			target: "Mozilla/5.0 (X11; Ubuntu; Linux x86_64; rv:127.0) Gecko/20100101 Firefox/127.0",
+			expected: map[string]any{
+				"user_agent.name":    "Firefox",
+				"user_agent.version": "127.0",
+				"os.name":            "Linux",
+			},
+		},
+		{
+			name:   "safari on macos",
+			target: "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.5 Safari/605.1.15",
+			expected: map[string]any{
+				"user_agent.name":    "Safari",
+				"user_agent.version": "17.5",
+				"os.name":            "macOS",
+				"os.version":         "10.15.7",
+				"device.model.name":  "Mac",
+			},
+		},
+		{
+			name:   "safari on iphone",
+			target: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.5 Mobile/15E148 Safari/604.1",
+			expected: map[string]any{
+				"user_agent.name":    "Safari",
+				"user_agent.version": "17.5",
+				"os.name":            "iOS",
+				"os.version":         "17.5",
+				"device.model.name":  "iPhone",
+			},
+		},
+		{
+			name:   "chrome on android",
+			target: "Mozilla/5.0 (Linux; Android 14; Pixel 8 Build/AP1A.240505.005) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.6478.71 Mobile Safari/537.36",
+			expected: map[string]any{
+				"user_agent.name":    "Chrome",
+				"user_agent.version": "126.0.6478.71",
+				"os.name":            "Android",
+				"os.version":         "14",
+				"device.model.name":  "Pixel 8",
+			},
+		},
+		{
+			name:   "reduced android",
+			target: "Mozilla/5.0 (Linux; Android 10; K) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Mobile Safari/537.36",
+			expected: map[string]any{
+				"user_agent.name":    "Chrome",
+				"user_agent.version": "126.0.0.0",
+				"os.name":            "Android",
+				"os.version":         "10",
+			},
+		},
+		{
+			name:   "internet explorer",
+			target: "Mozilla/5.0 (Windows NT 6.1; WOW64; Trident/7.0; rv:11.0) like Gecko",
+			expected: map[string]any{
+				"user_agent.name":    "IE",
+				"user_agent.version": "11.0",
+				"os.name":            "Windows",
+				"os.version":         "7",
+			},
+		},
+		{
+			name:   "curl",
+			target: "curl/8.8.0",
+			expected: map[string]any{
+				"user_agent.name":    "curl",
+				"user_agent.version": "8.8.0",
+			},
+		},
+		{
+			name:     "unknown",
+			target:   "my-custom-agent",
+			expected: map[string]any{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return tt.target, nil
+				},
+			}
+			result, err := userAgent[any](target)(context.Background(), nil)
+			require.NoError(t, err)
+
+			actual, ok := result.(pcommon.Map)
+			require.True(t, ok)
+			tt.expected["user_agent.original"] = tt.target
+			assert.Equal(t, tt.expected, actual.AsRaw())
+		})
+	}
+}
+
+func Test_userAgent_bad_target(t *testing.T) {
+	target := ottl.StandardStringGetter[any]{
+		Getter: func(_ context.Context, _ any) (any, error) {
+			return 1, nil
+		},
+	}
+	_, err := userAgent[any](target)(context.Background(), nil)
+	assert.Error(t, err)
+}
@@ -66,6 +66,7 @@ func converters[K any]() []ottl.Factory[K] {
 		NewParseCSVFactory[K](),
 		NewParseJSONFactory[K](),
 		NewParseKeyValueFactory[K](),
+		NewParseQueryStringFactory[K](),
 		NewParseXMLFactory[K](),
 		NewSecondsFactory[K](),
 		NewSHA1Factory[K](),
@@ -82,6 +83,8 @@ func converters[K any]() []ottl.Factory[K] {
 		NewUnixMilliFactory[K](),
 		NewUnixNanoFactory[K](),
 		NewUnixSecondsFactory[K](),
+		NewURLFactory[K](),
+		NewUserAgentFactory[K](),
 		NewUUIDFactory[K](),
 		NewAppendFactory[K](),
 		NewYearFactory[K](),
@@ -1834,6 +1834,79 @@ func Test_ParseConditions_Error(t *testing.T) {
 	}
 }
 
+func Test_ParseValueExpression(t *testing.T) {
+	p, _ := NewParser(
+		CreateFactoryMap[any](),
+		testParsePath[any],
+		componenttest.NewNopTelemetrySettings(),
+		WithEnumParser[any](testParseEnum),
+	)
+
+	tests := []struct {
+		name     string
+		raw      string
+		tCtx     any
+		expected any
+	}{
+		{
+			name:     "path",
+			raw:      `name`,
+			tCtx:     "bear",
+			expected: "bear",
+		},
+		{
+			name:     "literal",
+			raw:      `3.5`,
+			expected: 3.5,
+		},
+		{
+			name:     "math expression",
+			raw:      `1 + 2 * 3`,
+			expected: int64(7),
+		},
+		{
+			name:     "string",
+			raw:      `"foo"`,
+			expected: "foo",
+		},
+		{
+			name:     "nil",
+			raw:      `nil`,
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := p.ParseValueExpression(tt.raw)
+			assert.NoError(t, err)
+
+			got, err := expr.Eval(context.Background(), tt.tCtx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func Test_ParseValueExpression_Error(t *testing.T) {
+	p, _ := NewParser(
+		CreateFactoryMap[any](),
+		testParsePath[any],
+		componenttest.NewNopTelemetrySettings(),
+		WithEnumParser[any](testParseEnum),
+	)
+
+	for _, raw := range []string{
+		``,
+		`name ==`,
+		`set(name, "foo")`,
+		`unknown`,
+		`Missing()`,
+	} {
+		_, err := p.ParseValueExpression(raw)
+		assert.Error(t, err, raw)
+	}
+}
+
 // This test doesn't validate parser results, simply checks whether the parse succeeds or not.
 // It's a fast way to check a large range of possible syntaxes.
 func Test_parseStatement(t *testing.T) {
@@ -39,6 +39,21 @@ type Config struct {
 
 // Build will build a file input operator from the supplied configuration
 func (c Config) Build(set component.TelemetrySettings) (operator.Operator, error) {
+	input, err := c.build(set, false)
+	if err != nil {
+		return nil, err
+	}
+	return input, nil
+}
+
+// BuildWithEndpoints will build a file input operator from the supplied configuration,
+// which also tails the files of the endpoints added while it is running. The include
+// patterns of the configuration may be empty, in which case only those files are tailed.
+func (c Config) BuildWithEndpoints(set component.TelemetrySettings) (*Input, error) {
+	return c.build(set, true)
+}
+
+func (c Config) build(set component.TelemetrySettings, withEndpoints bool) (*Input, error) {
 	inputOperator, err := c.InputConfig.Build(set)
 	if err != nil {
 		return nil, err
@@ -60,6 +75,15 @@ func (c Config) Build(set component.TelemetrySettings) (operator.Operator, error
 		toBody:        toBody,
 	}
 
+	if withEndpoints {
+		input.set = set
+		input.config = c.Config
+		input.endpoints = map[string]*endpointConsumer{}
+		if len(c.Include) == 0 {
+			return input, nil
+		}
+	}
+
 	input.fileConsumer, err = c.Config.Build(set, input.emit)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package file // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/file"
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+)
+
+// Endpoint is a source of files discovered while the input is running, e.g. a pod or a container
+type Endpoint struct {
+	// ID uniquely identifies the endpoint
+	ID string
+	// Include are the glob patterns matching the files of the endpoint
+	Include []string
+	// Resource are the resource attributes of the entries read from the files of the endpoint
+	Resource map[string]any
+}
+
+// endpointConsumer tails the files of the endpoints sharing the same include patterns,
+// e.g. the endpoints of each port of a container
+type endpointConsumer struct {
+	manager *fileconsumer.Manager
+	ids     map[string]struct{}
+}
+
+// AddEndpoint starts tailing the files of the endpoint. The offsets of the files are
+// persisted per include patterns, so tailing resumes where it stopped when an endpoint
+// with the same include patterns is added again.
+func (i *Input) AddEndpoint(e Endpoint) error {
+	if i.endpoints == nil {
+		return errors.New("input was not built with endpoints")
+	}
+	if len(e.Include) == 0 {
+		return errors.New("endpoint has no include patterns")
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	key := endpointKey(e.Include)
+	if c, ok := i.endpoints[key]; ok {
+		c.ids[e.ID] = struct{}{}
+		return nil
+	}
+
+	cfg := i.config
+	cfg.Include = e.Include
+	resource := e.Resource
+	manager, err := cfg.Build(i.set, func(ctx context.Context, token []byte, attrs map[string]any) error {
+		return i.emitWithResource(ctx, token, attrs, resource)
+	})
+	if err != nil {
+		return err
+	}
+
+	if i.started {
+		if err = manager.Start(i.endpointPersister(key)); err != nil {
+			return err
+		}
+	}
+	i.endpoints[key] = &endpointConsumer{
+		manager: manager,
+		ids:     map[string]struct{}{e.ID: {}},
+	}
+	i.Logger().Debug("Started tailing endpoint", zap.String("endpoint", e.ID), zap.Strings("include", e.Include))
+	return nil
+}
+
+// RemoveEndpoint stops tailing the files of the endpoint, unless other endpoints
+// have the same include patterns.
+func (i *Input) RemoveEndpoint(id string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for key, c := range i.endpoints {
+		if _, ok := c.ids[id]; !ok {
+			continue
+		}
+		delete(c.ids, id)
+		if len(c.ids) > 0 {
+			return nil
+		}
+		delete(i.endpoints, key)
+		i.Logger().Debug("Stopped tailing endpoint", zap.String("endpoint", id))
+		if i.started {
+			return c.manager.Stop()
+		}
+		return nil
+	}
+	return nil
+}
+
+// endpointPersister scopes the offsets of the files of an endpoint
+func (i *Input) endpointPersister(key string) operator.Persister {
+	if i.persister == nil {
+		return nil
+	}
+	return operator.NewScopedPersister("endpoint."+key, i.persister)
+}
+
+func endpointKey(include []string) string {
+	sorted := append([]string(nil), include...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
@@ -5,8 +5,11 @@ package file // import "github.com/open-telemetry/opentelemetry-collector-contri
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 
+	"go.opentelemetry.io/collector/component"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
@@ -21,22 +24,65 @@ type toBodyFunc func([]byte) any
 type Input struct {
 	helper.InputOperator
 
+	// fileConsumer tails the files matched by the include patterns, if any
 	fileConsumer *fileconsumer.Manager
 
 	toBody toBodyFunc
+
+	// set and config build the consumers of endpoints, when built with endpoints
+	set    component.TelemetrySettings
+	config fileconsumer.Config
+
+	mu        sync.Mutex
+	started   bool
+	persister operator.Persister
+	endpoints map[string]*endpointConsumer
 }
 
 // Start will start the file monitoring process
 func (i *Input) Start(persister operator.Persister) error {
-	return i.fileConsumer.Start(persister)
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.fileConsumer != nil {
+		if err := i.fileConsumer.Start(persister); err != nil {
+			return err
+		}
+	}
+
+	i.started = true
+	i.persister = persister
+	for key, c := range i.endpoints {
+		if err := c.manager.Start(i.endpointPersister(key)); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // Stop will stop the file monitoring process
 func (i *Input) Stop() error {
-	return i.fileConsumer.Stop()
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	var errs []error
+	if i.fileConsumer != nil {
+		errs = append(errs, i.fileConsumer.Stop())
+	}
+	if i.started {
+		for _, c := range i.endpoints {
+			errs = append(errs, c.manager.Stop())
+		}
+	}
+	i.started = false
+	return errors.Join(errs...)
 }
 
 func (i *Input) emit(ctx context.Context, token []byte, attrs map[string]any) error {
+	return i.emitWithResource(ctx, token, attrs, nil)
+}
+
+func (i *Input) emitWithResource(ctx context.Context, token []byte, attrs map[string]any, resource map[string]any) error {
 	if len(token) == 0 {
 		return nil
 	}
@@ -51,6 +97,11 @@ func (i *Input) emit(ctx context.Context, token []byte, attrs map[string]any) er
 			i.Logger().Error("set attribute", zap.Error(err))
 		}
 	}
+	for k, v := range resource {
+		if err := ent.Set(entry.NewResourceField(k), v); err != nil {
+			i.Logger().Error("set resource", zap.Error(err))
+		}
+	}
 	i.Write(ctx, ent)
 	return nil
 }
@@ -11,8 +11,10 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
 )
 
@@ -244,3 +246,60 @@ func TestSkipEmpty(t *testing.T) {
 	waitForMessage(t, logReceived, "testlog1")
 	waitForMessage(t, logReceived, "testlog2")
 }
+
+func TestEndpoints(t *testing.T) {
+	t.Parallel()
+	cfg := NewConfigWithID("testfile")
+	cfg.PollInterval = 10 * time.Millisecond
+	cfg.StartAt = "beginning"
+	cfg.OutputIDs = []string{"fake"}
+	input, err := cfg.BuildWithEndpoints(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, input.SetOutputs([]operator.Operator{fake}))
+
+	persister := testutil.NewUnscopedMockPersister()
+	require.NoError(t, input.Start(persister))
+	defer func() {
+		require.NoError(t, input.Stop())
+	}()
+
+	dir := t.TempDir()
+	endpoint := Endpoint{
+		ID:       "pod-1",
+		Include:  []string{filepath.Join(dir, "*.log")},
+		Resource: map[string]any{"k8s.pod.name": "pod-1"},
+	}
+	require.NoError(t, input.AddEndpoint(endpoint))
+	// endpoints with the same include patterns share the files
+	require.NoError(t, input.AddEndpoint(Endpoint{ID: "pod-1:8080", Include: endpoint.Include}))
+
+	file, err := os.Create(filepath.Join(dir, "app.log"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = file.Close() })
+	writeString(t, file, "testlog1\n")
+
+	e := waitForOne(t, fake.Received)
+	require.Equal(t, "testlog1", e.Body)
+	require.Equal(t, map[string]any{"k8s.pod.name": "pod-1"}, e.Resource)
+	expectNoMessages(t, fake.Received)
+
+	require.NoError(t, input.RemoveEndpoint("pod-1:8080"))
+	writeString(t, file, "testlog2\n")
+	waitForMessage(t, fake.Received, "testlog2")
+
+	// logs written while the endpoint is removed are read once it is added again
+	require.NoError(t, input.RemoveEndpoint("pod-1"))
+	writeString(t, file, "testlog3\n")
+	expectNoMessages(t, fake.Received)
+
+	require.NoError(t, input.AddEndpoint(endpoint))
+	waitForMessage(t, fake.Received, "testlog3")
+	expectNoMessages(t, fake.Received)
+}
+
+func TestEndpointsNotBuilt(t *testing.T) {
+	input, _, tempDir := newTestFileOperator(t, nil)
+	err := input.AddEndpoint(Endpoint{ID: "pod-1", Include: []string{filepath.Join(tempDir, "*")}})
+	require.ErrorContains(t, err, "not built with endpoints")
+}
@@ -20,6 +20,9 @@ import (
 const (
 	operatorType       = "recombine"
 	defaultCombineWith = "\n"
+
+	modeExpression = "expression"
+	modeAuto       = "auto"
 )
 
 func init() {
@@ -35,6 +38,7 @@ func NewConfig() *Config {
 func NewConfigWithID(operatorID string) *Config {
 	return &Config{
 		TransformerConfig:     helper.NewTransformerConfig(operatorID, operatorType),
+		Mode:                  modeExpression,
 		MaxBatchSize:          1000,
 		MaxUnmatchedBatchSize: 100,
 		MaxSources:            1000,
@@ -48,6 +52,7 @@ func NewConfigWithID(operatorID string) *Config {
 // Config is the configuration of a recombine operator
 type Config struct {
 	helper.TransformerConfig `mapstructure:",squash"`
+	Mode                     string          `mapstructure:"mode"`
 	IsFirstEntry             string          `mapstructure:"is_first_entry"`
 	IsLastEntry              string          `mapstructure:"is_last_entry"`
 	MaxBatchSize             int             `mapstructure:"max_batch_size"`
@@ -68,28 +73,39 @@ func (c *Config) Build(set component.TelemetrySettings) (operator.Operator, erro
 		return nil, fmt.Errorf("failed to build transformer config: %w", err)
 	}
 
-	if c.IsLastEntry != "" && c.IsFirstEntry != "" {
-		return nil, fmt.Errorf("only one of is_first_entry and is_last_entry can be set")
-	}
-
-	if c.IsLastEntry == "" && c.IsFirstEntry == "" {
-		return nil, fmt.Errorf("one of is_first_entry and is_last_entry must be set")
-	}
-
 	var matchesFirst bool
 	var prog *vm.Program
-	if c.IsFirstEntry != "" {
+	switch c.Mode {
+	case modeAuto:
+		if c.IsLastEntry != "" || c.IsFirstEntry != "" {
+			return nil, fmt.Errorf("is_first_entry and is_last_entry cannot be set in auto mode")
+		}
+		// entries are combined until a line is detected to start a new one
 		matchesFirst = true
-		prog, err = helper.ExprCompileBool(c.IsFirstEntry)
-		if err != nil {
-			return nil, fmt.Errorf("failed to compile is_first_entry: %w", err)
+	case modeExpression, "":
+		if c.IsLastEntry != "" && c.IsFirstEntry != "" {
+			return nil, fmt.Errorf("only one of is_first_entry and is_last_entry can be set")
 		}
-	} else {
-		matchesFirst = false
-		prog, err = helper.ExprCompileBool(c.IsLastEntry)
-		if err != nil {
-			return nil, fmt.Errorf("failed to compile is_last_entry: %w", err)
+
+		if c.IsLastEntry == "" && c.IsFirstEntry == "" {
+			return nil, fmt.Errorf("one of is_first_entry and is_last_entry must be set")
 		}
+
+		if c.IsFirstEntry != "" {
+			matchesFirst = true
+			prog, err = helper.ExprCompileBool(c.IsFirstEntry)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compile is_first_entry: %w", err)
+			}
+		} else {
+			matchesFirst = false
+			prog, err = helper.ExprCompileBool(c.IsLastEntry)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compile is_last_entry: %w", err)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("invalid value '%s' for parameter 'mode'", c.Mode)
 	}
 
 	if c.CombineField.FieldInterface == nil {
@@ -93,6 +93,15 @@ func TestUnmarshal(t *testing.T) {
 					return cfg
 				}(),
 			},
+			{
+				Name:      "mode_auto",
+				ExpectErr: false,
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Mode = modeAuto
+					return cfg
+				}(),
+			},
 		},
 	}.Run(t)
 }
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package recombine // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/recombine"
+
+import (
+	"regexp"
+	"strings"
+)
+
+// traceState tracks the kind of stack trace being combined in a batch, if any
+type traceState int
+
+const (
+	traceNone traceState = iota
+	// tracePythonHeader follows a "Traceback (most recent call last):" line
+	tracePythonHeader
+	// tracePythonFrames follows the indented frames of a Python traceback,
+	// which are ended by the unindented exception line
+	tracePythonFrames
+	// traceGoPanic follows a Go panic, whose function lines are not indented
+	traceGoPanic
+	// traceOther follows any other stack trace line
+	traceOther
+)
+
+var (
+	// exceptionPattern matches exception headers of Java, .NET, Python and Node.js,
+	// e.g. "java.lang.IllegalStateException: message" or "TypeError: message"
+	exceptionPattern = regexp.MustCompile(`^[\w$.]*(Exception|Error|Throwable)(: .*)?$`)
+	// rubyFramePattern matches unindented Ruby backtrace lines, e.g. "app/models/user.rb:3:in `save'"
+	rubyFramePattern = regexp.MustCompile("^[^\\s:]+:\\d+:in [`'].*'$")
+	// goFramePattern matches the unindented lines of a Go panic
+	goFramePattern = regexp.MustCompile(`^(goroutine \d+ \[.*\]:|created by .*|\[signal .*\]|[\w./\-*()\[\]{}]+\(.*\))$`)
+)
+
+var continuationPrefixes = []string{
+	"Caused by: ",
+	"Traceback (most recent call last):",
+	"During handling of the above exception, another exception occurred:",
+	"The above exception was the direct cause of the following exception:",
+}
+
+// detectFirstEntry reports whether the line starts a new entry or continues
+// the batch of its source, which is in the given trace state.
+// It also returns the trace state of the batch once the line is added.
+func detectFirstEntry(line string, state traceState, batched bool) (bool, traceState) {
+	first, next := detectContinuation(line, state)
+	return first || !batched, next
+}
+
+func detectContinuation(line string, state traceState) (bool, traceState) {
+	if strings.TrimSpace(line) == "" {
+		// blank lines separate the parts of Go panics and chained Python exceptions
+		return state == traceNone, state
+	}
+
+	if line[0] == ' ' || line[0] == '\t' {
+		if state == tracePythonHeader {
+			state = tracePythonFrames
+		}
+		return false, state
+	}
+
+	if state == tracePythonFrames {
+		// the exception line ends the traceback
+		return false, traceOther
+	}
+	if state == traceGoPanic && goFramePattern.MatchString(line) {
+		return false, state
+	}
+
+	if strings.HasPrefix(line, "panic: ") || strings.HasPrefix(line, "fatal error: ") {
+		return true, traceGoPanic
+	}
+	if strings.HasPrefix(line, "Traceback (most recent call last):") {
+		return false, tracePythonHeader
+	}
+	for _, prefix := range continuationPrefixes {
+		if strings.HasPrefix(line, prefix) {
+			return false, traceOther
+		}
+	}
+	if exceptionPattern.MatchString(line) || rubyFramePattern.MatchString(line) {
+		return false, traceOther
+	}
+	if strings.HasPrefix(line, "Exception in thread ") {
+		return true, traceOther
+	}
+	return true, traceNone
+}
@@ -50,6 +50,8 @@ type sourceBatch struct {
 	recombined             *bytes.Buffer
 	firstEntryObservedTime time.Time
 	matchDetected          bool
+	// trace is the stack trace being combined, when detecting entries automatically
+	trace traceState
 }
 
 func (t *Transformer) Start(_ operator.Persister) error {
@@ -99,6 +101,21 @@ func (t *Transformer) Process(ctx context.Context, e *entry.Entry) error {
 	t.Lock()
 	defer t.Unlock()
 
+	var s string
+	err := e.Read(t.sourceIdentifier, &s)
+	if err != nil {
+		t.Logger().Warn("entry does not contain the source_identifier, so it may be pooled with other sources")
+		s = DefaultSourceIdentifier
+	}
+
+	if s == "" {
+		s = DefaultSourceIdentifier
+	}
+
+	if t.prog == nil {
+		return t.processAuto(ctx, e, s)
+	}
+
 	// Get the environment for executing the expression.
 	// In the future, we may want to provide access to the currently
 	// batched entries so users can do comparisons to other entries
@@ -113,16 +130,6 @@ func (t *Transformer) Process(ctx context.Context, e *entry.Entry) error {
 
 	// this is guaranteed to be a boolean because of expr.AsBool
 	matches := m.(bool)
-	var s string
-	err = e.Read(t.sourceIdentifier, &s)
-	if err != nil {
-		t.Logger().Warn("entry does not contain the source_identifier, so it may be pooled with other sources")
-		s = DefaultSourceIdentifier
-	}
-
-	if s == "" {
-		s = DefaultSourceIdentifier
-	}
 
 	switch {
 	// This is the first entry in the next batch
@@ -147,6 +154,37 @@ func (t *Transformer) Process(ctx context.Context, e *entry.Entry) error {
 	return nil
 }
 
+// processAuto combines the entry with the batch of its source,
+// unless it is detected to be the first entry of a new log
+func (t *Transformer) processAuto(ctx context.Context, e *entry.Entry, source string) error {
+	state := traceNone
+	batch, batched := t.batchMap[source]
+	if batched {
+		state = batch.trace
+	}
+
+	first := true
+	var line string
+	if err := e.Read(t.combineField, &line); err == nil {
+		first, state = detectFirstEntry(line, state, batched)
+	} else {
+		state = traceNone
+	}
+
+	if first {
+		if err := t.flushSource(ctx, source); err != nil {
+			return err
+		}
+	}
+	t.addToBatch(ctx, e, source, first)
+
+	// the batch is gone if adding the entry flushed it
+	if batch, ok := t.batchMap[source]; ok {
+		batch.trace = state
+	}
+	return nil
+}
+
 // addToBatch adds the current entry to the current batch of entries that will be combined
 func (t *Transformer) addToBatch(ctx context.Context, e *entry.Entry, source string, matches bool) {
 	batch, ok := t.batchMap[source]
@@ -236,6 +274,7 @@ func (t *Transformer) addNewBatch(source string, e *entry.Entry) *sourceBatch {
 	batch.recombined.Reset()
 	batch.firstEntryObservedTime = e.ObservedTimestamp
 	batch.matchDetected = false
+	batch.trace = traceNone
 	t.batchMap[source] = batch
 	return batch
 }
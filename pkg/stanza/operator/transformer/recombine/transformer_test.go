@@ -956,3 +956,205 @@ func TestSourceBatchDelete(t *testing.T) {
 	fake.ExpectEntry(t, expect)
 	require.NoError(t, recombine.Stop())
 }
+
+func TestTransformerAuto(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    []string
+		expected []string
+	}{
+		{
+			"Indentation",
+			[]string{
+				"first log",
+				"  continued",
+				"\tcontinued",
+				"second log",
+			},
+			[]string{
+				"first log\n  continued\n\tcontinued",
+				"second log",
+			},
+		},
+		{
+			"Java",
+			[]string{
+				"2024-06-11 10:00:00 ERROR Request failed",
+				"java.lang.IllegalStateException: boom",
+				"\tat com.example.Service.call(Service.java:42)",
+				"\tat com.example.Main.main(Main.java:10)",
+				"Caused by: java.io.IOException: closed",
+				"\tat com.example.Client.read(Client.java:7)",
+				"\t... 2 more",
+				"2024-06-11 10:00:01 INFO Recovered",
+			},
+			[]string{
+				"2024-06-11 10:00:00 ERROR Request failed\njava.lang.IllegalStateException: boom\n\tat com.example.Service.call(Service.java:42)\n\tat com.example.Main.main(Main.java:10)\nCaused by: java.io.IOException: closed\n\tat com.example.Client.read(Client.java:7)\n\t... 2 more",
+				"2024-06-11 10:00:01 INFO Recovered",
+			},
+		},
+		{
+			"Python",
+			[]string{
+				"ERROR:root:Request failed",
+				"Traceback (most recent call last):",
+				`  File "app.py", line 3, in <module>`,
+				"    main()",
+				"KeyError: 'user'",
+				"",
+				"During handling of the above exception, another exception occurred:",
+				"",
+				"Traceback (most recent call last):",
+				`  File "app.py", line 5, in <module>`,
+				"ValueError: invalid user",
+				"INFO:root:Recovered",
+			},
+			[]string{
+				"ERROR:root:Request failed\nTraceback (most recent call last):\n  File \"app.py\", line 3, in <module>\n    main()\nKeyError: 'user'\n\nDuring handling of the above exception, another exception occurred:\n\nTraceback (most recent call last):\n  File \"app.py\", line 5, in <module>\nValueError: invalid user",
+				"INFO:root:Recovered",
+			},
+		},
+		{
+			"PythonUnindentedException",
+			[]string{
+				"Traceback (most recent call last):",
+				`  File "app.py", line 3, in <module>`,
+				"ZeroDivisionError: division by zero",
+				"next log",
+			},
+			[]string{
+				"Traceback (most recent call last):\n  File \"app.py\", line 3, in <module>\nZeroDivisionError: division by zero",
+				"next log",
+			},
+		},
+		{
+			"Go",
+			[]string{
+				"starting server",
+				"panic: runtime error: invalid memory address or nil pointer dereference",
+				"[signal SIGSEGV: segmentation violation code=0x1 addr=0x0 pc=0x4553a5]",
+				"",
+				"goroutine 1 [running]:",
+				"main.(*server).handle(0x0)",
+				"\t/app/main.go:12 +0x25",
+				"main.main()",
+				"\t/app/main.go:20 +0x1d",
+				"created by main.start in goroutine 1",
+				"starting server",
+			},
+			[]string{
+				"starting server",
+				"panic: runtime error: invalid memory address or nil pointer dereference\n[signal SIGSEGV: segmentation violation code=0x1 addr=0x0 pc=0x4553a5]\n\ngoroutine 1 [running]:\nmain.(*server).handle(0x0)\n\t/app/main.go:12 +0x25\nmain.main()\n\t/app/main.go:20 +0x1d\ncreated by main.start in goroutine 1",
+				"starting server",
+			},
+		},
+		{
+			"DotNet",
+			[]string{
+				"Unhandled exception. System.InvalidOperationException: Operation is not valid",
+				" ---> System.NullReferenceException: Object reference not set to an instance of an object.",
+				"   at App.Service.Run() in /src/Service.cs:line 12",
+				"   --- End of inner exception stack trace ---",
+				"   at App.Program.Main(String[] args) in /src/Program.cs:line 8",
+				"info: App.Program[0]",
+			},
+			[]string{
+				"Unhandled exception. System.InvalidOperationException: Operation is not valid\n ---> System.NullReferenceException: Object reference not set to an instance of an object.\n   at App.Service.Run() in /src/Service.cs:line 12\n   --- End of inner exception stack trace ---\n   at App.Program.Main(String[] args) in /src/Program.cs:line 8",
+				"info: App.Program[0]",
+			},
+		},
+		{
+			"Ruby",
+			[]string{
+				"E, [2024-06-11T10:00:00] ERROR -- : undefined method `name' for nil (NoMethodError)",
+				"app/models/user.rb:3:in `display_name'",
+				"app/controllers/users_controller.rb:7:in `show'",
+				"\tfrom bin/rails:4:in `<main>'",
+				"I, [2024-06-11T10:00:01] INFO -- : Completed",
+			},
+			[]string{
+				"E, [2024-06-11T10:00:00] ERROR -- : undefined method `name' for nil (NoMethodError)\napp/models/user.rb:3:in `display_name'\napp/controllers/users_controller.rb:7:in `show'\n\tfrom bin/rails:4:in `<main>'",
+				"I, [2024-06-11T10:00:01] INFO -- : Completed",
+			},
+		},
+		{
+			"NodeJS",
+			[]string{
+				"request failed",
+				"TypeError: Cannot read properties of undefined (reading 'id')",
+				"    at handler (/app/server.js:10:15)",
+				"    at process.processTicksAndRejections (node:internal/process/task_queues:95:5)",
+				"request succeeded",
+			},
+			[]string{
+				"request failed\nTypeError: Cannot read properties of undefined (reading 'id')\n    at handler (/app/server.js:10:15)\n    at process.processTicksAndRejections (node:internal/process/task_queues:95:5)",
+				"request succeeded",
+			},
+		},
+		{
+			"BlankLinesOutsideTrace",
+			[]string{
+				"first log",
+				"",
+				"second log",
+			},
+			[]string{
+				"first log",
+				"",
+				"second log",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfig()
+			cfg.Mode = modeAuto
+			cfg.CombineField = entry.NewBodyField()
+			cfg.OutputIDs = []string{"fake"}
+			op, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+			require.NoError(t, op.Start(testutil.NewUnscopedMockPersister()))
+			r := op.(*Transformer)
+
+			fake := testutil.NewFakeOutput(t)
+			require.NoError(t, r.SetOutputs([]operator.Operator{fake}))
+
+			for _, line := range tc.input {
+				e := entry.New()
+				e.Body = line
+				require.NoError(t, r.Process(context.Background(), e))
+			}
+			require.NoError(t, op.Stop())
+
+			for _, expected := range tc.expected {
+				select {
+				case e := <-fake.Received:
+					require.Equal(t, expected, e.Body)
+				case <-time.After(time.Second):
+					require.FailNow(t, "Timed out waiting for entry", expected)
+				}
+			}
+			select {
+			case e := <-fake.Received:
+				require.FailNow(t, "Received unexpected entry: ", e)
+			default:
+			}
+		})
+	}
+}
+
+func TestBuildAutoMode(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Mode = modeAuto
+	cfg.CombineField = entry.NewBodyField()
+	cfg.IsFirstEntry = MatchAll
+	_, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+	require.ErrorContains(t, err, "cannot be set in auto mode")
+
+	cfg = NewConfig()
+	cfg.Mode = "unknown"
+	cfg.CombineField = entry.NewBodyField()
+	_, err = cfg.Build(componenttest.NewNopTelemetrySettings())
+	require.ErrorContains(t, err, "invalid value 'unknown' for parameter 'mode'")
+}
@@ -15,6 +15,14 @@ var _ component.ConfigValidator = (*Config)(nil)
 type Config struct {
 	MaxStale   time.Duration `mapstructure:"max_stale"`
 	MaxStreams int           `mapstructure:"max_streams"`
+
+	// StorageID refers to a storage extension used to checkpoint the
+	// aggregation state, so it survives restarts. If unset, state is only kept
+	// in memory.
+	StorageID *component.ID `mapstructure:"storage"`
+	// CheckpointInterval is how often the aggregation state is written to
+	// storage. State is always written on shutdown as well.
+	CheckpointInterval time.Duration `mapstructure:"checkpoint_interval"`
 }
 
 func (c *Config) Validate() error {
@@ -24,6 +32,9 @@ func (c *Config) Validate() error {
 	if c.MaxStreams < 0 {
 		return fmt.Errorf("max_streams must be a positive number (got %d)", c.MaxStreams)
 	}
+	if c.StorageID != nil && c.CheckpointInterval <= 0 {
+		return fmt.Errorf("checkpoint_interval must be a positive duration (got %s)", c.CheckpointInterval)
+	}
 	return nil
 }
 
@@ -34,5 +45,7 @@ func createDefaultConfig() component.Config {
 		// disable. TODO: find good default
 		// https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/31603
 		MaxStreams: 0,
+
+		CheckpointInterval: time.Minute,
 	}
 }
@@ -19,6 +19,8 @@ import (
 func TestLoadConfig(t *testing.T) {
 	t.Parallel()
 
+	storageID := component.MustNewID("file_storage")
+
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
 	require.NoError(t, err)
 
@@ -31,6 +33,8 @@ func TestLoadConfig(t *testing.T) {
 			expected: &Config{
 				MaxStale:   1 * time.Minute,
 				MaxStreams: 10,
+
+				CheckpointInterval: time.Minute,
 			},
 		},
 		{
@@ -38,6 +42,8 @@ func TestLoadConfig(t *testing.T) {
 			expected: &Config{
 				MaxStale:   2 * time.Minute,
 				MaxStreams: 0,
+
+				CheckpointInterval: time.Minute,
 			},
 		},
 		{
@@ -45,6 +51,18 @@ func TestLoadConfig(t *testing.T) {
 			expected: &Config{
 				MaxStale:   5 * time.Minute,
 				MaxStreams: 20,
+
+				CheckpointInterval: time.Minute,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "storage"),
+			expected: &Config{
+				MaxStale:   5 * time.Minute,
+				MaxStreams: 0,
+
+				StorageID:          &storageID,
+				CheckpointInterval: 30 * time.Second,
 			},
 		},
 	}
@@ -63,3 +81,19 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	id := component.NewIDWithName(metadata.Type, "invalid-checkpoint_interval")
+
+	cfg := NewFactory().CreateDefaultConfig()
+	sub, err := cm.Sub(id.String())
+	require.NoError(t, err)
+	require.NoError(t, sub.Unmarshal(cfg))
+
+	assert.EqualError(t, component.ValidateConfig(cfg), "checkpoint_interval must be a positive duration (got 0s)")
+}
@@ -29,5 +29,5 @@ func createMetricsProcessor(_ context.Context, set processor.Settings, cfg compo
 	}
 
 	meter := metadata.Meter(set.TelemetrySettings)
-	return newProcessor(pcfg, set.Logger, meter, next), nil
+	return newProcessor(pcfg, set.ID, set.Logger, meter, next), nil
 }
@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// checkpoint encodes the aggregation state of streams into a self-contained
+// binary format, so it can be persisted and restored across restarts.
+package checkpoint // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/checkpoint"
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/data"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/streams"
+)
+
+// version of the encoding. bumped on incompatible changes, so outdated
+// checkpoints are rejected instead of being misinterpreted.
+const version byte = 1
+
+var (
+	marshaler   = &pmetric.ProtoMarshaler{}
+	unmarshaler = &pmetric.ProtoUnmarshaler{}
+)
+
+// Encode serializes all streams yielded by items.
+func Encode[D data.Point[D]](items streams.Seq[D]) ([]byte, error) {
+	buf := []byte{version}
+
+	var err error
+	items(func(id streams.Ident, dp D) bool {
+		var ib, db []byte
+		if ib, err = id.MarshalBinary(); err != nil {
+			return false
+		}
+		if db, err = marshal(dp); err != nil {
+			return false
+		}
+		buf = binary.AppendUvarint(buf, uint64(len(ib)))
+		buf = append(buf, ib...)
+		buf = binary.AppendUvarint(buf, uint64(len(db)))
+		buf = append(buf, db...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// Decode deserializes buf as produced by [Encode], calling fn for each stream.
+// Decoding stops at the first error, which is returned.
+func Decode[D data.Point[D]](buf []byte, fn func(streams.Ident, D) error) error {
+	if len(buf) == 0 {
+		return nil
+	}
+	if buf[0] != version {
+		return ErrVersion{Got: buf[0]}
+	}
+	buf = buf[1:]
+
+	for len(buf) > 0 {
+		ib, rest, err := chunk(buf)
+		if err != nil {
+			return err
+		}
+		db, rest, err := chunk(rest)
+		if err != nil {
+			return err
+		}
+		buf = rest
+
+		var id streams.Ident
+		if err := id.UnmarshalBinary(ib); err != nil {
+			return err
+		}
+		dp, err := unmarshal[D](db)
+		if err != nil {
+			return err
+		}
+		if err := fn(id, dp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var ErrTruncated = errors.New("checkpoint: unexpected end of data")
+
+type ErrVersion struct {
+	Got byte
+}
+
+func (e ErrVersion) Error() string {
+	return fmt.Sprintf("checkpoint: unsupported version %d, expected %d", e.Got, version)
+}
+
+// chunk reads a length-prefixed byte slice from the front of buf
+func chunk(buf []byte) (b, rest []byte, err error) {
+	n, size := binary.Uvarint(buf)
+	if size <= 0 || n > uint64(len(buf)-size) {
+		return nil, nil, ErrTruncated
+	}
+	buf = buf[size:]
+	return buf[:n], buf[n:], nil
+}
+
+// marshal wraps dp into an otherwise empty [pmetric.Metrics], so the
+// protobuf encoding of pdata can be reused
+func marshal[D data.Point[D]](dp D) ([]byte, error) {
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+
+	switch dp := any(dp).(type) {
+	case data.Number:
+		dp.NumberDataPoint.CopyTo(m.SetEmptySum().DataPoints().AppendEmpty())
+	case data.Histogram:
+		dp.HistogramDataPoint.CopyTo(m.SetEmptyHistogram().DataPoints().AppendEmpty())
+	case data.ExpHistogram:
+		dp.DataPoint.CopyTo(m.SetEmptyExponentialHistogram().DataPoints().AppendEmpty())
+	default:
+		return nil, fmt.Errorf("checkpoint: unsupported datapoint type %T", dp)
+	}
+
+	return marshaler.MarshalMetrics(md)
+}
+
+func unmarshal[D data.Point[D]](buf []byte) (D, error) {
+	var zero D
+
+	md, err := unmarshaler.UnmarshalMetrics(buf)
+	if err != nil {
+		return zero, err
+	}
+	if md.DataPointCount() != 1 {
+		return zero, fmt.Errorf("checkpoint: expected exactly one datapoint, got %d", md.DataPointCount())
+	}
+	m := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+
+	var dp any
+	switch any(zero).(type) {
+	case data.Number:
+		if m.Type() == pmetric.MetricTypeSum {
+			dp = data.Number{NumberDataPoint: m.Sum().DataPoints().At(0)}
+		}
+	case data.Histogram:
+		if m.Type() == pmetric.MetricTypeHistogram {
+			dp = data.Histogram{HistogramDataPoint: m.Histogram().DataPoints().At(0)}
+		}
+	case data.ExpHistogram:
+		if m.Type() == pmetric.MetricTypeExponentialHistogram {
+			dp = data.ExpHistogram{DataPoint: m.ExponentialHistogram().DataPoints().At(0)}
+		}
+	}
+
+	v, ok := dp.(D)
+	if !ok {
+		return zero, fmt.Errorf("checkpoint: cannot decode %s datapoint into %T", m.Type(), zero)
+	}
+	return v, nil
+}
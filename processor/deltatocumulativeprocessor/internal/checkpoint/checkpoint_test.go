@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package checkpoint_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	exp "github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/streams"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/checkpoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/data"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/data/expo/expotest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/streams"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/testdata/random"
+)
+
+func TestRoundtripNumber(t *testing.T) {
+	sum := random.Sum()
+
+	want := make(exp.HashMap[data.Number])
+	for i := 0; i < 10; i++ {
+		id, dp := sum.Stream()
+		dp.SetStartTimestamp(dp.Timestamp() - 1000)
+		want[id] = dp
+	}
+
+	buf, err := checkpoint.Encode[data.Number](want.Items())
+	require.NoError(t, err)
+
+	got := make(exp.HashMap[data.Number])
+	err = checkpoint.Decode(buf, got.Store)
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+}
+
+func TestRoundtripExpHistogram(t *testing.T) {
+	sum := random.Sum()
+	id, _ := sum.Stream()
+
+	dp := expotest.Histogram{
+		PosNeg: expotest.Bins{0, 0, 0, 1, 2, 3, 0, 0}.Into(),
+		Scale:  2,
+		Count:  6,
+		Zt:     0.5,
+	}.Into()
+	dp.SetStartTimestamp(pcommon.Timestamp(1000))
+	dp.SetTimestamp(pcommon.Timestamp(2000))
+
+	want := exp.HashMap[data.ExpHistogram]{id: {DataPoint: dp}}
+
+	buf, err := checkpoint.Encode[data.ExpHistogram](want.Items())
+	require.NoError(t, err)
+
+	got := make(exp.HashMap[data.ExpHistogram])
+	err = checkpoint.Decode(buf, got.Store)
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+}
+
+func TestDecodeEmpty(t *testing.T) {
+	err := checkpoint.Decode(nil, func(streams.Ident, data.Number) error {
+		t.Fatal("must not be called")
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestDecodeErrors(t *testing.T) {
+	sum := random.Sum()
+	id, dp := sum.Stream()
+	valid, err := checkpoint.Encode[data.Number](exp.HashMap[data.Number]{id: dp}.Items())
+	require.NoError(t, err)
+
+	nop := func(streams.Ident, data.Number) error { return nil }
+
+	t.Run("version", func(t *testing.T) {
+		buf := append([]byte{}, valid...)
+		buf[0] = 42
+		err := checkpoint.Decode(buf, nop)
+		require.Equal(t, checkpoint.ErrVersion{Got: 42}, err)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		for i := 2; i < len(valid); i++ {
+			require.Error(t, checkpoint.Decode(valid[:i], nop))
+		}
+	})
+
+	t.Run("type", func(t *testing.T) {
+		err := checkpoint.Decode(valid, func(streams.Ident, data.ExpHistogram) error { return nil })
+		require.ErrorContains(t, err, "cannot decode "+pmetric.MetricTypeSum.String())
+	})
+}
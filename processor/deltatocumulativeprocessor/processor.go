@@ -6,17 +6,23 @@ package deltatocumulativeprocessor // import "github.com/open-telemetry/opentele
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sort"
 	"sync"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/staleness"
+	exp "github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/streams"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/checkpoint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/data"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/delta"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/maybe"
@@ -38,9 +44,16 @@ type Processor struct {
 	expo Pipeline[data.ExpHistogram]
 
 	mtx sync.Mutex
+
+	id  component.ID
+	cfg Config
+
+	// storage is only set if checkpointing is enabled
+	storage storage.Client
+	wg      sync.WaitGroup
 }
 
-func newProcessor(cfg *Config, log *zap.Logger, meter metric.Meter, next consumer.Metrics) *Processor {
+func newProcessor(cfg *Config, id component.ID, log *zap.Logger, meter metric.Meter, next consumer.Metrics) *Processor {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	tel := telemetry.New(meter)
@@ -51,6 +64,9 @@ func newProcessor(cfg *Config, log *zap.Logger, meter metric.Meter, next consume
 		cancel: cancel,
 		next:   next,
 
+		id:  id,
+		cfg: *cfg,
+
 		sums: pipeline[data.Number](cfg, &tel),
 		expo: pipeline[data.ExpHistogram](cfg, &tel),
 	}
@@ -61,6 +77,9 @@ func newProcessor(cfg *Config, log *zap.Logger, meter metric.Meter, next consume
 type Pipeline[D data.Point[D]] struct {
 	aggr  streams.Aggregator[D]
 	stale maybe.Ptr[staleness.Staleness[D]]
+	dps   streams.Map[D]
+	// limit is the maximum number of streams, 0 if unlimited
+	limit int
 }
 
 func pipeline[D data.Point[D]](cfg *Config, tel *telemetry.Telemetry) Pipeline[D] {
@@ -77,6 +96,7 @@ func pipeline[D data.Point[D]](cfg *Config, tel *telemetry.Telemetry) Pipeline[D
 		dps, _ = stale.Try()
 	}
 	if cfg.MaxStreams > 0 {
+		pipe.limit = cfg.MaxStreams
 		tel.WithLimit(int64(cfg.MaxStreams))
 		lim := streams.Limit(dps, cfg.MaxStreams)
 		if stale, ok := pipe.stale.Try(); ok {
@@ -87,11 +107,94 @@ func pipeline[D data.Point[D]](cfg *Config, tel *telemetry.Telemetry) Pipeline[D
 
 	dps = telemetry.ObserveNonFatal(dps, &tel.Metrics)
 
+	pipe.dps = dps
 	pipe.aggr = streams.IntoAggregator(dps)
 	return pipe
 }
 
-func (p *Processor) Start(_ context.Context, _ component.Host) error {
+// restore decodes the checkpointed streams in buf and adds them to the
+// pipeline. Nothing is added if buf cannot be decoded entirely.
+//
+// The last time a stream was seen is taken from the timestamp of its
+// checkpointed sample, so that streams which became stale in the meantime are
+// expired instead of being continued.
+//
+// The streams are restored from the most recent one, up to the stream limit.
+// The restored streams don't go through the limit, so it is enforced here.
+func (p Pipeline[D]) restore(buf []byte) (int, error) {
+	items := make(exp.HashMap[D])
+	if err := checkpoint.Decode(buf, items.Store); err != nil {
+		return 0, err
+	}
+
+	ids := make([]identity.Stream, 0, len(items))
+	for id := range items {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return items[ids[i]].Timestamp() > items[ids[j]].Timestamp()
+	})
+
+	var errs error
+	restored := 0
+	for i, id := range ids {
+		if p.limit > 0 && p.dps.Len() >= p.limit {
+			errs = errors.Join(errs, fmt.Errorf("%w: %d checkpointed streams dropped", streams.ErrLimit(p.limit), len(ids)-i))
+			break
+		}
+		dp := items[id]
+		var err error
+		if stale, ok := p.stale.Try(); ok {
+			err = stale.Restore(id, dp, dp.Timestamp().AsTime())
+		} else {
+			err = p.dps.Store(id, dp)
+		}
+		if err == nil {
+			restored++
+		}
+		errs = errors.Join(errs, err)
+	}
+	if stale, ok := p.stale.Try(); ok {
+		stale.ExpireOldEntries()
+	}
+	return restored, errs
+}
+
+const (
+	keySums = "sums"
+	keyExpo = "expo"
+)
+
+func (p *Processor) Start(ctx context.Context, host component.Host) error {
+	if p.cfg.StorageID != nil {
+		client, err := getStorageClient(ctx, host, *p.cfg.StorageID, p.id)
+		if err != nil {
+			return err
+		}
+		p.storage = client
+
+		if err := p.restore(ctx); err != nil {
+			p.log.Warn("failed to restore checkpointed state. affected streams start from zero", zap.Error(err))
+		}
+
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			tick := time.NewTicker(p.cfg.CheckpointInterval)
+			defer tick.Stop()
+			for {
+				select {
+				case <-p.ctx.Done():
+					return
+				case <-tick.C:
+					if err := p.checkpoint(p.ctx); err != nil {
+						p.log.Warn("failed to checkpoint state", zap.Error(err))
+					}
+				}
+			}
+		}()
+	}
+
 	sums, sok := p.sums.stale.Try()
 	expo, eok := p.expo.stale.Try()
 	if !(sok && eok) {
@@ -115,9 +218,50 @@ func (p *Processor) Start(_ context.Context, _ component.Host) error {
 	return nil
 }
 
-func (p *Processor) Shutdown(_ context.Context) error {
+func (p *Processor) Shutdown(ctx context.Context) error {
 	p.cancel()
-	return nil
+	if p.storage == nil {
+		return nil
+	}
+
+	// no more samples are accepted once canceled, so this final checkpoint
+	// captures the complete state
+	p.wg.Wait()
+	err := p.checkpoint(ctx)
+	return errors.Join(err, p.storage.Close(ctx))
+}
+
+// checkpoint writes the current aggregation state of all pipelines to storage
+func (p *Processor) checkpoint(ctx context.Context) error {
+	p.mtx.Lock()
+	sums, serr := checkpoint.Encode[data.Number](p.sums.dps.Items())
+	expo, eerr := checkpoint.Encode[data.ExpHistogram](p.expo.dps.Items())
+	p.mtx.Unlock()
+
+	if err := errors.Join(serr, eerr); err != nil {
+		return err
+	}
+	return p.storage.Batch(ctx,
+		storage.SetOperation(keySums, sums),
+		storage.SetOperation(keyExpo, expo),
+	)
+}
+
+// restore loads the aggregation state of all pipelines from storage
+func (p *Processor) restore(ctx context.Context) error {
+	sums, serr := p.storage.Get(ctx, keySums)
+	expo, eerr := p.storage.Get(ctx, keyExpo)
+	if err := errors.Join(serr, eerr); err != nil {
+		return err
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	ns, serr := p.sums.restore(sums)
+	ne, eerr := p.expo.restore(expo)
+	p.log.Debug("restored checkpointed streams", zap.Int("sums", ns), zap.Int("expo", ne))
+	return errors.Join(serr, eerr)
 }
 
 func (p *Processor) Capabilities() consumer.Capabilities {
@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package deltatocumulativeprocessor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/staleness"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/data"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/metadata"
+)
+
+func TestCheckpointRestore(t *testing.T) {
+	now := time.Unix(1000, 0)
+	staleness.NowFunc = func() time.Time { return now }
+	defer func() { staleness.NowFunc = time.Now }()
+
+	storageID := component.MustNewID("memory_storage")
+	host := storageHost{id: storageID, ext: &memoryStorage{}}
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.StorageID = &storageID
+
+	run := func(samples ...int64) []int64 {
+		sink := new(consumertest.MetricsSink)
+		proc := newProcessor(cfg, component.NewID(metadata.Type), zap.NewNop(), noop.NewMeterProvider().Meter(""), sink)
+		require.NoError(t, proc.Start(context.Background(), host))
+
+		for _, v := range samples {
+			now = now.Add(time.Second)
+			require.NoError(t, proc.ConsumeMetrics(context.Background(), deltaSum(now, v)))
+		}
+		require.NoError(t, proc.Shutdown(context.Background()))
+
+		var got []int64
+		for _, md := range sink.AllMetrics() {
+			dp := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+			require.Equal(t, pcommon.NewTimestampFromTime(time.Unix(1000, 0)), dp.StartTimestamp())
+			got = append(got, dp.IntValue())
+		}
+		return got
+	}
+
+	require.Equal(t, []int64{1, 3}, run(1, 2))
+
+	// restored state continues accumulating
+	require.Equal(t, []int64{6, 10}, run(3, 4))
+
+	// state is not restored once stale
+	now = now.Add(cfg.MaxStale)
+	sink := new(consumertest.MetricsSink)
+	proc := newProcessor(cfg, component.NewID(metadata.Type), zap.NewNop(), noop.NewMeterProvider().Meter(""), sink)
+	require.NoError(t, proc.Start(context.Background(), host))
+	require.Equal(t, 0, proc.sums.dps.Len())
+	require.NoError(t, proc.Shutdown(context.Background()))
+}
+
+func TestCheckpointRestoreMaxStreams(t *testing.T) {
+	now := time.Unix(1000, 0)
+	staleness.NowFunc = func() time.Time { return now }
+	defer func() { staleness.NowFunc = time.Now }()
+
+	storageID := component.MustNewID("memory_storage")
+	host := storageHost{id: storageID, ext: &memoryStorage{}}
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.StorageID = &storageID
+
+	proc := newProcessor(cfg, component.NewID(metadata.Type), zap.NewNop(), noop.NewMeterProvider().Meter(""), new(consumertest.MetricsSink))
+	require.NoError(t, proc.Start(context.Background(), host))
+	for _, name := range []string{"a", "b", "c"} {
+		now = now.Add(time.Second)
+		md := deltaSum(now, 1)
+		md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).SetName(name)
+		require.NoError(t, proc.ConsumeMetrics(context.Background(), md))
+	}
+	require.NoError(t, proc.Shutdown(context.Background()))
+
+	// only the most recent streams are restored within the limit
+	cfg.MaxStreams = 2
+	proc = newProcessor(cfg, component.NewID(metadata.Type), zap.NewNop(), noop.NewMeterProvider().Meter(""), new(consumertest.MetricsSink))
+	require.NoError(t, proc.Start(context.Background(), host))
+	require.Equal(t, 2, proc.sums.dps.Len())
+	var restored []pcommon.Timestamp
+	proc.sums.dps.Items()(func(_ identity.Stream, dp data.Number) bool {
+		restored = append(restored, dp.Timestamp())
+		return true
+	})
+	// the streams of b and c were seen last
+	require.ElementsMatch(t, []pcommon.Timestamp{
+		pcommon.NewTimestampFromTime(now.Add(-time.Second)),
+		pcommon.NewTimestampFromTime(now),
+	}, restored)
+	require.NoError(t, proc.Shutdown(context.Background()))
+}
+
+func deltaSum(ts time.Time, v int64) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("requests")
+	sum := m.SetEmptySum()
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	sum.SetIsMonotonic(true)
+
+	dp := sum.DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(pcommon.NewTimestampFromTime(ts.Add(-time.Second)))
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+	dp.SetIntValue(v)
+	return md
+}
+
+type storageHost struct {
+	component.Host
+	id  component.ID
+	ext component.Component
+}
+
+func (h storageHost) GetExtensions() map[component.ID]component.Component {
+	return map[component.ID]component.Component{h.id: h.ext}
+}
+
+// memoryStorage is a storage extension that keeps data across clients, to
+// simulate a restart
+type memoryStorage struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	mtx  sync.Mutex
+	data map[string][]byte
+}
+
+func (m *memoryStorage) GetClient(context.Context, component.Kind, component.ID, string) (storage.Client, error) {
+	return m, nil
+}
+
+func (m *memoryStorage) Get(_ context.Context, key string) ([]byte, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.data[key], nil
+}
+
+func (m *memoryStorage) Set(_ context.Context, key string, value []byte) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if m.data == nil {
+		m.data = make(map[string][]byte)
+	}
+	m.data[key] = value
+	return nil
+}
+
+func (m *memoryStorage) Delete(_ context.Context, key string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	delete(m.data, key)
+	return nil
+}
+
+func (m *memoryStorage) Batch(ctx context.Context, ops ...storage.Operation) error {
+	for _, op := range ops {
+		var err error
+		switch op.Type {
+		case storage.Get:
+			op.Value, err = m.Get(ctx, op.Key)
+		case storage.Set:
+			err = m.Set(ctx, op.Key, op.Value)
+		case storage.Delete:
+			err = m.Delete(ctx, op.Key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *memoryStorage) Close(context.Context) error {
+	return nil
+}
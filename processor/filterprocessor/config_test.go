@@ -866,6 +866,14 @@ func TestLoadingConfigOTTL(t *testing.T) {
 						`attributes["test"] == "pass"`,
 					},
 				},
+				Profiles: ProfileFilters{
+					ProfileConditions: []string{
+						`attributes["test"] == "pass"`,
+					},
+					SampleConditions: []string{
+						`IsMatch(functions[0], "^runtime\\.")`,
+					},
+				},
 			},
 		},
 		{
@@ -907,6 +915,12 @@ func TestLoadingConfigOTTL(t *testing.T) {
 		{
 			id: component.NewIDWithName(metadata.Type, "bad_syntax_log"),
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_syntax_profile"),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_syntax_sample"),
+		},
 	}
 
 	for _, tt := range tests {
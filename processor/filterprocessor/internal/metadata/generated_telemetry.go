@@ -27,6 +27,7 @@ type TelemetryBuilder struct {
 	meter                             metric.Meter
 	ProcessorFilterDatapointsFiltered metric.Int64Counter
 	ProcessorFilterLogsFiltered       metric.Int64Counter
+	ProcessorFilterSamplesFiltered    metric.Int64Counter
 	ProcessorFilterSpansFiltered      metric.Int64Counter
 	level                             configtelemetry.Level
 }
@@ -66,6 +67,12 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...teleme
 		metric.WithUnit("1"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ProcessorFilterSamplesFiltered, err = builder.meter.Int64Counter(
+		"processor_filter_samples.filtered",
+		metric.WithDescription("Number of profile samples dropped by the filter processor"),
+		metric.WithUnit("1"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ProcessorFilterSpansFiltered, err = builder.meter.Int64Counter(
 		"processor_filter_spans.filtered",
 		metric.WithDescription("Number of spans dropped by the filter processor"),
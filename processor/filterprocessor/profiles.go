@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filterprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofile"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlsample"
+)
+
+type filterProfileProcessor struct {
+	skipProfileExpr expr.BoolExpr[ottlprofile.TransformContext]
+	skipSampleExpr  expr.BoolExpr[ottlsample.TransformContext]
+	telemetry       *filterProcessorTelemetry
+	logger          *zap.Logger
+}
+
+func newFilterProfilesProcessor(set processor.Settings, cfg *Config) (*filterProfileProcessor, error) {
+	var err error
+	fpp := &filterProfileProcessor{
+		logger: set.Logger,
+	}
+
+	fpt, err := newfilterProcessorTelemetry(set)
+	if err != nil {
+		return nil, fmt.Errorf("error creating filter processor telemetry: %w", err)
+	}
+	fpp.telemetry = fpt
+
+	if cfg.Profiles.ProfileConditions != nil {
+		fpp.skipProfileExpr, err = filterottl.NewBoolExprForProfile(cfg.Profiles.ProfileConditions, filterottl.StandardProfileFuncs(), cfg.ErrorMode, set.TelemetrySettings)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Profiles.SampleConditions != nil {
+		fpp.skipSampleExpr, err = filterottl.NewBoolExprForSample(cfg.Profiles.SampleConditions, filterottl.StandardSampleFuncs(), cfg.ErrorMode, set.TelemetrySettings)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return fpp, nil
+}
+
+// processProfiles filters the given profiles and samples based off the filterProfileProcessor's filters.
+func (fpp *filterProfileProcessor) processProfiles(ctx context.Context, rps pprofile.ResourceProfilesSlice) (pprofile.ResourceProfilesSlice, error) {
+	if fpp.skipProfileExpr == nil && fpp.skipSampleExpr == nil {
+		return rps, nil
+	}
+
+	sampleCountBeforeFilters := sampleCount(rps)
+
+	var errors error
+	rps.RemoveIf(func(rp pprofile.ResourceProfiles) bool {
+		resource := rp.Resource()
+		rp.ScopeProfiles().RemoveIf(func(sp pprofile.ScopeProfiles) bool {
+			scope := sp.Scope()
+			sp.Profiles().RemoveIf(func(profile pprofile.ProfileContainer) bool {
+				if fpp.skipProfileExpr != nil {
+					skip, err := fpp.skipProfileExpr.Eval(ctx, ottlprofile.NewTransformContext(profile, scope, resource))
+					if err != nil {
+						errors = multierr.Append(errors, err)
+						return false
+					}
+					if skip {
+						return true
+					}
+				}
+				if fpp.skipSampleExpr != nil {
+					profile.Profile().Sample().RemoveIf(func(sample pprofile.Sample) bool {
+						skip, err := fpp.skipSampleExpr.Eval(ctx, ottlsample.NewTransformContext(sample, profile, scope, resource))
+						if err != nil {
+							errors = multierr.Append(errors, err)
+							return false
+						}
+						return skip
+					})
+					return profile.Profile().Sample().Len() == 0
+				}
+				return false
+			})
+			return sp.Profiles().Len() == 0
+		})
+		return rp.ScopeProfiles().Len() == 0
+	})
+
+	sampleCountAfterFilters := sampleCount(rps)
+	fpp.telemetry.record(triggerSamplesDropped, int64(sampleCountBeforeFilters-sampleCountAfterFilters))
+
+	if errors != nil {
+		fpp.logger.Error("failed processing profiles", zap.Error(errors))
+		return rps, errors
+	}
+	if rps.Len() == 0 {
+		return rps, processorhelper.ErrSkipProcessingData
+	}
+	return rps, nil
+}
+
+// sampleCount returns the number of samples of the profiles
+func sampleCount(rps pprofile.ResourceProfilesSlice) int {
+	count := 0
+	for i := 0; i < rps.Len(); i++ {
+		sps := rps.At(i).ScopeProfiles()
+		for j := 0; j < sps.Len(); j++ {
+			profiles := sps.At(j).Profiles()
+			for k := 0; k < profiles.Len(); k++ {
+				count += profiles.At(k).Profile().Sample().Len()
+			}
+		}
+	}
+	return count
+}
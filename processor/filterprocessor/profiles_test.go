@@ -0,0 +1,168 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filterprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func TestFilterProfileProcessorWithOTTL(t *testing.T) {
+	tests := []struct {
+		name             string
+		conditions       ProfileFilters
+		filterEverything bool
+		want             func(rps pprofile.ResourceProfilesSlice)
+		errorMode        ottl.ErrorMode
+	}{
+		{
+			name: "drop profiles",
+			conditions: ProfileFilters{
+				ProfileConditions: []string{
+					`attributes["process.executable.name"] == "worker"`,
+				},
+			},
+			want: func(rps pprofile.ResourceProfilesSlice) {
+				rps.At(0).ScopeProfiles().At(0).Profiles().RemoveIf(func(profile pprofile.ProfileContainer) bool {
+					return profile.Attributes().AsRaw()["process.executable.name"] == "worker"
+				})
+			},
+			errorMode: ottl.IgnoreError,
+		},
+		{
+			name: "drop everything by dropping all profiles",
+			conditions: ProfileFilters{
+				ProfileConditions: []string{
+					`IsMatch(attributes["process.executable.name"], ".*")`,
+				},
+			},
+			filterEverything: true,
+			errorMode:        ottl.IgnoreError,
+		},
+		{
+			name: "drop samples",
+			conditions: ProfileFilters{
+				SampleConditions: []string{
+					`IsMatch(functions[0], "^runtime\\.")`,
+				},
+			},
+			want: func(rps pprofile.ResourceProfilesSlice) {
+				rps.At(0).ScopeProfiles().At(0).Profiles().At(1).Profile().Sample().RemoveIf(func(sample pprofile.Sample) bool {
+					return sample.LocationsStartIndex() == 1
+				})
+			},
+			errorMode: ottl.IgnoreError,
+		},
+		{
+			name: "drop everything by dropping all samples",
+			conditions: ProfileFilters{
+				SampleConditions: []string{
+					`IsMatch(functions[0], ".*")`,
+				},
+			},
+			filterEverything: true,
+			errorMode:        ottl.IgnoreError,
+		},
+		{
+			name: "with error conditions",
+			conditions: ProfileFilters{
+				ProfileConditions: []string{
+					`Substring("", 0, 100) == "test"`,
+				},
+			},
+			want:      func(_ pprofile.ResourceProfilesSlice) {},
+			errorMode: ottl.IgnoreError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor, err := newFilterProfilesProcessor(processortest.NewNopSettings(), &Config{Profiles: tt.conditions, ErrorMode: tt.errorMode})
+			assert.NoError(t, err)
+
+			got, err := processor.processProfiles(context.Background(), constructProfiles())
+
+			if tt.filterEverything {
+				assert.Equal(t, processorhelper.ErrSkipProcessingData, err)
+			} else {
+				exRps := constructProfiles()
+				tt.want(exRps)
+				assert.Equal(t, exRps, got)
+			}
+		})
+	}
+}
+
+func TestFilterProfileProcessorTelemetry(t *testing.T) {
+	telemetryTest(t, "FilterProfileProcessorTelemetry", func(t *testing.T, tel testTelemetry) {
+		processor, err := newFilterProfilesProcessor(tel.NewProcessorCreateSettings(), &Config{
+			Profiles: ProfileFilters{
+				ProfileConditions: []string{
+					`attributes["process.executable.name"] == "api"`,
+				},
+			}, ErrorMode: ottl.IgnoreError,
+		})
+		assert.NoError(t, err)
+
+		_, err = processor.processProfiles(context.Background(), constructProfiles())
+		assert.NoError(t, err)
+
+		tel.assertMetrics(t, expectedMetrics{
+			samplesFiltered: 1,
+		})
+	})
+}
+
+func constructProfiles() pprofile.ResourceProfilesSlice {
+	rps := pprofile.NewResourceProfilesSlice()
+	rp0 := rps.AppendEmpty()
+	rp0.Resource().Attributes().PutStr("host.name", "localhost")
+	rp0sp0 := rp0.ScopeProfiles().AppendEmpty()
+	rp0sp0.Scope().SetName("scope1")
+	fillProfileOne(rp0sp0.Profiles().AppendEmpty())
+	fillProfileTwo(rp0sp0.Profiles().AppendEmpty())
+	return rps
+}
+
+func fillProfileOne(profile pprofile.ProfileContainer) {
+	profile.Attributes().PutStr("process.executable.name", "api")
+	fillProfileStack(profile.Profile(), "main.handle")
+	sample := profile.Profile().Sample().AppendEmpty()
+	sample.SetLocationsLength(1)
+	sample.Value().FromRaw([]int64{1})
+}
+
+func fillProfileTwo(profile pprofile.ProfileContainer) {
+	profile.Attributes().PutStr("process.executable.name", "worker")
+	fillProfileStack(profile.Profile(), "main.work")
+	work := profile.Profile().Sample().AppendEmpty()
+	work.SetLocationsLength(1)
+	work.Value().FromRaw([]int64{3})
+	gc := profile.Profile().Sample().AppendEmpty()
+	gc.SetLocationsStartIndex(1)
+	gc.SetLocationsLength(1)
+	gc.Value().FromRaw([]int64{2})
+}
+
+func fillProfileStack(profile pprofile.Profile, functionName string) {
+	profile.StringTable().FromRaw([]string{"", functionName, "runtime.gcBgMarkWorker"})
+
+	function := profile.Function().AppendEmpty()
+	function.SetName(1)
+	gcFunction := profile.Function().AppendEmpty()
+	gcFunction.SetName(2)
+
+	location := profile.Location().AppendEmpty()
+	location.Line().AppendEmpty().SetFunctionIndex(0)
+	gcLocation := profile.Location().AppendEmpty()
+	gcLocation.Line().AppendEmpty().SetFunctionIndex(1)
+
+	profile.LocationIndices().FromRaw([]int64{0, 1})
+}
@@ -19,6 +19,7 @@ const (
 	triggerMetricDataPointsDropped trigger = iota
 	triggerLogsDropped
 	triggerSpansDropped
+	triggerSamplesDropped
 )
 
 type filterProcessorTelemetry struct {
@@ -51,6 +52,8 @@ func (fpt *filterProcessorTelemetry) record(trigger trigger, dropped int64) {
 		triggerMeasure = fpt.telemetryBuilder.ProcessorFilterLogsFiltered
 	case triggerSpansDropped:
 		triggerMeasure = fpt.telemetryBuilder.ProcessorFilterSpansFiltered
+	case triggerSamplesDropped:
+		triggerMeasure = fpt.telemetryBuilder.ProcessorFilterSamplesFiltered
 	}
 
 	triggerMeasure.Add(fpt.exportCtx, dropped, metric.WithAttributes(fpt.processorAttr...))
@@ -31,6 +31,8 @@ type expectedMetrics struct {
 	logsFiltered int64
 	// processor_filter_spans_filtered
 	spansFiltered int64
+	// processor_filter_samples_filtered
+	samplesFiltered int64
 }
 
 func telemetryTest(t *testing.T, name string, testFunc func(t *testing.T, tel testTelemetry)) {
@@ -119,6 +121,26 @@ func (tt *testTelemetry) assertMetrics(t *testing.T, expected expectedMetrics) {
 		}
 		metricdatatest.AssertEqual(t, want, got, metricdatatest.IgnoreTimestamp())
 	}
+	if expected.samplesFiltered > 0 {
+		name := "processor_filter_samples.filtered"
+		got := tt.getMetric(name, md)
+		want := metricdata.Metrics{
+			Name:        name,
+			Description: "Number of profile samples dropped by the filter processor",
+			Unit:        "1",
+			Data: metricdata.Sum[int64]{
+				Temporality: metricdata.CumulativeTemporality,
+				IsMonotonic: true,
+				DataPoints: []metricdata.DataPoint[int64]{
+					{
+						Value:      expected.samplesFiltered,
+						Attributes: attribute.NewSet(attribute.String("filter", "filter")),
+					},
+				},
+			},
+		}
+		metricdatatest.AssertEqual(t, want, got, metricdatatest.IgnoreTimestamp())
+	}
 }
 
 func (tt *testTelemetry) getMetric(name string, got metricdata.ResourceMetrics) metricdata.Metrics {
@@ -13,19 +13,46 @@ import (
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	maxmind "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider"
 )
 
 func TestLoadConfig(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
-		id           component.ID
-		expected     component.Config
-		errorMessage string
+		id                    component.ID
+		expected              component.Config
+		errorMessage          string
+		unmarshalErrorMessage string
 	}{
 		{
-			id:       component.NewID(metadata.Type),
-			expected: &Config{},
+			id:           component.NewID(metadata.Type),
+			errorMessage: "must specify at least one geo IP data provider when using the geoip processor",
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "maxmind"),
+			expected: &Config{
+				Providers: map[string]provider.Config{
+					"maxmind": &maxmind.Config{DatabasePath: "/tmp/db", Language: "en"},
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "maxmind_language"),
+			expected: &Config{
+				Providers: map[string]provider.Config{
+					"maxmind": &maxmind.Config{DatabasePath: "/tmp/db", Language: "es"},
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_providers_config"),
+			errorMessage: "error validating provider maxmind: a local geoIP database path must be provided",
+		},
+		{
+			id:                    component.NewIDWithName(metadata.Type, "invalid_provider_key"),
+			unmarshalErrorMessage: "invalid provider key: invalid",
 		},
 	}
 
@@ -39,6 +66,11 @@ func TestLoadConfig(t *testing.T) {
 
 			sub, err := cm.Sub(tt.id.String())
 			require.NoError(t, err)
+
+			if tt.unmarshalErrorMessage != "" {
+				assert.ErrorContains(t, sub.Unmarshal(cfg), tt.unmarshalErrorMessage)
+				return
+			}
 			require.NoError(t, sub.Unmarshal(cfg))
 
 			if tt.errorMessage != "" {
@@ -5,6 +5,7 @@ package geoipprocessor // import "github.com/open-telemetry/opentelemetry-collec
 
 import (
 	"context"
+	"fmt"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -14,6 +15,8 @@ import (
 	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	maxmind "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider"
 )
 
 var (
@@ -22,6 +25,11 @@ var (
 	// These keys are used to identify an IP address attribute associated with the resource.
 	defaultResourceAttributes = []attribute.Key{
 		semconv.SourceAddressKey, // This key represents the standard source address attribute as defined in the OpenTelemetry semantic conventions.
+		semconv.ClientAddressKey, // This key represents the standard client address attribute as defined in the OpenTelemetry semantic conventions.
+	}
+	// providerFactories holds the factories of the available geo IP data providers, keyed by their configuration name.
+	providerFactories = map[string]provider.GeoIPProviderFactory{
+		maxmind.TypeStr: &maxmind.Factory{},
 	}
 )
 
@@ -31,19 +39,73 @@ func NewFactory() processor.Factory {
 	return processor.NewFactory(metadata.Type, createDefaultConfig, processor.WithMetrics(createMetricsProcessor, metadata.MetricsStability), processor.WithLogs(createLogsProcessor, metadata.LogsStability), processor.WithTraces(createTracesProcessor, metadata.TracesStability))
 }
 
+func getProviderFactory(key string) (provider.GeoIPProviderFactory, bool) {
+	if factory, ok := providerFactories[key]; ok {
+		return factory, true
+	}
+
+	return nil, false
+}
+
 // createDefaultConfig returns a default configuration for the processor.
 func createDefaultConfig() component.Config {
 	return &Config{}
 }
 
+// createGeoIPProviders creates a list of GeoIPProvider instances based on the provided configuration and providers factories.
+func createGeoIPProviders(
+	ctx context.Context,
+	set processor.Settings,
+	config *Config,
+	factories map[string]provider.GeoIPProviderFactory,
+) ([]provider.GeoIPProvider, error) {
+	providers := make([]provider.GeoIPProvider, 0, len(config.Providers))
+
+	for key, cfg := range config.Providers {
+		factory, ok := factories[key]
+		if !ok {
+			return nil, fmt.Errorf("geoIP provider factory not found for key: %q", key)
+		}
+
+		geoProvider, err := factory.CreateGeoIPProvider(ctx, set, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create provider for key %q: %w", key, err)
+		}
+
+		providers = append(providers, geoProvider)
+	}
+
+	return providers, nil
+}
+
 func createMetricsProcessor(ctx context.Context, set processor.Settings, cfg component.Config, nextConsumer consumer.Metrics) (processor.Metrics, error) {
-	return processorhelper.NewMetricsProcessor(ctx, set, cfg, nextConsumer, newGeoIPProcessor(defaultResourceAttributes).processMetrics, processorhelper.WithCapabilities(processorCapabilities))
+	geoProcessor, err := newGeoIPProcessorFromConfig(ctx, set, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	return processorhelper.NewMetricsProcessor(ctx, set, cfg, nextConsumer, geoProcessor.processMetrics, processorhelper.WithCapabilities(processorCapabilities), processorhelper.WithStart(geoProcessor.start), processorhelper.WithShutdown(geoProcessor.shutdown))
 }
 
 func createTracesProcessor(ctx context.Context, set processor.Settings, cfg component.Config, nextConsumer consumer.Traces) (processor.Traces, error) {
-	return processorhelper.NewTracesProcessor(ctx, set, cfg, nextConsumer, newGeoIPProcessor(defaultResourceAttributes).processTraces, processorhelper.WithCapabilities(processorCapabilities))
+	geoProcessor, err := newGeoIPProcessorFromConfig(ctx, set, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	return processorhelper.NewTracesProcessor(ctx, set, cfg, nextConsumer, geoProcessor.processTraces, processorhelper.WithCapabilities(processorCapabilities), processorhelper.WithStart(geoProcessor.start), processorhelper.WithShutdown(geoProcessor.shutdown))
 }
 
 func createLogsProcessor(ctx context.Context, set processor.Settings, cfg component.Config, nextConsumer consumer.Logs) (processor.Logs, error) {
-	return processorhelper.NewLogsProcessor(ctx, set, cfg, nextConsumer, newGeoIPProcessor(defaultResourceAttributes).processLogs, processorhelper.WithCapabilities(processorCapabilities))
+	geoProcessor, err := newGeoIPProcessorFromConfig(ctx, set, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	return processorhelper.NewLogsProcessor(ctx, set, cfg, nextConsumer, geoProcessor.processLogs, processorhelper.WithCapabilities(processorCapabilities), processorhelper.WithStart(geoProcessor.start), processorhelper.WithShutdown(geoProcessor.shutdown))
+}
+
+func newGeoIPProcessorFromConfig(ctx context.Context, set processor.Settings, cfg *Config) (*geoIPProcessor, error) {
+	providers, err := createGeoIPProviders(ctx, set, cfg, providerFactories)
+	if err != nil {
+		return nil, err
+	}
+	return newGeoIPProcessor(defaultResourceAttributes, providers), nil
 }
@@ -5,12 +5,18 @@ package geoipprocessor
 
 import (
 	"context"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	maxmind "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider/testdata"
 )
 
 func TestCreateDefaultConfig(t *testing.T) {
@@ -50,3 +56,29 @@ func TestCreateProcessor(t *testing.T) {
 	assert.NotNil(t, lp)
 	assert.NoError(t, err)
 }
+
+func TestCreateProcessorWithProviders(t *testing.T) {
+	factory := NewFactory()
+	params := processortest.NewNopSettings()
+
+	dbPath := filepath.Join(t.TempDir(), "city.mmdb")
+	require.NoError(t, testdata.WriteIPv4Database(dbPath, "GeoLite2-City", map[string]testdata.Record{
+		"1.2.3.0/24": testdata.CityRecord("EU", "ES", "CT", "Barcelona", "08012", 41.3888, 2.159),
+	}))
+
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Providers = map[string]provider.Config{
+		"maxmind": &maxmind.Config{DatabasePath: dbPath},
+	}
+
+	lp, err := factory.CreateLogsProcessor(context.Background(), params, cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, lp.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, lp.Shutdown(context.Background()))
+
+	cfg.Providers = map[string]provider.Config{
+		"maxmind": &maxmind.Config{DatabasePath: filepath.Join(t.TempDir(), "missing.mmdb")},
+	}
+	_, err = factory.CreateTracesProcessor(context.Background(), params, cfg, consumertest.NewNop())
+	assert.ErrorContains(t, err, "failed to create provider for key \"maxmind\"")
+}
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"net"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -25,9 +26,10 @@ type geoIPProcessor struct {
 	resourceAttributes []attribute.Key
 }
 
-func newGeoIPProcessor(resourceAttributes []attribute.Key) *geoIPProcessor {
+func newGeoIPProcessor(resourceAttributes []attribute.Key, providers []provider.GeoIPProvider) *geoIPProcessor {
 	return &geoIPProcessor{
 		resourceAttributes: resourceAttributes,
+		providers:          providers,
 	}
 }
 
@@ -76,16 +78,48 @@ func (g *geoIPProcessor) processResource(ctx context.Context, resource pcommon.R
 
 	attributes, err := g.geoLocation(ctx, ipAddr)
 	if err != nil {
+		// An IP address missing from the data source is not an error for the pipeline.
+		if errors.Is(err, provider.ErrNoMetadataFound) {
+			return nil
+		}
 		return err
 	}
 
 	for _, geoAttr := range attributes.ToSlice() {
-		resource.Attributes().PutStr(string(geoAttr.Key), geoAttr.Value.AsString())
+		switch geoAttr.Value.Type() {
+		case attribute.FLOAT64:
+			resource.Attributes().PutDouble(string(geoAttr.Key), geoAttr.Value.AsFloat64())
+		case attribute.INT64:
+			resource.Attributes().PutInt(string(geoAttr.Key), geoAttr.Value.AsInt64())
+		case attribute.BOOL:
+			resource.Attributes().PutBool(string(geoAttr.Key), geoAttr.Value.AsBool())
+		default:
+			resource.Attributes().PutStr(string(geoAttr.Key), geoAttr.Value.AsString())
+		}
 	}
 
 	return nil
 }
 
+// start starts the configured providers, e.g. to watch their data sources for changes.
+func (g *geoIPProcessor) start(ctx context.Context, _ component.Host) error {
+	for _, provider := range g.providers {
+		if err := provider.Start(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// shutdown releases the resources held by the configured providers.
+func (g *geoIPProcessor) shutdown(ctx context.Context) error {
+	var errs error
+	for _, provider := range g.providers {
+		errs = errors.Join(errs, provider.Close(ctx))
+	}
+	return errs
+}
+
 func (g *geoIPProcessor) processMetrics(ctx context.Context, ms pmetric.Metrics) (pmetric.Metrics, error) {
 	rm := ms.ResourceMetrics()
 	for i := 0; i < rm.Len(); i++ {
@@ -5,6 +5,7 @@ package geoipprocessor
 
 import (
 	"context"
+	"errors"
 	"net"
 	"testing"
 
@@ -38,6 +39,14 @@ func (pm *ProviderMock) Location(ctx context.Context, ip net.IP) (attribute.Set,
 	return pm.LocationF(ctx, ip)
 }
 
+func (pm *ProviderMock) Start(context.Context) error {
+	return nil
+}
+
+func (pm *ProviderMock) Close(context.Context) error {
+	return nil
+}
+
 type generateResourceFunc func(res pcommon.Resource)
 
 func generateTraces(resourceFunc ...generateResourceFunc) ptrace.Traces {
@@ -188,14 +197,69 @@ func TestProcessPdata(t *testing.T) {
 				}),
 			},
 		},
+		{
+			name:               "default client.address attribute",
+			resourceAttributes: defaultResourceAttributes,
+			initResourceAttributes: []generateResourceFunc{
+				withAttributes([]attribute.KeyValue{
+					attribute.String(string(semconv.ClientAddressKey), "1.2.3.4"),
+				}),
+			},
+			geoLocationMock: func(context.Context, net.IP) (attribute.Set, error) {
+				return attribute.NewSet(attribute.String("geo.city_name", "barcelona")), nil
+			},
+			expectedResourceAttributes: []generateResourceFunc{
+				withAttributes([]attribute.KeyValue{
+					attribute.String(string(semconv.ClientAddressKey), "1.2.3.4"),
+					attribute.String("geo.city_name", "barcelona"),
+				}),
+			},
+		},
+		{
+			name:               "ip not found by the provider",
+			resourceAttributes: defaultResourceAttributes,
+			initResourceAttributes: []generateResourceFunc{
+				withAttributes([]attribute.KeyValue{
+					attribute.String(string(semconv.SourceAddressKey), "1.2.3.4"),
+				}),
+			},
+			geoLocationMock: func(context.Context, net.IP) (attribute.Set, error) {
+				return attribute.Set{}, provider.ErrNoMetadataFound
+			},
+			expectedResourceAttributes: []generateResourceFunc{
+				withAttributes([]attribute.KeyValue{
+					attribute.String(string(semconv.SourceAddressKey), "1.2.3.4"),
+				}),
+			},
+		},
+		{
+			name:               "keep attribute value types",
+			resourceAttributes: defaultResourceAttributes,
+			initResourceAttributes: []generateResourceFunc{
+				withAttributes([]attribute.KeyValue{
+					attribute.String(string(semconv.SourceAddressKey), "1.2.3.4"),
+				}),
+			},
+			geoLocationMock: func(context.Context, net.IP) (attribute.Set, error) {
+				return attribute.NewSet(attribute.Float64("geo.location.lat", 41.3888), attribute.Float64("geo.location.lon", 2.159)), nil
+			},
+			expectedResourceAttributes: []generateResourceFunc{
+				withAttributes([]attribute.KeyValue{
+					attribute.String(string(semconv.SourceAddressKey), "1.2.3.4"),
+				}),
+				func(res pcommon.Resource) {
+					res.Attributes().PutDouble("geo.location.lat", 41.3888)
+					res.Attributes().PutDouble("geo.location.lon", 2.159)
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// prepare processor
 			baseProviderMock.LocationF = tt.geoLocationMock
-			processor := newGeoIPProcessor(tt.resourceAttributes)
-			processor.providers = []provider.GeoIPProvider{&baseProviderMock}
+			processor := newGeoIPProcessor(tt.resourceAttributes, []provider.GeoIPProvider{&baseProviderMock})
 
 			// assert metrics
 			actualMetrics, err := processor.processMetrics(context.Background(), generateMetrics(tt.initResourceAttributes...))
@@ -214,3 +278,17 @@ func TestProcessPdata(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessorProviderError(t *testing.T) {
+	providerErr := errors.New("provider failure")
+	processor := newGeoIPProcessor(defaultResourceAttributes, []provider.GeoIPProvider{&ProviderMock{
+		LocationF: func(context.Context, net.IP) (attribute.Set, error) {
+			return attribute.Set{}, providerErr
+		},
+	}})
+
+	_, err := processor.processLogs(context.Background(), generateLogs(withAttributes([]attribute.KeyValue{
+		attribute.String(string(semconv.SourceAddressKey), "1.2.3.4"),
+	})))
+	require.ErrorIs(t, err, providerErr)
+}
@@ -0,0 +1,22 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package conventions // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
+
+// TODO: replace with the semconv package once the geo namespace is part of a released semantic conventions version.
+const (
+	// AttributeGeoContinentCode represents the two-letter code of the continent.
+	AttributeGeoContinentCode = "geo.continent.code"
+	// AttributeGeoCountryIsoCode represents the two-letter ISO Country Code (ISO 3166-1 alpha2).
+	AttributeGeoCountryIsoCode = "geo.country.iso_code"
+	// AttributeGeoLocalityName represents the locality name, such as a city or town name.
+	AttributeGeoLocalityName = "geo.locality.name"
+	// AttributeGeoLocationLat represents the latitude of the geo location in WGS84.
+	AttributeGeoLocationLat = "geo.location.lat"
+	// AttributeGeoLocationLon represents the longitude of the geo location in WGS84.
+	AttributeGeoLocationLon = "geo.location.lon"
+	// AttributeGeoPostalCode represents the postal code associated with the location.
+	AttributeGeoPostalCode = "geo.postal_code"
+	// AttributeGeoRegionIsoCode represents the region ISO code (ISO 3166-2).
+	AttributeGeoRegionIsoCode = "geo.region.iso_code"
+)
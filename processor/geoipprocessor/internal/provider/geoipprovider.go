@@ -5,13 +5,39 @@ package provider // import "github.com/open-telemetry/opentelemetry-collector-co
 
 import (
 	"context"
+	"errors"
 	"net"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/otel/attribute"
 )
 
+// ErrNoMetadataFound is returned by a GeoIPProvider when the given IP address is not present in its data source.
+var ErrNoMetadataFound = errors.New("no geo IP metadata found")
+
+// Config is the configuration of a GeoIPProvider.
+type Config interface {
+	component.ConfigValidator
+}
+
 // GeoIPProvider defines methods for obtaining the geographical location based on the provided IP address.
 type GeoIPProvider interface {
 	// Location returns a set of attributes representing the geographical location for the given IP address. It requires a context for managing request lifetime.
 	Location(context.Context, net.IP) (attribute.Set, error)
+
+	// Start starts any background work of the provider, such as watching its data source for changes.
+	Start(context.Context) error
+
+	// Close releases any resources held by the provider.
+	Close(context.Context) error
+}
+
+// GeoIPProviderFactory can create GeoIPProvider instances.
+type GeoIPProviderFactory interface {
+	// CreateDefaultConfig creates the default configuration for the GeoIPProvider.
+	CreateDefaultConfig() Config
+
+	// CreateGeoIPProvider creates a provider based on this config. The processor settings are used to provide the logger.
+	CreateGeoIPProvider(ctx context.Context, settings processor.Settings, cfg Config) (GeoIPProvider, error)
 }
@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package maxmind // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider"
+
+import (
+	"errors"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+)
+
+// Config defines configuration for the MaxMind database provider.
+type Config struct {
+	// DatabasePath section allows specifying a local GeoIP database file to retrieve the geographical metadata from.
+	// Any MaxMind DB (.mmdb) file following the GeoIP2/GeoLite2 City layout is supported, including
+	// IP2Location databases distributed in the MMDB format.
+	DatabasePath string `mapstructure:"database_path"`
+
+	// Language is the locale used to select localized names (e.g. the city name) from the database. Defaults to "en".
+	Language string `mapstructure:"language"`
+}
+
+var _ provider.Config = (*Config)(nil)
+
+// Validate implements provider.Config.
+func (c *Config) Validate() error {
+	if c.DatabasePath == "" {
+		return errors.New("a local geoIP database path must be provided")
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package maxmind // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+)
+
+// TypeStr the mmdb provider identifier.
+const TypeStr = "maxmind"
+
+const defaultLanguage = "en"
+
+// Factory is the Factory for the MaxMind database provider.
+type Factory struct{}
+
+var _ provider.GeoIPProviderFactory = (*Factory)(nil)
+
+// CreateDefaultConfig creates the default configuration for the provider.
+func (f *Factory) CreateDefaultConfig() provider.Config {
+	return &Config{
+		Language: defaultLanguage,
+	}
+}
+
+// CreateGeoIPProvider creates a provider using the given config.
+func (f *Factory) CreateGeoIPProvider(_ context.Context, settings processor.Settings, cfg provider.Config) (provider.GeoIPProvider, error) {
+	return newMaxMindProvider(cfg.(*Config), settings.Logger)
+}
@@ -0,0 +1,231 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package maxmind // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/oschwald/maxminddb-golang"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+)
+
+// cityRecord holds the subset of the GeoIP2 City database layout mapped into geo attributes.
+type cityRecord struct {
+	City struct {
+		Names map[string]string `maxminddb:"names"`
+	} `maxminddb:"city"`
+	Continent struct {
+		Code string `maxminddb:"code"`
+	} `maxminddb:"continent"`
+	Country struct {
+		IsoCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+	Location struct {
+		Latitude  *float64 `maxminddb:"latitude"`
+		Longitude *float64 `maxminddb:"longitude"`
+	} `maxminddb:"location"`
+	Postal struct {
+		Code string `maxminddb:"code"`
+	} `maxminddb:"postal"`
+	Subdivisions []struct {
+		IsoCode string `maxminddb:"iso_code"`
+	} `maxminddb:"subdivisions"`
+}
+
+var errProviderClosed = errors.New("maxmind provider is closed")
+
+type maxMindProvider struct {
+	databasePath string
+	language     string
+	logger       *zap.Logger
+
+	mu     sync.RWMutex
+	reader *maxminddb.Reader
+
+	watcher    *fsnotify.Watcher
+	shutdownCH chan struct{}
+	wg         sync.WaitGroup
+}
+
+var _ provider.GeoIPProvider = (*maxMindProvider)(nil)
+
+func newMaxMindProvider(cfg *Config, logger *zap.Logger) (*maxMindProvider, error) {
+	reader, err := openDatabase(cfg.DatabasePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not open geoip database: %w", err)
+	}
+
+	language := cfg.Language
+	if language == "" {
+		language = defaultLanguage
+	}
+
+	return &maxMindProvider{
+		databasePath: cfg.DatabasePath,
+		language:     language,
+		logger:       logger,
+		reader:       reader,
+	}, nil
+}
+
+// openDatabase loads the whole database into memory instead of memory-mapping it, so a file
+// that is rewritten in place cannot corrupt the reader that is currently serving lookups.
+func openDatabase(path string) (*maxminddb.Reader, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return maxminddb.FromBytes(content)
+}
+
+// Start watches the database file and reloads it whenever it is modified or replaced.
+// The parent directory is watched instead of the file itself, so atomic replacements
+// (write to a temporary file and rename) and Kubernetes ConfigMap symlink swaps are detected.
+func (g *maxMindProvider) Start(_ context.Context) error {
+	if g.shutdownCH != nil {
+		return errors.New("maxmind provider database watcher is already running")
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err = watcher.Add(filepath.Dir(g.databasePath)); err != nil {
+		_ = watcher.Close()
+		return err
+	}
+
+	g.watcher = watcher
+	g.shutdownCH = make(chan struct{})
+	g.wg.Add(1)
+	go g.startWatcher()
+	return nil
+}
+
+func (g *maxMindProvider) startWatcher() {
+	defer g.wg.Done()
+	target := filepath.Clean(g.databasePath)
+	for {
+		select {
+		case <-g.shutdownCH:
+			return
+		case event, ok := <-g.watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != target {
+				continue
+			}
+			if event.Has(fsnotify.Write) || event.Has(fsnotify.Create) {
+				g.reload()
+			}
+		case err, ok := <-g.watcher.Errors:
+			if !ok {
+				return
+			}
+			g.logger.Error("error watching geoip database", zap.Error(err))
+		}
+	}
+}
+
+// reload opens the database file again and swaps it with the current one. On failure the
+// previously loaded database is kept, so a partially written file never disrupts lookups.
+func (g *maxMindProvider) reload() {
+	reader, err := openDatabase(g.databasePath)
+	if err != nil {
+		g.logger.Warn("could not reload geoip database, keeping the previous one", zap.String("path", g.databasePath), zap.Error(err))
+		return
+	}
+
+	g.mu.Lock()
+	previous := g.reader
+	g.reader = reader
+	g.mu.Unlock()
+
+	if err = previous.Close(); err != nil {
+		g.logger.Warn("could not close previous geoip database", zap.Error(err))
+	}
+	g.logger.Info("geoip database reloaded", zap.String("path", g.databasePath))
+}
+
+// Location implements provider.GeoIPProvider for MaxMind DB files.
+func (g *maxMindProvider) Location(_ context.Context, ipAddress net.IP) (attribute.Set, error) {
+	var record cityRecord
+
+	g.mu.RLock()
+	if g.reader == nil {
+		g.mu.RUnlock()
+		return attribute.Set{}, errProviderClosed
+	}
+	_, ok, err := g.reader.LookupNetwork(ipAddress, &record)
+	g.mu.RUnlock()
+
+	if err != nil {
+		return attribute.Set{}, err
+	}
+	if !ok {
+		return attribute.Set{}, provider.ErrNoMetadataFound
+	}
+
+	attrs := make([]attribute.KeyValue, 0, 7)
+	if record.Continent.Code != "" {
+		attrs = append(attrs, attribute.String(conventions.AttributeGeoContinentCode, record.Continent.Code))
+	}
+	if record.Country.IsoCode != "" {
+		attrs = append(attrs, attribute.String(conventions.AttributeGeoCountryIsoCode, record.Country.IsoCode))
+	}
+	if cityName := record.City.Names[g.language]; cityName != "" {
+		attrs = append(attrs, attribute.String(conventions.AttributeGeoLocalityName, cityName))
+	}
+	if record.Location.Latitude != nil && record.Location.Longitude != nil {
+		attrs = append(attrs,
+			attribute.Float64(conventions.AttributeGeoLocationLat, *record.Location.Latitude),
+			attribute.Float64(conventions.AttributeGeoLocationLon, *record.Location.Longitude),
+		)
+	}
+	if record.Postal.Code != "" {
+		attrs = append(attrs, attribute.String(conventions.AttributeGeoPostalCode, record.Postal.Code))
+	}
+	// The semantic conventions define the region ISO code as ISO 3166-2, e.g. "CA-QC", while the database
+	// only stores the subdivision part.
+	if len(record.Subdivisions) > 0 && record.Subdivisions[0].IsoCode != "" && record.Country.IsoCode != "" {
+		attrs = append(attrs, attribute.String(conventions.AttributeGeoRegionIsoCode, record.Country.IsoCode+"-"+record.Subdivisions[0].IsoCode))
+	}
+
+	if len(attrs) == 0 {
+		return attribute.Set{}, provider.ErrNoMetadataFound
+	}
+
+	return attribute.NewSet(attrs...), nil
+}
+
+// Close stops watching the database file and closes it.
+func (g *maxMindProvider) Close(_ context.Context) error {
+	var errs error
+	if g.shutdownCH != nil {
+		close(g.shutdownCH)
+		g.wg.Wait()
+		g.shutdownCH = nil
+		errs = errors.Join(errs, g.watcher.Close())
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.reader != nil {
+		errs = errors.Join(errs, g.reader.Close())
+		g.reader = nil
+	}
+	return errs
+}
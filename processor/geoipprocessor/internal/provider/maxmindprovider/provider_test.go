@@ -0,0 +1,181 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package maxmind
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider/testdata"
+)
+
+func writeCityDatabase(t *testing.T, path string, records map[string]testdata.Record) {
+	require.NoError(t, testdata.WriteIPv4Database(path, "GeoLite2-City", records))
+}
+
+func TestInvalidNewProvider(t *testing.T) {
+	_, err := newMaxMindProvider(&Config{DatabasePath: filepath.Join(t.TempDir(), "missing.mmdb")}, zap.NewNop())
+	require.ErrorContains(t, err, "could not open geoip database")
+
+	invalidPath := filepath.Join(t.TempDir(), "invalid.mmdb")
+	require.NoError(t, os.WriteFile(invalidPath, []byte("not a database"), 0600))
+	_, err = newMaxMindProvider(&Config{DatabasePath: invalidPath}, zap.NewNop())
+	require.ErrorContains(t, err, "could not open geoip database")
+}
+
+func TestProviderLocation(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "city.mmdb")
+	writeCityDatabase(t, dbPath, map[string]testdata.Record{
+		"1.2.3.0/24":     testdata.CityRecord("EU", "ES", "CT", "Barcelona", "08012", 41.3888, 2.159),
+		"89.160.20.0/28": testdata.CityRecord("EU", "SE", "E", "Linköping", "", 58.4167, 15.6167),
+		"10.0.0.0/8":     {"country": map[string]any{"iso_code": "US"}},
+		"172.16.0.0/12":  {"asn": "private"},
+	})
+
+	tests := []struct {
+		name               string
+		ip                 net.IP
+		expectedAttributes attribute.Set
+		expectedErr        error
+	}{
+		{
+			name: "city record",
+			ip:   net.ParseIP("1.2.3.4"),
+			expectedAttributes: attribute.NewSet(
+				attribute.String(conventions.AttributeGeoContinentCode, "EU"),
+				attribute.String(conventions.AttributeGeoCountryIsoCode, "ES"),
+				attribute.String(conventions.AttributeGeoLocalityName, "Barcelona"),
+				attribute.Float64(conventions.AttributeGeoLocationLat, 41.3888),
+				attribute.Float64(conventions.AttributeGeoLocationLon, 2.159),
+				attribute.String(conventions.AttributeGeoPostalCode, "08012"),
+				attribute.String(conventions.AttributeGeoRegionIsoCode, "ES-CT"),
+			),
+		},
+		{
+			name: "city record without postal code",
+			ip:   net.ParseIP("89.160.20.1"),
+			expectedAttributes: attribute.NewSet(
+				attribute.String(conventions.AttributeGeoContinentCode, "EU"),
+				attribute.String(conventions.AttributeGeoCountryIsoCode, "SE"),
+				attribute.String(conventions.AttributeGeoLocalityName, "Linköping"),
+				attribute.Float64(conventions.AttributeGeoLocationLat, 58.4167),
+				attribute.Float64(conventions.AttributeGeoLocationLon, 15.6167),
+				attribute.String(conventions.AttributeGeoRegionIsoCode, "SE-E"),
+			),
+		},
+		{
+			name: "partial record",
+			ip:   net.ParseIP("10.1.2.3"),
+			expectedAttributes: attribute.NewSet(
+				attribute.String(conventions.AttributeGeoCountryIsoCode, "US"),
+			),
+		},
+		{
+			name:        "record without geo fields",
+			ip:          net.ParseIP("172.16.0.1"),
+			expectedErr: provider.ErrNoMetadataFound,
+		},
+		{
+			name:        "ip not in database",
+			ip:          net.ParseIP("8.8.8.8"),
+			expectedErr: provider.ErrNoMetadataFound,
+		},
+	}
+
+	p, err := newMaxMindProvider(&Config{DatabasePath: dbPath}, zap.NewNop())
+	require.NoError(t, err)
+	defer func() { require.NoError(t, p.Close(context.Background())) }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actualAttributes, err := p.Location(context.Background(), tt.ip)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.expectedAttributes.Equals(&actualAttributes), "expected %v, got %v", tt.expectedAttributes.Encoded(attribute.DefaultEncoder()), actualAttributes.Encoded(attribute.DefaultEncoder()))
+		})
+	}
+}
+
+func TestProviderHotReload(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "city.mmdb")
+	writeCityDatabase(t, dbPath, map[string]testdata.Record{
+		"1.2.3.0/24": testdata.CityRecord("EU", "ES", "CT", "Barcelona", "08012", 41.3888, 2.159),
+	})
+
+	p, err := newMaxMindProvider(&Config{DatabasePath: dbPath}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background()))
+	defer func() { require.NoError(t, p.Close(context.Background())) }()
+
+	cityName := func(ip string) string {
+		attrs, err := p.Location(context.Background(), net.ParseIP(ip))
+		if err != nil {
+			return ""
+		}
+		value, _ := attrs.Value(conventions.AttributeGeoLocalityName)
+		return value.AsString()
+	}
+	require.Equal(t, "Barcelona", cityName("1.2.3.4"))
+
+	// replace the database atomically, the way database updaters do
+	tmpPath := filepath.Join(dir, "city.mmdb.tmp")
+	writeCityDatabase(t, tmpPath, map[string]testdata.Record{
+		"1.2.3.0/24": testdata.CityRecord("EU", "ES", "MD", "Madrid", "28001", 40.4168, -3.7038),
+	})
+	require.NoError(t, os.Rename(tmpPath, dbPath))
+	require.Eventually(t, func() bool {
+		return cityName("1.2.3.4") == "Madrid"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// an invalid database keeps the previous one
+	require.NoError(t, os.WriteFile(dbPath, []byte("corrupted"), 0600))
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, "Madrid", cityName("1.2.3.4"))
+
+	// writing in place is detected as well
+	writeCityDatabase(t, dbPath, map[string]testdata.Record{
+		"1.2.3.0/24": testdata.CityRecord("EU", "ES", "AN", "Sevilla", "41001", 37.3891, -5.9845),
+	})
+	require.Eventually(t, func() bool {
+		return cityName("1.2.3.4") == "Sevilla"
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestProviderLocationAfterClose(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "city.mmdb")
+	writeCityDatabase(t, dbPath, map[string]testdata.Record{})
+
+	p, err := newMaxMindProvider(&Config{DatabasePath: dbPath}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, p.Close(context.Background()))
+
+	_, err = p.Location(context.Background(), net.ParseIP("1.2.3.4"))
+	require.ErrorIs(t, err, errProviderClosed)
+}
+
+func TestProviderStartTwice(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "city.mmdb")
+	writeCityDatabase(t, dbPath, map[string]testdata.Record{})
+
+	p, err := newMaxMindProvider(&Config{DatabasePath: dbPath}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background()))
+	require.Error(t, p.Start(context.Background()))
+	require.NoError(t, p.Close(context.Background()))
+}
@@ -0,0 +1,253 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package testdata generates small MaxMind DB (.mmdb) fixtures for the geoip processor tests.
+package testdata // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider/testdata"
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"net"
+	"os"
+	"sort"
+)
+
+// Data types of the MaxMind DB data section.
+const (
+	typeString = 2
+	typeDouble = 3
+	typeUint16 = 5
+	typeUint32 = 6
+	typeMap    = 7
+	typeUint64 = 9
+	typeArray  = 11
+	typeBool   = 14
+)
+
+const (
+	recordSize    = 24
+	separatorSize = 16
+)
+
+var metadataStartMarker = []byte("\xAB\xCD\xEFMaxMind.com")
+
+// Record is a database entry, encoded as a MaxMind DB map.
+type Record map[string]any
+
+// Uint16 is encoded with the MaxMind DB uint16 data type.
+type Uint16 uint16
+
+// Uint32 is encoded with the MaxMind DB uint32 data type.
+type Uint32 uint32
+
+// Uint64 is encoded with the MaxMind DB uint64 data type.
+type Uint64 uint64
+
+// CityRecord returns a record following the GeoIP2 City database layout.
+func CityRecord(continentCode, countryIsoCode, subdivisionIsoCode, cityName, postalCode string, latitude, longitude float64) Record {
+	return Record{
+		"city":      map[string]any{"names": map[string]any{"en": cityName}},
+		"continent": map[string]any{"code": continentCode},
+		"country":   map[string]any{"iso_code": countryIsoCode},
+		"location": map[string]any{
+			"latitude":  latitude,
+			"longitude": longitude,
+		},
+		"postal":       map[string]any{"code": postalCode},
+		"subdivisions": []any{map[string]any{"iso_code": subdivisionIsoCode}},
+	}
+}
+
+type node struct {
+	children [2]*node
+	// data holds the data section offset of a leaf, it is only meaningful when leaf is true.
+	leaf [2]bool
+	data [2]int
+}
+
+// WriteIPv4Database writes an IPv4-only MaxMind DB file at path containing the given records keyed by CIDR.
+// Networks must not overlap.
+func WriteIPv4Database(path, databaseType string, records map[string]Record) error {
+	networks := make([]string, 0, len(records))
+	for network := range records {
+		networks = append(networks, network)
+	}
+	sort.Strings(networks)
+
+	data := &bytes.Buffer{}
+	root := &node{}
+	for _, network := range networks {
+		_, ipNet, err := net.ParseCIDR(network)
+		if err != nil {
+			return err
+		}
+		ip := ipNet.IP.To4()
+		if ip == nil {
+			return fmt.Errorf("%s is not an IPv4 network", network)
+		}
+		prefixLength, _ := ipNet.Mask.Size()
+		if prefixLength == 0 {
+			return fmt.Errorf("%s: an empty prefix is not supported", network)
+		}
+
+		offset := data.Len()
+		if err = encode(data, map[string]any(records[network])); err != nil {
+			return err
+		}
+
+		current := root
+		for i := 0; i < prefixLength; i++ {
+			bit := (ip[i/8] >> (7 - uint(i%8))) & 1
+			if i == prefixLength-1 {
+				current.leaf[bit] = true
+				current.data[bit] = offset
+				break
+			}
+			if current.children[bit] == nil {
+				current.children[bit] = &node{}
+			}
+			current = current.children[bit]
+		}
+	}
+
+	// number the nodes breadth first, the root being node 0
+	nodes := []*node{root}
+	index := map[*node]int{root: 0}
+	for i := 0; i < len(nodes); i++ {
+		for _, child := range nodes[i].children {
+			if child != nil {
+				index[child] = len(nodes)
+				nodes = append(nodes, child)
+			}
+		}
+	}
+	nodeCount := len(nodes)
+
+	out := &bytes.Buffer{}
+	for _, n := range nodes {
+		for bit := 0; bit < 2; bit++ {
+			var record int
+			switch {
+			case n.leaf[bit]:
+				record = nodeCount + separatorSize + n.data[bit]
+			case n.children[bit] != nil:
+				record = index[n.children[bit]]
+			default:
+				record = nodeCount
+			}
+			out.Write([]byte{byte(record >> 16), byte(record >> 8), byte(record)})
+		}
+	}
+	out.Write(make([]byte, separatorSize))
+	out.Write(data.Bytes())
+	out.Write(metadataStartMarker)
+
+	err := encode(out, map[string]any{
+		"binary_format_major_version": Uint16(2),
+		"binary_format_minor_version": Uint16(0),
+		"build_epoch":                 Uint64(0),
+		"database_type":               databaseType,
+		"description":                 map[string]any{"en": "geoipprocessor test database"},
+		"ip_version":                  Uint16(4),
+		"languages":                   []any{"en"},
+		"node_count":                  Uint32(nodeCount),
+		"record_size":                 Uint16(recordSize),
+	})
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, out.Bytes(), 0600)
+}
+
+func encode(buf *bytes.Buffer, value any) error {
+	switch v := value.(type) {
+	case string:
+		writeControl(buf, typeString, len(v))
+		buf.WriteString(v)
+	case float64:
+		writeControl(buf, typeDouble, 8)
+		_ = binary.Write(buf, binary.BigEndian, math.Float64bits(v))
+	case bool:
+		size := 0
+		if v {
+			size = 1
+		}
+		writeControl(buf, typeBool, size)
+	case Uint16:
+		writeUint(buf, typeUint16, uint64(v))
+	case Uint32:
+		writeUint(buf, typeUint32, uint64(v))
+	case Uint64:
+		writeUint(buf, typeUint64, uint64(v))
+	case Record:
+		return encode(buf, map[string]any(v))
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		writeControl(buf, typeMap, len(v))
+		for _, k := range keys {
+			if err := encode(buf, k); err != nil {
+				return err
+			}
+			if err := encode(buf, v[k]); err != nil {
+				return err
+			}
+		}
+	case []any:
+		writeControl(buf, typeArray, len(v))
+		for _, elem := range v {
+			if err := encode(buf, elem); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported type %T", value)
+	}
+	return nil
+}
+
+func writeUint(buf *bytes.Buffer, typeNum int, value uint64) {
+	var payload []byte
+	for value > 0 {
+		payload = append([]byte{byte(value)}, payload...)
+		value >>= 8
+	}
+	writeControl(buf, typeNum, len(payload))
+	buf.Write(payload)
+}
+
+// writeControl writes the control byte(s) of a data field: the type in the three highest bits
+// (or in an extra byte for extended types) followed by the payload size.
+func writeControl(buf *bytes.Buffer, typeNum, size int) {
+	var sizeBits byte
+	var sizeExtra []byte
+	switch {
+	case size < 29:
+		sizeBits = byte(size)
+	case size < 29+256:
+		sizeBits = 29
+		sizeExtra = []byte{byte(size - 29)}
+	case size < 285+65536:
+		sizeBits = 30
+		s := size - 285
+		sizeExtra = []byte{byte(s >> 8), byte(s)}
+	default:
+		sizeBits = 31
+		s := size - 65821
+		sizeExtra = []byte{byte(s >> 16), byte(s >> 8), byte(s)}
+	}
+
+	if typeNum <= 7 {
+		buf.WriteByte(byte(typeNum<<5) | sizeBits)
+	} else {
+		buf.WriteByte(sizeBits)
+		buf.WriteByte(byte(typeNum - 7))
+	}
+	buf.Write(sizeExtra)
+}
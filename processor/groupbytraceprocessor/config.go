@@ -4,9 +4,16 @@
 package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
 
 import (
+	"errors"
 	"time"
+
+	"go.opentelemetry.io/collector/component"
 )
 
+var errStorageRequired = errors.New("'store_on_disk' requires a 'storage' extension")
+
+var _ component.ConfigValidator = (*Config)(nil)
+
 // Config is the configuration for the processor.
 type Config struct {
 
@@ -25,12 +32,22 @@ type Config struct {
 	// DiscardOrphans instructs the processor to discard traces without the root span.
 	// This typically indicates that the trace is incomplete.
 	// Default: false.
-	// Not yet implemented, and an error will be returned when this option is used.
 	DiscardOrphans bool `mapstructure:"discard_orphans"`
 
-	// StoreOnDisk tells the processor to keep only the trace ID in memory, serializing the trace spans to disk.
+	// StoreOnDisk tells the processor to keep only the trace ID in memory, serializing the trace spans to the storage extension.
 	// Useful when the duration to wait for traces to complete is high.
 	// Default: false.
-	// Not yet implemented, and an error will be returned when this option is used.
 	StoreOnDisk bool `mapstructure:"store_on_disk"`
+
+	// StorageID refers to the storage extension the trace spans are serialized to when
+	// StoreOnDisk is set.
+	StorageID *component.ID `mapstructure:"storage"`
+}
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.StoreOnDisk && cfg.StorageID == nil {
+		return errStorageRequired
+	}
+	return nil
 }
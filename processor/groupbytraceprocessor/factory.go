@@ -5,7 +5,6 @@ package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"go.opencensus.io/stats/view"
@@ -24,11 +23,6 @@ const (
 	defaultStoreOnDisk    = false
 )
 
-var (
-	errDiskStorageNotSupported    = fmt.Errorf("option 'disk storage' not supported in this release")
-	errDiscardOrphansNotSupported = fmt.Errorf("option 'discard orphans' not supported in this release")
-)
-
 // NewFactory returns a new factory for the Filter processor.
 func NewFactory() processor.Factory {
 	// TODO: find a more appropriate way to get this done, as we are swallowing the error here
@@ -47,7 +41,6 @@ func createDefaultConfig() component.Config {
 		NumWorkers:   defaultNumWorkers,
 		WaitDuration: defaultWaitDuration,
 
-		// not supported for now
 		DiscardOrphans: defaultDiscardOrphans,
 		StoreOnDisk:    defaultStoreOnDisk,
 	}
@@ -64,14 +57,13 @@ func createTracesProcessor(
 
 	var st storage
 	if oCfg.StoreOnDisk {
-		return nil, errDiskStorageNotSupported
+		if oCfg.StorageID == nil {
+			return nil, errStorageRequired
+		}
+		st = newDiskStorage(*oCfg.StorageID, params.ID)
+	} else {
+		st = newMemoryStorage()
 	}
-	if oCfg.DiscardOrphans {
-		return nil, errDiscardOrphansNotSupported
-	}
-
-	// the only supported storage for now
-	st = newMemoryStorage()
 
 	return newGroupByTraceProcessor(params.Logger, st, nextConsumer, *oCfg), nil
 }
@@ -8,6 +8,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/processor/processortest"
 )
 
@@ -36,33 +38,42 @@ func TestCreateTestProcessor(t *testing.T) {
 	assert.NotNil(t, p)
 }
 
-func TestCreateTestProcessorWithNotImplementedOptions(t *testing.T) {
+func TestCreateTestProcessorWithStoreOnDisk(t *testing.T) {
 	// prepare
 	f := NewFactory()
 	next := &mockProcessor{}
+	storageID := component.MustNewID("file_storage")
 
 	// test
-	for _, tt := range []struct {
-		config      *Config
-		expectedErr error
-	}{
-		{
-			&Config{
-				DiscardOrphans: true,
-			},
-			errDiscardOrphansNotSupported,
-		},
-		{
-			&Config{
-				StoreOnDisk: true,
-			},
-			errDiskStorageNotSupported,
-		},
-	} {
-		p, err := f.CreateTracesProcessor(context.Background(), processortest.NewNopSettings(), tt.config, next)
+	p, err := f.CreateTracesProcessor(context.Background(), processortest.NewNopSettings(), &Config{
+		NumTraces:      defaultNumTraces,
+		NumWorkers:     defaultNumWorkers,
+		WaitDuration:   defaultWaitDuration,
+		DiscardOrphans: true,
+		StoreOnDisk:    true,
+		StorageID:      &storageID,
+	}, next)
 
-		// verify
-		assert.Error(t, tt.expectedErr, err)
-		assert.Nil(t, p)
+	// verify
+	require.NoError(t, err)
+	gbt, ok := p.(*groupByTraceProcessor)
+	require.True(t, ok)
+	assert.IsType(t, &diskStorage{}, gbt.st)
+}
+
+func TestCreateTestProcessorWithStoreOnDiskWithoutStorage(t *testing.T) {
+	// prepare
+	f := NewFactory()
+	next := &mockProcessor{}
+	cfg := &Config{
+		StoreOnDisk: true,
 	}
+
+	// test
+	p, err := f.CreateTracesProcessor(context.Background(), processortest.NewNopSettings(), cfg, next)
+
+	// verify
+	assert.ErrorIs(t, cfg.Validate(), errStorageRequired)
+	assert.ErrorIs(t, err, errStorageRequired)
+	assert.Nil(t, p)
 }
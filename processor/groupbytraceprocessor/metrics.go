@@ -20,6 +20,7 @@ var (
 	mReleasedSpans      = stats.Int64("spans_released", "Spans released to the next consumer", stats.UnitDimensionless)
 	mReleasedTraces     = stats.Int64("traces_released", "Traces released to the next consumer", stats.UnitDimensionless)
 	mIncompleteReleases = stats.Int64("incomplete_releases", "Releases that are suspected to have been incomplete", stats.UnitDimensionless)
+	mOrphansDiscarded   = stats.Int64("orphans_discarded", "Traces discarded because their root span was not received", stats.UnitDimensionless)
 	mEventLatency       = stats.Int64("event_latency", "How long the queue events are taking to be processed", stats.UnitMilliseconds)
 )
 
@@ -69,6 +70,12 @@ func metricViews() []*view.View {
 			Description: mIncompleteReleases.Description(),
 			Aggregation: view.Sum(),
 		},
+		{
+			Name:        processorhelper.BuildCustomMetricName(metadata.Type.String(), mOrphansDiscarded.Name()),
+			Measure:     mOrphansDiscarded,
+			Description: mOrphansDiscarded.Description(),
+			Aggregation: view.Sum(),
+		},
 		{
 			Name:        processorhelper.BuildCustomMetricName(metadata.Type.String(), mEventLatency.Name()),
 			Measure:     mEventLatency,
@@ -18,6 +18,7 @@ func TestProcessorMetrics(t *testing.T) {
 		"processor_groupbytrace_spans_released",
 		"processor_groupbytrace_traces_released",
 		"processor_groupbytrace_incomplete_releases",
+		"processor_groupbytrace_orphans_discarded",
 		"processor_groupbytrace_event_latency",
 	}
 
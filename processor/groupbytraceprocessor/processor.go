@@ -83,14 +83,18 @@ func (sp *groupByTraceProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (sp *groupByTraceProcessor) Start(context.Context, component.Host) error {
+func (sp *groupByTraceProcessor) Start(_ context.Context, host component.Host) error {
 	// start these metrics, as it might take a while for them to receive their first event
 	stats.Record(context.Background(), mTracesEvicted.M(0))
 	stats.Record(context.Background(), mIncompleteReleases.M(0))
+	stats.Record(context.Background(), mOrphansDiscarded.M(0))
 	stats.Record(context.Background(), mNumTracesConf.M(int64(sp.config.NumTraces)))
 
+	if err := sp.st.start(host); err != nil {
+		return err
+	}
 	sp.eventMachine.startInBackground()
-	return sp.st.start()
+	return nil
 }
 
 // Shutdown is invoked during service shutdown.
@@ -183,6 +187,17 @@ func (sp *groupByTraceProcessor) markAsReleased(traceID pcommon.TraceID, fire fu
 		return fmt.Errorf("the trace %q couldn't be found at the storage", traceID)
 	}
 
+	if sp.config.DiscardOrphans && !hasRootSpan(trace) {
+		sp.logger.Debug("discarding orphan trace", zap.Stringer("traceID", traceID))
+		stats.Record(context.Background(), mOrphansDiscarded.M(1))
+
+		fire(event{
+			typ:     traceRemoved,
+			payload: traceID,
+		})
+		return nil
+	}
+
 	// signal that the trace is ready to be released
 	sp.logger.Debug("trace marked as released", zap.Stringer("traceID", traceID))
 
@@ -235,3 +250,19 @@ func (sp *groupByTraceProcessor) addSpans(traceID pcommon.TraceID, trace ptrace.
 	sp.logger.Debug("creating trace at the storage", zap.Stringer("traceID", traceID))
 	return sp.st.createOrAppend(traceID, trace)
 }
+
+// hasRootSpan returns whether any of the spans of the trace has no parent,
+// otherwise the trace is an orphan whose root span was never received
+func hasRootSpan(rss []ptrace.ResourceSpans) bool {
+	for _, rs := range rss {
+		for i := 0; i < rs.ScopeSpans().Len(); i++ {
+			spans := rs.ScopeSpans().At(i).Spans()
+			for j := 0; j < spans.Len(); j++ {
+				if spans.At(j).ParentSpanID().IsEmpty() {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
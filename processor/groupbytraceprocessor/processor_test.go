@@ -19,6 +19,7 @@ import (
 	"go.opentelemetry.io/collector/processor"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal"
 )
 
@@ -545,6 +546,95 @@ func TestAsyncOnRelease(t *testing.T) {
 	close(blockCh)
 }
 
+func TestDiscardOrphans(t *testing.T) {
+	// prepare
+	config := Config{
+		WaitDuration:   time.Nanosecond,
+		NumTraces:      10,
+		NumWorkers:     1,
+		DiscardOrphans: true,
+	}
+
+	rootTraceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	orphanTraceID := pcommon.TraceID([16]byte{2, 3, 4, 5})
+	orphan := simpleTracesWithID(orphanTraceID)
+	orphan.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetParentSpanID([8]byte{1, 2, 3, 4})
+
+	var receivedTraceIDs []pcommon.TraceID
+	mockProcessor := &mockProcessor{
+		onTraces: func(_ context.Context, received ptrace.Traces) error {
+			receivedTraceIDs = append(receivedTraceIDs, received.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID())
+			return nil
+		},
+	}
+
+	wgDeleted := &sync.WaitGroup{}
+	wgDeleted.Add(2)
+	backing := newMemoryStorage()
+	st := &mockStorage{
+		onCreateOrAppend: backing.createOrAppend,
+		onGet:            backing.get,
+		onDelete: func(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+			defer wgDeleted.Done()
+			return backing.delete(traceID)
+		},
+	}
+
+	p := newGroupByTraceProcessor(zap.NewNop(), st, mockProcessor, config)
+	ctx := context.Background()
+	require.NoError(t, p.Start(ctx, nil))
+
+	// test
+	require.NoError(t, p.ConsumeTraces(ctx, orphan))
+	require.NoError(t, p.ConsumeTraces(ctx, simpleTracesWithID(rootTraceID)))
+
+	// verify
+	wgDeleted.Wait()
+	require.NoError(t, p.Shutdown(ctx))
+	assert.Equal(t, 0, backing.count())
+	assert.Eventually(t, func() bool {
+		mockProcessor.mutex.Lock()
+		defer mockProcessor.mutex.Unlock()
+		return len(receivedTraceIDs) == 1
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, []pcommon.TraceID{rootTraceID}, receivedTraceIDs)
+}
+
+func TestTraceIsDispatchedFromDiskStorage(t *testing.T) {
+	// prepare
+	config := Config{
+		WaitDuration: time.Nanosecond,
+		NumTraces:    10,
+		NumWorkers:   1,
+		StoreOnDisk:  true,
+	}
+	traces := simpleTraces()
+
+	wgReceived := &sync.WaitGroup{}
+	wgReceived.Add(1)
+	mockProcessor := &mockProcessor{
+		onTraces: func(_ context.Context, received ptrace.Traces) error {
+			assert.Equal(t, traces, received)
+			wgReceived.Done()
+			return nil
+		},
+	}
+
+	st := newDiskStorage(storagetest.NewStorageID("test"), component.MustNewID("groupbytrace"))
+	p := newGroupByTraceProcessor(zap.NewNop(), st, mockProcessor, config)
+	ctx := context.Background()
+	require.NoError(t, p.Start(ctx, storagetest.NewStorageHost().WithInMemoryStorageExtension("test")))
+	defer func() {
+		assert.NoError(t, p.Shutdown(ctx))
+	}()
+
+	// test
+	require.NoError(t, p.ConsumeTraces(ctx, traces))
+
+	// verify
+	wgReceived.Wait()
+}
+
 func BenchmarkConsumeTracesCompleteOnFirstBatch(b *testing.B) {
 	// prepare
 	config := Config{
@@ -630,7 +720,7 @@ func (st *mockStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans,
 	}
 	return nil, nil
 }
-func (st *mockStorage) start() error {
+func (st *mockStorage) start(component.Host) error {
 	if st.onStart != nil {
 		return st.onStart()
 	}
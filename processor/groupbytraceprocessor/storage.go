@@ -4,6 +4,7 @@
 package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
 
 import (
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
@@ -24,8 +25,9 @@ type storage interface {
 	// or nil in case a trace cannot be found
 	delete(pcommon.TraceID) ([]ptrace.ResourceSpans, error)
 
-	// start gives the storage the opportunity to initialize any resources or procedures
-	start() error
+	// start gives the storage the opportunity to initialize any resources or procedures,
+	// such as retrieving a client from a storage extension of the host
+	start(component.Host) error
 
 	// shutdown signals the storage that the processor is shutting down
 	shutdown() error
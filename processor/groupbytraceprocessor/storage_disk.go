@@ -0,0 +1,202 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opentelemetry.io/collector/component"
+	extensionstorage "go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
+)
+
+// diskStorage serializes the spans of the traces to a storage extension, keeping only
+// the trace IDs in memory. Each batch of spans received for a trace is stored under its own
+// key, so that appending spans to a trace doesn't require reading the spans stored before.
+type diskStorage struct {
+	sync.Mutex
+	storageID   component.ID
+	componentID component.ID
+	client      extensionstorage.Client
+	// batches is the number of batches stored for each trace
+	batches     map[pcommon.TraceID]int
+	marshaler   ptrace.ProtoMarshaler
+	unmarshaler ptrace.ProtoUnmarshaler
+
+	stopped                   bool
+	stoppedLock               sync.RWMutex
+	metricsCollectionInterval time.Duration
+}
+
+var _ storage = (*diskStorage)(nil)
+
+func newDiskStorage(storageID component.ID, componentID component.ID) *diskStorage {
+	return &diskStorage{
+		storageID:                 storageID,
+		componentID:               componentID,
+		batches:                   make(map[pcommon.TraceID]int),
+		metricsCollectionInterval: time.Second,
+	}
+}
+
+func (st *diskStorage) createOrAppend(traceID pcommon.TraceID, td ptrace.Traces) error {
+	buf, err := st.marshaler.MarshalTraces(td)
+	if err != nil {
+		return fmt.Errorf("failed to marshal trace %q: %w", traceID, err)
+	}
+
+	st.Lock()
+	defer st.Unlock()
+
+	// getting zero value is fine
+	n := st.batches[traceID]
+	if err = st.client.Set(context.Background(), batchKey(traceID, n), buf); err != nil {
+		return err
+	}
+	st.batches[traceID] = n + 1
+
+	return nil
+}
+
+func (st *diskStorage) get(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	st.Lock()
+	defer st.Unlock()
+
+	n, ok := st.batches[traceID]
+	if !ok {
+		return nil, nil
+	}
+	return st.read(traceID, n)
+}
+
+func (st *diskStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	st.Lock()
+	defer st.Unlock()
+
+	n, ok := st.batches[traceID]
+	if !ok {
+		return nil, nil
+	}
+	rss, err := st.read(traceID, n)
+	if err != nil {
+		return nil, err
+	}
+	if err = st.remove(traceID, n); err != nil {
+		return nil, err
+	}
+	delete(st.batches, traceID)
+	return rss, nil
+}
+
+// read returns the resource spans of the n batches stored for the trace
+func (st *diskStorage) read(traceID pcommon.TraceID, n int) ([]ptrace.ResourceSpans, error) {
+	ops := make([]extensionstorage.Operation, n)
+	for i := range ops {
+		ops[i] = extensionstorage.GetOperation(batchKey(traceID, i))
+	}
+	if err := st.client.Batch(context.Background(), ops...); err != nil {
+		return nil, err
+	}
+
+	var result []ptrace.ResourceSpans
+	for _, op := range ops {
+		if op.Value == nil {
+			continue
+		}
+		td, err := st.unmarshaler.UnmarshalTraces(op.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal trace %q: %w", traceID, err)
+		}
+		for i := 0; i < td.ResourceSpans().Len(); i++ {
+			result = append(result, td.ResourceSpans().At(i))
+		}
+	}
+	return result, nil
+}
+
+// remove deletes the n batches stored for the trace
+func (st *diskStorage) remove(traceID pcommon.TraceID, n int) error {
+	ops := make([]extensionstorage.Operation, n)
+	for i := range ops {
+		ops[i] = extensionstorage.DeleteOperation(batchKey(traceID, i))
+	}
+	return st.client.Batch(context.Background(), ops...)
+}
+
+func (st *diskStorage) start(host component.Host) error {
+	if host == nil {
+		return errors.New("disk storage requires a host")
+	}
+	ext, ok := host.GetExtensions()[st.storageID]
+	if !ok {
+		return fmt.Errorf("storage extension '%s' not found", st.storageID)
+	}
+	storageExt, ok := ext.(extensionstorage.Extension)
+	if !ok {
+		return fmt.Errorf("non-storage extension '%s' found", st.storageID)
+	}
+	client, err := storageExt.GetClient(context.Background(), component.KindProcessor, st.componentID, "")
+	if err != nil {
+		return err
+	}
+	st.client = client
+
+	go st.periodicMetrics()
+	return nil
+}
+
+// shutdown deletes the traces which were not released, as their IDs are only
+// kept in memory and their spans couldn't be found on the next start.
+func (st *diskStorage) shutdown() error {
+	st.stoppedLock.Lock()
+	st.stopped = true
+	st.stoppedLock.Unlock()
+
+	st.Lock()
+	defer st.Unlock()
+	if st.client == nil {
+		return nil
+	}
+
+	var errs error
+	for traceID, n := range st.batches {
+		errs = multierr.Append(errs, st.remove(traceID, n))
+	}
+	st.batches = make(map[pcommon.TraceID]int)
+	return multierr.Append(errs, st.client.Close(context.Background()))
+}
+
+func (st *diskStorage) periodicMetrics() {
+	numTraces := st.count()
+	stats.Record(context.Background(), mNumTracesInMemory.M(int64(numTraces)))
+
+	st.stoppedLock.RLock()
+	stopped := st.stopped
+	st.stoppedLock.RUnlock()
+	if stopped {
+		return
+	}
+
+	time.AfterFunc(st.metricsCollectionInterval, func() {
+		st.periodicMetrics()
+	})
+}
+
+func (st *diskStorage) count() int {
+	st.Lock()
+	defer st.Unlock()
+	return len(st.batches)
+}
+
+func batchKey(traceID pcommon.TraceID, n int) string {
+	return traceID.String() + "/" + strconv.Itoa(n)
+}
@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package groupbytraceprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func newStartedDiskStorage(t *testing.T, host component.Host) *diskStorage {
+	st := newDiskStorage(storagetest.NewStorageID("test"), component.MustNewID("groupbytrace"))
+	require.NoError(t, st.start(host))
+	return st
+}
+
+func TestDiskCreateAndGetTrace(t *testing.T) {
+	// prepare
+	st := newStartedDiskStorage(t, storagetest.NewStorageHost().WithInMemoryStorageExtension("test"))
+	defer func() {
+		assert.NoError(t, st.shutdown())
+	}()
+
+	traceIDs := []pcommon.TraceID{
+		pcommon.TraceID([16]byte{1, 2, 3, 4}),
+		pcommon.TraceID([16]byte{2, 3, 4, 5}),
+	}
+
+	// test
+	for _, traceID := range traceIDs {
+		assert.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	}
+
+	// verify
+	assert.Equal(t, 2, st.count())
+	for _, traceID := range traceIDs {
+		retrieved, err := st.get(traceID)
+		require.NoError(t, err)
+		assert.Equal(t, []ptrace.ResourceSpans{simpleTracesWithID(traceID).ResourceSpans().At(0)}, retrieved)
+	}
+
+	retrieved, err := st.get(pcommon.TraceID([16]byte{9, 9, 9, 9}))
+	require.NoError(t, err)
+	assert.Nil(t, retrieved)
+}
+
+func TestDiskAppendSpans(t *testing.T) {
+	// prepare
+	st := newStartedDiskStorage(t, storagetest.NewStorageHost().WithInMemoryStorageExtension("test"))
+	defer func() {
+		assert.NoError(t, st.shutdown())
+	}()
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	first := simpleTracesWithID(traceID)
+	first.ResourceSpans().At(0).Resource().Attributes().PutStr("service.name", "first")
+	second := simpleTracesWithID(traceID)
+	second.ResourceSpans().At(0).Resource().Attributes().PutStr("service.name", "second")
+
+	// test
+	require.NoError(t, st.createOrAppend(traceID, first))
+	require.NoError(t, st.createOrAppend(traceID, second))
+
+	// verify
+	assert.Equal(t, 1, st.count())
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Equal(t, []ptrace.ResourceSpans{first.ResourceSpans().At(0), second.ResourceSpans().At(0)}, retrieved)
+}
+
+func TestDiskDeleteTrace(t *testing.T) {
+	// prepare
+	st := newStartedDiskStorage(t, storagetest.NewStorageHost().WithInMemoryStorageExtension("test"))
+	defer func() {
+		assert.NoError(t, st.shutdown())
+	}()
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	trace := simpleTracesWithID(traceID)
+	require.NoError(t, st.createOrAppend(traceID, trace))
+
+	// test
+	deleted, err := st.delete(traceID)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, []ptrace.ResourceSpans{trace.ResourceSpans().At(0)}, deleted)
+	assert.Equal(t, 0, st.count())
+
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Nil(t, retrieved)
+
+	value, err := st.client.Get(context.Background(), batchKey(traceID, 0))
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestDiskShutdownRemovesTraces(t *testing.T) {
+	// prepare
+	dir := t.TempDir()
+	st := newStartedDiskStorage(t, storagetest.NewStorageHost().WithFileBackedStorageExtension("test", dir))
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+
+	// test
+	require.NoError(t, st.shutdown())
+
+	// verify
+	client := storagetest.NewFileBackedClient(component.KindProcessor, st.componentID, "", dir)
+	value, err := client.Get(context.Background(), batchKey(traceID, 0))
+	require.NoError(t, err)
+	assert.Nil(t, value)
+	require.NoError(t, client.Close(context.Background()))
+}
+
+func TestDiskStartErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		host        component.Host
+		expectedErr string
+	}{
+		{
+			name:        "no host",
+			expectedErr: "disk storage requires a host",
+		},
+		{
+			name:        "extension not found",
+			host:        storagetest.NewStorageHost(),
+			expectedErr: "storage extension 'test_storage/test' not found",
+		},
+		{
+			name:        "non-storage extension",
+			host:        storagetest.NewStorageHost().WithExtension(storagetest.NewStorageID("test"), storagetest.NewNonStorageExtension("test")),
+			expectedErr: "non-storage extension 'test_storage/test' found",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newDiskStorage(storagetest.NewStorageID("test"), component.MustNewID("groupbytrace"))
+			assert.EqualError(t, st.start(tt.host), tt.expectedErr)
+			assert.NoError(t, st.shutdown())
+		})
+	}
+}
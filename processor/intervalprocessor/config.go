@@ -5,13 +5,15 @@ package intervalprocessor // import "github.com/open-telemetry/opentelemetry-col
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
 )
 
 var (
-	ErrInvalidIntervalValue = errors.New("invalid interval value")
+	ErrInvalidIntervalValue    = errors.New("invalid interval value")
+	ErrInvalidGaugeAggregation = errors.New("invalid gauge aggregation")
 )
 
 var _ component.Config = (*Config)(nil)
@@ -20,6 +22,50 @@ var _ component.Config = (*Config)(nil)
 type Config struct {
 	// Interval is the time
 	Interval time.Duration `mapstructure:"interval"`
+	// PassThrough is a configuration that determines whether gauge and summary metrics should be passed through
+	// as they are or aggregated. Both are passed through by default, aggregating them is opt-in.
+	PassThrough PassThrough `mapstructure:"pass_through"`
+	// Gauge is a configuration that determines how gauge datapoints are aggregated within an interval.
+	Gauge GaugeConfig `mapstructure:"gauge"`
+}
+
+type PassThrough struct {
+	// Gauge is a flag that determines whether gauge metrics should be passed through
+	// as they are or aggregated.
+	Gauge bool `mapstructure:"gauge"`
+	// Summary is a flag that determines whether summary metrics should be passed through
+	// as they are or aggregated.
+	Summary bool `mapstructure:"summary"`
+}
+
+type GaugeConfig struct {
+	// Aggregation is used for all gauges that are not listed in Metrics.
+	Aggregation Aggregation `mapstructure:"aggregation"`
+	// Metrics overrides the aggregation of individual gauges, keyed by metric name.
+	Metrics map[string]Aggregation `mapstructure:"metrics"`
+}
+
+// Aggregation determines how the datapoints of a gauge stream are reduced to a single datapoint per interval.
+type Aggregation string
+
+const (
+	// AggregationLast keeps the datapoint with the latest timestamp.
+	AggregationLast Aggregation = "last"
+	// AggregationMin keeps the datapoint with the smallest value.
+	AggregationMin Aggregation = "min"
+	// AggregationMax keeps the datapoint with the largest value.
+	AggregationMax Aggregation = "max"
+	// AggregationMean exports the arithmetic mean of all values as a double.
+	AggregationMean Aggregation = "mean"
+)
+
+func (a Aggregation) validate() error {
+	switch a {
+	case AggregationLast, AggregationMin, AggregationMax, AggregationMean:
+		return nil
+	}
+	return fmt.Errorf("%w %q, must be one of %q, %q, %q or %q", ErrInvalidGaugeAggregation, a,
+		AggregationLast, AggregationMin, AggregationMax, AggregationMean)
 }
 
 // Validate checks whether the input configuration has all of the required fields for the processor.
@@ -29,5 +75,22 @@ func (config *Config) Validate() error {
 		return ErrInvalidIntervalValue
 	}
 
+	if err := config.Gauge.Aggregation.validate(); err != nil {
+		return err
+	}
+	for name, aggr := range config.Gauge.Metrics {
+		if err := aggr.validate(); err != nil {
+			return fmt.Errorf("gauge %q: %w", name, err)
+		}
+	}
+
 	return nil
 }
+
+// aggregation returns the aggregation configured for the gauge with the given name.
+func (gc GaugeConfig) aggregation(name string) Aggregation {
+	if aggr, ok := gc.Metrics[name]; ok {
+		return aggr
+	}
+	return gc.Aggregation
+}
@@ -26,6 +26,13 @@ func NewFactory() processor.Factory {
 func createDefaultConfig() component.Config {
 	return &Config{
 		Interval: 60 * time.Second,
+		PassThrough: PassThrough{
+			Gauge:   true,
+			Summary: true,
+		},
+		Gauge: GaugeConfig{
+			Aggregation: AggregationLast,
+		},
 	}
 }
 
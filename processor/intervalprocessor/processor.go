@@ -36,8 +36,15 @@ type Processor struct {
 	numberLookup       map[identity.Stream]pmetric.NumberDataPoint
 	histogramLookup    map[identity.Stream]pmetric.HistogramDataPoint
 	expHistogramLookup map[identity.Stream]pmetric.ExponentialHistogramDataPoint
+	summaryLookup      map[identity.Stream]pmetric.SummaryDataPoint
+	gaugeLookup        map[identity.Stream]pmetric.NumberDataPoint
+	// gaugeMeans holds the running sum and count of gauge streams using AggregationMean.
+	// The mean is only computed on export.
+	gaugeMeans map[identity.Stream]*mean
 
 	exportInterval time.Duration
+	passThrough    PassThrough
+	gauge          GaugeConfig
 
 	nextConsumer consumer.Metrics
 }
@@ -59,8 +66,13 @@ func newProcessor(config *Config, log *zap.Logger, nextConsumer consumer.Metrics
 		numberLookup:       map[identity.Stream]pmetric.NumberDataPoint{},
 		histogramLookup:    map[identity.Stream]pmetric.HistogramDataPoint{},
 		expHistogramLookup: map[identity.Stream]pmetric.ExponentialHistogramDataPoint{},
+		summaryLookup:      map[identity.Stream]pmetric.SummaryDataPoint{},
+		gaugeLookup:        map[identity.Stream]pmetric.NumberDataPoint{},
+		gaugeMeans:         map[identity.Stream]*mean{},
 
 		exportInterval: config.Interval,
+		passThrough:    config.PassThrough,
+		gauge:          config.Gauge,
 
 		nextConsumer: nextConsumer,
 	}
@@ -102,8 +114,26 @@ func (p *Processor) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) erro
 		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
 			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
 				switch m.Type() {
-				case pmetric.MetricTypeGauge, pmetric.MetricTypeSummary:
-					return false
+				case pmetric.MetricTypeGauge:
+					if p.passThrough.Gauge {
+						return false
+					}
+
+					mClone, metricID := p.getOrCloneMetric(rm, sm, m)
+					cloneGauge := mClone.Gauge()
+
+					p.aggregateGauge(m.Gauge().DataPoints(), cloneGauge.DataPoints(), metricID, p.gauge.aggregation(m.Name()))
+					return true
+				case pmetric.MetricTypeSummary:
+					if p.passThrough.Summary {
+						return false
+					}
+
+					mClone, metricID := p.getOrCloneMetric(rm, sm, m)
+					cloneSummary := mClone.Summary()
+
+					aggregateDataPoints(m.Summary().DataPoints(), cloneSummary.DataPoints(), metricID, p.summaryLookup)
+					return true
 				case pmetric.MetricTypeSum:
 					// Check if we care about this value
 					sum := m.Sum()
@@ -185,11 +215,70 @@ func aggregateDataPoints[DPS metrics.DataPointSlice[DP], DP metrics.DataPoint[DP
 	}
 }
 
+type mean struct {
+	sum   float64
+	count int
+}
+
+// aggregateGauge reduces the datapoints of each gauge stream to a single datapoint using aggr.
+// The timestamp of the resulting datapoint is always the latest one seen in the interval.
+func (p *Processor) aggregateGauge(dataPoints pmetric.NumberDataPointSlice, mCloneDataPoints pmetric.NumberDataPointSlice, metricID identity.Metric, aggr Aggregation) {
+	for i := 0; i < dataPoints.Len(); i++ {
+		dp := dataPoints.At(i)
+
+		streamID := identity.OfStream(metricID, dp)
+		existingDP, ok := p.gaugeLookup[streamID]
+		if !ok {
+			dpClone := mCloneDataPoints.AppendEmpty()
+			dp.CopyTo(dpClone)
+			p.gaugeLookup[streamID] = dpClone
+			if aggr == AggregationMean {
+				p.gaugeMeans[streamID] = &mean{sum: numberValue(dp), count: 1}
+			}
+			continue
+		}
+
+		latest := max(dp.Timestamp(), existingDP.Timestamp())
+
+		switch aggr {
+		case AggregationLast:
+			if dp.Timestamp() > existingDP.Timestamp() {
+				dp.CopyTo(existingDP)
+			}
+		case AggregationMin:
+			if numberValue(dp) < numberValue(existingDP) {
+				dp.CopyTo(existingDP)
+			}
+		case AggregationMax:
+			if numberValue(dp) > numberValue(existingDP) {
+				dp.CopyTo(existingDP)
+			}
+		case AggregationMean:
+			m := p.gaugeMeans[streamID]
+			m.sum += numberValue(dp)
+			m.count++
+		}
+
+		existingDP.SetTimestamp(latest)
+	}
+}
+
+func numberValue(dp pmetric.NumberDataPoint) float64 {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return float64(dp.IntValue())
+	}
+	return dp.DoubleValue()
+}
+
 func (p *Processor) exportMetrics() {
 	md := func() pmetric.Metrics {
 		p.stateLock.Lock()
 		defer p.stateLock.Unlock()
 
+		for streamID, m := range p.gaugeMeans {
+			p.gaugeLookup[streamID].SetDoubleValue(m.sum / float64(m.count))
+		}
+
 		// ConsumeMetrics() has prepared our own pmetric.Metrics instance ready for us to use
 		// Take it and clear replace it with a new empty one
 		out := p.md
@@ -202,6 +291,9 @@ func (p *Processor) exportMetrics() {
 		clear(p.numberLookup)
 		clear(p.histogramLookup)
 		clear(p.expHistogramLookup)
+		clear(p.summaryLookup)
+		clear(p.gaugeLookup)
+		clear(p.gaugeMeans)
 
 		return out
 	}()
@@ -21,22 +21,44 @@ import (
 func TestAggregation(t *testing.T) {
 	t.Parallel()
 
-	testCases := []string{
-		"basic_aggregation",
-		"non_monotonic_sums_are_passed_through",
-		"summaries_are_passed_through",
-		"histograms_are_aggregated",
-		"exp_histograms_are_aggregated",
-		"all_delta_metrics_are_passed_through",
+	testCases := []struct {
+		name        string
+		passThrough PassThrough
+		gauge       GaugeConfig
+	}{
+		{name: "basic_aggregation"},
+		{name: "non_monotonic_sums_are_passed_through"},
+		{name: "summaries_are_aggregated"},
+		{name: "summaries_are_passed_through", passThrough: PassThrough{Summary: true}},
+		{name: "gauges_are_aggregated"},
+		{name: "gauges_are_passed_through", passThrough: PassThrough{Gauge: true}},
+		{
+			name: "gauge_aggregations",
+			gauge: GaugeConfig{
+				Aggregation: AggregationLast,
+				Metrics: map[string]Aggregation{
+					"test.gauge.min":      AggregationMin,
+					"test.gauge.max":      AggregationMax,
+					"test.gauge.mean":     AggregationMean,
+					"test.gauge.mean.int": AggregationMean,
+				},
+			},
+		},
+		{name: "histograms_are_aggregated"},
+		{name: "exp_histograms_are_aggregated"},
+		{name: "all_delta_metrics_are_passed_through"},
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	config := &Config{Interval: time.Second}
-
 	for _, tc := range testCases {
-		testName := tc
+		testName := tc.name
+
+		config := &Config{Interval: time.Second, PassThrough: tc.passThrough, Gauge: tc.gauge}
+		if config.Gauge.Aggregation == "" {
+			config.Gauge.Aggregation = AggregationLast
+		}
 
 		t.Run(testName, func(t *testing.T) {
 			t.Parallel()
@@ -75,6 +97,9 @@ func TestAggregation(t *testing.T) {
 			require.Empty(t, processor.numberLookup)
 			require.Empty(t, processor.histogramLookup)
 			require.Empty(t, processor.expHistogramLookup)
+			require.Empty(t, processor.summaryLookup)
+			require.Empty(t, processor.gaugeLookup)
+			require.Empty(t, processor.gaugeMeans)
 
 			// Exporting again should return nothing
 			processor.exportMetrics()
@@ -102,3 +127,52 @@ func TestAggregation(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultConfigPassesThrough(t *testing.T) {
+	t.Parallel()
+
+	config := createDefaultConfig().(*Config)
+	require.True(t, config.PassThrough.Gauge, "gauges should only be aggregated when enabled")
+	require.True(t, config.PassThrough.Summary, "summaries should only be aggregated when enabled")
+}
+
+func TestValidateConfig(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		config *Config
+		err    error
+	}{
+		{
+			name:   "default",
+			config: createDefaultConfig().(*Config),
+		},
+		{
+			name:   "invalid interval",
+			config: &Config{Interval: 0, Gauge: GaugeConfig{Aggregation: AggregationLast}},
+			err:    ErrInvalidIntervalValue,
+		},
+		{
+			name:   "invalid aggregation",
+			config: &Config{Interval: time.Second, Gauge: GaugeConfig{Aggregation: "median"}},
+			err:    ErrInvalidGaugeAggregation,
+		},
+		{
+			name: "invalid metric aggregation",
+			config: &Config{Interval: time.Second, Gauge: GaugeConfig{
+				Aggregation: AggregationLast,
+				Metrics:     map[string]Aggregation{"system.cpu.utilization": "sum"},
+			}},
+			err: ErrInvalidGaugeAggregation,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			require.ErrorIs(t, tc.config.Validate(), tc.err)
+		})
+	}
+}
@@ -5,46 +5,48 @@ package remotetapprocessor // import "github.com/open-telemetry/opentelemetry-co
 
 import "sync"
 
-// channelSet is a collection of byte channels where adding, removing, and writing to
-// the channels is synchronized.
+// channelSet is a collection of sessions where adding, removing, and writing to
+// the channels of the sessions is synchronized.
 type channelSet struct {
 	i       int
 	mu      sync.RWMutex
-	chanmap map[int]chan []byte
+	chanmap map[int]*session
 }
 
 func newChannelSet() *channelSet {
 	return &channelSet{
-		chanmap: map[int]chan []byte{},
+		chanmap: map[int]*session{},
 	}
 }
 
-// add adds the channel to the channelSet and returns a key (just an int) used to
-// remove the channel later.
-func (c *channelSet) add(ch chan []byte) int {
+// add adds the session to the channelSet and returns a key (just an int) used to
+// remove the session later.
+func (c *channelSet) add(s *session) int {
 	c.mu.Lock()
 	idx := c.i
-	c.chanmap[idx] = ch
+	c.chanmap[idx] = s
 	c.i++
 	c.mu.Unlock()
 	return idx
 }
 
-// writeBytes writes the passed in bytes to all of the channels in the
-// channelSet.
-func (c *channelSet) writeBytes(bytes []byte) {
+// forEach calls write for each of the sessions in the channelSet. The bytes
+// returned by write, if any, are written to the channel of the session.
+func (c *channelSet) forEach(write func(s *session) []byte) {
 	c.mu.RLock()
-	for _, ch := range c.chanmap {
-		ch <- bytes
+	for _, s := range c.chanmap {
+		if bytes := write(s); bytes != nil {
+			s.ch <- bytes
+		}
 	}
 	c.mu.RUnlock()
 }
 
-// closeAndRemove closes then removes the channel associated with the passed in
-// key. Panics if an invalid key is passed in.
+// closeAndRemove closes then removes the channel of the session associated with
+// the passed in key. Panics if an invalid key is passed in.
 func (c *channelSet) closeAndRemove(key int) {
 	c.mu.Lock()
-	close(c.chanmap[key])
+	close(c.chanmap[key].ch)
 	delete(c.chanmap, key)
 	c.mu.Unlock()
 }
@@ -62,8 +64,8 @@ func (c *channelSet) shutdown() {
 		i++
 	}
 
-	for key := range keys {
-		close(c.chanmap[key])
+	for _, key := range keys {
+		close(c.chanmap[key].ch)
 		delete(c.chanmap, key)
 	}
 }
@@ -8,14 +8,17 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
 )
 
 func TestChannelset(t *testing.T) {
 	cs := newChannelSet()
 	ch := make(chan []byte)
-	key := cs.add(ch)
+	key := cs.add(newSession(ch, 1, zap.NewNop()))
 	go func() {
-		cs.writeBytes([]byte("hello"))
+		cs.forEach(func(*session) []byte {
+			return []byte("hello")
+		})
 	}()
 	assert.Eventually(t, func() bool {
 		return assert.Equal(t, []byte("hello"), <-ch)
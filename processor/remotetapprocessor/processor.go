@@ -19,7 +19,6 @@ import (
 	"go.opentelemetry.io/collector/processor"
 	"go.uber.org/zap"
 	"golang.org/x/net/websocket"
-	"golang.org/x/time/rate"
 )
 
 type wsprocessor struct {
@@ -28,7 +27,11 @@ type wsprocessor struct {
 	server            *http.Server
 	shutdownWG        sync.WaitGroup
 	cs                *channelSet
-	limiter           *rate.Limiter
+}
+
+// sessionError is the message sent to a websocket client before closing its rejected session
+type sessionError struct {
+	Error string `json:"error"`
 }
 
 var logMarshaler = &plog.JSONMarshaler{}
@@ -40,7 +43,6 @@ func newProcessor(settings processor.Settings, config *Config) *wsprocessor {
 		config:            config,
 		telemetrySettings: settings.TelemetrySettings,
 		cs:                newChannelSet(),
-		limiter:           rate.NewLimiter(config.Limit, int(config.Limit)),
 	}
 }
 
@@ -71,9 +73,17 @@ func (w *wsprocessor) handleConn(conn *websocket.Conn) {
 		w.telemetrySettings.Logger.Debug("Error setting deadline", zap.Error(err))
 		return
 	}
-	ch := make(chan []byte)
-	idx := w.cs.add(ch)
-	for bytes := range ch {
+	sess, err := newSessionFromQuery(conn.Request().URL.Query(), make(chan []byte), w.config.Limit, w.telemetrySettings)
+	if err != nil {
+		w.telemetrySettings.Logger.Debug("Rejecting remote tap session", zap.Error(err))
+		// let the client know why the connection is closed
+		if errSend := websocket.JSON.Send(conn, sessionError{Error: err.Error()}); errSend != nil {
+			w.telemetrySettings.Logger.Debug("websocket write error", zap.Error(errSend))
+		}
+		return
+	}
+	idx := w.cs.add(sess)
+	for bytes := range sess.ch {
 		_, err := conn.Write(bytes)
 		if err != nil {
 			w.telemetrySettings.Logger.Debug("websocket write error: %w", zap.Error(err))
@@ -100,41 +110,77 @@ func (w *wsprocessor) Shutdown(ctx context.Context) error {
 	return err
 }
 
-func (w *wsprocessor) ConsumeMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
-	if w.limiter.Allow() {
-		b, err := metricMarshaler.MarshalMetrics(md)
-		if err != nil {
-			w.telemetrySettings.Logger.Debug("Error serializing to JSON", zap.Error(err))
-		} else {
-			w.cs.writeBytes(b)
+func (w *wsprocessor) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	var all []byte
+	w.cs.forEach(func(s *session) []byte {
+		if !s.ready() {
+			return nil
 		}
-	}
+		if s.unfiltered() {
+			if all == nil {
+				all = w.marshal(metricMarshaler.MarshalMetrics(md))
+			}
+			return s.allowed(all)
+		}
+		filtered, ok := s.filterMetrics(ctx, md)
+		if !ok {
+			return nil
+		}
+		return s.allowed(w.marshal(metricMarshaler.MarshalMetrics(filtered)))
+	})
 
 	return md, nil
 }
 
-func (w *wsprocessor) ConsumeLogs(_ context.Context, ld plog.Logs) (plog.Logs, error) {
-	if w.limiter.Allow() {
-		b, err := logMarshaler.MarshalLogs(ld)
-		if err != nil {
-			w.telemetrySettings.Logger.Debug("Error serializing to JSON", zap.Error(err))
-		} else {
-			w.cs.writeBytes(b)
+func (w *wsprocessor) ConsumeLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	var all []byte
+	w.cs.forEach(func(s *session) []byte {
+		if !s.ready() {
+			return nil
 		}
-	}
+		if s.unfiltered() {
+			if all == nil {
+				all = w.marshal(logMarshaler.MarshalLogs(ld))
+			}
+			return s.allowed(all)
+		}
+		filtered, ok := s.filterLogs(ctx, ld)
+		if !ok {
+			return nil
+		}
+		return s.allowed(w.marshal(logMarshaler.MarshalLogs(filtered)))
+	})
 
 	return ld, nil
 }
 
-func (w *wsprocessor) ConsumeTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
-	if w.limiter.Allow() {
-		b, err := traceMarshaler.MarshalTraces(td)
-		if err != nil {
-			w.telemetrySettings.Logger.Debug("Error serializing to JSON", zap.Error(err))
-		} else {
-			w.cs.writeBytes(b)
+func (w *wsprocessor) ConsumeTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	var all []byte
+	w.cs.forEach(func(s *session) []byte {
+		if !s.ready() {
+			return nil
 		}
-	}
+		if s.unfiltered() {
+			if all == nil {
+				all = w.marshal(traceMarshaler.MarshalTraces(td))
+			}
+			return s.allowed(all)
+		}
+		filtered, ok := s.filterTraces(ctx, td)
+		if !ok {
+			return nil
+		}
+		return s.allowed(w.marshal(traceMarshaler.MarshalTraces(filtered)))
+	})
 
 	return td, nil
 }
+
+// marshal returns the serialized data, nil if it failed to be serialized
+func (w *wsprocessor) marshal(b []byte, err error) []byte {
+	if err != nil {
+		w.telemetrySettings.Logger.Debug("Error serializing to JSON", zap.Error(err))
+		return nil
+	}
+	return b
+}
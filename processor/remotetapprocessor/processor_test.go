@@ -13,6 +13,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor/processortest"
+	"go.uber.org/zap"
 	"golang.org/x/time/rate"
 )
 
@@ -39,7 +40,7 @@ func TestConsumeMetrics(t *testing.T) {
 			processor := newProcessor(processortest.NewNopSettings(), conf)
 
 			ch := make(chan []byte)
-			idx := processor.cs.add(ch)
+			idx := processor.cs.add(newSession(ch, conf.Limit, zap.NewNop()))
 			receiveNum := 0
 			wg := &sync.WaitGroup{}
 			wg.Add(1)
@@ -88,7 +89,7 @@ func TestConsumeLogs(t *testing.T) {
 			processor := newProcessor(processortest.NewNopSettings(), conf)
 
 			ch := make(chan []byte)
-			idx := processor.cs.add(ch)
+			idx := processor.cs.add(newSession(ch, conf.Limit, zap.NewNop()))
 			receiveNum := 0
 			wg := &sync.WaitGroup{}
 			wg.Add(1)
@@ -137,7 +138,7 @@ func TestConsumeTraces(t *testing.T) {
 			processor := newProcessor(processortest.NewNopSettings(), conf)
 
 			ch := make(chan []byte)
-			idx := processor.cs.add(ch)
+			idx := processor.cs.add(newSession(ch, conf.Limit, zap.NewNop()))
 			receiveNum := 0
 			wg := &sync.WaitGroup{}
 			wg.Add(1)
@@ -6,6 +6,7 @@ package remotetapprocessor
 import (
 	"context"
 	"net"
+	"net/url"
 	"testing"
 	"time"
 
@@ -129,3 +130,71 @@ func TestSocketConnectionTraces(t *testing.T) {
 	err = rawConn.Close()
 	require.NoError(t, err)
 }
+
+func TestSocketConnectionFiltered(t *testing.T) {
+	cfg := &Config{
+		ServerConfig: confighttp.ServerConfig{
+			Endpoint: "localhost:12004",
+		},
+		Limit: 1,
+	}
+	logSink := &consumertest.LogsSink{}
+	processor, err := NewFactory().CreateLogsProcessor(context.Background(), processortest.NewNopSettings(), cfg,
+		logSink)
+	require.NoError(t, err)
+	err = processor.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	rawConn, err := net.Dial("tcp", "localhost:12004")
+	require.NoError(t, err)
+	query := url.Values{filterParam: {`body == "bar"`}}
+	wsConfig, err := websocket.NewConfig("http://localhost:12004/?"+query.Encode(), "http://localhost:12004")
+	require.NoError(t, err)
+	wsConn, err := websocket.NewClient(wsConfig, rawConn)
+	require.NoError(t, err)
+	log := plog.NewLogs()
+	records := log.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	records.AppendEmpty().Body().SetStr("foo")
+	records.AppendEmpty().Body().SetStr("bar")
+	buf := make([]byte, 1024)
+	require.Eventuallyf(t, func() bool {
+		err = processor.ConsumeLogs(context.Background(), log)
+		require.NoError(t, err)
+		n, _ := wsConn.Read(buf)
+		return n == 132
+	}, 1*time.Second, 100*time.Millisecond, "received message")
+	require.Equal(t, `{"resourceLogs":[{"resource":{},"scopeLogs":[{"scope":{},"logRecords":[{"body":{"stringValue":"bar"},"traceId":"","spanId":""}]}]}]}`, string(buf[0:132]))
+
+	err = processor.Shutdown(context.Background())
+	require.NoError(t, err)
+	err = rawConn.Close()
+	require.NoError(t, err)
+}
+
+func TestSocketConnectionRejected(t *testing.T) {
+	cfg := &Config{
+		ServerConfig: confighttp.ServerConfig{
+			Endpoint: "localhost:12005",
+		},
+		Limit: 1,
+	}
+	processor, err := NewFactory().CreateLogsProcessor(context.Background(), processortest.NewNopSettings(), cfg,
+		&consumertest.LogsSink{})
+	require.NoError(t, err)
+	err = processor.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	rawConn, err := net.Dial("tcp", "localhost:12005")
+	require.NoError(t, err)
+	query := url.Values{samplingRateParam: {"2"}}
+	wsConfig, err := websocket.NewConfig("http://localhost:12005/?"+query.Encode(), "http://localhost:12005")
+	require.NoError(t, err)
+	wsConn, err := websocket.NewClient(wsConfig, rawConn)
+	require.NoError(t, err)
+	var msg sessionError
+	require.NoError(t, websocket.JSON.Receive(wsConn, &msg))
+	require.Equal(t, `invalid sampling_rate "2": must be between 0 and 1`, msg.Error)
+
+	err = processor.Shutdown(context.Background())
+	require.NoError(t, err)
+	err = rawConn.Close()
+	require.NoError(t, err)
+}
@@ -0,0 +1,228 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package remotetapprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/remotetapprocessor"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/url"
+	"strconv"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+const (
+	// filterParam is the query parameter holding the OTTL condition of a session
+	filterParam = "filter"
+	// samplingRateParam is the query parameter holding the fraction of the matching records sent to a session
+	samplingRateParam = "sampling_rate"
+)
+
+// session is a websocket client of the processor. Only the records matching its filter, sampled at its
+// sampling rate, are serialized and sent to it, at most limit messages per second.
+type session struct {
+	ch           chan []byte
+	limiter      *rate.Limiter
+	logger       *zap.Logger
+	samplingRate float64
+
+	// filtered is true when the session was opened with a filter. The conditions of the signals the
+	// filter doesn't parse for are nil, and the session receives no data of these signals.
+	filtered      bool
+	spanCond      *ottl.Condition[ottlspan.TransformContext]
+	logCond       *ottl.Condition[ottllog.TransformContext]
+	dataPointCond *ottl.Condition[ottldatapoint.TransformContext]
+}
+
+func newSession(ch chan []byte, limit rate.Limit, logger *zap.Logger) *session {
+	return &session{
+		ch:           ch,
+		limiter:      rate.NewLimiter(limit, int(limit)),
+		logger:       logger,
+		samplingRate: 1,
+	}
+}
+
+// newSessionFromQuery creates a session from the filter and sampling rate passed in the query of the
+// websocket URL.
+func newSessionFromQuery(query url.Values, ch chan []byte, limit rate.Limit, set component.TelemetrySettings) (*session, error) {
+	s := newSession(ch, limit, set.Logger)
+	if raw := query.Get(samplingRateParam); raw != "" {
+		samplingRate, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", samplingRateParam, raw, err)
+		}
+		if samplingRate < 0 || samplingRate > 1 {
+			return nil, fmt.Errorf("invalid %s %q: must be between 0 and 1", samplingRateParam, raw)
+		}
+		s.samplingRate = samplingRate
+	}
+
+	filter := query.Get(filterParam)
+	if filter == "" {
+		return s, nil
+	}
+	s.filtered = true
+
+	var errs error
+	spanParser, err := ottlspan.NewParser(ottlfuncs.StandardConverters[ottlspan.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+	if s.spanCond, err = spanParser.ParseCondition(filter); err != nil {
+		errs = errors.Join(errs, err)
+	}
+	logParser, err := ottllog.NewParser(ottlfuncs.StandardConverters[ottllog.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+	if s.logCond, err = logParser.ParseCondition(filter); err != nil {
+		errs = errors.Join(errs, err)
+	}
+	dataPointParser, err := ottldatapoint.NewParser(ottlfuncs.StandardConverters[ottldatapoint.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+	if s.dataPointCond, err = dataPointParser.ParseCondition(filter); err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	if s.spanCond == nil && s.logCond == nil && s.dataPointCond == nil {
+		return nil, fmt.Errorf("invalid %s %q: %w", filterParam, filter, errs)
+	}
+	return s, nil
+}
+
+// unfiltered returns true if the session receives all the data it is sent
+func (s *session) unfiltered() bool {
+	return !s.filtered && s.samplingRate >= 1
+}
+
+// ready returns true if the rate limit of the session allows sending a message. The token is only
+// taken by allowed, once there is something to send.
+func (s *session) ready() bool {
+	return s.limiter.Tokens() >= 1
+}
+
+// allowed returns b if the rate limit of the session allows sending it, nil otherwise
+func (s *session) allowed(b []byte) []byte {
+	if b == nil || !s.limiter.Allow() {
+		return nil
+	}
+	return b
+}
+
+func (s *session) sample() bool {
+	return s.samplingRate >= 1 || rand.Float64() < s.samplingRate // nolint:gosec
+}
+
+// filterTraces returns a copy of td holding only the spans matching the filter of the session, false if no
+// span is left
+func (s *session) filterTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, bool) {
+	if s.filtered && s.spanCond == nil {
+		return td, false
+	}
+	out := ptrace.NewTraces()
+	td.CopyTo(out)
+	out.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+			ss.Spans().RemoveIf(func(span ptrace.Span) bool {
+				return !keepRecord(ctx, s, s.spanCond, ottlspan.NewTransformContext(span, ss.Scope(), rs.Resource()))
+			})
+			return ss.Spans().Len() == 0
+		})
+		return rs.ScopeSpans().Len() == 0
+	})
+	return out, out.ResourceSpans().Len() > 0
+}
+
+// filterLogs returns a copy of ld holding only the log records matching the filter of the session, false
+// if no log record is left
+func (s *session) filterLogs(ctx context.Context, ld plog.Logs) (plog.Logs, bool) {
+	if s.filtered && s.logCond == nil {
+		return ld, false
+	}
+	out := plog.NewLogs()
+	ld.CopyTo(out)
+	out.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+		rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+			sl.LogRecords().RemoveIf(func(lr plog.LogRecord) bool {
+				return !keepRecord(ctx, s, s.logCond, ottllog.NewTransformContext(lr, sl.Scope(), rl.Resource()))
+			})
+			return sl.LogRecords().Len() == 0
+		})
+		return rl.ScopeLogs().Len() == 0
+	})
+	return out, out.ResourceLogs().Len() > 0
+}
+
+// filterMetrics returns a copy of md holding only the data points matching the filter of the session,
+// false if no data point is left
+func (s *session) filterMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, bool) {
+	if s.filtered && s.dataPointCond == nil {
+		return md, false
+	}
+	out := pmetric.NewMetrics()
+	md.CopyTo(out)
+	out.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			metrics := sm.Metrics()
+			metrics.RemoveIf(func(m pmetric.Metric) bool {
+				keep := func(dp any) bool {
+					return keepRecord(ctx, s, s.dataPointCond, ottldatapoint.NewTransformContext(dp, m, metrics, sm.Scope(), rm.Resource()))
+				}
+				switch m.Type() {
+				case pmetric.MetricTypeGauge:
+					m.Gauge().DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool { return !keep(dp) })
+					return m.Gauge().DataPoints().Len() == 0
+				case pmetric.MetricTypeSum:
+					m.Sum().DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool { return !keep(dp) })
+					return m.Sum().DataPoints().Len() == 0
+				case pmetric.MetricTypeHistogram:
+					m.Histogram().DataPoints().RemoveIf(func(dp pmetric.HistogramDataPoint) bool { return !keep(dp) })
+					return m.Histogram().DataPoints().Len() == 0
+				case pmetric.MetricTypeExponentialHistogram:
+					m.ExponentialHistogram().DataPoints().RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool { return !keep(dp) })
+					return m.ExponentialHistogram().DataPoints().Len() == 0
+				case pmetric.MetricTypeSummary:
+					m.Summary().DataPoints().RemoveIf(func(dp pmetric.SummaryDataPoint) bool { return !keep(dp) })
+					return m.Summary().DataPoints().Len() == 0
+				}
+				return true
+			})
+			return metrics.Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	return out, out.ResourceMetrics().Len() > 0
+}
+
+// keepRecord evaluates the condition of the session against a record, and samples the matching records. Records
+// the condition fails to evaluate on are dropped.
+func keepRecord[K any](ctx context.Context, s *session, cond *ottl.Condition[K], tCtx K) bool {
+	if cond != nil {
+		match, err := cond.Eval(ctx, tCtx)
+		if err != nil {
+			s.logger.Debug("Error evaluating the filter of a remote tap session", zap.Error(err))
+			return false
+		}
+		if !match {
+			return false
+		}
+	}
+	return s.sample()
+}
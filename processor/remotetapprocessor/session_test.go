@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package remotetapprocessor
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func newTestSession(t *testing.T, query url.Values) *session {
+	s, err := newSessionFromQuery(query, make(chan []byte), 1, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	return s
+}
+
+func TestNewSessionFromQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   url.Values
+		wantErr string
+	}{
+		{
+			name:  "no_parameters",
+			query: url.Values{},
+		},
+		{
+			name:  "filter_and_sampling_rate",
+			query: url.Values{filterParam: {`resource.attributes["service.name"] == "checkout"`}, samplingRateParam: {"0.5"}},
+		},
+		{
+			name:    "invalid_sampling_rate",
+			query:   url.Values{samplingRateParam: {"half"}},
+			wantErr: `invalid sampling_rate "half"`,
+		},
+		{
+			name:    "sampling_rate_out_of_range",
+			query:   url.Values{samplingRateParam: {"1.5"}},
+			wantErr: `invalid sampling_rate "1.5": must be between 0 and 1`,
+		},
+		{
+			name:    "invalid_filter",
+			query:   url.Values{filterParam: {`attributes["foo"] ==`}},
+			wantErr: `invalid filter`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newSessionFromQuery(tt.query, make(chan []byte), 1, componenttest.NewNopTelemetrySettings())
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSessionUnfiltered(t *testing.T) {
+	assert.True(t, newTestSession(t, url.Values{}).unfiltered())
+	assert.False(t, newTestSession(t, url.Values{samplingRateParam: {"0.1"}}).unfiltered())
+	assert.False(t, newTestSession(t, url.Values{filterParam: {`name == "foo"`}}).unfiltered())
+}
+
+func TestSessionFilterTraces(t *testing.T) {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "checkout")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	spans.AppendEmpty().SetName("foo")
+	spans.AppendEmpty().SetName("bar")
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("foo")
+
+	s := newTestSession(t, url.Values{filterParam: {`resource.attributes["service.name"] == "checkout" and name == "foo"`}})
+	filtered, ok := s.filterTraces(context.Background(), td)
+	require.True(t, ok)
+	require.Equal(t, 1, filtered.SpanCount())
+	assert.Equal(t, "foo", filtered.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+	// the data passed down the pipeline is left untouched
+	assert.Equal(t, 3, td.SpanCount())
+
+	s = newTestSession(t, url.Values{filterParam: {`name == "baz"`}})
+	_, ok = s.filterTraces(context.Background(), td)
+	assert.False(t, ok)
+}
+
+func TestSessionFilterLogs(t *testing.T) {
+	ld := plog.NewLogs()
+	records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	records.AppendEmpty().SetSeverityText("ERROR")
+	records.AppendEmpty().SetSeverityText("INFO")
+
+	s := newTestSession(t, url.Values{filterParam: {`severity_text == "ERROR"`}})
+	filtered, ok := s.filterLogs(context.Background(), ld)
+	require.True(t, ok)
+	require.Equal(t, 1, filtered.LogRecordCount())
+	assert.Equal(t, "ERROR", filtered.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).SeverityText())
+
+	// span fields don't exist in the log context, the session doesn't receive logs
+	s = newTestSession(t, url.Values{filterParam: {`kind == SPAN_KIND_SERVER`}})
+	_, ok = s.filterLogs(context.Background(), ld)
+	assert.False(t, ok)
+}
+
+func TestSessionFilterMetrics(t *testing.T) {
+	md := pmetric.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	gauge := metrics.AppendEmpty()
+	gauge.SetName("foo")
+	gauge.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+	gauge.Gauge().DataPoints().AppendEmpty().SetIntValue(2)
+	sum := metrics.AppendEmpty()
+	sum.SetName("bar")
+	sum.SetEmptySum().DataPoints().AppendEmpty().SetIntValue(2)
+
+	s := newTestSession(t, url.Values{filterParam: {`metric.name == "foo" and value_int == 2`}})
+	filtered, ok := s.filterMetrics(context.Background(), md)
+	require.True(t, ok)
+	require.Equal(t, 1, filtered.DataPointCount())
+	m := filtered.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "foo", m.Name())
+	assert.Equal(t, int64(2), m.Gauge().DataPoints().At(0).IntValue())
+}
+
+func TestSessionSampling(t *testing.T) {
+	ld := plog.NewLogs()
+	records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for i := 0; i < 10; i++ {
+		records.AppendEmpty().Body().SetStr("foo")
+	}
+
+	s := newTestSession(t, url.Values{samplingRateParam: {"0"}})
+	_, ok := s.filterLogs(context.Background(), ld)
+	assert.False(t, ok)
+
+	s = newTestSession(t, url.Values{samplingRateParam: {"1"}})
+	filtered, ok := s.filterLogs(context.Background(), ld)
+	require.True(t, ok)
+	assert.Equal(t, 10, filtered.LogRecordCount())
+}
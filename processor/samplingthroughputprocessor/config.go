@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package samplingthroughputprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/samplingthroughputprocessor"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var errNoExtension = errors.New("the jaegerremotesampling extension must be set")
+
+// Config defines configuration for the sampling throughput processor.
+type Config struct {
+	// Extension is the ID of the jaegerremotesampling extension the throughput is recorded to.
+	Extension component.ID `mapstructure:"extension"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Extension == (component.ID{}) {
+		return errNoExtension
+	}
+	return nil
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{}
+}
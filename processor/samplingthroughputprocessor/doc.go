@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package samplingthroughputprocessor records the throughput of the traces passing through it to a
+// jaegerremotesampling extension, whose adaptive source computes the sampling probabilities from it.
+package samplingthroughputprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/samplingthroughputprocessor"
@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package samplingthroughputprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/samplingthroughputprocessor"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling"
+)
+
+type throughputProcessor struct {
+	extensionID component.ID
+	recorder    jaegerremotesampling.ThroughputRecorder
+}
+
+func newThroughputProcessor(cfg *Config) *throughputProcessor {
+	return &throughputProcessor{
+		extensionID: cfg.Extension,
+	}
+}
+
+// start looks the jaegerremotesampling extension up in the host extensions
+func (p *throughputProcessor) start(_ context.Context, host component.Host) error {
+	ext, found := host.GetExtensions()[p.extensionID]
+	if !found {
+		return fmt.Errorf("specified jaegerremotesampling extension with id %q could not be found", p.extensionID)
+	}
+	recorder, ok := ext.(jaegerremotesampling.ThroughputRecorder)
+	if !ok {
+		return fmt.Errorf("specified extension with id %q is not a jaegerremotesampling extension", p.extensionID)
+	}
+	p.recorder = recorder
+	return nil
+}
+
+func (p *throughputProcessor) processTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	jaegerremotesampling.RecordTraces(p.recorder, td)
+	return td, nil
+}
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package samplingthroughputprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+type throughputKey struct {
+	service   string
+	operation string
+}
+
+type recordingExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	throughput map[throughputKey]int64
+}
+
+func (e *recordingExtension) RecordThroughput(service, operation string, count int64) {
+	e.throughput[throughputKey{service: service, operation: operation}] += count
+}
+
+type nopExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+type extensionsHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func newTraces() ptrace.Traces {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "frontend")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	spans.AppendEmpty().SetName("GET /")
+	spans.AppendEmpty().SetName("GET /")
+	child := spans.AppendEmpty()
+	child.SetName("query")
+	child.SetParentSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	return td
+}
+
+func TestProcessorRecordsThroughput(t *testing.T) {
+	extID := component.MustNewID("jaegerremotesampling")
+	ext := &recordingExtension{throughput: map[throughputKey]int64{}}
+	host := &extensionsHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{extID: ext},
+	}
+
+	sink := &consumertest.TracesSink{}
+	p, err := NewFactory().CreateTracesProcessor(context.Background(), processortest.NewNopSettings(), &Config{Extension: extID}, sink)
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	td := newTraces()
+	require.NoError(t, p.ConsumeTraces(context.Background(), td))
+
+	// only the root spans are counted, and the traces are passed through
+	assert.Equal(t, map[throughputKey]int64{{service: "frontend", operation: "GET /"}: 2}, ext.throughput)
+	require.Len(t, sink.AllTraces(), 1)
+	assert.Equal(t, td, sink.AllTraces()[0])
+}
+
+func TestProcessorStartErrors(t *testing.T) {
+	extID := component.MustNewID("jaegerremotesampling")
+
+	tests := []struct {
+		name       string
+		extensions map[component.ID]component.Component
+	}{
+		{
+			name: "missing extension",
+		},
+		{
+			name: "not a jaegerremotesampling extension",
+			extensions: map[component.ID]component.Component{
+				extID: &nopExtension{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host := &extensionsHost{Host: componenttest.NewNopHost(), extensions: tt.extensions}
+			p, err := NewFactory().CreateTracesProcessor(context.Background(), processortest.NewNopSettings(), &Config{Extension: extID}, consumertest.NewNop())
+			require.NoError(t, err)
+			assert.Error(t, p.Start(context.Background(), host))
+		})
+	}
+}
@@ -4,8 +4,11 @@
 package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
 
 import (
+	"errors"
 	"time"
 
+	"go.opentelemetry.io/collector/component"
+
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
@@ -234,4 +237,45 @@ type Config struct {
 	// PolicyCfgs sets the tail-based sampling policy which makes a sampling decision
 	// for a given trace when requested.
 	PolicyCfgs []PolicyCfg `mapstructure:"policies"`
+	// DecisionCache holds the configuration for the caches of the sampling decisions,
+	// which are used to apply the decision of a trace to the spans arriving after it was released.
+	DecisionCache DecisionCacheConfig `mapstructure:"decision_cache"`
+}
+
+// DecisionCacheConfig holds the configurable settings of the caches of the sampling decisions.
+type DecisionCacheConfig struct {
+	// SampledCacheSize specifies the number of the IDs of the sampled traces to keep.
+	// When the cache is full, the least recently used ID is evicted.
+	// For effective use, this value should be at least an order of magnitude higher than NumTraces.
+	// If left as default 0, sampled decisions are not cached.
+	SampledCacheSize int `mapstructure:"sampled_cache_size"`
+	// NonSampledCacheSize specifies the number of the IDs of the traces which were not sampled to keep.
+	// When the cache is full, the least recently used ID is evicted.
+	// If left as default 0, not sampled decisions are not cached.
+	NonSampledCacheSize int `mapstructure:"non_sampled_cache_size"`
+	// StorageID refers to a storage extension the caches are persisted to on shutdown
+	// and restored from on start, so decisions survive restarts. If unset, the caches are only kept in memory.
+	StorageID *component.ID `mapstructure:"storage"`
+	// PersistInterval specifies how often the caches are persisted to the storage while decisions are made,
+	// so that they survive a crash too. If 0, the caches are only persisted on shutdown.
+	PersistInterval time.Duration `mapstructure:"persist_interval"`
+}
+
+var _ component.ConfigValidator = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.DecisionCache.SampledCacheSize < 0 {
+		return errors.New("decision_cache.sampled_cache_size must not be negative")
+	}
+	if cfg.DecisionCache.NonSampledCacheSize < 0 {
+		return errors.New("decision_cache.non_sampled_cache_size must not be negative")
+	}
+	if cfg.DecisionCache.StorageID != nil && cfg.DecisionCache.SampledCacheSize == 0 && cfg.DecisionCache.NonSampledCacheSize == 0 {
+		return errors.New("decision_cache.storage requires a decision cache size")
+	}
+	if cfg.DecisionCache.PersistInterval < 0 {
+		return errors.New("decision_cache.persist_interval must not be negative")
+	}
+	return nil
 }
@@ -30,12 +30,19 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, sub.Unmarshal(cfg))
 
+	fileStorageID := component.MustNewID("file_storage")
 	assert.Equal(t,
 		cfg,
 		&Config{
 			DecisionWait:            10 * time.Second,
 			NumTraces:               100,
 			ExpectedNewTracesPerSec: 10,
+			DecisionCache: DecisionCacheConfig{
+				SampledCacheSize:    1000,
+				NonSampledCacheSize: 500,
+				StorageID:           &fileStorageID,
+				PersistInterval:     5 * time.Second,
+			},
 			PolicyCfgs: []PolicyCfg{
 				{
 					sharedPolicyCfg: sharedPolicyCfg{
@@ -186,3 +193,65 @@ func TestLoadConfig(t *testing.T) {
 			},
 		})
 }
+
+func TestValidateConfig(t *testing.T) {
+	storageID := component.MustNewID("file_storage")
+	tests := []struct {
+		name        string
+		cache       DecisionCacheConfig
+		expectedErr string
+	}{
+		{
+			name: "no cache",
+		},
+		{
+			name: "cache with storage",
+			cache: DecisionCacheConfig{
+				SampledCacheSize: 1000,
+				StorageID:        &storageID,
+			},
+		},
+		{
+			name: "negative sampled cache size",
+			cache: DecisionCacheConfig{
+				SampledCacheSize: -1,
+			},
+			expectedErr: "decision_cache.sampled_cache_size must not be negative",
+		},
+		{
+			name: "negative non sampled cache size",
+			cache: DecisionCacheConfig{
+				NonSampledCacheSize: -1,
+			},
+			expectedErr: "decision_cache.non_sampled_cache_size must not be negative",
+		},
+		{
+			name: "storage without cache",
+			cache: DecisionCacheConfig{
+				StorageID: &storageID,
+			},
+			expectedErr: "decision_cache.storage requires a decision cache size",
+		},
+		{
+			name: "negative persist interval",
+			cache: DecisionCacheConfig{
+				SampledCacheSize: 1000,
+				StorageID:        &storageID,
+				PersistInterval:  -time.Second,
+			},
+			expectedErr: "decision_cache.persist_interval must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.DecisionCache = tt.cache
+			err := cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
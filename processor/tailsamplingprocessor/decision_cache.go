@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
+)
+
+const (
+	sampledCacheKey    = "sampled_trace_ids"
+	nonSampledCacheKey = "non_sampled_trace_ids"
+)
+
+// newDecisionCache returns an LRU cache of the given size, or a cache which never holds
+// any ID when the size is zero.
+func newDecisionCache(size int) (cache.Cache[bool], error) {
+	if size <= 0 {
+		return cache.NewNopDecisionCache[bool](), nil
+	}
+	return cache.NewLRUDecisionCache[bool](size)
+}
+
+func getStorageClient(ctx context.Context, host component.Host, storageID component.ID, componentID component.ID) (storage.Client, error) {
+	ext, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExt.GetClient(ctx, component.KindProcessor, componentID, "")
+}
+
+// restoreDecisionCaches loads the trace IDs of the decision caches persisted to storage
+func (tsp *tailSamplingSpanProcessor) restoreDecisionCaches(ctx context.Context) error {
+	sampled, err := tsp.storageClient.Get(ctx, sampledCacheKey)
+	if err != nil {
+		return err
+	}
+	nonSampled, err := tsp.storageClient.Get(ctx, nonSampledCacheKey)
+	if err != nil {
+		return err
+	}
+	return errors.Join(
+		decodeDecisionCache(tsp.sampledIDCache, sampled),
+		decodeDecisionCache(tsp.nonSampledIDCache, nonSampled),
+	)
+}
+
+// persistDecisionCaches writes the trace IDs of the decision caches to storage
+func (tsp *tailSamplingSpanProcessor) persistDecisionCaches(ctx context.Context) error {
+	return tsp.storageClient.Batch(ctx,
+		storage.SetOperation(sampledCacheKey, encodeDecisionCache(tsp.sampledIDCache)),
+		storage.SetOperation(nonSampledCacheKey, encodeDecisionCache(tsp.nonSampledIDCache)),
+	)
+}
+
+// persistDecisionCachesOnTick persists the decision caches if decisions were cached since they were last persisted
+func (tsp *tailSamplingSpanProcessor) persistDecisionCachesOnTick() {
+	if !tsp.decisionCachesDirty.Swap(false) {
+		return
+	}
+
+	tsp.persistMu.Lock()
+	defer tsp.persistMu.Unlock()
+	if tsp.storageClient == nil {
+		return
+	}
+	if err := tsp.persistDecisionCaches(tsp.ctx); err != nil {
+		tsp.decisionCachesDirty.Store(true)
+		tsp.logger.Warn("failed to persist the decision caches", zap.Error(err))
+	}
+}
+
+// encodeDecisionCache concatenates the trace IDs of the cache, from the least to the most recently used
+func encodeDecisionCache(c cache.Cache[bool]) []byte {
+	keys := c.Keys()
+	buf := make([]byte, 0, len(keys)*len(pcommon.TraceID{}))
+	for _, id := range keys {
+		buf = append(buf, id[:]...)
+	}
+	return buf
+}
+
+// decodeDecisionCache puts the trace IDs encoded by encodeDecisionCache into the cache,
+// preserving their recency
+func decodeDecisionCache(c cache.Cache[bool], buf []byte) error {
+	size := len(pcommon.TraceID{})
+	if len(buf)%size != 0 {
+		return fmt.Errorf("invalid decision cache of %d bytes", len(buf))
+	}
+	for i := 0; i < len(buf); i += size {
+		c.Put(pcommon.TraceID(buf[i:i+size]), true)
+	}
+	return nil
+}
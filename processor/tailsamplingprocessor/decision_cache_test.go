@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
+)
+
+func TestDecisionCachePersisted(t *testing.T) {
+	// prepare
+	storageID := storagetest.NewStorageID("test")
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	cfg := Config{
+		DecisionWait: defaultTestDecisionWait,
+		NumTraces:    defaultNumTraces,
+		PolicyCfgs: []PolicyCfg{
+			{
+				sharedPolicyCfg: sharedPolicyCfg{
+					Name: "always",
+					Type: AlwaysSample,
+				},
+			},
+		},
+		DecisionCache: DecisionCacheConfig{
+			SampledCacheSize: 100,
+			StorageID:        &storageID,
+		},
+	}
+	traceID := uInt64ToTraceID(1)
+	// the same component ID must be used across restarts to get the same storage client
+	set := processortest.NewNopSettings()
+
+	newProcessor := func(nextConsumer *consumertest.TracesSink) *tailSamplingSpanProcessor {
+		p, err := newTracesProcessor(context.Background(), set, nextConsumer, cfg, withDecisionBatcher(newSyncIDBatcher()))
+		require.NoError(t, err)
+		require.NoError(t, p.Start(context.Background(), host))
+		return p.(*tailSamplingSpanProcessor)
+	}
+
+	first := new(consumertest.TracesSink)
+	tsp := newProcessor(first)
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+	require.Equal(t, 1, first.SpanCount())
+	require.NoError(t, tsp.Shutdown(context.Background()))
+
+	// test
+	second := new(consumertest.TracesSink)
+	tsp = newProcessor(second)
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+
+	// verify
+	assert.Equal(t, 1, second.SpanCount(), "the late span should be released without waiting for a decision")
+}
+
+func TestDecisionCachePersistedPeriodically(t *testing.T) {
+	// prepare
+	storageID := storagetest.NewStorageID("test")
+	host := storagetest.NewStorageHost().WithInMemoryStorageExtension("test")
+	cfg := Config{
+		DecisionWait: defaultTestDecisionWait,
+		NumTraces:    defaultNumTraces,
+		PolicyCfgs: []PolicyCfg{
+			{
+				sharedPolicyCfg: sharedPolicyCfg{
+					Name: "always",
+					Type: AlwaysSample,
+				},
+			},
+		},
+		DecisionCache: DecisionCacheConfig{
+			SampledCacheSize: 100,
+			StorageID:        &storageID,
+			// the ticks are triggered by the test
+			PersistInterval: time.Hour,
+		},
+	}
+	traceID := uInt64ToTraceID(1)
+	p, err := newTracesProcessor(context.Background(), processortest.NewNopSettings(), consumertest.NewNop(), cfg, withDecisionBatcher(newSyncIDBatcher()))
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background(), host))
+	tsp := p.(*tailSamplingSpanProcessor)
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	// test
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+	tsp.persistTicker.OnTick()
+	stored, err := tsp.storageClient.Get(context.Background(), sampledCacheKey)
+	require.NoError(t, err)
+	assert.Nil(t, stored, "the caches shouldn't be persisted before a decision is made")
+
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+	tsp.persistTicker.OnTick()
+
+	// verify
+	stored, err = tsp.storageClient.Get(context.Background(), sampledCacheKey)
+	require.NoError(t, err)
+	assert.Equal(t, traceID[:], stored, "the caches should be persisted once a decision is made, before shutdown")
+	assert.False(t, tsp.decisionCachesDirty.Load())
+}
+
+func TestDecisionCacheStorageNotFound(t *testing.T) {
+	storageID := storagetest.NewStorageID("test")
+	cfg := Config{
+		DecisionWait: defaultTestDecisionWait,
+		NumTraces:    defaultNumTraces,
+		DecisionCache: DecisionCacheConfig{
+			SampledCacheSize: 100,
+			StorageID:        &storageID,
+		},
+	}
+	p, err := newTracesProcessor(context.Background(), processortest.NewNopSettings(), consumertest.NewNop(), cfg, withDecisionBatcher(newSyncIDBatcher()))
+	require.NoError(t, err)
+
+	err = p.Start(context.Background(), storagetest.NewStorageHost())
+	assert.EqualError(t, err, "storage extension 'test_storage/test' not found")
+	require.NoError(t, p.Shutdown(context.Background()))
+}
+
+func TestEncodeDecodeDecisionCache(t *testing.T) {
+	c, err := cache.NewLRUDecisionCache[bool](10)
+	require.NoError(t, err)
+	ids := []pcommon.TraceID{uInt64ToTraceID(1), uInt64ToTraceID(2), uInt64ToTraceID(3)}
+	for _, id := range ids {
+		c.Put(id, true)
+	}
+
+	restored, err := cache.NewLRUDecisionCache[bool](10)
+	require.NoError(t, err)
+	require.NoError(t, decodeDecisionCache(restored, encodeDecisionCache(c)))
+	assert.Equal(t, ids, restored.Keys())
+
+	assert.EqualError(t, decodeDecisionCache(restored, []byte{1, 2, 3}), "invalid decision cache of 3 bytes")
+}
+
+func TestNewDecisionCache(t *testing.T) {
+	c, err := newDecisionCache(0)
+	require.NoError(t, err)
+	c.Put(uInt64ToTraceID(1), true)
+	_, ok := c.Get(uInt64ToTraceID(1))
+	assert.False(t, ok)
+
+	c, err = newDecisionCache(1)
+	require.NoError(t, err)
+	c.Put(uInt64ToTraceID(1), true)
+	_, ok = c.Get(uInt64ToTraceID(1))
+	assert.True(t, ok)
+}
@@ -28,6 +28,9 @@ func createDefaultConfig() component.Config {
 	return &Config{
 		DecisionWait: 30 * time.Second,
 		NumTraces:    50000,
+		DecisionCache: DecisionCacheConfig{
+			PersistInterval: 10 * time.Second,
+		},
 	}
 }
 
@@ -38,5 +41,5 @@ func createTracesProcessor(
 	nextConsumer consumer.Traces,
 ) (processor.Traces, error) {
 	tCfg := cfg.(*Config)
-	return newTracesProcessor(ctx, params.TelemetrySettings, nextConsumer, *tCfg)
+	return newTracesProcessor(ctx, params, nextConsumer, *tCfg)
 }
@@ -16,6 +16,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/processor/processortest"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
 )
 
@@ -43,6 +44,8 @@ func TestCreateProcessor(t *testing.T) {
 
 	// this will cause the processor to properly initialize, so that we can later shutdown and
 	// have all the go routines cleanly shut down
-	assert.NoError(t, tp.Start(context.Background(), componenttest.NewNopHost()))
+	// the config persists the decision caches to the file_storage extension
+	host := storagetest.NewStorageHost().WithExtension(component.MustNewID("file_storage"), storagetest.NewInMemoryStorageExtension("file_storage"))
+	assert.NoError(t, tp.Start(context.Background(), host))
 	assert.NoError(t, tp.Shutdown(context.Background()))
 }
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestLRUSinglePutGet(t *testing.T) {
+	c, err := NewLRUDecisionCache[int](2)
+	require.NoError(t, err)
+
+	id := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	c.Put(id, 123)
+	v, ok := c.Get(id)
+	assert.True(t, ok)
+	assert.Equal(t, 123, v)
+
+	_, ok = c.Get(pcommon.TraceID([16]byte{9, 9, 9, 9}))
+	assert.False(t, ok)
+}
+
+func TestLRUOverflow(t *testing.T) {
+	c, err := NewLRUDecisionCache[bool](2)
+	require.NoError(t, err)
+
+	ids := []pcommon.TraceID{
+		{1, 2, 3, 4},
+		{2, 3, 4, 5},
+		{3, 4, 5, 6},
+	}
+	for _, id := range ids {
+		c.Put(id, true)
+	}
+
+	// the least recently used id has been evicted
+	_, ok := c.Get(ids[0])
+	assert.False(t, ok)
+	assert.Equal(t, ids[1:], c.Keys())
+}
+
+func TestLRUKeysOrder(t *testing.T) {
+	c, err := NewLRUDecisionCache[bool](3)
+	require.NoError(t, err)
+
+	ids := []pcommon.TraceID{
+		{1, 2, 3, 4},
+		{2, 3, 4, 5},
+		{3, 4, 5, 6},
+	}
+	for _, id := range ids {
+		c.Put(id, true)
+	}
+	_, ok := c.Get(ids[0])
+	assert.True(t, ok)
+
+	assert.Equal(t, []pcommon.TraceID{ids[1], ids[2], ids[0]}, c.Keys())
+}
+
+func TestLRUDelete(t *testing.T) {
+	c, err := NewLRUDecisionCache[bool](2)
+	require.NoError(t, err)
+
+	id := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	c.Put(id, true)
+	c.Delete(id)
+	_, ok := c.Get(id)
+	assert.False(t, ok)
+	assert.Empty(t, c.Keys())
+}
+
+func TestLRUInvalidSize(t *testing.T) {
+	_, err := NewLRUDecisionCache[bool](0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cache // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
+
+import "go.opentelemetry.io/collector/pdata/pcommon"
+
+// nopDecisionCache is a Cache which never holds any id, used when a decision cache is disabled.
+type nopDecisionCache[V any] struct{}
+
+var _ Cache[any] = (*nopDecisionCache[any])(nil)
+
+// NewNopDecisionCache returns a Cache which never holds any id.
+func NewNopDecisionCache[V any]() Cache[V] {
+	return &nopDecisionCache[V]{}
+}
+
+func (n *nopDecisionCache[V]) Get(pcommon.TraceID) (V, bool) {
+	var v V
+	return v, false
+}
+
+func (n *nopDecisionCache[V]) Put(pcommon.TraceID, V) {
+}
+
+func (n *nopDecisionCache[V]) Delete(pcommon.TraceID) {
+}
+
+func (n *nopDecisionCache[V]) Keys() []pcommon.TraceID {
+	return nil
+}
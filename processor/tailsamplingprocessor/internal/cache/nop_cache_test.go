@@ -0,0 +1,22 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestNopCache(t *testing.T) {
+	c := NewNopDecisionCache[bool]()
+	id := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	c.Put(id, true)
+	v, ok := c.Get(id)
+	assert.False(t, v)
+	assert.False(t, ok)
+	c.Delete(id)
+	assert.Empty(t, c.Keys())
+}
@@ -24,19 +24,20 @@ func Tracer(settings component.TelemetrySettings) trace.Tracer {
 // TelemetryBuilder provides an interface for components to report telemetry
 // as defined in metadata and user config.
 type TelemetryBuilder struct {
-	meter                                              metric.Meter
-	ProcessorTailSamplingCountSpansSampled             metric.Int64Counter
-	ProcessorTailSamplingCountTracesSampled            metric.Int64Counter
-	ProcessorTailSamplingGlobalCountTracesSampled      metric.Int64Counter
-	ProcessorTailSamplingNewTraceIDReceived            metric.Int64Counter
-	ProcessorTailSamplingSamplingDecisionLatency       metric.Int64Histogram
-	ProcessorTailSamplingSamplingDecisionTimerLatency  metric.Int64Histogram
-	ProcessorTailSamplingSamplingLateSpanAge           metric.Int64Histogram
-	ProcessorTailSamplingSamplingPolicyEvaluationError metric.Int64Counter
-	ProcessorTailSamplingSamplingTraceDroppedTooEarly  metric.Int64Counter
-	ProcessorTailSamplingSamplingTraceRemovalAge       metric.Int64Histogram
-	ProcessorTailSamplingSamplingTracesOnMemory        metric.Int64Gauge
-	level                                              configtelemetry.Level
+	meter                                               metric.Meter
+	ProcessorTailSamplingCountSpansSampled              metric.Int64Counter
+	ProcessorTailSamplingCountTracesSampled             metric.Int64Counter
+	ProcessorTailSamplingEarlyReleasesFromCacheDecision metric.Int64Counter
+	ProcessorTailSamplingGlobalCountTracesSampled       metric.Int64Counter
+	ProcessorTailSamplingNewTraceIDReceived             metric.Int64Counter
+	ProcessorTailSamplingSamplingDecisionLatency        metric.Int64Histogram
+	ProcessorTailSamplingSamplingDecisionTimerLatency   metric.Int64Histogram
+	ProcessorTailSamplingSamplingLateSpanAge            metric.Int64Histogram
+	ProcessorTailSamplingSamplingPolicyEvaluationError  metric.Int64Counter
+	ProcessorTailSamplingSamplingTraceDroppedTooEarly   metric.Int64Counter
+	ProcessorTailSamplingSamplingTraceRemovalAge        metric.Int64Histogram
+	ProcessorTailSamplingSamplingTracesOnMemory         metric.Int64Gauge
+	level                                               configtelemetry.Level
 }
 
 // telemetryBuilderOption applies changes to default builder.
@@ -74,6 +75,12 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...teleme
 		metric.WithUnit("{traces}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ProcessorTailSamplingEarlyReleasesFromCacheDecision, err = builder.meter.Int64Counter(
+		"processor_tail_sampling_early_releases_from_cache_decision",
+		metric.WithDescription("Number of spans that were immediately released or dropped due to a decision cache hit"),
+		metric.WithUnit("{spans}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ProcessorTailSamplingGlobalCountTracesSampled, err = builder.meter.Int64Counter(
 		"processor_tail_sampling_global_count_traces_sampled",
 		metric.WithDescription("Global count of traces that were sampled or not by at least one policy"),
@@ -5,6 +5,7 @@ package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"runtime"
@@ -14,6 +15,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
@@ -22,6 +24,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/timeutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/idbatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
@@ -56,6 +59,21 @@ type tailSamplingSpanProcessor struct {
 	decisionBatcher idbatcher.Batcher
 	deleteChan      chan pcommon.TraceID
 	numTracesOnMap  *atomic.Uint64
+
+	// the caches of the trace IDs which were sampled or not, used to apply
+	// the decision of a trace to the spans arriving after it was released
+	sampledIDCache    cache.Cache[bool]
+	nonSampledIDCache cache.Cache[bool]
+	componentID       component.ID
+	storageID         *component.ID
+	// storageClient is only set if the decision caches are persisted
+	storageClient storage.Client
+	// persistMu protects storageClient from being closed while the caches are persisted
+	persistMu       sync.Mutex
+	persistTicker   timeutils.TTicker
+	persistInterval time.Duration
+	// decisionCachesDirty is set when decisions were cached since the caches were last persisted
+	decisionCachesDirty atomic.Bool
 }
 
 // spanAndScope a structure for holding information about span and its instrumentation scope.
@@ -81,22 +99,27 @@ type Option func(*tailSamplingSpanProcessor)
 
 // newTracesProcessor returns a processor.TracesProcessor that will perform tail sampling according to the given
 // configuration.
-func newTracesProcessor(ctx context.Context, settings component.TelemetrySettings, nextConsumer consumer.Traces, cfg Config, opts ...Option) (processor.Traces, error) {
+func newTracesProcessor(ctx context.Context, set processor.Settings, nextConsumer consumer.Traces, cfg Config, opts ...Option) (processor.Traces, error) {
+	settings := set.TelemetrySettings
 	telemetry, err := metadata.NewTelemetryBuilder(settings)
 	if err != nil {
 		return nil, err
 	}
 
 	tsp := &tailSamplingSpanProcessor{
-		ctx:            ctx,
-		telemetry:      telemetry,
-		nextConsumer:   nextConsumer,
-		maxNumTraces:   cfg.NumTraces,
-		logger:         settings.Logger,
-		numTracesOnMap: &atomic.Uint64{},
-		deleteChan:     make(chan pcommon.TraceID, cfg.NumTraces),
+		ctx:             ctx,
+		telemetry:       telemetry,
+		nextConsumer:    nextConsumer,
+		maxNumTraces:    cfg.NumTraces,
+		logger:          settings.Logger,
+		numTracesOnMap:  &atomic.Uint64{},
+		deleteChan:      make(chan pcommon.TraceID, cfg.NumTraces),
+		componentID:     set.ID,
+		storageID:       cfg.DecisionCache.StorageID,
+		persistInterval: cfg.DecisionCache.PersistInterval,
 	}
 	tsp.policyTicker = &timeutils.PolicyTicker{OnTickFunc: tsp.samplingPolicyOnTick}
+	tsp.persistTicker = &timeutils.PolicyTicker{OnTickFunc: tsp.persistDecisionCachesOnTick}
 
 	for _, opt := range opts {
 		opt(tsp)
@@ -106,6 +129,17 @@ func newTracesProcessor(ctx context.Context, settings component.TelemetrySetting
 		tsp.tickerFrequency = time.Second
 	}
 
+	if tsp.sampledIDCache == nil {
+		if tsp.sampledIDCache, err = newDecisionCache(cfg.DecisionCache.SampledCacheSize); err != nil {
+			return nil, err
+		}
+	}
+	if tsp.nonSampledIDCache == nil {
+		if tsp.nonSampledIDCache, err = newDecisionCache(cfg.DecisionCache.NonSampledCacheSize); err != nil {
+			return nil, err
+		}
+	}
+
 	if tsp.policies == nil {
 		policyNames := map[string]bool{}
 		tsp.policies = make([]*policy, len(cfg.PolicyCfgs))
@@ -158,6 +192,20 @@ func withPolicies(policies []*policy) Option {
 	}
 }
 
+// withSampledDecisionCache sets the cache which the processor uses to store recently sampled trace IDs.
+func withSampledDecisionCache(c cache.Cache[bool]) Option {
+	return func(tsp *tailSamplingSpanProcessor) {
+		tsp.sampledIDCache = c
+	}
+}
+
+// withNonSampledDecisionCache sets the cache which the processor uses to store recently non-sampled trace IDs.
+func withNonSampledDecisionCache(c cache.Cache[bool]) Option {
+	return func(tsp *tailSamplingSpanProcessor) {
+		tsp.nonSampledIDCache = c
+	}
+}
+
 // withTickerFrequency sets the frequency at which the processor will evaluate the sampling policies.
 func withTickerFrequency(frequency time.Duration) Option {
 	return func(tsp *tailSamplingSpanProcessor) {
@@ -253,8 +301,12 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		trace.Unlock()
 
 		if decision == sampling.Sampled {
+			tsp.sampledIDCache.Put(id, true)
 			_ = tsp.nextConsumer.ConsumeTraces(context.Background(), allSpans)
+		} else {
+			tsp.nonSampledIDCache.Put(id, true)
 		}
+		tsp.decisionCachesDirty.Store(true)
 	}
 
 	tsp.logger.Debug("Sampling policy evaluation completed",
@@ -344,6 +396,25 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 	var newTraceIDs int64
 	for id, spans := range idToSpansAndScope {
 		lenSpans := int64(len(spans))
+
+		// the spans of traces whose decision is cached are released or dropped right away,
+		// even if the trace was already removed from memory
+		if _, ok := tsp.sampledIDCache.Get(id); ok {
+			traceTd := ptrace.NewTraces()
+			appendToTraces(traceTd, resourceSpans, spans)
+			if err := tsp.nextConsumer.ConsumeTraces(tsp.ctx, traceTd); err != nil {
+				tsp.logger.Warn(
+					"Error sending spans with cached sampling decision to destination",
+					zap.Error(err))
+			}
+			tsp.telemetry.ProcessorTailSamplingEarlyReleasesFromCacheDecision.Add(tsp.ctx, lenSpans, attrSampledTrue)
+			continue
+		}
+		if _, ok := tsp.nonSampledIDCache.Get(id); ok {
+			tsp.telemetry.ProcessorTailSamplingEarlyReleasesFromCacheDecision.Add(tsp.ctx, lenSpans, attrSampledFalse)
+			continue
+		}
+
 		lenPolicies := len(tsp.policies)
 		initialDecisions := make([]sampling.Decision, lenPolicies)
 		for i := 0; i < lenPolicies; i++ {
@@ -417,16 +488,40 @@ func (tsp *tailSamplingSpanProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (tsp *tailSamplingSpanProcessor) Start(context.Context, component.Host) error {
+func (tsp *tailSamplingSpanProcessor) Start(ctx context.Context, host component.Host) error {
+	if tsp.storageID != nil {
+		client, err := getStorageClient(ctx, host, *tsp.storageID, tsp.componentID)
+		if err != nil {
+			return err
+		}
+		tsp.storageClient = client
+		if err = tsp.restoreDecisionCaches(ctx); err != nil {
+			tsp.logger.Warn("failed to restore the decision caches", zap.Error(err))
+		}
+		if tsp.persistInterval > 0 {
+			tsp.persistTicker.Start(tsp.persistInterval)
+		}
+	}
+
 	tsp.policyTicker.Start(tsp.tickerFrequency)
 	return nil
 }
 
 // Shutdown is invoked during service shutdown.
-func (tsp *tailSamplingSpanProcessor) Shutdown(context.Context) error {
+func (tsp *tailSamplingSpanProcessor) Shutdown(ctx context.Context) error {
 	tsp.decisionBatcher.Stop()
 	tsp.policyTicker.Stop()
-	return nil
+	tsp.persistTicker.Stop()
+
+	tsp.persistMu.Lock()
+	defer tsp.persistMu.Unlock()
+	if tsp.storageClient == nil {
+		return nil
+	}
+	err := tsp.persistDecisionCaches(ctx)
+	err = errors.Join(err, tsp.storageClient.Close(ctx))
+	tsp.storageClient = nil
+	return err
 }
 
 func (tsp *tailSamplingSpanProcessor) dropTrace(traceID pcommon.TraceID, deletionTime time.Time) {
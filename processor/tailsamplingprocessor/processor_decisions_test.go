@@ -6,6 +6,7 @@ package tailsamplingprocessor
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -14,6 +15,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
 )
 
@@ -24,7 +26,7 @@ func TestSamplingPolicyTypicalPath(t *testing.T) {
 	}
 	nextConsumer := new(consumertest.TracesSink)
 	s := setupTestTelemetry()
-	ct := s.NewSettings().TelemetrySettings
+	ct := s.NewSettings()
 	idb := newSyncIDBatcher()
 
 	mpe1 := &mockPolicyEvaluator{}
@@ -69,7 +71,7 @@ func TestSamplingPolicyInvertSampled(t *testing.T) {
 	}
 	nextConsumer := new(consumertest.TracesSink)
 	s := setupTestTelemetry()
-	ct := s.NewSettings().TelemetrySettings
+	ct := s.NewSettings()
 	idb := newSyncIDBatcher()
 
 	mpe1 := &mockPolicyEvaluator{}
@@ -114,7 +116,7 @@ func TestSamplingMultiplePolicies(t *testing.T) {
 	}
 	nextConsumer := new(consumertest.TracesSink)
 	s := setupTestTelemetry()
-	ct := s.NewSettings().TelemetrySettings
+	ct := s.NewSettings()
 	idb := newSyncIDBatcher()
 
 	mpe1 := &mockPolicyEvaluator{}
@@ -165,7 +167,7 @@ func TestSamplingPolicyDecisionNotSampled(t *testing.T) {
 	}
 	nextConsumer := new(consumertest.TracesSink)
 	s := setupTestTelemetry()
-	ct := s.NewSettings().TelemetrySettings
+	ct := s.NewSettings()
 	idb := newSyncIDBatcher()
 
 	mpe1 := &mockPolicyEvaluator{}
@@ -211,7 +213,7 @@ func TestSamplingPolicyDecisionInvertNotSampled(t *testing.T) {
 	}
 	nextConsumer := new(consumertest.TracesSink)
 	s := setupTestTelemetry()
-	ct := s.NewSettings().TelemetrySettings
+	ct := s.NewSettings()
 	idb := newSyncIDBatcher()
 
 	mpe1 := &mockPolicyEvaluator{}
@@ -262,7 +264,7 @@ func TestLateArrivingSpansAssignedOriginalDecision(t *testing.T) {
 	}
 	nextConsumer := new(consumertest.TracesSink)
 	s := setupTestTelemetry()
-	ct := s.NewSettings().TelemetrySettings
+	ct := s.NewSettings()
 	idb := newSyncIDBatcher()
 
 	mpe1 := &mockPolicyEvaluator{}
@@ -324,3 +326,70 @@ func TestLateArrivingSpansAssignedOriginalDecision(t *testing.T) {
 	require.EqualValues(t, 1, mpe2.EvaluationCount)
 	require.EqualValues(t, 0, nextConsumer.SpanCount(), "original final decision not honored")
 }
+
+func TestLateSpansInheritCachedDecision(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		decision      sampling.Decision
+		expectedSpans int
+	}{
+		{
+			name:          "sampled",
+			decision:      sampling.Sampled,
+			expectedSpans: 2,
+		},
+		{
+			name:          "not sampled",
+			decision:      sampling.NotSampled,
+			expectedSpans: 0,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{
+				DecisionWait: defaultTestDecisionWait,
+				NumTraces:    defaultNumTraces,
+			}
+			nextConsumer := new(consumertest.TracesSink)
+			s := setupTestTelemetry()
+			ct := s.NewSettings()
+			idb := newSyncIDBatcher()
+
+			mpe := &mockPolicyEvaluator{NextDecision: tt.decision}
+			policies := []*policy{
+				{name: "mock-policy-1", evaluator: mpe, attribute: metric.WithAttributes(attribute.String("policy", "mock-policy-1"))},
+			}
+
+			sampledIDCache, err := cache.NewLRUDecisionCache[bool](100)
+			require.NoError(t, err)
+			nonSampledIDCache, err := cache.NewLRUDecisionCache[bool](100)
+			require.NoError(t, err)
+
+			p, err := newTracesProcessor(context.Background(), ct, nextConsumer, cfg, withDecisionBatcher(idb), withPolicies(policies),
+				withSampledDecisionCache(sampledIDCache), withNonSampledDecisionCache(nonSampledIDCache))
+			require.NoError(t, err)
+
+			require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() {
+				require.NoError(t, p.Shutdown(context.Background()))
+			}()
+
+			traceID := uInt64ToTraceID(1)
+			require.NoError(t, p.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+
+			tsp := p.(*tailSamplingSpanProcessor)
+			tsp.policyTicker.OnTick()
+			tsp.policyTicker.OnTick()
+			require.EqualValues(t, 1, mpe.EvaluationCount)
+
+			// the trace is removed from memory, as it happens once num_traces is exceeded
+			tsp.dropTrace(traceID, time.Now())
+
+			// the late span SHOULD get the cached decision, without evaluating the policies again
+			require.NoError(t, p.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+			tsp.policyTicker.OnTick()
+			tsp.policyTicker.OnTick()
+			require.EqualValues(t, 1, mpe.EvaluationCount)
+			require.EqualValues(t, tt.expectedSpans, nextConsumer.SpanCount())
+		})
+	}
+}
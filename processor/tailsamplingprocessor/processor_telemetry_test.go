@@ -37,7 +37,7 @@ func TestMetricsAfterOneEvaluation(t *testing.T) {
 		},
 	}
 	cs := &consumertest.TracesSink{}
-	ct := s.NewSettings().TelemetrySettings
+	ct := s.NewSettings()
 	proc, err := newTracesProcessor(context.Background(), ct, cs, cfg, withDecisionBatcher(syncBatcher))
 	require.NoError(t, err)
 	defer func() {
@@ -238,7 +238,7 @@ func TestProcessorTailSamplingCountSpansSampled(t *testing.T) {
 		},
 	}
 	cs := &consumertest.TracesSink{}
-	ct := s.NewSettings().TelemetrySettings
+	ct := s.NewSettings()
 	proc, err := newTracesProcessor(context.Background(), ct, cs, cfg, withDecisionBatcher(syncBatcher))
 	require.NoError(t, err)
 	defer func() {
@@ -303,7 +303,7 @@ func TestProcessorTailSamplingSamplingTraceRemovalAge(t *testing.T) {
 		},
 	}
 	cs := &consumertest.TracesSink{}
-	ct := s.NewSettings().TelemetrySettings
+	ct := s.NewSettings()
 	proc, err := newTracesProcessor(context.Background(), ct, cs, cfg, withDecisionBatcher(syncBatcher))
 	require.NoError(t, err)
 	defer func() {
@@ -364,7 +364,7 @@ func TestProcessorTailSamplingSamplingLateSpanAge(t *testing.T) {
 		},
 	}
 	cs := &consumertest.TracesSink{}
-	ct := s.NewSettings().TelemetrySettings
+	ct := s.NewSettings()
 	proc, err := newTracesProcessor(context.Background(), ct, cs, cfg, withDecisionBatcher(syncBatcher))
 	require.NoError(t, err)
 	defer func() {
@@ -408,3 +408,79 @@ func TestProcessorTailSamplingSamplingLateSpanAge(t *testing.T) {
 	got := s.getMetric(m.Name, md)
 	metricdatatest.AssertEqual(t, m, got, metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreValue())
 }
+
+func TestProcessorTailSamplingEarlyReleasesFromCacheDecision(t *testing.T) {
+	// prepare
+	s := setupTestTelemetry()
+	b := newSyncIDBatcher()
+	syncBatcher := b.(*syncIDBatcher)
+
+	cfg := Config{
+		DecisionWait: 1,
+		NumTraces:    100,
+		PolicyCfgs: []PolicyCfg{
+			{
+				sharedPolicyCfg: sharedPolicyCfg{
+					Name: "always",
+					Type: AlwaysSample,
+				},
+			},
+		},
+		DecisionCache: DecisionCacheConfig{
+			SampledCacheSize: 100,
+		},
+	}
+	cs := &consumertest.TracesSink{}
+	ct := s.NewSettings()
+	proc, err := newTracesProcessor(context.Background(), ct, cs, cfg, withDecisionBatcher(syncBatcher))
+	require.NoError(t, err)
+	defer func() {
+		err = proc.Shutdown(context.Background())
+		require.NoError(t, err)
+	}()
+
+	err = proc.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	traces := simpleTraces()
+	traceID := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID()
+
+	lateSpan := ptrace.NewTraces()
+	lateSpan.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetTraceID(traceID)
+
+	// test
+	err = proc.ConsumeTraces(context.Background(), traces)
+	require.NoError(t, err)
+
+	tsp := proc.(*tailSamplingSpanProcessor)
+	tsp.policyTicker.OnTick() // the first tick always gets an empty batch
+	tsp.policyTicker.OnTick()
+
+	err = proc.ConsumeTraces(context.Background(), lateSpan)
+	require.NoError(t, err)
+
+	// verify
+	var md metricdata.ResourceMetrics
+	require.NoError(t, s.reader.Collect(context.Background(), &md))
+
+	m := metricdata.Metrics{
+		Name:        "processor_tail_sampling_early_releases_from_cache_decision",
+		Description: "Number of spans that were immediately released or dropped due to a decision cache hit",
+		Unit:        "{spans}",
+		Data: metricdata.Sum[int64]{
+			IsMonotonic: true,
+			Temporality: metricdata.CumulativeTemporality,
+			DataPoints: []metricdata.DataPoint[int64]{
+				{
+					Attributes: attribute.NewSet(
+						attribute.String("sampled", "true"),
+					),
+					Value: 1,
+				},
+			},
+		},
+	}
+	got := s.getMetric(m.Name, md)
+	metricdatatest.AssertEqual(t, m, got, metricdatatest.IgnoreTimestamp())
+	assert.Equal(t, 2, cs.SpanCount())
+}
@@ -11,6 +11,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
@@ -23,6 +24,7 @@ import (
 var _ consumer.Traces = &resourceStatements{}
 var _ consumer.Metrics = &resourceStatements{}
 var _ consumer.Logs = &resourceStatements{}
+var _ ProfilesConsumer = &resourceStatements{}
 var _ baseContext = &resourceStatements{}
 
 type resourceStatements struct {
@@ -90,9 +92,28 @@ func (r resourceStatements) ConsumeLogs(ctx context.Context, ld plog.Logs) error
 	return nil
 }
 
+func (r resourceStatements) ConsumeProfiles(ctx context.Context, rps pprofile.ResourceProfilesSlice) error {
+	for i := 0; i < rps.Len(); i++ {
+		rprofiles := rps.At(i)
+		tCtx := ottlresource.NewTransformContext(rprofiles.Resource())
+		condition, err := r.BoolExpr.Eval(ctx, tCtx)
+		if err != nil {
+			return err
+		}
+		if condition {
+			err := r.Execute(ctx, tCtx)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 var _ consumer.Traces = &scopeStatements{}
 var _ consumer.Metrics = &scopeStatements{}
 var _ consumer.Logs = &scopeStatements{}
+var _ ProfilesConsumer = &scopeStatements{}
 var _ baseContext = &scopeStatements{}
 
 type scopeStatements struct {
@@ -169,6 +190,27 @@ func (s scopeStatements) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 	return nil
 }
 
+func (s scopeStatements) ConsumeProfiles(ctx context.Context, rps pprofile.ResourceProfilesSlice) error {
+	for i := 0; i < rps.Len(); i++ {
+		rprofiles := rps.At(i)
+		for j := 0; j < rprofiles.ScopeProfiles().Len(); j++ {
+			sprofiles := rprofiles.ScopeProfiles().At(j)
+			tCtx := ottlscope.NewTransformContext(sprofiles.Scope(), rprofiles.Resource())
+			condition, err := s.BoolExpr.Eval(ctx, tCtx)
+			if err != nil {
+				return err
+			}
+			if condition {
+				err := s.Execute(ctx, tCtx)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
 type parserCollection struct {
 	settings       component.TelemetrySettings
 	resourceParser ottl.Parser[ottlresource.TransformContext]
@@ -180,6 +222,7 @@ type baseContext interface {
 	consumer.Traces
 	consumer.Metrics
 	consumer.Logs
+	ProfilesConsumer
 }
 
 func (pc parserCollection) parseCommonContextStatements(contextStatement ContextStatements) (baseContext, error) {
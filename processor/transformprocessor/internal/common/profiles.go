@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package common // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofile"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlsample"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlscope"
+)
+
+// ProfilesConsumer is the profiles counterpart of consumer.Traces, consumer.Metrics and consumer.Logs.
+// The collector core does not provide a consumer interface nor a top-level container for profiles yet,
+// the profiles being consumed as a slice of resource profiles.
+type ProfilesConsumer interface {
+	Capabilities() consumer.Capabilities
+	ConsumeProfiles(ctx context.Context, rps pprofile.ResourceProfilesSlice) error
+}
+
+var _ ProfilesConsumer = &profileStatements{}
+
+type profileStatements struct {
+	ottl.StatementSequence[ottlprofile.TransformContext]
+	expr.BoolExpr[ottlprofile.TransformContext]
+}
+
+func (p profileStatements) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{
+		MutatesData: true,
+	}
+}
+
+func (p profileStatements) ConsumeProfiles(ctx context.Context, rps pprofile.ResourceProfilesSlice) error {
+	for i := 0; i < rps.Len(); i++ {
+		rprofiles := rps.At(i)
+		for j := 0; j < rprofiles.ScopeProfiles().Len(); j++ {
+			sprofiles := rprofiles.ScopeProfiles().At(j)
+			profiles := sprofiles.Profiles()
+			for k := 0; k < profiles.Len(); k++ {
+				tCtx := ottlprofile.NewTransformContext(profiles.At(k), sprofiles.Scope(), rprofiles.Resource())
+				condition, err := p.BoolExpr.Eval(ctx, tCtx)
+				if err != nil {
+					return err
+				}
+				if condition {
+					err := p.Execute(ctx, tCtx)
+					if err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+var _ ProfilesConsumer = &sampleStatements{}
+
+type sampleStatements struct {
+	ottl.StatementSequence[ottlsample.TransformContext]
+	expr.BoolExpr[ottlsample.TransformContext]
+}
+
+func (s sampleStatements) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{
+		MutatesData: true,
+	}
+}
+
+func (s sampleStatements) ConsumeProfiles(ctx context.Context, rps pprofile.ResourceProfilesSlice) error {
+	for i := 0; i < rps.Len(); i++ {
+		rprofiles := rps.At(i)
+		for j := 0; j < rprofiles.ScopeProfiles().Len(); j++ {
+			sprofiles := rprofiles.ScopeProfiles().At(j)
+			profiles := sprofiles.Profiles()
+			for k := 0; k < profiles.Len(); k++ {
+				profile := profiles.At(k)
+				samples := profile.Profile().Sample()
+				for n := 0; n < samples.Len(); n++ {
+					tCtx := ottlsample.NewTransformContext(samples.At(n), profile, sprofiles.Scope(), rprofiles.Resource())
+					condition, err := s.BoolExpr.Eval(ctx, tCtx)
+					if err != nil {
+						return err
+					}
+					if condition {
+						err := s.Execute(ctx, tCtx)
+						if err != nil {
+							return err
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+type ProfileParserCollection struct {
+	parserCollection
+	profileParser ottl.Parser[ottlprofile.TransformContext]
+	sampleParser  ottl.Parser[ottlsample.TransformContext]
+}
+
+type ProfileParserCollectionOption func(*ProfileParserCollection) error
+
+func WithProfileParser(functions map[string]ottl.Factory[ottlprofile.TransformContext]) ProfileParserCollectionOption {
+	return func(pp *ProfileParserCollection) error {
+		profileParser, err := ottlprofile.NewParser(functions, pp.settings)
+		if err != nil {
+			return err
+		}
+		pp.profileParser = profileParser
+		return nil
+	}
+}
+
+func WithSampleParser(functions map[string]ottl.Factory[ottlsample.TransformContext]) ProfileParserCollectionOption {
+	return func(pp *ProfileParserCollection) error {
+		sampleParser, err := ottlsample.NewParser(functions, pp.settings)
+		if err != nil {
+			return err
+		}
+		pp.sampleParser = sampleParser
+		return nil
+	}
+}
+
+func WithProfileErrorMode(errorMode ottl.ErrorMode) ProfileParserCollectionOption {
+	return func(pp *ProfileParserCollection) error {
+		pp.errorMode = errorMode
+		return nil
+	}
+}
+
+func NewProfileParserCollection(settings component.TelemetrySettings, options ...ProfileParserCollectionOption) (*ProfileParserCollection, error) {
+	rp, err := ottlresource.NewParser(ResourceFunctions(), settings)
+	if err != nil {
+		return nil, err
+	}
+	sp, err := ottlscope.NewParser(ScopeFunctions(), settings)
+	if err != nil {
+		return nil, err
+	}
+	ppc := &ProfileParserCollection{
+		parserCollection: parserCollection{
+			settings:       settings,
+			resourceParser: rp,
+			scopeParser:    sp,
+		},
+	}
+
+	for _, op := range options {
+		err := op(ppc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ppc, nil
+}
+
+func (pc ProfileParserCollection) ParseContextStatements(contextStatements ContextStatements) (ProfilesConsumer, error) {
+	switch contextStatements.Context {
+	case Profile:
+		parsedStatements, err := pc.profileParser.ParseStatements(contextStatements.Statements)
+		if err != nil {
+			return nil, err
+		}
+		globalExpr, errGlobalBoolExpr := parseGlobalExpr(filterottl.NewBoolExprForProfile, contextStatements.Conditions, pc.parserCollection, filterottl.StandardProfileFuncs())
+		if errGlobalBoolExpr != nil {
+			return nil, errGlobalBoolExpr
+		}
+		pStatements := ottlprofile.NewStatementSequence(parsedStatements, pc.settings, ottlprofile.WithStatementSequenceErrorMode(pc.errorMode))
+		return profileStatements{pStatements, globalExpr}, nil
+	case Sample:
+		parsedStatements, err := pc.sampleParser.ParseStatements(contextStatements.Statements)
+		if err != nil {
+			return nil, err
+		}
+		globalExpr, errGlobalBoolExpr := parseGlobalExpr(filterottl.NewBoolExprForSample, contextStatements.Conditions, pc.parserCollection, filterottl.StandardSampleFuncs())
+		if errGlobalBoolExpr != nil {
+			return nil, errGlobalBoolExpr
+		}
+		sStatements := ottlsample.NewStatementSequence(parsedStatements, pc.settings, ottlsample.WithStatementSequenceErrorMode(pc.errorMode))
+		return sampleStatements{sStatements, globalExpr}, nil
+	default:
+		return pc.parseCommonContextStatements(contextStatements)
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package profiles // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/profiles"
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofile"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlsample"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+func ProfileFunctions() map[string]ottl.Factory[ottlprofile.TransformContext] {
+	// No profile-only functions yet.
+	return ottlfuncs.StandardFuncs[ottlprofile.TransformContext]()
+}
+
+func SampleFunctions() map[string]ottl.Factory[ottlsample.TransformContext] {
+	// No profile-only functions yet.
+	return ottlfuncs.StandardFuncs[ottlsample.TransformContext]()
+}
@@ -0,0 +1,273 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package profiles
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
+)
+
+var (
+	TestProfileStartTime      = time.Date(2020, 2, 11, 20, 26, 12, 321, time.UTC)
+	TestProfileStartTimestamp = pcommon.NewTimestampFromTime(TestProfileStartTime)
+
+	TestProfileEndTime      = time.Date(2020, 2, 11, 20, 26, 13, 789, time.UTC)
+	TestProfileEndTimestamp = pcommon.NewTimestampFromTime(TestProfileEndTime)
+
+	profileID = [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+)
+
+func Test_ProcessProfiles_ResourceContext(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      func(rps pprofile.ResourceProfilesSlice)
+	}{
+		{
+			statement: `set(attributes["test"], "pass")`,
+			want: func(rps pprofile.ResourceProfilesSlice) {
+				rps.At(0).Resource().Attributes().PutStr("test", "pass")
+			},
+		},
+		{
+			statement: `set(attributes["test"], "pass") where attributes["host.name"] == "wrong"`,
+			want: func(_ pprofile.ResourceProfilesSlice) {
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			rps := constructProfiles()
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "resource", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings())
+			assert.NoError(t, err)
+
+			_, err = processor.ProcessProfiles(context.Background(), rps)
+			assert.NoError(t, err)
+
+			exRps := constructProfiles()
+			tt.want(exRps)
+
+			assert.Equal(t, exRps, rps)
+		})
+	}
+}
+
+func Test_ProcessProfiles_ScopeContext(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      func(rps pprofile.ResourceProfilesSlice)
+	}{
+		{
+			statement: `set(attributes["test"], "pass") where name == "scope"`,
+			want: func(rps pprofile.ResourceProfilesSlice) {
+				rps.At(0).ScopeProfiles().At(0).Scope().Attributes().PutStr("test", "pass")
+			},
+		},
+		{
+			statement: `set(attributes["test"], "pass") where version == 2`,
+			want: func(_ pprofile.ResourceProfilesSlice) {
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			rps := constructProfiles()
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "scope", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings())
+			assert.NoError(t, err)
+
+			_, err = processor.ProcessProfiles(context.Background(), rps)
+			assert.NoError(t, err)
+
+			exRps := constructProfiles()
+			tt.want(exRps)
+
+			assert.Equal(t, exRps, rps)
+		})
+	}
+}
+
+func Test_ProcessProfiles_ProfileContext(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      func(rps pprofile.ResourceProfilesSlice)
+	}{
+		{
+			statement: `set(attributes["test"], "pass") where attributes["process.executable.name"] == "api"`,
+			want: func(rps pprofile.ResourceProfilesSlice) {
+				rps.At(0).ScopeProfiles().At(0).Profiles().At(0).Attributes().PutStr("test", "pass")
+			},
+		},
+		{
+			statement: `set(attributes["test"], "pass") where resource.attributes["host.name"] == "localhost"`,
+			want: func(rps pprofile.ResourceProfilesSlice) {
+				rps.At(0).ScopeProfiles().At(0).Profiles().At(0).Attributes().PutStr("test", "pass")
+				rps.At(0).ScopeProfiles().At(0).Profiles().At(1).Attributes().PutStr("test", "pass")
+			},
+		},
+		{
+			statement: `delete_key(attributes, "user.email")`,
+			want: func(rps pprofile.ResourceProfilesSlice) {
+				rps.At(0).ScopeProfiles().At(0).Profiles().At(0).Attributes().Remove("user.email")
+			},
+		},
+		{
+			statement: `set(period, 20000000) where profile_id.string == "0102030405060708090a0b0c0d0e0f10"`,
+			want: func(rps pprofile.ResourceProfilesSlice) {
+				rps.At(0).ScopeProfiles().At(0).Profiles().At(0).Profile().SetPeriod(20_000_000)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			rps := constructProfiles()
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "profile", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings())
+			assert.NoError(t, err)
+
+			_, err = processor.ProcessProfiles(context.Background(), rps)
+			assert.NoError(t, err)
+
+			exRps := constructProfiles()
+			tt.want(exRps)
+
+			assert.Equal(t, exRps, rps)
+		})
+	}
+}
+
+func Test_ProcessProfiles_SampleContext(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      func(rps pprofile.ResourceProfilesSlice)
+	}{
+		{
+			statement: `set(values, [0, 0]) where IsMatch(functions[0], "^main\\.handle")`,
+			want: func(rps pprofile.ResourceProfilesSlice) {
+				rps.At(0).ScopeProfiles().At(0).Profiles().At(0).Profile().Sample().At(0).Value().FromRaw([]int64{0, 0})
+			},
+		},
+		{
+			statement: `set(values, [0, 0]) where attributes["thread.name"] == "worker"`,
+			want: func(rps pprofile.ResourceProfilesSlice) {
+				rps.At(0).ScopeProfiles().At(0).Profiles().At(0).Profile().Sample().At(0).Value().FromRaw([]int64{0, 0})
+				rps.At(0).ScopeProfiles().At(0).Profiles().At(1).Profile().Sample().At(0).Value().FromRaw([]int64{0, 0})
+			},
+		},
+		{
+			statement: `set(values, [0, 0]) where profile.attributes["process.executable.name"] == "worker"`,
+			want: func(rps pprofile.ResourceProfilesSlice) {
+				rps.At(0).ScopeProfiles().At(0).Profiles().At(1).Profile().Sample().At(0).Value().FromRaw([]int64{0, 0})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			rps := constructProfiles()
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "sample", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings())
+			assert.NoError(t, err)
+
+			_, err = processor.ProcessProfiles(context.Background(), rps)
+			assert.NoError(t, err)
+
+			exRps := constructProfiles()
+			tt.want(exRps)
+
+			assert.Equal(t, exRps, rps)
+		})
+	}
+}
+
+func Test_ProcessProfiles_Error(t *testing.T) {
+	tests := []struct {
+		context common.ContextID
+	}{
+		{
+			context: "resource",
+		},
+		{
+			context: "scope",
+		},
+		{
+			context: "profile",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.context), func(t *testing.T) {
+			rps := constructProfiles()
+			processor, err := NewProcessor([]common.ContextStatements{{Context: tt.context, Statements: []string{`set(attributes["test"], ParseJSON(1))`}}}, ottl.PropagateError, componenttest.NewNopTelemetrySettings())
+			assert.NoError(t, err)
+
+			_, err = processor.ProcessProfiles(context.Background(), rps)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_NewProcessor_ReadOnlySamplePath(t *testing.T) {
+	processor, err := NewProcessor([]common.ContextStatements{{Context: "sample", Statements: []string{`set(attributes["test"], "pass")`}}}, ottl.PropagateError, componenttest.NewNopTelemetrySettings())
+	assert.NoError(t, err)
+
+	_, err = processor.ProcessProfiles(context.Background(), constructProfiles())
+	assert.Error(t, err)
+}
+
+func constructProfiles() pprofile.ResourceProfilesSlice {
+	rps := pprofile.NewResourceProfilesSlice()
+	rp0 := rps.AppendEmpty()
+	rp0.Resource().Attributes().PutStr("host.name", "localhost")
+	rp0ils0 := rp0.ScopeProfiles().AppendEmpty()
+	rp0ils0.Scope().SetName("scope")
+	fillProfileOne(rp0ils0.Profiles().AppendEmpty())
+	fillProfileTwo(rp0ils0.Profiles().AppendEmpty())
+	return rps
+}
+
+func fillProfileOne(profile pprofile.ProfileContainer) {
+	profile.ProfileID().FromRaw(profileID[:])
+	profile.SetStartTime(TestProfileStartTimestamp)
+	profile.SetEndTime(TestProfileEndTimestamp)
+	profile.Attributes().PutStr("process.executable.name", "api")
+	profile.Attributes().PutStr("user.email", "user@example.com")
+	profile.Profile().SetPeriod(10_000_000)
+	fillStack(profile.Profile(), "main.handle")
+}
+
+func fillProfileTwo(profile pprofile.ProfileContainer) {
+	profile.SetStartTime(TestProfileStartTimestamp)
+	profile.SetEndTime(TestProfileEndTimestamp)
+	profile.Attributes().PutStr("process.executable.name", "worker")
+	profile.Profile().SetPeriod(10_000_000)
+	fillStack(profile.Profile(), "runtime.gcBgMarkWorker")
+}
+
+func fillStack(profile pprofile.Profile, functionName string) {
+	profile.StringTable().FromRaw([]string{"", functionName, "main.go"})
+	profile.AttributeTable().PutStr("thread.name", "worker")
+
+	function := profile.Function().AppendEmpty()
+	function.SetName(1)
+	function.SetFilename(2)
+
+	location := profile.Location().AppendEmpty()
+	location.SetAddress(0x10)
+	location.Line().AppendEmpty().SetLine(12)
+	profile.LocationIndices().FromRaw([]int64{0})
+
+	sample := profile.Sample().AppendEmpty()
+	sample.SetLocationsLength(1)
+	sample.Value().FromRaw([]int64{1, 10_000_000})
+	sample.Attributes().FromRaw([]uint64{0})
+}
@@ -4,7 +4,11 @@
 package filelogreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/filelogreceiver"
 
 import (
+	"context"
+	"errors"
+
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/consumerretry"
@@ -16,12 +20,26 @@ import (
 
 // NewFactory creates a factory for filelog receiver
 func NewFactory() receiver.Factory {
-	return adapter.NewFactory(ReceiverType{}, metadata.LogsStability)
+	return receiver.NewFactory(
+		metadata.Type,
+		ReceiverType{}.CreateDefaultConfig,
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability),
+	)
+}
+
+func createLogsReceiver(ctx context.Context, set receiver.Settings, cfg component.Config, nextConsumer consumer.Logs) (receiver.Logs, error) {
+	if len(cfg.(*FileLogConfig).WatchObservers) > 0 {
+		return newObserverReceiver(ctx, set, cfg.(*FileLogConfig), nextConsumer)
+	}
+	return adapter.NewFactory(ReceiverType{}, metadata.LogsStability).CreateLogsReceiver(ctx, set, cfg, nextConsumer)
 }
 
 // ReceiverType implements stanza.LogReceiverType
 // to create a file tailing receiver
-type ReceiverType struct{}
+type ReceiverType struct {
+	// tailer receives the endpoints of the watched observers, if any
+	tailer *observerTailer
+}
 
 // Type is the receiver type
 func (f ReceiverType) Type() component.Type {
@@ -51,9 +69,29 @@ func (f ReceiverType) BaseConfig(cfg component.Config) adapter.BaseConfig {
 type FileLogConfig struct {
 	InputConfig        file.Config `mapstructure:",squash"`
 	adapter.BaseConfig `mapstructure:",squash"`
+
+	// WatchObservers are the observers whose endpoints have their files tailed
+	WatchObservers []component.ID `mapstructure:"watch_observers"`
+	// EndpointInclude are the templates of the include patterns of the endpoints, by endpoint type
+	EndpointInclude map[string][]string `mapstructure:"endpoint_include"`
+}
+
+// Validate checks the endpoint include templates
+func (c *FileLogConfig) Validate() error {
+	if len(c.WatchObservers) == 0 {
+		if len(c.EndpointInclude) > 0 {
+			return errors.New("'endpoint_include' requires 'watch_observers'")
+		}
+		return nil
+	}
+	_, err := parseEndpointInclude(c.EndpointInclude)
+	return err
 }
 
 // InputConfig unmarshals the input operator
 func (f ReceiverType) InputConfig(cfg component.Config) operator.Config {
+	if f.tailer != nil {
+		return operator.NewConfig(&endpointsInputConfig{Config: cfg.(*FileLogConfig).InputConfig, tailer: f.tailer})
+	}
 	return operator.NewConfig(&cfg.(*FileLogConfig).InputConfig)
 }
@@ -0,0 +1,246 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filelogreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/filelogreceiver"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/file"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/filelogreceiver/internal/metadata"
+)
+
+// defaultEndpointInclude are the include patterns of the endpoint types whose log files have well-known locations
+var defaultEndpointInclude = map[string][]string{
+	string(observer.PodType):       {"/var/log/pods/{{.namespace}}_{{.name}}_{{.uid}}/*/*.log"},
+	string(observer.ContainerType): {"/var/lib/docker/containers/{{.container_id}}/{{.container_id}}-json.log"},
+}
+
+// parseEndpointInclude parses the include templates of each endpoint type,
+// falling back to the default ones for the types which are not configured
+func parseEndpointInclude(configured map[string][]string) (map[observer.EndpointType][]*template.Template, error) {
+	patterns := map[string][]string{}
+	for endpointType, include := range defaultEndpointInclude {
+		patterns[endpointType] = include
+	}
+	for endpointType, include := range configured {
+		switch observer.EndpointType(endpointType) {
+		case observer.PodType, observer.PortType, observer.ContainerType, observer.HostPortType, observer.K8sNodeType, observer.K8sServiceType:
+		default:
+			return nil, fmt.Errorf("'endpoint_include' has unknown endpoint type %q", endpointType)
+		}
+		patterns[endpointType] = include
+	}
+
+	templates := map[observer.EndpointType][]*template.Template{}
+	for endpointType, include := range patterns {
+		for _, pattern := range include {
+			tmpl, err := template.New(endpointType).Option("missingkey=error").Parse(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("'endpoint_include' of endpoint type %q: %w", endpointType, err)
+			}
+			templates[observer.EndpointType(endpointType)] = append(templates[observer.EndpointType(endpointType)], tmpl)
+		}
+	}
+	return templates, nil
+}
+
+// endpointsInputConfig builds a file input which also tails the files of the endpoints of the observers
+type endpointsInputConfig struct {
+	file.Config
+	tailer *observerTailer
+}
+
+func (c *endpointsInputConfig) Build(set component.TelemetrySettings) (operator.Operator, error) {
+	input, err := c.Config.BuildWithEndpoints(set)
+	if err != nil {
+		return nil, err
+	}
+	c.tailer.input = input
+	return input, nil
+}
+
+var _ observer.Notify = (*observerTailer)(nil)
+
+// observerTailer adds the endpoints notified by the observers to the file input
+type observerTailer struct {
+	id        component.ID
+	logger    *zap.Logger
+	templates map[observer.EndpointType][]*template.Template
+	input     *file.Input
+}
+
+func (t *observerTailer) ID() observer.NotifyID {
+	return observer.NotifyID(t.id.String())
+}
+
+func (t *observerTailer) OnAdd(added []observer.Endpoint) {
+	for _, e := range added {
+		t.add(e)
+	}
+}
+
+func (t *observerTailer) OnRemove(removed []observer.Endpoint) {
+	for _, e := range removed {
+		t.remove(e)
+	}
+}
+
+// OnChange re-adds the changed endpoints to update their resource attributes.
+// Their files resume from their persisted offsets.
+func (t *observerTailer) OnChange(changed []observer.Endpoint) {
+	for _, e := range changed {
+		t.remove(e)
+		t.add(e)
+	}
+}
+
+func (t *observerTailer) add(e observer.Endpoint) {
+	if e.Details == nil {
+		return
+	}
+	templates, ok := t.templates[e.Details.Type()]
+	if !ok {
+		return
+	}
+	env, err := e.Env()
+	if err != nil {
+		t.logger.Error("failed to get endpoint env", zap.String("endpoint", string(e.ID)), zap.Error(err))
+		return
+	}
+
+	include := make([]string, 0, len(templates))
+	for _, tmpl := range templates {
+		var b strings.Builder
+		if err = tmpl.Execute(&b, env); err != nil {
+			t.logger.Error("failed to resolve endpoint include pattern", zap.String("endpoint", string(e.ID)), zap.Error(err))
+			return
+		}
+		include = append(include, b.String())
+	}
+
+	err = t.input.AddEndpoint(file.Endpoint{
+		ID:       string(e.ID),
+		Include:  include,
+		Resource: endpointResource(e.Details),
+	})
+	if err != nil {
+		t.logger.Error("failed to tail endpoint", zap.String("endpoint", string(e.ID)), zap.Error(err))
+	}
+}
+
+func (t *observerTailer) remove(e observer.Endpoint) {
+	if err := t.input.RemoveEndpoint(string(e.ID)); err != nil {
+		t.logger.Error("failed to stop tailing endpoint", zap.String("endpoint", string(e.ID)), zap.Error(err))
+	}
+}
+
+// endpointResource returns the resource attributes of the entries read from the files of an endpoint
+func endpointResource(details observer.EndpointDetails) map[string]any {
+	resource := map[string]any{}
+	switch d := details.(type) {
+	case *observer.Pod:
+		podResource(resource, d)
+	case *observer.Port:
+		podResource(resource, &d.Pod)
+	case *observer.Container:
+		resource["container.id"] = d.ContainerID
+		resource["container.name"] = d.Name
+		resource["container.image.name"] = d.Image
+		resource["container.image.tag"] = d.Tag
+		for k, v := range d.Labels {
+			resource["container.label."+k] = v
+		}
+	case *observer.HostPort:
+		resource["process.executable.name"] = d.ProcessName
+		resource["process.command_line"] = d.Command
+	case *observer.K8sNode:
+		resource["k8s.node.name"] = d.Name
+		resource["k8s.node.uid"] = d.UID
+	case *observer.K8sService:
+		resource["k8s.namespace.name"] = d.Namespace
+		resource["k8s.service.name"] = d.Name
+		resource["k8s.service.uid"] = d.UID
+	}
+	return resource
+}
+
+func podResource(resource map[string]any, pod *observer.Pod) {
+	resource["k8s.pod.name"] = pod.Name
+	resource["k8s.pod.uid"] = pod.UID
+	resource["k8s.namespace.name"] = pod.Namespace
+	for k, v := range pod.Labels {
+		resource["k8s.pod.labels."+k] = v
+	}
+}
+
+// observerReceiver tails the files of the endpoints of the watched observers
+type observerReceiver struct {
+	receiver.Logs
+	watchObservers []component.ID
+	tailer         *observerTailer
+	observables    []observer.Observable
+}
+
+func newObserverReceiver(ctx context.Context, set receiver.Settings, cfg *FileLogConfig, nextConsumer consumer.Logs) (receiver.Logs, error) {
+	templates, err := parseEndpointInclude(cfg.EndpointInclude)
+	if err != nil {
+		return nil, err
+	}
+	tailer := &observerTailer{
+		id:        set.ID,
+		logger:    set.Logger,
+		templates: templates,
+	}
+	logs, err := adapter.NewFactory(ReceiverType{tailer: tailer}, metadata.LogsStability).CreateLogsReceiver(ctx, set, cfg, nextConsumer)
+	if err != nil {
+		return nil, err
+	}
+	return &observerReceiver{
+		Logs:           logs,
+		watchObservers: cfg.WatchObservers,
+		tailer:         tailer,
+	}, nil
+}
+
+func (r *observerReceiver) Start(ctx context.Context, host component.Host) error {
+	if err := r.Logs.Start(ctx, host); err != nil {
+		return err
+	}
+
+	extensions := host.GetExtensions()
+	for _, id := range r.watchObservers {
+		ext, ok := extensions[id]
+		if !ok {
+			return fmt.Errorf("failed to find observer %q in the extensions list", id.String())
+		}
+		obs, ok := ext.(observer.Observable)
+		if !ok {
+			return fmt.Errorf("extension %q in watch_observers is not an observer", id.String())
+		}
+		r.observables = append(r.observables, obs)
+	}
+	for _, obs := range r.observables {
+		obs.ListAndWatch(r.tailer)
+	}
+	return nil
+}
+
+func (r *observerReceiver) Shutdown(ctx context.Context) error {
+	for _, obs := range r.observables {
+		obs.Unsubscribe(r.tailer)
+	}
+	r.observables = nil
+	return r.Logs.Shutdown(ctx)
+}
@@ -0,0 +1,168 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filelogreceiver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+type mockObserver struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	mu     sync.Mutex
+	notify observer.Notify
+}
+
+func (m *mockObserver) ListAndWatch(notify observer.Notify) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.notify = notify
+}
+
+func (m *mockObserver) Unsubscribe(observer.Notify) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.notify = nil
+}
+
+func (m *mockObserver) subscriber() observer.Notify {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.notify
+}
+
+type mockHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (m *mockHost) GetExtensions() map[component.ID]component.Component {
+	return m.extensions
+}
+
+func TestConfigValidateEndpointInclude(t *testing.T) {
+	cfg := createDefaultConfig()
+	cfg.EndpointInclude = map[string][]string{"pod": {"/var/log/{{.name}}.log"}}
+	assert.EqualError(t, cfg.Validate(), "'endpoint_include' requires 'watch_observers'")
+
+	cfg.WatchObservers = []component.ID{component.MustNewID("k8s_observer")}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.EndpointInclude = map[string][]string{"unknown": {"/var/log/*.log"}}
+	assert.EqualError(t, cfg.Validate(), `'endpoint_include' has unknown endpoint type "unknown"`)
+
+	cfg.EndpointInclude = map[string][]string{"pod": {"/var/log/{{.name"}}
+	assert.ErrorContains(t, cfg.Validate(), `'endpoint_include' of endpoint type "pod"`)
+}
+
+func TestEndpointResource(t *testing.T) {
+	pod := observer.Pod{
+		Name:      "pod-1",
+		UID:       "uid-1",
+		Namespace: "default",
+		Labels:    map[string]string{"app": "web"},
+	}
+	expected := map[string]any{
+		"k8s.pod.name":       "pod-1",
+		"k8s.pod.uid":        "uid-1",
+		"k8s.namespace.name": "default",
+		"k8s.pod.labels.app": "web",
+	}
+	assert.Equal(t, expected, endpointResource(&pod))
+	assert.Equal(t, expected, endpointResource(&observer.Port{Pod: pod, Port: 8080}))
+
+	assert.Equal(t, map[string]any{
+		"container.id":         "abc",
+		"container.name":       "web",
+		"container.image.name": "nginx",
+		"container.image.tag":  "1.27",
+		"container.label.team": "platform",
+	}, endpointResource(&observer.Container{
+		ContainerID: "abc",
+		Name:        "web",
+		Image:       "nginx",
+		Tag:         "1.27",
+		Labels:      map[string]string{"team": "platform"},
+	}))
+}
+
+func TestObserverTailing(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	observerID := component.MustNewID("k8s_observer")
+	cfg := createDefaultConfig()
+	cfg.InputConfig.StartAt = "beginning"
+	cfg.InputConfig.PollInterval = 10 * time.Millisecond
+	cfg.WatchObservers = []component.ID{observerID}
+	cfg.EndpointInclude = map[string][]string{
+		"pod": {filepath.Join(tempDir, "{{.namespace}}_{{.name}}", "*.log")},
+	}
+	require.NoError(t, cfg.Validate())
+
+	sink := new(consumertest.LogsSink)
+	rcvr, err := NewFactory().CreateLogsReceiver(context.Background(), receivertest.NewNopSettings(), cfg, sink)
+	require.NoError(t, err)
+
+	obs := &mockObserver{}
+	host := &mockHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{observerID: obs},
+	}
+	require.NoError(t, rcvr.Start(context.Background(), host))
+
+	podDir := filepath.Join(tempDir, "default_pod-1")
+	require.NoError(t, os.Mkdir(podDir, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(podDir, "0.log"), []byte("log from pod-1\n"), 0o600))
+
+	endpoint := observer.Endpoint{
+		ID: "k8s_observer/pod-1",
+		Details: &observer.Pod{
+			Name:      "pod-1",
+			UID:       "uid-1",
+			Namespace: "default",
+		},
+	}
+	require.NotNil(t, obs.subscriber())
+	obs.subscriber().OnAdd([]observer.Endpoint{endpoint})
+
+	require.Eventually(t, expectNLogs(sink, 1), 2*time.Second, 5*time.Millisecond)
+	rl := sink.AllLogs()[0].ResourceLogs().At(0)
+	assert.Equal(t, map[string]any{
+		"k8s.pod.name":       "pod-1",
+		"k8s.pod.uid":        "uid-1",
+		"k8s.namespace.name": "default",
+	}, rl.Resource().Attributes().AsRaw())
+	assert.Equal(t, "log from pod-1", rl.ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+
+	obs.subscriber().OnRemove([]observer.Endpoint{endpoint})
+	require.NoError(t, rcvr.Shutdown(context.Background()))
+	assert.Nil(t, obs.subscriber())
+}
+
+func TestObserverNotFound(t *testing.T) {
+	cfg := createDefaultConfig()
+	cfg.WatchObservers = []component.ID{component.MustNewID("k8s_observer")}
+
+	rcvr, err := NewFactory().CreateLogsReceiver(context.Background(), receivertest.NewNopSettings(), cfg, new(consumertest.LogsSink))
+	require.NoError(t, err)
+	err = rcvr.Start(context.Background(), componenttest.NewNopHost())
+	assert.EqualError(t, err, `failed to find observer "k8s_observer" in the extensions list`)
+	require.NoError(t, rcvr.Shutdown(context.Background()))
+}
@@ -4,9 +4,11 @@
 package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
 
 import (
+	"errors"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka"
@@ -37,6 +39,18 @@ type HeaderExtraction struct {
 	Headers        []string `mapstructure:"headers"`
 }
 
+// SchemaRegistry defines the schema registry from which the schemas of
+// Confluent-framed messages are fetched.
+type SchemaRegistry struct {
+	// Endpoint of the Confluent compatible schema registry, such as http://localhost:8081
+	Endpoint string `mapstructure:"endpoint"`
+	// Username and password used for basic authentication, if any
+	Username string              `mapstructure:"username"`
+	Password configopaque.String `mapstructure:"password"`
+	// Timeout of the requests made to the schema registry (default 10s)
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
 // Config defines configuration for Kafka receiver.
 type Config struct {
 	// The list of kafka brokers (default localhost:9092)
@@ -50,7 +64,8 @@ type Config struct {
 	ProtocolVersion string `mapstructure:"protocol_version"`
 	// The name of the kafka topic to consume from (default "otlp_spans" for traces, "otlp_metrics" for metrics, "otlp_logs" for logs)
 	Topic string `mapstructure:"topic"`
-	// Encoding of the messages (default "otlp_proto")
+	// Encoding of the messages (default "otlp_proto"). Either one of the built-in
+	// encodings or the ID of an encoding extension.
 	Encoding string `mapstructure:"encoding"`
 	// The consumer group that receiver will be consuming messages from (default "otel-collector")
 	GroupID string `mapstructure:"group_id"`
@@ -74,6 +89,9 @@ type Config struct {
 
 	// Extract headers from kafka records
 	HeaderExtraction HeaderExtraction `mapstructure:"header_extraction"`
+
+	// Schema registry used by the confluent_avro and confluent_protobuf encodings
+	SchemaRegistry SchemaRegistry `mapstructure:"schema_registry"`
 }
 
 const (
@@ -85,5 +103,8 @@ var _ component.Config = (*Config)(nil)
 
 // Validate checks the receiver configuration is valid
 func (cfg *Config) Validate() error {
+	if isConfluentEncoding(cfg.Encoding) && cfg.SchemaRegistry.Endpoint == "" {
+		return errors.New("schema_registry.endpoint must be set for the " + cfg.Encoding + " encoding")
+	}
 	return nil
 }
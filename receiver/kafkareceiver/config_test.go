@@ -60,6 +60,9 @@ func TestLoadConfig(t *testing.T) {
 					Enable:   true,
 					Interval: 1 * time.Second,
 				},
+				SchemaRegistry: SchemaRegistry{
+					Timeout: 10 * time.Second,
+				},
 			},
 		},
 		{
@@ -92,6 +95,37 @@ func TestLoadConfig(t *testing.T) {
 					Enable:   true,
 					Interval: 1 * time.Second,
 				},
+				SchemaRegistry: SchemaRegistry{
+					Timeout: 10 * time.Second,
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "avro"),
+			expected: &Config{
+				Topic:         "logins",
+				Encoding:      "confluent_avro",
+				Brokers:       []string{"localhost:9092"},
+				ClientID:      "otel-collector",
+				GroupID:       "otel-collector",
+				InitialOffset: "latest",
+				Metadata: kafkaexporter.Metadata{
+					Full: true,
+					Retry: kafkaexporter.MetadataRetry{
+						Max:     3,
+						Backoff: time.Millisecond * 250,
+					},
+				},
+				AutoCommit: AutoCommit{
+					Enable:   true,
+					Interval: 1 * time.Second,
+				},
+				SchemaRegistry: SchemaRegistry{
+					Endpoint: "http://localhost:8081",
+					Username: "registry",
+					Password: "secret",
+					Timeout:  5 * time.Second,
+				},
 			},
 		},
 	}
@@ -110,3 +144,14 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Encoding = confluentProtobufEncoding
+	assert.EqualError(t, cfg.Validate(), "schema_registry.endpoint must be set for the confluent_protobuf encoding")
+
+	cfg.SchemaRegistry.Endpoint = "http://localhost:8081"
+	assert.NoError(t, cfg.Validate())
+}
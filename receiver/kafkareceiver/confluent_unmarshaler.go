@@ -0,0 +1,312 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/linkedin/goavro/v2"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver/internal/schemaregistry"
+)
+
+const (
+	confluentAvroEncoding     = "confluent_avro"
+	confluentProtobufEncoding = "confluent_protobuf"
+
+	// confluentMagicByte starts every message of the Confluent wire format, followed by
+	// the schema ID as a 4-byte big-endian integer.
+	confluentMagicByte  = 0
+	confluentHeaderSize = 5
+
+	// protobufSchemaFile is the name under which a Protobuf schema is compiled.
+	protobufSchemaFile = "schema.proto"
+)
+
+var errInvalidConfluentMessage = errors.New("message is not in the Confluent wire format")
+
+// confluentDecoder decodes the payload following the Confluent header into a map.
+type confluentDecoder func([]byte) (map[string]any, error)
+
+// confluentLogsUnmarshaler unmarshals the messages produced with a Confluent serializer,
+// whose schema is fetched from a schema registry. Each message becomes a log record with
+// the decoded record as its body.
+type confluentLogsUnmarshaler struct {
+	encoding string
+	registry *schemaregistry.Client
+
+	mu       sync.Mutex
+	decoders map[int]confluentDecoder
+}
+
+var _ LogsUnmarshaler = (*confluentLogsUnmarshaler)(nil)
+
+func isConfluentEncoding(encoding string) bool {
+	return encoding == confluentAvroEncoding || encoding == confluentProtobufEncoding
+}
+
+func newConfluentLogsUnmarshaler(encoding string, cfg SchemaRegistry) *confluentLogsUnmarshaler {
+	return &confluentLogsUnmarshaler{
+		encoding: encoding,
+		registry: schemaregistry.NewClient(cfg.Endpoint, cfg.Username, string(cfg.Password), cfg.Timeout),
+		decoders: map[int]confluentDecoder{},
+	}
+}
+
+func (c *confluentLogsUnmarshaler) Unmarshal(buf []byte) (plog.Logs, error) {
+	p := plog.NewLogs()
+	if len(buf) < confluentHeaderSize || buf[0] != confluentMagicByte {
+		return p, errInvalidConfluentMessage
+	}
+	decode, err := c.decoder(int(binary.BigEndian.Uint32(buf[1:confluentHeaderSize])))
+	if err != nil {
+		return p, err
+	}
+	record, err := decode(buf[confluentHeaderSize:])
+	if err != nil {
+		return p, err
+	}
+
+	logRecord := p.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	logRecord.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+	if err := logRecord.Body().SetEmptyMap().FromRaw(record); err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+func (c *confluentLogsUnmarshaler) Encoding() string {
+	return c.encoding
+}
+
+// decoder returns the decoder of the schema with the given ID, creating it on first use.
+// The schema is fetched without holding the lock, so that the messages of known schemas are not
+// blocked by the registry.
+func (c *confluentLogsUnmarshaler) decoder(id int) (confluentDecoder, error) {
+	c.mu.Lock()
+	decode, ok := c.decoders[id]
+	c.mu.Unlock()
+	if ok {
+		return decode, nil
+	}
+
+	ctx := context.Background()
+	schema, err := c.registry.SchemaByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case c.encoding == confluentAvroEncoding && schema.Type == schemaregistry.TypeAvro:
+		decode, err = newAvroDecoder(schema)
+	case c.encoding == confluentProtobufEncoding && schema.Type == schemaregistry.TypeProtobuf:
+		decode, err = newProtobufDecoder(ctx, c.registry, schema)
+	default:
+		return nil, fmt.Errorf("schema %d is of type %s, which is not supported by the %s encoding", id, schema.Type, c.encoding)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema %d: %w", id, err)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// keep the decoder created by a concurrent fetch of the same schema, if any
+	if existing, ok := c.decoders[id]; ok {
+		return existing, nil
+	}
+	c.decoders[id] = decode
+	return decode, nil
+}
+
+func newAvroDecoder(schema schemaregistry.Schema) (confluentDecoder, error) {
+	if len(schema.References) > 0 {
+		return nil, errors.New("avro schema references are not supported")
+	}
+	codec, err := goavro.NewCodec(schema.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create avro codec: %w", err)
+	}
+	return func(buf []byte) (map[string]any, error) {
+		native, _, err := codec.NativeFromBinary(buf)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deserialize avro record: %w", err)
+		}
+		record, ok := native.(map[string]any)
+		if !ok {
+			// records of primitive schemas are wrapped so they can be set as a map body
+			record = map[string]any{"value": native}
+		}
+		replaceAvroLogicalTypes(record)
+		return record, nil
+	}, nil
+}
+
+// replaceAvroLogicalTypes replaces the values of logical types, which FromRaw does not support
+func replaceAvroLogicalTypes(m map[string]any) {
+	for k, v := range m {
+		m[k] = transformAvroValue(v)
+	}
+}
+
+func transformAvroValue(value any) any {
+	switch v := value.(type) {
+	case time.Time:
+		return v.UnixNano()
+	case time.Duration:
+		return v.Nanoseconds()
+	case *big.Rat:
+		f, _ := v.Float64()
+		return f
+	case map[string]any:
+		replaceAvroLogicalTypes(v)
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = transformAvroValue(item)
+		}
+		return v
+	}
+	return value
+}
+
+func newProtobufDecoder(ctx context.Context, registry *schemaregistry.Client, schema schemaregistry.Schema) (confluentDecoder, error) {
+	sources := map[string]string{protobufSchemaFile: schema.Schema}
+	if err := resolveProtobufReferences(ctx, registry, schema.References, sources); err != nil {
+		return nil, err
+	}
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{
+			Accessor: protocompile.SourceAccessorFromMap(sources),
+		}),
+	}
+	files, err := compiler.Compile(ctx, protobufSchemaFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile protobuf schema: %w", err)
+	}
+	file := files[0]
+
+	return func(buf []byte) (map[string]any, error) {
+		descriptor, payload, err := protobufMessageDescriptor(file, buf)
+		if err != nil {
+			return nil, err
+		}
+		msg := dynamicpb.NewMessage(descriptor)
+		if err := proto.Unmarshal(payload, msg); err != nil {
+			return nil, fmt.Errorf("failed to deserialize protobuf message: %w", err)
+		}
+		return protobufMessageToMap(msg), nil
+	}, nil
+}
+
+// resolveProtobufReferences fetches the schemas imported by a Protobuf schema, recursively.
+func resolveProtobufReferences(ctx context.Context, registry *schemaregistry.Client, references []schemaregistry.Reference, sources map[string]string) error {
+	for _, ref := range references {
+		if _, ok := sources[ref.Name]; ok {
+			continue
+		}
+		schema, err := registry.SchemaBySubject(ctx, ref.Subject, ref.Version)
+		if err != nil {
+			return err
+		}
+		sources[ref.Name] = schema.Schema
+		if err := resolveProtobufReferences(ctx, registry, schema.References, sources); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// protobufMessageDescriptor reads the message indexes which precede a Protobuf payload,
+// returning the descriptor of the message they point to and the payload itself.
+// The indexes are a zigzag varint count followed by as many zigzag varints, each of them
+// being the index of a message in the file or in the previous message. A single zero byte
+// is a shortcut for the first message of the file.
+func protobufMessageDescriptor(file protoreflect.FileDescriptor, buf []byte) (protoreflect.MessageDescriptor, []byte, error) {
+	count, n := binary.Varint(buf)
+	if n <= 0 || count < 0 {
+		return nil, nil, errors.New("invalid protobuf message indexes")
+	}
+	buf = buf[n:]
+	indexes := []int64{0}
+	if count > 0 {
+		indexes = make([]int64, count)
+		for i := range indexes {
+			if indexes[i], n = binary.Varint(buf); n <= 0 {
+				return nil, nil, errors.New("invalid protobuf message indexes")
+			}
+			buf = buf[n:]
+		}
+	}
+
+	messages := file.Messages()
+	var descriptor protoreflect.MessageDescriptor
+	for _, index := range indexes {
+		if index < 0 || index >= int64(messages.Len()) {
+			return nil, nil, fmt.Errorf("protobuf message index %d out of range", index)
+		}
+		descriptor = messages.Get(int(index))
+		messages = descriptor.Messages()
+	}
+	return descriptor, buf, nil
+}
+
+// protobufMessageToMap converts the populated fields of a message to a map keyed by field name.
+func protobufMessageToMap(msg protoreflect.Message) map[string]any {
+	m := map[string]any{}
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList():
+			list := v.List()
+			items := make([]any, list.Len())
+			for i := range items {
+				items[i] = protobufValue(fd, list.Get(i))
+			}
+			m[string(fd.Name())] = items
+		case fd.IsMap():
+			entries := map[string]any{}
+			v.Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+				entries[k.String()] = protobufValue(fd.MapValue(), v)
+				return true
+			})
+			m[string(fd.Name())] = entries
+		default:
+			m[string(fd.Name())] = protobufValue(fd, v)
+		}
+		return true
+	})
+	return m
+}
+
+func protobufValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) any {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return protobufMessageToMap(v.Message())
+	case protoreflect.EnumKind:
+		if value := fd.Enum().Values().ByNumber(v.Enum()); value != nil {
+			return string(value.Name())
+		}
+		return int64(v.Enum())
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return v.Int()
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return v.Uint()
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return v.Float()
+	default:
+		// bool, string and bytes
+		return v.Interface()
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkareceiver
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/linkedin/goavro/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const (
+	testAvroSchema = `{
+		"type": "record",
+		"name": "Login",
+		"fields": [
+			{"name": "user", "type": "string"},
+			{"name": "attempts", "type": "int"},
+			{"name": "tags", "type": {"type": "array", "items": "string"}},
+			{"name": "at", "type": {"type": "long", "logicalType": "timestamp-millis"}}
+		]
+	}`
+	testProtobufSchema = `syntax = "proto3";
+package test;
+
+import "common.proto";
+
+message Ignored {}
+
+message Envelope {
+  message Login {
+    string user = 1;
+    int64 attempts = 2;
+    repeated string tags = 3;
+    common.Status status = 4;
+    map<string, int32> counters = 5;
+    common.Device device = 6;
+  }
+}`
+	testProtobufCommonSchema = `syntax = "proto3";
+package common;
+
+enum Status {
+  UNKNOWN = 0;
+  ACTIVE = 1;
+}
+
+message Device {
+  string name = 1;
+}`
+)
+
+func newTestSchemaRegistry(t *testing.T) *httptest.Server {
+	schemas := map[string]map[string]any{
+		"/schemas/ids/1": {"schema": testAvroSchema},
+		"/schemas/ids/2": {
+			"schemaType": "PROTOBUF",
+			"schema":     testProtobufSchema,
+			"references": []map[string]any{{"name": "common.proto", "subject": "common", "version": 1}},
+		},
+		"/subjects/common/versions/1": {"schemaType": "PROTOBUF", "schema": testProtobufCommonSchema},
+		"/schemas/ids/3":              {"schemaType": "JSON", "schema": `{"type": "object"}`},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		schema, ok := schemas[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(schema))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func confluentMessage(id uint32, payload []byte) []byte {
+	buf := []byte{confluentMagicByte, 0, 0, 0, 0}
+	binary.BigEndian.PutUint32(buf[1:], id)
+	return append(buf, payload...)
+}
+
+func TestConfluentAvroUnmarshaler(t *testing.T) {
+	srv := newTestSchemaRegistry(t)
+	unmarshaler := newConfluentLogsUnmarshaler(confluentAvroEncoding, SchemaRegistry{Endpoint: srv.URL, Timeout: time.Second})
+	assert.Equal(t, confluentAvroEncoding, unmarshaler.Encoding())
+
+	codec, err := goavro.NewCodec(testAvroSchema)
+	require.NoError(t, err)
+	at := time.UnixMilli(1718000000000)
+	payload, err := codec.BinaryFromNative(nil, map[string]any{
+		"user":     "alice",
+		"attempts": 3,
+		"tags":     []any{"web", "mobile"},
+		"at":       at,
+	})
+	require.NoError(t, err)
+
+	logs, err := unmarshaler.Unmarshal(confluentMessage(1, payload))
+	require.NoError(t, err)
+	require.Equal(t, 1, logs.LogRecordCount())
+	assert.Equal(t, map[string]any{
+		"user":     "alice",
+		"attempts": int64(3),
+		"tags":     []any{"web", "mobile"},
+		"at":       at.UnixNano(),
+	}, logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Map().AsRaw())
+}
+
+func TestConfluentProtobufUnmarshaler(t *testing.T) {
+	srv := newTestSchemaRegistry(t)
+	unmarshaler := newConfluentLogsUnmarshaler(confluentProtobufEncoding, SchemaRegistry{Endpoint: srv.URL, Timeout: time.Second})
+
+	compiler := protocompile.Compiler{
+		Resolver: &protocompile.SourceResolver{
+			Accessor: protocompile.SourceAccessorFromMap(map[string]string{
+				"schema.proto": testProtobufSchema,
+				"common.proto": testProtobufCommonSchema,
+			}),
+		},
+	}
+	files, err := compiler.Compile(context.Background(), "schema.proto")
+	require.NoError(t, err)
+	descriptor := files[0].Messages().ByName("Envelope").Messages().ByName("Login")
+	msg := dynamicpb.NewMessage(descriptor)
+	fields := descriptor.Fields()
+	msg.Set(fields.ByName("user"), protoreflect.ValueOf("alice"))
+	msg.Set(fields.ByName("attempts"), protoreflect.ValueOf(int64(3)))
+	tags := msg.Mutable(fields.ByName("tags")).List()
+	tags.Append(protoreflect.ValueOf("web"))
+	tags.Append(protoreflect.ValueOf("mobile"))
+	msg.Set(fields.ByName("status"), protoreflect.ValueOfEnum(1))
+	counters := msg.Mutable(fields.ByName("counters")).Map()
+	counters.Set(protoreflect.ValueOf("errors").MapKey(), protoreflect.ValueOf(int32(2)))
+	device := msg.Mutable(fields.ByName("device")).Message()
+	device.Set(device.Descriptor().Fields().ByName("name"), protoreflect.ValueOf("phone"))
+	payload, err := proto.Marshal(msg)
+	require.NoError(t, err)
+
+	// the message indexes [1, 0] point to the first message nested in the second one of the file
+	indexes := binary.AppendVarint(binary.AppendVarint(binary.AppendVarint(nil, 2), 1), 0)
+	logs, err := unmarshaler.Unmarshal(confluentMessage(2, append(indexes, payload...)))
+	require.NoError(t, err)
+	require.Equal(t, 1, logs.LogRecordCount())
+	assert.Equal(t, map[string]any{
+		"user":     "alice",
+		"attempts": int64(3),
+		"tags":     []any{"web", "mobile"},
+		"status":   "ACTIVE",
+		"counters": map[string]any{"errors": int64(2)},
+		"device":   map[string]any{"name": "phone"},
+	}, logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Map().AsRaw())
+}
+
+func TestConfluentUnmarshalerErrors(t *testing.T) {
+	srv := newTestSchemaRegistry(t)
+	for _, tt := range []struct {
+		name        string
+		encoding    string
+		message     []byte
+		expectedErr string
+	}{
+		{
+			name:        "no header",
+			encoding:    confluentAvroEncoding,
+			message:     []byte{0, 1},
+			expectedErr: errInvalidConfluentMessage.Error(),
+		},
+		{
+			name:        "wrong magic byte",
+			encoding:    confluentAvroEncoding,
+			message:     []byte{1, 0, 0, 0, 1, 2},
+			expectedErr: errInvalidConfluentMessage.Error(),
+		},
+		{
+			name:        "unknown schema",
+			encoding:    confluentAvroEncoding,
+			message:     confluentMessage(9, nil),
+			expectedErr: "failed to fetch schema 9: unexpected status 404",
+		},
+		{
+			name:        "schema type mismatch",
+			encoding:    confluentAvroEncoding,
+			message:     confluentMessage(2, nil),
+			expectedErr: "schema 2 is of type PROTOBUF, which is not supported by the confluent_avro encoding",
+		},
+		{
+			name:        "unsupported schema type",
+			encoding:    confluentProtobufEncoding,
+			message:     confluentMessage(3, nil),
+			expectedErr: "schema 3 is of type JSON, which is not supported by the confluent_protobuf encoding",
+		},
+		{
+			name:        "invalid avro record",
+			encoding:    confluentAvroEncoding,
+			message:     confluentMessage(1, []byte{1}),
+			expectedErr: "failed to deserialize avro record",
+		},
+		{
+			name:        "message index out of range",
+			encoding:    confluentProtobufEncoding,
+			message:     confluentMessage(2, binary.AppendVarint(binary.AppendVarint(nil, 1), 5)),
+			expectedErr: "protobuf message index 5 out of range",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			unmarshaler := newConfluentLogsUnmarshaler(tt.encoding, SchemaRegistry{Endpoint: srv.URL, Timeout: time.Second})
+			_, err := unmarshaler.Unmarshal(tt.message)
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
+
+import (
+	"fmt"
+	"reflect"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// loadEncodingExtension returns the extension whose ID is the given encoding, if there is one.
+// It fails if the extension does not implement the unmarshaler of the signal.
+func loadEncodingExtension[T any](host component.Host, encoding string) (T, bool, error) {
+	var unmarshaler T
+	var id component.ID
+	if err := id.UnmarshalText([]byte(encoding)); err != nil {
+		return unmarshaler, false, nil
+	}
+	ext, ok := host.GetExtensions()[id]
+	if !ok {
+		return unmarshaler, false, nil
+	}
+	unmarshaler, ok = ext.(T)
+	if !ok {
+		return unmarshaler, false, fmt.Errorf("extension %q is not a %s", encoding, reflect.TypeOf((*T)(nil)).Elem())
+	}
+	return unmarshaler, true, nil
+}
+
+// tracesEncodingUnmarshaler adapts a traces encoding extension to TracesUnmarshaler.
+type tracesEncodingUnmarshaler struct {
+	unmarshaler ptrace.Unmarshaler
+	encoding    string
+}
+
+func (t *tracesEncodingUnmarshaler) Unmarshal(data []byte) (ptrace.Traces, error) {
+	return t.unmarshaler.UnmarshalTraces(data)
+}
+
+func (t *tracesEncodingUnmarshaler) Encoding() string {
+	return t.encoding
+}
+
+// metricsEncodingUnmarshaler adapts a metrics encoding extension to MetricsUnmarshaler.
+type metricsEncodingUnmarshaler struct {
+	unmarshaler pmetric.Unmarshaler
+	encoding    string
+}
+
+func (m *metricsEncodingUnmarshaler) Unmarshal(data []byte) (pmetric.Metrics, error) {
+	return m.unmarshaler.UnmarshalMetrics(data)
+}
+
+func (m *metricsEncodingUnmarshaler) Encoding() string {
+	return m.encoding
+}
+
+// logsEncodingUnmarshaler adapts a logs encoding extension to LogsUnmarshaler.
+type logsEncodingUnmarshaler struct {
+	unmarshaler plog.Unmarshaler
+	encoding    string
+}
+
+func (l *logsEncodingUnmarshaler) Unmarshal(data []byte) (plog.Logs, error) {
+	return l.unmarshaler.UnmarshalLogs(data)
+}
+
+func (l *logsEncodingUnmarshaler) Encoding() string {
+	return l.encoding
+}
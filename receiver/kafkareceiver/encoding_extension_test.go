@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkareceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+)
+
+type hostWithExtensions struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h hostWithExtensions) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+type nopExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+// testEncodingExtension unmarshals every message into a single span, data point or log record
+// whose name or body is the message.
+type testEncodingExtension struct {
+	nopExtension
+}
+
+func (testEncodingExtension) UnmarshalTraces(buf []byte) (ptrace.Traces, error) {
+	traces := ptrace.NewTraces()
+	traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName(string(buf))
+	return traces, nil
+}
+
+func (testEncodingExtension) UnmarshalMetrics(buf []byte) (pmetric.Metrics, error) {
+	metrics := pmetric.NewMetrics()
+	metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName(string(buf))
+	return metrics, nil
+}
+
+func (testEncodingExtension) UnmarshalLogs(buf []byte) (plog.Logs, error) {
+	logs := plog.NewLogs()
+	logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(string(buf))
+	return logs, nil
+}
+
+func newHostWithEncodingExtensions() component.Host {
+	return hostWithExtensions{
+		extensions: map[component.ID]component.Component{
+			component.MustNewIDWithName("test_encoding", "custom"): testEncodingExtension{},
+			component.MustNewID("otlp_proto"):                      testEncodingExtension{},
+			component.MustNewID("nop"):                             nopExtension{},
+		},
+	}
+}
+
+func TestLoadEncodingExtension(t *testing.T) {
+	host := newHostWithEncodingExtensions()
+
+	unmarshaler, ok, err := loadEncodingExtension[plog.Unmarshaler](host, "test_encoding/custom")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, testEncodingExtension{}, unmarshaler)
+
+	_, ok, err = loadEncodingExtension[plog.Unmarshaler](host, "test_encoding/missing")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, ok, err = loadEncodingExtension[plog.Unmarshaler](host, "/invalid")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, ok, err = loadEncodingExtension[plog.Unmarshaler](host, "nop")
+	assert.EqualError(t, err, `extension "nop" is not a plog.Unmarshaler`)
+	assert.False(t, ok)
+}
+
+func TestReceiversUseEncodingExtension(t *testing.T) {
+	host := newHostWithEncodingExtensions()
+	cfg := *createDefaultConfig().(*Config)
+	cfg.Encoding = "test_encoding/custom"
+
+	traces, err := newTracesReceiver(cfg, receivertest.NewNopSettings(), nil, consumertest.NewNop())
+	require.NoError(t, err)
+	traces.consumerGroup = &testConsumerGroup{}
+	require.NoError(t, traces.Start(context.Background(), host))
+	defer func() { require.NoError(t, traces.Shutdown(context.Background())) }()
+	assert.Equal(t, "test_encoding/custom", traces.unmarshaler.Encoding())
+	td, err := traces.unmarshaler.Unmarshal([]byte("span"))
+	require.NoError(t, err)
+	assert.Equal(t, "span", td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+
+	metrics, err := newMetricsReceiver(cfg, receivertest.NewNopSettings(), nil, consumertest.NewNop())
+	require.NoError(t, err)
+	metrics.consumerGroup = &testConsumerGroup{}
+	require.NoError(t, metrics.Start(context.Background(), host))
+	defer func() { require.NoError(t, metrics.Shutdown(context.Background())) }()
+	assert.Equal(t, "test_encoding/custom", metrics.unmarshaler.Encoding())
+	md, err := metrics.unmarshaler.Unmarshal([]byte("metric"))
+	require.NoError(t, err)
+	assert.Equal(t, "metric", md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Name())
+
+	logs, err := newLogsReceiver(cfg, receivertest.NewNopSettings(), nil, consumertest.NewNop())
+	require.NoError(t, err)
+	logs.consumerGroup = &testConsumerGroup{}
+	require.NoError(t, logs.Start(context.Background(), host))
+	defer func() { require.NoError(t, logs.Shutdown(context.Background())) }()
+	assert.Equal(t, "test_encoding/custom", logs.unmarshaler.Encoding())
+	ld, err := logs.unmarshaler.Unmarshal([]byte("log"))
+	require.NoError(t, err)
+	assert.Equal(t, "log", ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+}
+
+func TestEncodingExtensionTakesPrecedence(t *testing.T) {
+	cfg := *createDefaultConfig().(*Config)
+	r, err := newLogsReceiver(cfg, receivertest.NewNopSettings(), defaultLogsUnmarshalers("Test Version", zap.NewNop())[defaultEncoding], consumertest.NewNop())
+	require.NoError(t, err)
+	r.consumerGroup = &testConsumerGroup{}
+	require.NoError(t, r.Start(context.Background(), newHostWithEncodingExtensions()))
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+	assert.IsType(t, &logsEncodingUnmarshaler{}, r.unmarshaler)
+}
+
+func TestEncodingExtensionOfWrongSignal(t *testing.T) {
+	cfg := *createDefaultConfig().(*Config)
+	cfg.Encoding = "nop"
+	r, err := newTracesReceiver(cfg, receivertest.NewNopSettings(), nil, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.EqualError(t, r.Start(context.Background(), newHostWithEncodingExtensions()), `extension "nop" is not a ptrace.Unmarshaler`)
+}
@@ -39,6 +39,8 @@ const (
 	defaultAutoCommitEnable = true
 	// default from sarama.NewConfig()
 	defaultAutoCommitInterval = 1 * time.Second
+
+	defaultSchemaRegistryTimeout = 10 * time.Second
 )
 
 var errUnrecognizedEncoding = fmt.Errorf("unrecognized encoding")
@@ -119,6 +121,9 @@ func createDefaultConfig() component.Config {
 		HeaderExtraction: HeaderExtraction{
 			ExtractHeaders: false,
 		},
+		SchemaRegistry: SchemaRegistry{
+			Timeout: defaultSchemaRegistryTimeout,
+		},
 	}
 }
 
@@ -142,8 +147,12 @@ func (f *kafkaReceiverFactory) createTracesReceiver(
 	if oCfg.Topic == "" {
 		oCfg.Topic = defaultTracesTopic
 	}
+	if isConfluentEncoding(oCfg.Encoding) {
+		return nil, fmt.Errorf("the %s encoding is only supported for logs", oCfg.Encoding)
+	}
+	// the unmarshaler is nil when the encoding refers to an extension, which is loaded on start
 	unmarshaler := f.tracesUnmarshalers[oCfg.Encoding]
-	if unmarshaler == nil {
+	if unmarshaler == nil && !isEncodingExtension(oCfg.Encoding) {
 		return nil, errUnrecognizedEncoding
 	}
 
@@ -168,8 +177,12 @@ func (f *kafkaReceiverFactory) createMetricsReceiver(
 	if oCfg.Topic == "" {
 		oCfg.Topic = defaultMetricsTopic
 	}
+	if isConfluentEncoding(oCfg.Encoding) {
+		return nil, fmt.Errorf("the %s encoding is only supported for logs", oCfg.Encoding)
+	}
+	// the unmarshaler is nil when the encoding refers to an extension, which is loaded on start
 	unmarshaler := f.metricsUnmarshalers[oCfg.Encoding]
-	if unmarshaler == nil {
+	if unmarshaler == nil && !isEncodingExtension(oCfg.Encoding) {
 		return nil, errUnrecognizedEncoding
 	}
 
@@ -194,9 +207,16 @@ func (f *kafkaReceiverFactory) createLogsReceiver(
 	if oCfg.Topic == "" {
 		oCfg.Topic = defaultLogsTopic
 	}
-	unmarshaler, err := getLogsUnmarshaler(oCfg.Encoding, f.logsUnmarshalers)
-	if err != nil {
-		return nil, err
+	var unmarshaler LogsUnmarshaler
+	if isConfluentEncoding(oCfg.Encoding) {
+		unmarshaler = newConfluentLogsUnmarshaler(oCfg.Encoding, oCfg.SchemaRegistry)
+	} else {
+		var err error
+		unmarshaler, err = getLogsUnmarshaler(oCfg.Encoding, f.logsUnmarshalers)
+		// the unmarshaler is nil when the encoding refers to an extension, which is loaded on start
+		if err != nil && !isEncodingExtension(oCfg.Encoding) {
+			return nil, err
+		}
 	}
 
 	r, err := newLogsReceiver(oCfg, set, unmarshaler, nextConsumer)
@@ -206,6 +226,13 @@ func (f *kafkaReceiverFactory) createLogsReceiver(
 	return r, nil
 }
 
+// isEncodingExtension returns true when the encoding is the ID of an encoding extension, whose type
+// ends with _encoding
+func isEncodingExtension(encoding string) bool {
+	var id component.ID
+	return id.UnmarshalText([]byte(encoding)) == nil && strings.HasSuffix(id.Type().String(), "_encoding")
+}
+
 func getLogsUnmarshaler(encoding string, unmarshalers map[string]LogsUnmarshaler) (LogsUnmarshaler, error) {
 	var enc string
 	unmarshaler, ok := unmarshalers[encoding]
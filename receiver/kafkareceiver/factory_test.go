@@ -117,6 +117,42 @@ func TestCreateLogsReceiver(t *testing.T) {
 	require.Error(t, r.Start(context.Background(), componenttest.NewNopHost()))
 }
 
+func TestCreateReceiverEncoding(t *testing.T) {
+	f := NewFactory()
+	cfg := createDefaultConfig().(*Config)
+	// disable contacting broker
+	cfg.Metadata.Full = false
+	cfg.ProtocolVersion = "2.0.0"
+	cfg.SchemaRegistry.Endpoint = "http://localhost:8081"
+
+	cfg.Encoding = confluentAvroEncoding
+	_, err := f.CreateTracesReceiver(context.Background(), receivertest.NewNopSettings(), cfg, nil)
+	assert.EqualError(t, err, "the confluent_avro encoding is only supported for logs")
+	_, err = f.CreateMetricsReceiver(context.Background(), receivertest.NewNopSettings(), cfg, nil)
+	assert.EqualError(t, err, "the confluent_avro encoding is only supported for logs")
+	_, err = f.CreateLogsReceiver(context.Background(), receivertest.NewNopSettings(), cfg, nil)
+	assert.NoError(t, err)
+
+	cfg.Encoding = "foo"
+	_, err = f.CreateTracesReceiver(context.Background(), receivertest.NewNopSettings(), cfg, nil)
+	assert.ErrorIs(t, err, errUnrecognizedEncoding)
+	_, err = f.CreateMetricsReceiver(context.Background(), receivertest.NewNopSettings(), cfg, nil)
+	assert.ErrorIs(t, err, errUnrecognizedEncoding)
+	_, err = f.CreateLogsReceiver(context.Background(), receivertest.NewNopSettings(), cfg, nil)
+	assert.ErrorIs(t, err, errUnrecognizedEncoding)
+
+	// encoding extensions are only loaded on start
+	for _, encoding := range []string{"otlp_encoding/custom", "text_encoding"} {
+		cfg.Encoding = encoding
+		_, err = f.CreateTracesReceiver(context.Background(), receivertest.NewNopSettings(), cfg, nil)
+		assert.NoError(t, err)
+		_, err = f.CreateMetricsReceiver(context.Background(), receivertest.NewNopSettings(), cfg, nil)
+		assert.NoError(t, err)
+		_, err = f.CreateLogsReceiver(context.Background(), receivertest.NewNopSettings(), cfg, nil)
+		assert.NoError(t, err)
+	}
+}
+
 func TestGetLogsUnmarshaler_encoding_text_error(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package schemaregistry implements a client for Confluent compatible schema registries.
+package schemaregistry // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver/internal/schemaregistry"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The types of schemas supported by the registry.
+const (
+	TypeAvro     = "AVRO"
+	TypeProtobuf = "PROTOBUF"
+	TypeJSON     = "JSON"
+)
+
+// Schema is a schema stored in the registry.
+type Schema struct {
+	// Type of the schema, one of TypeAvro, TypeProtobuf or TypeJSON.
+	Type string `json:"schemaType"`
+	// Schema is the textual definition of the schema.
+	Schema string `json:"schema"`
+	// References are the other schemas imported by this one.
+	References []Reference `json:"references"`
+}
+
+// Reference points to the version of a subject imported by a schema under the given name.
+type Reference struct {
+	Name    string `json:"name"`
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+// Client fetches schemas from a schema registry. As the schema of an ID never changes,
+// the schemas fetched by ID are cached for the lifetime of the client.
+type Client struct {
+	endpoint   string
+	username   string
+	password   string
+	httpClient *http.Client
+
+	mu      sync.Mutex
+	schemas map[int]Schema
+}
+
+// NewClient returns a client of the schema registry served at the given endpoint, such as
+// http://localhost:8081. Basic authentication is used when the username is not empty.
+func NewClient(endpoint string, username string, password string, timeout time.Duration) *Client {
+	return &Client{
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		username:   username,
+		password:   password,
+		httpClient: &http.Client{Timeout: timeout},
+		schemas:    map[int]Schema{},
+	}
+}
+
+// SchemaByID returns the schema registered with the given ID.
+func (c *Client) SchemaByID(ctx context.Context, id int) (Schema, error) {
+	c.mu.Lock()
+	schema, ok := c.schemas[id]
+	c.mu.Unlock()
+	if ok {
+		return schema, nil
+	}
+
+	if err := c.get(ctx, "/schemas/ids/"+strconv.Itoa(id), &schema); err != nil {
+		return Schema{}, fmt.Errorf("failed to fetch schema %d: %w", id, err)
+	}
+	schema = normalize(schema)
+
+	c.mu.Lock()
+	c.schemas[id] = schema
+	c.mu.Unlock()
+	return schema, nil
+}
+
+// SchemaBySubject returns the given version of the schema registered under a subject.
+func (c *Client) SchemaBySubject(ctx context.Context, subject string, version int) (Schema, error) {
+	var schema Schema
+	path := "/subjects/" + url.PathEscape(subject) + "/versions/" + strconv.Itoa(version)
+	if err := c.get(ctx, path, &schema); err != nil {
+		return Schema{}, fmt.Errorf("failed to fetch version %d of subject %q: %w", version, subject, err)
+	}
+	return normalize(schema), nil
+}
+
+func (c *Client) get(ctx context.Context, path string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.schemaregistry.v1+json")
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return json.Unmarshal(body, v)
+}
+
+// normalize sets the type of the schema, which the registry omits for Avro schemas.
+func normalize(schema Schema) Schema {
+	if schema.Type == "" {
+		schema.Type = TypeAvro
+	}
+	return schema
+}
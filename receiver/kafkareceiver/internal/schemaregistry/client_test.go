@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaregistry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRegistry(t *testing.T, requests *atomic.Int64) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/schemas/ids/1", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		user, password, ok := r.BasicAuth()
+		if !ok || user != "user" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"schema": "\"string\""}`))
+	})
+	mux.HandleFunc("/schemas/ids/2", func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`{"schemaType": "PROTOBUF", "schema": "syntax = \"proto3\";", "references": [{"name": "other.proto", "subject": "other", "version": 3}]}`))
+	})
+	mux.HandleFunc("/subjects/other/versions/3", func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`{"subject": "other", "version": 3, "id": 4, "schemaType": "PROTOBUF", "schema": "syntax = \"proto3\";"}`))
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error_code": 40403, "message": "Schema not found"}`))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestSchemaByID(t *testing.T) {
+	requests := &atomic.Int64{}
+	srv := newTestRegistry(t, requests)
+	client := NewClient(srv.URL+"/", "user", "secret", time.Second)
+
+	for i := 0; i < 2; i++ {
+		schema, err := client.SchemaByID(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, Schema{Type: TypeAvro, Schema: `"string"`}, schema)
+	}
+	assert.Equal(t, int64(1), requests.Load(), "the schema should be cached")
+
+	schema, err := client.SchemaByID(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, Schema{
+		Type:       TypeProtobuf,
+		Schema:     `syntax = "proto3";`,
+		References: []Reference{{Name: "other.proto", Subject: "other", Version: 3}},
+	}, schema)
+}
+
+func TestSchemaByIDErrors(t *testing.T) {
+	srv := newTestRegistry(t, &atomic.Int64{})
+
+	_, err := NewClient(srv.URL, "user", "wrong", time.Second).SchemaByID(context.Background(), 1)
+	assert.EqualError(t, err, "failed to fetch schema 1: unexpected status 401: ")
+
+	_, err = NewClient(srv.URL, "", "", time.Second).SchemaByID(context.Background(), 5)
+	assert.EqualError(t, err, `failed to fetch schema 5: unexpected status 404: {"error_code": 40403, "message": "Schema not found"}`)
+}
+
+func TestSchemaBySubject(t *testing.T) {
+	srv := newTestRegistry(t, &atomic.Int64{})
+	client := NewClient(srv.URL, "", "", time.Second)
+
+	schema, err := client.SchemaBySubject(context.Background(), "other", 3)
+	require.NoError(t, err)
+	assert.Equal(t, Schema{Type: TypeProtobuf, Schema: `syntax = "proto3";`}, schema)
+
+	_, err = client.SchemaBySubject(context.Background(), "other", 4)
+	assert.ErrorContains(t, err, `failed to fetch version 4 of subject "other": unexpected status 404`)
+}
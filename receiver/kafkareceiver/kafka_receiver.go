@@ -15,6 +15,9 @@ import (
 	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 	"go.uber.org/zap"
@@ -84,10 +87,6 @@ var _ receiver.Metrics = (*kafkaMetricsConsumer)(nil)
 var _ receiver.Logs = (*kafkaLogsConsumer)(nil)
 
 func newTracesReceiver(config Config, set receiver.Settings, unmarshaler TracesUnmarshaler, nextConsumer consumer.Traces) (*kafkaTracesConsumer, error) {
-	if unmarshaler == nil {
-		return nil, errUnrecognizedEncoding
-	}
-
 	return &kafkaTracesConsumer{
 		config:            config,
 		topics:            []string{config.Topic},
@@ -127,7 +126,19 @@ func createKafkaClient(config Config) (sarama.ConsumerGroup, error) {
 	return sarama.NewConsumerGroup(config.Brokers, config.GroupID, saramaConfig)
 }
 
-func (c *kafkaTracesConsumer) Start(_ context.Context, _ component.Host) error {
+func (c *kafkaTracesConsumer) Start(_ context.Context, host component.Host) error {
+	// encoding extensions take precedence over the built-in encodings
+	unmarshaler, ok, err := loadEncodingExtension[ptrace.Unmarshaler](host, c.config.Encoding)
+	if err != nil {
+		return err
+	}
+	if ok {
+		c.unmarshaler = &tracesEncodingUnmarshaler{unmarshaler: unmarshaler, encoding: c.config.Encoding}
+	}
+	if c.unmarshaler == nil {
+		return errUnrecognizedEncoding
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	c.cancelConsumeLoop = cancel
 	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
@@ -197,10 +208,6 @@ func (c *kafkaTracesConsumer) Shutdown(context.Context) error {
 }
 
 func newMetricsReceiver(config Config, set receiver.Settings, unmarshaler MetricsUnmarshaler, nextConsumer consumer.Metrics) (*kafkaMetricsConsumer, error) {
-	if unmarshaler == nil {
-		return nil, errUnrecognizedEncoding
-	}
-
 	return &kafkaMetricsConsumer{
 		config:            config,
 		topics:            []string{config.Topic},
@@ -214,7 +221,19 @@ func newMetricsReceiver(config Config, set receiver.Settings, unmarshaler Metric
 	}, nil
 }
 
-func (c *kafkaMetricsConsumer) Start(_ context.Context, _ component.Host) error {
+func (c *kafkaMetricsConsumer) Start(_ context.Context, host component.Host) error {
+	// encoding extensions take precedence over the built-in encodings
+	unmarshaler, ok, err := loadEncodingExtension[pmetric.Unmarshaler](host, c.config.Encoding)
+	if err != nil {
+		return err
+	}
+	if ok {
+		c.unmarshaler = &metricsEncodingUnmarshaler{unmarshaler: unmarshaler, encoding: c.config.Encoding}
+	}
+	if c.unmarshaler == nil {
+		return errUnrecognizedEncoding
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	c.cancelConsumeLoop = cancel
 	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
@@ -284,10 +303,6 @@ func (c *kafkaMetricsConsumer) Shutdown(context.Context) error {
 }
 
 func newLogsReceiver(config Config, set receiver.Settings, unmarshaler LogsUnmarshaler, nextConsumer consumer.Logs) (*kafkaLogsConsumer, error) {
-	if unmarshaler == nil {
-		return nil, errUnrecognizedEncoding
-	}
-
 	return &kafkaLogsConsumer{
 		config:            config,
 		topics:            []string{config.Topic},
@@ -301,7 +316,19 @@ func newLogsReceiver(config Config, set receiver.Settings, unmarshaler LogsUnmar
 	}, nil
 }
 
-func (c *kafkaLogsConsumer) Start(_ context.Context, _ component.Host) error {
+func (c *kafkaLogsConsumer) Start(_ context.Context, host component.Host) error {
+	// encoding extensions take precedence over the built-in encodings
+	unmarshaler, ok, err := loadEncodingExtension[plog.Unmarshaler](host, c.config.Encoding)
+	if err != nil {
+		return err
+	}
+	if ok {
+		c.unmarshaler = &logsEncodingUnmarshaler{unmarshaler: unmarshaler, encoding: c.config.Encoding}
+	}
+	if c.unmarshaler == nil {
+		return errUnrecognizedEncoding
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	c.cancelConsumeLoop = cancel
 	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
@@ -50,8 +50,9 @@ func TestNewTracesReceiver_encoding_err(t *testing.T) {
 	}
 	unmarshaler := defaultTracesUnmarshalers()[c.Encoding]
 	r, err := newTracesReceiver(c, receivertest.NewNopSettings(), unmarshaler, consumertest.NewNop())
-	require.Error(t, err)
-	assert.Nil(t, r)
+	require.NoError(t, err)
+	// the encoding could be an extension, which is only known on start
+	err = r.Start(context.Background(), componenttest.NewNopHost())
 	assert.EqualError(t, err, errUnrecognizedEncoding.Error())
 }
 
@@ -95,6 +96,7 @@ func TestTracesReceiverStart(t *testing.T) {
 		nextConsumer:  consumertest.NewNop(),
 		settings:      receivertest.NewNopSettings(),
 		consumerGroup: &testConsumerGroup{},
+		unmarshaler:   defaultTracesUnmarshalers()[defaultEncoding],
 	}
 
 	require.NoError(t, c.Start(context.Background(), componenttest.NewNopHost()))
@@ -127,6 +129,7 @@ func TestTracesReceiver_error(t *testing.T) {
 		nextConsumer:  consumertest.NewNop(),
 		settings:      settings,
 		consumerGroup: &testConsumerGroup{err: expectedErr},
+		unmarshaler:   defaultTracesUnmarshalers()[defaultEncoding],
 	}
 
 	require.NoError(t, c.Start(context.Background(), componenttest.NewNopHost()))
@@ -316,8 +319,10 @@ func TestNewMetricsReceiver_encoding_err(t *testing.T) {
 		Encoding: "foo",
 	}
 	unmarshaler := defaultMetricsUnmarshalers()[c.Encoding]
-	_, err := newMetricsReceiver(c, receivertest.NewNopSettings(), unmarshaler, consumertest.NewNop())
-	require.Error(t, err)
+	r, err := newMetricsReceiver(c, receivertest.NewNopSettings(), unmarshaler, consumertest.NewNop())
+	require.NoError(t, err)
+	// the encoding could be an extension, which is only known on start
+	err = r.Start(context.Background(), componenttest.NewNopHost())
 	assert.EqualError(t, err, errUnrecognizedEncoding.Error())
 }
 
@@ -383,6 +388,7 @@ func TestMetricsReceiver_error(t *testing.T) {
 		nextConsumer:  consumertest.NewNop(),
 		settings:      settings,
 		consumerGroup: &testConsumerGroup{err: expectedErr},
+		unmarshaler:   defaultMetricsUnmarshalers()[defaultEncoding],
 	}
 
 	require.NoError(t, c.Start(context.Background(), componenttest.NewNopHost()))
@@ -571,8 +577,9 @@ func TestNewLogsReceiver_encoding_err(t *testing.T) {
 	}
 	unmarshaler := defaultLogsUnmarshalers("Test Version", zap.NewNop())[c.Encoding]
 	r, err := newLogsReceiver(c, receivertest.NewNopSettings(), unmarshaler, consumertest.NewNop())
-	require.Error(t, err)
-	assert.Nil(t, r)
+	require.NoError(t, err)
+	// the encoding could be an extension, which is only known on start
+	err = r.Start(context.Background(), componenttest.NewNopHost())
 	assert.EqualError(t, err, errUnrecognizedEncoding.Error())
 }
 
@@ -617,6 +624,7 @@ func TestLogsReceiverStart(t *testing.T) {
 		nextConsumer:  consumertest.NewNop(),
 		settings:      receivertest.NewNopSettings(),
 		consumerGroup: &testConsumerGroup{},
+		unmarshaler:   defaultLogsUnmarshalers("Test Version", zap.NewNop())[defaultEncoding],
 	}
 
 	require.NoError(t, c.Start(context.Background(), componenttest.NewNopHost()))
@@ -650,6 +658,7 @@ func TestLogsReceiver_error(t *testing.T) {
 		settings:      settings,
 		consumerGroup: &testConsumerGroup{err: expectedErr},
 		config:        *createDefaultConfig().(*Config),
+		unmarshaler:   defaultLogsUnmarshalers("Test Version", zap.NewNop())[defaultEncoding],
 	}
 
 	require.NoError(t, c.Start(context.Background(), componenttest.NewNopHost()))
@@ -935,10 +944,10 @@ func TestCreateLogsReceiver_encoding_text_error(t *testing.T) {
 	cfg := Config{
 		Encoding: "text_uft-8",
 	}
-	unmarshaler := defaultLogsUnmarshalers("Test Version", zap.NewNop())[cfg.Encoding]
-	_, err := newLogsReceiver(cfg, receivertest.NewNopSettings(), unmarshaler, consumertest.NewNop())
+	f := kafkaReceiverFactory{logsUnmarshalers: map[string]LogsUnmarshaler{}}
+	_, err := f.createLogsReceiver(context.Background(), receivertest.NewNopSettings(), &cfg, consumertest.NewNop())
 	// encoding error comes first
-	assert.Error(t, err, "unsupported encoding")
+	assert.ErrorContains(t, err, "unsupported encoding")
 }
 
 func TestToSaramaInitialOffset_earliest(t *testing.T) {
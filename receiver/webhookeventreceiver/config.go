@@ -7,15 +7,28 @@ import (
 	"errors"
 	"time"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.uber.org/multierr"
 )
 
 var (
 	errMissingEndpointFromConfig   = errors.New("missing receiver server endpoint from config")
+	errNegativeMaxRequestBodySize  = errors.New("max_request_body_size must not be negative")
 	errReadTimeoutExceedsMaxValue  = errors.New("The duration specified for read_timeout exceeds the maximum allowed value of 10s")
 	errWriteTimeoutExceedsMaxValue = errors.New("The duration specified for write_timeout exceeds the maximum allowed value of 10s")
 	errRequiredHeader              = errors.New("both key and value are required to assign a required_header")
+	errInvalidSignatureProvider    = errors.New("signature provider must be one of github, stripe, slack or generic")
+	errMissingSignatureSecret      = errors.New("a secret is required to verify signatures")
+	errMissingSignatureHeader      = errors.New("a header is required to verify generic signatures")
+	errInvalidSignatureAlgorithm   = errors.New("signature algorithm must be one of sha1, sha256 or sha512")
+	errInvalidSignatureEncoding    = errors.New("signature encoding must be either hex or base64")
+	errNegativeSignatureMaxAge     = errors.New("signature max_age must not be negative")
+	errInvalidSplitMode            = errors.New("split_mode must be either line or json")
+	errJSONAttributesWithoutJSON   = errors.New("json_attributes requires the json split_mode")
+	errOTLPPathWithoutExtension    = errors.New("ack otlp_path requires an ack extension")
+	errOTLPPathConflict            = errors.New("ack otlp_path must differ from path and health_path")
 )
 
 // Config defines configuration for the Generic Webhook receiver.
@@ -26,6 +39,44 @@ type Config struct {
 	Path                    string                   `mapstructure:"path"`            // path for data collection. Default is /events
 	HealthPath              string                   `mapstructure:"health_path"`     // path for health check api. Default is /health_check
 	RequiredHeader          RequiredHeader           `mapstructure:"required_header"` // optional setting to set a required header for all requests to have
+	Ack                     Ack                      `mapstructure:"ack"`             // optional setting to acknowledge the events once exported
+	Signature               SignatureConfig          `mapstructure:"signature"`       // optional setting to verify the HMAC signature of the requests
+	SplitMode               string                   `mapstructure:"split_mode"`      // how the payload is split into log records, line or json. Default is line
+	JSONAttributes          []string                 `mapstructure:"json_attributes"` // fields of the json records promoted to log record attributes
+}
+
+// Ack defines configuration for the end-to-end acknowledgement of the events
+type Ack struct {
+	// Extension defines the ack extension the ack IDs of the requests are generated by. Requests holding the
+	// X-Ack-Partition header are given an ack ID, only acknowledged once the events were successfully consumed.
+	Extension *component.ID `mapstructure:"extension"`
+	// OTLPPath is the path of an OTLP/HTTP logs endpoint served next to the events one, for the OTLP clients to
+	// acknowledge their logs end-to-end. Disabled if empty.
+	OTLPPath string `mapstructure:"otlp_path"`
+}
+
+// SignatureConfig defines how the HMAC signature of the requests is verified. Requests with a missing or invalid
+// signature, or a timestamp outside of the accepted window, are rejected.
+type SignatureConfig struct {
+	// Provider is the webhook provider the signature scheme is taken from: github, stripe, slack or generic.
+	// Signatures aren't verified if empty.
+	Provider string `mapstructure:"provider"`
+	// Secret is the key the payloads are signed with.
+	Secret configopaque.String `mapstructure:"secret"`
+	// Header holding the signature of the generic provider.
+	Header string `mapstructure:"header"`
+	// Algorithm of the HMAC of the generic provider, sha1, sha256 or sha512. Default is sha256.
+	Algorithm string `mapstructure:"algorithm"`
+	// Encoding of the signature of the generic provider, hex or base64. Default is hex.
+	Encoding string `mapstructure:"encoding"`
+	// Prefix of the signature of the generic provider, removed before decoding it, such as "sha256=".
+	Prefix string `mapstructure:"prefix"`
+	// TimestampHeader holding the time the generic provider signed the request at, in seconds since the epoch.
+	// When set, the signed payload is the timestamp and the body joined by a dot.
+	TimestampHeader string `mapstructure:"timestamp_header"`
+	// MaxAge is the maximum difference between the signature timestamp and the time the request is received at,
+	// for the providers signing a timestamp. Default is 5m, 0 disables the check.
+	MaxAge time.Duration `mapstructure:"max_age"`
 }
 
 type RequiredHeader struct {
@@ -42,6 +93,10 @@ func (cfg *Config) Validate() error {
 		errs = multierr.Append(errs, errMissingEndpointFromConfig)
 	}
 
+	if cfg.ServerConfig.MaxRequestBodySize < 0 {
+		errs = multierr.Append(errs, errNegativeMaxRequestBodySize)
+	}
+
 	// If a user defines a custom read/write timeout there is a maximum value
 	// of 10s imposed here.
 	if cfg.ReadTimeout != "" {
@@ -70,5 +125,59 @@ func (cfg *Config) Validate() error {
 		errs = multierr.Append(errs, errRequiredHeader)
 	}
 
+	if cfg.Ack.OTLPPath != "" {
+		if cfg.Ack.Extension == nil {
+			errs = multierr.Append(errs, errOTLPPathWithoutExtension)
+		}
+		if cfg.Ack.OTLPPath == cfg.Path || cfg.Ack.OTLPPath == cfg.HealthPath {
+			errs = multierr.Append(errs, errOTLPPathConflict)
+		}
+	}
+
+	errs = multierr.Append(errs, cfg.Signature.validate())
+
+	switch cfg.SplitMode {
+	case "", splitModeLine:
+		if len(cfg.JSONAttributes) > 0 {
+			errs = multierr.Append(errs, errJSONAttributesWithoutJSON)
+		}
+	case splitModeJSON:
+	default:
+		errs = multierr.Append(errs, errInvalidSplitMode)
+	}
+
+	return errs
+}
+
+func (cfg *SignatureConfig) validate() error {
+	var errs error
+	switch cfg.Provider {
+	case "":
+		return nil
+	case signatureProviderGitHub, signatureProviderStripe, signatureProviderSlack:
+	case signatureProviderGeneric:
+		if cfg.Header == "" {
+			errs = multierr.Append(errs, errMissingSignatureHeader)
+		}
+		switch cfg.Algorithm {
+		case "", signatureAlgorithmSHA1, signatureAlgorithmSHA256, signatureAlgorithmSHA512:
+		default:
+			errs = multierr.Append(errs, errInvalidSignatureAlgorithm)
+		}
+		switch cfg.Encoding {
+		case "", signatureEncodingHex, signatureEncodingBase64:
+		default:
+			errs = multierr.Append(errs, errInvalidSignatureEncoding)
+		}
+	default:
+		errs = multierr.Append(errs, errInvalidSignatureProvider)
+	}
+
+	if cfg.Secret == "" {
+		errs = multierr.Append(errs, errMissingSignatureSecret)
+	}
+	if cfg.MaxAge < 0 {
+		errs = multierr.Append(errs, errNegativeSignatureMaxAge)
+	}
 	return errs
 }
@@ -6,6 +6,7 @@ package webhookeventreceiver
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
@@ -26,11 +27,23 @@ func TestValidateConfig(t *testing.T) {
 	errs = multierr.Append(errs, errWriteTimeoutExceedsMaxValue)
 	errs = multierr.Append(errs, errRequiredHeader)
 
+	ackID := component.MustNewID("ack")
+
 	tests := []struct {
 		desc   string
 		expect error
 		conf   Config
 	}{
+		{
+			desc:   "Negative max request body size",
+			expect: errNegativeMaxRequestBodySize,
+			conf: Config{
+				ServerConfig: confighttp.ServerConfig{
+					Endpoint:           "localhost:0",
+					MaxRequestBodySize: -1,
+				},
+			},
+		},
 		{
 			desc:   "Missing valid endpoint",
 			expect: errMissingEndpointFromConfig,
@@ -86,6 +99,135 @@ func TestValidateConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc:   "OTLP path without an ack extension",
+			expect: errOTLPPathWithoutExtension,
+			conf: Config{
+				ServerConfig: confighttp.ServerConfig{
+					Endpoint: "localhost:0",
+				},
+				Ack: Ack{
+					OTLPPath: "/v1/logs",
+				},
+			},
+		},
+		{
+			desc:   "OTLP path conflicting with the events path",
+			expect: errOTLPPathConflict,
+			conf: Config{
+				ServerConfig: confighttp.ServerConfig{
+					Endpoint: "localhost:0",
+				},
+				Path: "/events",
+				Ack: Ack{
+					Extension: &ackID,
+					OTLPPath:  "/events",
+				},
+			},
+		},
+		{
+			desc:   "Unknown signature provider",
+			expect: errInvalidSignatureProvider,
+			conf: Config{
+				ServerConfig: confighttp.ServerConfig{
+					Endpoint: "localhost:0",
+				},
+				Signature: SignatureConfig{
+					Provider: "unknown",
+					Secret:   "secret",
+				},
+			},
+		},
+		{
+			desc:   "Signature without a secret",
+			expect: errMissingSignatureSecret,
+			conf: Config{
+				ServerConfig: confighttp.ServerConfig{
+					Endpoint: "localhost:0",
+				},
+				Signature: SignatureConfig{
+					Provider: "github",
+				},
+			},
+		},
+		{
+			desc:   "Generic signature without a header",
+			expect: errMissingSignatureHeader,
+			conf: Config{
+				ServerConfig: confighttp.ServerConfig{
+					Endpoint: "localhost:0",
+				},
+				Signature: SignatureConfig{
+					Provider: "generic",
+					Secret:   "secret",
+				},
+			},
+		},
+		{
+			desc:   "Generic signature with an unknown algorithm",
+			expect: errInvalidSignatureAlgorithm,
+			conf: Config{
+				ServerConfig: confighttp.ServerConfig{
+					Endpoint: "localhost:0",
+				},
+				Signature: SignatureConfig{
+					Provider:  "generic",
+					Secret:    "secret",
+					Header:    "X-Signature",
+					Algorithm: "md5",
+				},
+			},
+		},
+		{
+			desc:   "Generic signature with an unknown encoding",
+			expect: errInvalidSignatureEncoding,
+			conf: Config{
+				ServerConfig: confighttp.ServerConfig{
+					Endpoint: "localhost:0",
+				},
+				Signature: SignatureConfig{
+					Provider: "generic",
+					Secret:   "secret",
+					Header:   "X-Signature",
+					Encoding: "base32",
+				},
+			},
+		},
+		{
+			desc:   "Negative signature max age",
+			expect: errNegativeSignatureMaxAge,
+			conf: Config{
+				ServerConfig: confighttp.ServerConfig{
+					Endpoint: "localhost:0",
+				},
+				Signature: SignatureConfig{
+					Provider: "slack",
+					Secret:   "secret",
+					MaxAge:   -time.Second,
+				},
+			},
+		},
+		{
+			desc:   "Unknown split mode",
+			expect: errInvalidSplitMode,
+			conf: Config{
+				ServerConfig: confighttp.ServerConfig{
+					Endpoint: "localhost:0",
+				},
+				SplitMode: "xml",
+			},
+		},
+		{
+			desc:   "JSON attributes without the json split mode",
+			expect: errJSONAttributesWithoutJSON,
+			conf: Config{
+				ServerConfig: confighttp.ServerConfig{
+					Endpoint: "localhost:0",
+				},
+				SplitMode:      "line",
+				JSONAttributes: []string{"action"},
+			},
+		},
 		{
 			desc:   "Multiple invalid configs",
 			expect: errs,
@@ -124,7 +266,8 @@ func TestLoadConfig(t *testing.T) {
 
 	expect := &Config{
 		ServerConfig: confighttp.ServerConfig{
-			Endpoint: "localhost:8080",
+			Endpoint:           "localhost:8080",
+			MaxRequestBodySize: 1048576,
 		},
 		ReadTimeout:  "500ms",
 		WriteTimeout: "500ms",
@@ -134,6 +277,10 @@ func TestLoadConfig(t *testing.T) {
 			Key:   "key-present",
 			Value: "value-present",
 		},
+		SplitMode: "line",
+		Signature: SignatureConfig{
+			MaxAge: 5 * time.Minute,
+		},
 	}
 
 	// create expected config
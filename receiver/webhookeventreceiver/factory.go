@@ -5,8 +5,10 @@ package webhookeventreceiver // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
 
@@ -24,6 +26,10 @@ const (
 	defaultWriteTimeout = "500ms"
 	defaultPath         = "/events"
 	defaultHealthPath   = "/health_check"
+	defaultSplitMode    = splitModeLine
+	defaultMaxAge       = 5 * time.Minute
+
+	defaultMaxRequestBodySize = 20 * 1024 * 1024 // 20MiB
 )
 
 // NewFactory creates a factory for Generic Webhook Receiver.
@@ -38,10 +44,17 @@ func NewFactory() receiver.Factory {
 // Default configuration for the generic webhook receiver
 func createDefaultConfig() component.Config {
 	return &Config{
+		ServerConfig: confighttp.ServerConfig{
+			MaxRequestBodySize: defaultMaxRequestBodySize,
+		},
 		Path:         defaultPath,
 		HealthPath:   defaultHealthPath,
 		ReadTimeout:  defaultReadTimeout,
 		WriteTimeout: defaultWriteTimeout,
+		SplitMode:    defaultSplitMode,
+		Signature: SignatureConfig{
+			MaxAge: defaultMaxAge,
+		},
 	}
 }
 
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhookeventreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/webhookeventreceiver"
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/webhookeventreceiver/internal/metadata"
+)
+
+const (
+	pbContentType   = "application/x-protobuf"
+	jsonContentType = "application/json"
+)
+
+var errInvalidContentType = errors.New("invalid content type. Valid content types are application/x-protobuf and application/json")
+
+// handleOTLPReq handles the OTLP/HTTP logs requests. The logs are consumed as is, and the response follows the
+// OTLP/HTTP specification: failures are retryable, with a 503 status code, unless the error is permanent.
+func (er *eventReceiver) handleOTLPReq(w http.ResponseWriter, r *http.Request) {
+	ctx := er.obsrecv.StartLogsOp(r.Context())
+
+	if er.cfg.RequiredHeader.Key != "" && r.Header.Get(er.cfg.RequiredHeader.Key) != er.cfg.RequiredHeader.Value {
+		er.failBadReq(ctx, w, http.StatusUnauthorized, errMissingRequiredHeader)
+		er.obsrecv.EndLogsOp(ctx, metadata.Type.String(), 0, errMissingRequiredHeader)
+		return
+	}
+
+	contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if contentType != pbContentType && contentType != jsonContentType {
+		er.failBadReq(ctx, w, http.StatusUnsupportedMediaType, errInvalidContentType)
+		er.obsrecv.EndLogsOp(ctx, metadata.Type.String(), 0, errInvalidContentType)
+		return
+	}
+
+	bodyReader := er.limitBody(w, r.Body)
+	switch r.Header.Get("Content-Encoding") {
+	case "":
+	case "gzip", "x-gzip":
+		reader := er.gzipPool.Get().(*gzip.Reader)
+		defer er.gzipPool.Put(reader)
+		if err := reader.Reset(bodyReader); err != nil {
+			er.failBadReq(ctx, w, http.StatusBadRequest, err)
+			er.obsrecv.EndLogsOp(ctx, metadata.Type.String(), 0, err)
+			return
+		}
+		bodyReader = er.limitBody(w, reader)
+	default:
+		er.failBadReq(ctx, w, http.StatusUnsupportedMediaType, errInvalidEncodingType)
+		er.obsrecv.EndLogsOp(ctx, metadata.Type.String(), 0, errInvalidEncodingType)
+		return
+	}
+
+	body, err := io.ReadAll(bodyReader)
+	_ = bodyReader.Close()
+	if err != nil {
+		er.failBadReq(ctx, w, bodyErrorStatusCode(err), err)
+		er.obsrecv.EndLogsOp(ctx, metadata.Type.String(), 0, err)
+		return
+	}
+
+	req := plogotlp.NewExportRequest()
+	if contentType == pbContentType {
+		err = req.UnmarshalProto(body)
+	} else {
+		err = req.UnmarshalJSON(body)
+	}
+	if err != nil {
+		er.failBadReq(ctx, w, http.StatusBadRequest, err)
+		er.obsrecv.EndLogsOp(ctx, metadata.Type.String(), 0, err)
+		return
+	}
+
+	ld := req.Logs()
+	numLogs := ld.LogRecordCount()
+	consumerErr := er.logConsumer.ConsumeLogs(ctx, ld)
+	er.obsrecv.EndLogsOp(ctx, metadata.Type.String(), numLogs, consumerErr)
+	if consumerErr != nil {
+		statusCode := http.StatusServiceUnavailable
+		if consumererror.IsPermanent(consumerErr) {
+			statusCode = http.StatusBadRequest
+		}
+		er.failBadReq(ctx, w, statusCode, consumerErr)
+		return
+	}
+
+	resp := plogotlp.NewExportResponse()
+	var respBody []byte
+	if contentType == pbContentType {
+		respBody, err = resp.MarshalProto()
+	} else {
+		respBody, err = resp.MarshalJSON()
+	}
+	if err != nil {
+		er.failBadReq(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(respBody)
+}
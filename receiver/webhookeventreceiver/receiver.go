@@ -5,9 +5,11 @@ package webhookeventreceiver // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"bufio"
+	"bytes"
 	"compress/gzip"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"sync"
@@ -17,10 +19,12 @@ import (
 	"github.com/julienschmidt/httprouter"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/webhookeventreceiver/internal/metadata"
 )
 
@@ -43,6 +47,7 @@ type eventReceiver struct {
 	shutdownWG  sync.WaitGroup
 	obsrecv     *receiverhelper.ObsReport
 	gzipPool    *sync.Pool
+	verifier    *signatureVerifier
 }
 
 func newLogsReceiver(params receiver.Settings, cfg Config, consumer consumer.Logs) (receiver.Logs, error) {
@@ -76,6 +81,7 @@ func newLogsReceiver(params receiver.Settings, cfg Config, consumer consumer.Log
 		logConsumer: consumer,
 		obsrecv:     obsrecv,
 		gzipPool:    &sync.Pool{New: func() any { return new(gzip.Reader) }},
+		verifier:    newSignatureVerifier(cfg.Signature),
 	}
 
 	return er, nil
@@ -88,6 +94,24 @@ func (er *eventReceiver) Start(ctx context.Context, host component.Host) error {
 		return nil
 	}
 
+	var eventsHandler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		er.handleReq(w, r, nil)
+	})
+	var otlpHandler http.Handler = http.HandlerFunc(er.handleOTLPReq)
+	// acknowledge the events once consumed if the ack extension is present
+	if er.cfg.Ack.Extension != nil {
+		ext, found := host.GetExtensions()[*er.cfg.Ack.Extension]
+		if !found {
+			return fmt.Errorf("specified ack extension with id %q could not be found", *er.cfg.Ack.Extension)
+		}
+		ackExt, ok := ext.(ackextension.AckExtension)
+		if !ok {
+			return fmt.Errorf("specified extension with id %q is not an ack extension", *er.cfg.Ack.Extension)
+		}
+		eventsHandler = ackextension.NewHandler(ackExt, eventsHandler)
+		otlpHandler = ackextension.NewHandler(ackExt, otlpHandler)
+	}
+
 	// create listener from config
 	ln, err := er.cfg.ServerConfig.ToListener(ctx)
 	if err != nil {
@@ -97,8 +121,11 @@ func (er *eventReceiver) Start(ctx context.Context, host component.Host) error {
 	// set up router.
 	router := httprouter.New()
 
-	router.POST(er.cfg.Path, er.handleReq)
+	router.Handler(http.MethodPost, er.cfg.Path, eventsHandler)
 	router.GET(er.cfg.HealthPath, er.handleHealthCheck)
+	if er.cfg.Ack.OTLPPath != "" {
+		router.Handler(http.MethodPost, er.cfg.Ack.OTLPPath, otlpHandler)
+	}
 
 	// webhook server standup and configuration
 	er.server, err = er.cfg.ServerConfig.ToServer(ctx, host, er.settings.TelemetrySettings, router)
@@ -174,7 +201,22 @@ func (er *eventReceiver) handleReq(w http.ResponseWriter, r *http.Request, _ htt
 		er.failBadReq(ctx, w, http.StatusBadRequest, errEmptyResponseBody)
 	}
 
-	bodyReader := r.Body
+	bodyReader := er.limitBody(w, r.Body)
+	// the signature is computed over the raw body, which is read in full to verify it
+	if er.verifier != nil {
+		body, err := io.ReadAll(bodyReader)
+		_ = bodyReader.Close()
+		if err != nil {
+			er.failBadReq(ctx, w, bodyErrorStatusCode(err), err)
+			return
+		}
+		if err = er.verifier.verify(r.Header, body, time.Now()); err != nil {
+			er.failBadReq(ctx, w, http.StatusUnauthorized, err)
+			return
+		}
+		bodyReader = io.NopCloser(bytes.NewReader(body))
+	}
+
 	// gzip encoded case
 	if encoding == "gzip" || encoding == "x-gzip" {
 		reader := er.gzipPool.Get().(*gzip.Reader)
@@ -186,13 +228,34 @@ func (er *eventReceiver) handleReq(w http.ResponseWriter, r *http.Request, _ htt
 			_ = r.Body.Close()
 			return
 		}
-		bodyReader = reader
+		// the decompressed body is limited too, so that a small compressed body can't exhaust the memory
+		bodyReader = er.limitBody(w, reader)
 		defer er.gzipPool.Put(reader)
 	}
 
 	// finish reading the body into a log
-	sc := bufio.NewScanner(bodyReader)
-	ld, numLogs := reqToLog(sc, r.URL.Query(), er.cfg, er.settings)
+	var ld plog.Logs
+	var numLogs int
+	if er.cfg.SplitMode == splitModeJSON {
+		var err error
+		ld, numLogs, err = reqJSONToLog(bodyReader, r.URL.Query(), er.cfg, er.settings)
+		if err != nil {
+			_ = bodyReader.Close()
+			er.failBadReq(ctx, w, bodyErrorStatusCode(err), err)
+			er.obsrecv.EndLogsOp(ctx, metadata.Type.String(), 0, err)
+			return
+		}
+	} else {
+		sc := bufio.NewScanner(bodyReader)
+		ld, numLogs = reqToLog(sc, r.URL.Query(), er.cfg, er.settings)
+		var maxBytesErr *http.MaxBytesError
+		if err := sc.Err(); errors.As(err, &maxBytesErr) {
+			_ = bodyReader.Close()
+			er.failBadReq(ctx, w, http.StatusRequestEntityTooLarge, err)
+			er.obsrecv.EndLogsOp(ctx, metadata.Type.String(), 0, err)
+			return
+		}
+	}
 	consumerErr := er.logConsumer.ConsumeLogs(ctx, ld)
 
 	_ = bodyReader.Close()
@@ -217,6 +280,23 @@ func (er *eventReceiver) handleHealthCheck(w http.ResponseWriter, _ *http.Reques
 // write response on a failed/bad request. Generates a small json body based on the thrown by
 // the handle func and the appropriate http status code. many webhooks will either log these responses or
 // notify webhook users should a none 2xx code be detected.
+// limitBody bounds the number of bytes read from the body to max_request_body_size, if set
+func (er *eventReceiver) limitBody(w http.ResponseWriter, body io.ReadCloser) io.ReadCloser {
+	if er.cfg.MaxRequestBodySize <= 0 {
+		return body
+	}
+	return http.MaxBytesReader(w, body, er.cfg.MaxRequestBodySize)
+}
+
+// bodyErrorStatusCode returns the status code of the requests whose body failed to be read or parsed
+func bodyErrorStatusCode(err error) int {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
+
 func (er *eventReceiver) failBadReq(_ context.Context,
 	w http.ResponseWriter,
 	httpStatusCode int,
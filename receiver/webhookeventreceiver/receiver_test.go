@@ -7,20 +7,31 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
 )
 
 func TestCreateNewLogReceiver(t *testing.T) {
@@ -74,6 +85,12 @@ func TestCreateNewLogReceiver(t *testing.T) {
 func TestHandleReq(t *testing.T) {
 	cfg := createDefaultConfig().(*Config)
 	cfg.Endpoint = "localhost:0"
+	signedCfg := createDefaultConfig().(*Config)
+	signedCfg.Endpoint = "localhost:0"
+	signedCfg.Signature = SignatureConfig{Provider: "github", Secret: testSecret}
+	jsonCfg := createDefaultConfig().(*Config)
+	jsonCfg.Endpoint = "localhost:0"
+	jsonCfg.SplitMode = splitModeJSON
 
 	tests := []struct {
 		desc string
@@ -116,6 +133,20 @@ func TestHandleReq(t *testing.T) {
 			cfg:  *cfg,
 			req:  httptest.NewRequest("POST", "http://localhost/events", strings.NewReader("log1\nlog2")),
 		},
+		{
+			desc: "Signed request",
+			cfg:  *signedCfg,
+			req: func() *http.Request {
+				req := httptest.NewRequest("POST", "http://localhost/events", bytes.NewReader(testBody))
+				req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(testSign(sha256.New, string(testBody))))
+				return req
+			}(),
+		},
+		{
+			desc: "JSON array split into logs",
+			cfg:  *jsonCfg,
+			req:  httptest.NewRequest("POST", "http://localhost/events", strings.NewReader(`[{"action":"opened"},{"action":"closed"}]`)),
+		},
 	}
 
 	for _, test := range tests {
@@ -150,6 +181,19 @@ func TestFailedReq(t *testing.T) {
 	headerCfg.Endpoint = "localhost:0"
 	headerCfg.RequiredHeader.Key = "key-present"
 	headerCfg.RequiredHeader.Value = "value-present"
+	signedCfg := createDefaultConfig().(*Config)
+	signedCfg.Endpoint = "localhost:0"
+	signedCfg.Signature = SignatureConfig{Provider: "github", Secret: testSecret}
+	jsonCfg := createDefaultConfig().(*Config)
+	jsonCfg.Endpoint = "localhost:0"
+	jsonCfg.SplitMode = splitModeJSON
+	limitedCfg := createDefaultConfig().(*Config)
+	limitedCfg.Endpoint = "localhost:0"
+	limitedCfg.MaxRequestBodySize = 64
+	limitedJSONCfg := createDefaultConfig().(*Config)
+	limitedJSONCfg.Endpoint = "localhost:0"
+	limitedJSONCfg.MaxRequestBodySize = 64
+	limitedJSONCfg.SplitMode = splitModeJSON
 
 	tests := []struct {
 		desc   string
@@ -199,6 +243,57 @@ func TestFailedReq(t *testing.T) {
 			}(),
 			status: http.StatusUnauthorized,
 		},
+		{
+			desc:   "Missing signature",
+			cfg:    *signedCfg,
+			req:    httptest.NewRequest("POST", "http://localhost/events", bytes.NewReader(testBody)),
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc: "Invalid signature",
+			cfg:  *signedCfg,
+			req: func() *http.Request {
+				req := httptest.NewRequest("POST", "http://localhost/events", bytes.NewReader(testBody))
+				req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(testSign(sha256.New, "tampered")))
+				return req
+			}(),
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "Invalid json payload",
+			cfg:    *jsonCfg,
+			req:    httptest.NewRequest("POST", "http://localhost/events", strings.NewReader(`{"action":`)),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "Body too large",
+			cfg:    *limitedCfg,
+			req:    httptest.NewRequest("POST", "http://localhost/events", strings.NewReader(strings.Repeat("log\n", 100))),
+			status: http.StatusRequestEntityTooLarge,
+		},
+		{
+			desc:   "JSON body too large",
+			cfg:    *limitedJSONCfg,
+			req:    httptest.NewRequest("POST", "http://localhost/events", strings.NewReader(`[`+strings.Repeat(`{"action":"opened"},`, 10)+`{}]`)),
+			status: http.StatusRequestEntityTooLarge,
+		},
+		{
+			desc: "Decompressed body too large",
+			cfg:  *limitedCfg,
+			req: func() *http.Request {
+				var msg bytes.Buffer
+				gzipWriter := gzip.NewWriter(&msg)
+				_, err := gzipWriter.Write([]byte(strings.Repeat("log\n", 1000)))
+				require.NoError(t, err, "Gzip writer failed")
+				require.NoError(t, gzipWriter.Close(), "Gzip writer failed")
+				require.Less(t, msg.Len(), 64, "the compressed body should be within the limit")
+
+				req := httptest.NewRequest("POST", "http://localhost/events", &msg)
+				req.Header.Set("Content-Encoding", "gzip")
+				return req
+			}(),
+			status: http.StatusRequestEntityTooLarge,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
@@ -240,3 +335,213 @@ func TestHealthCheck(t *testing.T) {
 	response := w.Result()
 	require.Equal(t, http.StatusOK, response.StatusCode)
 }
+
+type ackHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *ackHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func TestHandleReqWithAck(t *testing.T) {
+	ackID := component.MustNewID("ack")
+	ext, err := ackextension.NewFactory().CreateExtension(context.Background(), extensiontest.NewNopSettings(), ackextension.NewFactory().CreateDefaultConfig())
+	require.NoError(t, err)
+	ackExt := ext.(ackextension.AckExtension)
+	host := &ackHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{ackID: ext},
+	}
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "localhost:0"
+	cfg.Ack.Extension = &ackID
+
+	tests := []struct {
+		desc         string
+		consumer     consumer.Logs
+		expectedCode int
+		expectedAck  bool
+	}{
+		{
+			desc:         "consumed events are acknowledged",
+			consumer:     consumertest.NewNop(),
+			expectedCode: http.StatusOK,
+			expectedAck:  true,
+		},
+		{
+			desc:         "failed events aren't acknowledged",
+			consumer:     consumertest.NewErr(errors.New("export failed")),
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			receiver, err := newLogsReceiver(receivertest.NewNopSettings(), *cfg, test.consumer)
+			require.NoError(t, err, "Failed to create receiver")
+
+			r := receiver.(*eventReceiver)
+			require.NoError(t, r.Start(context.Background(), host), "Failed to start receiver")
+			defer func() {
+				require.NoError(t, r.Shutdown(context.Background()), "Failed to shutdown receiver")
+			}()
+
+			req := httptest.NewRequest("POST", "http://localhost/events", strings.NewReader("test"))
+			req.Header.Set(ackextension.PartitionHeader, "agent")
+			w := httptest.NewRecorder()
+			r.server.Handler.ServeHTTP(w, req)
+
+			response := w.Result()
+			_, err = io.ReadAll(response.Body)
+			require.NoError(t, err, "Failed to read message body")
+			require.Equal(t, test.expectedCode, response.StatusCode)
+
+			// the ack ID is returned whether the events were consumed or not
+			id, err := strconv.ParseUint(response.Header.Get(ackextension.AckIDHeader), 10, 64)
+			require.NoError(t, err)
+			require.Equal(t, map[uint64]bool{id: test.expectedAck}, ackExt.QueryAcks("agent", []uint64{id}))
+		})
+	}
+}
+
+func TestHandleOTLPReqWithAck(t *testing.T) {
+	ackID := component.MustNewID("ack")
+	ext, err := ackextension.NewFactory().CreateExtension(context.Background(), extensiontest.NewNopSettings(), ackextension.NewFactory().CreateDefaultConfig())
+	require.NoError(t, err)
+	ackExt := ext.(ackextension.AckExtension)
+	host := &ackHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{ackID: ext},
+	}
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "localhost:0"
+	cfg.Ack.Extension = &ackID
+	cfg.Ack.OTLPPath = "/v1/logs"
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("test")
+	pbBody, err := plogotlp.NewExportRequestFromLogs(ld).MarshalProto()
+	require.NoError(t, err)
+	jsonBody, err := plogotlp.NewExportRequestFromLogs(ld).MarshalJSON()
+	require.NoError(t, err)
+
+	tests := []struct {
+		desc         string
+		consumer     consumer.Logs
+		contentType  string
+		body         []byte
+		expectedCode int
+		expectedAck  bool
+	}{
+		{
+			desc:         "consumed protobuf logs are acknowledged",
+			consumer:     consumertest.NewNop(),
+			contentType:  "application/x-protobuf",
+			body:         pbBody,
+			expectedCode: http.StatusOK,
+			expectedAck:  true,
+		},
+		{
+			desc:         "consumed json logs are acknowledged",
+			consumer:     consumertest.NewNop(),
+			contentType:  "application/json",
+			body:         jsonBody,
+			expectedCode: http.StatusOK,
+			expectedAck:  true,
+		},
+		{
+			desc:         "failed logs aren't acknowledged",
+			consumer:     consumertest.NewErr(errors.New("export failed")),
+			contentType:  "application/x-protobuf",
+			body:         pbBody,
+			expectedCode: http.StatusServiceUnavailable,
+		},
+		{
+			desc:         "permanently failed logs aren't acknowledged",
+			consumer:     consumertest.NewErr(consumererror.NewPermanent(errors.New("export failed"))),
+			contentType:  "application/x-protobuf",
+			body:         pbBody,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			desc:         "invalid logs aren't acknowledged",
+			consumer:     consumertest.NewNop(),
+			contentType:  "application/x-protobuf",
+			body:         []byte("invalid"),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			desc:         "unsupported content type",
+			consumer:     consumertest.NewNop(),
+			contentType:  "text/plain",
+			body:         pbBody,
+			expectedCode: http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			receiver, err := newLogsReceiver(receivertest.NewNopSettings(), *cfg, test.consumer)
+			require.NoError(t, err, "Failed to create receiver")
+
+			r := receiver.(*eventReceiver)
+			require.NoError(t, r.Start(context.Background(), host), "Failed to start receiver")
+			defer func() {
+				require.NoError(t, r.Shutdown(context.Background()), "Failed to shutdown receiver")
+			}()
+
+			req := httptest.NewRequest("POST", "http://localhost/v1/logs", bytes.NewReader(test.body))
+			req.Header.Set("Content-Type", test.contentType)
+			req.Header.Set(ackextension.PartitionHeader, "agent")
+			w := httptest.NewRecorder()
+			r.server.Handler.ServeHTTP(w, req)
+
+			response := w.Result()
+			_, err = io.ReadAll(response.Body)
+			require.NoError(t, err, "Failed to read message body")
+			require.Equal(t, test.expectedCode, response.StatusCode)
+
+			id, err := strconv.ParseUint(response.Header.Get(ackextension.AckIDHeader), 10, 64)
+			require.NoError(t, err)
+			require.Equal(t, map[uint64]bool{id: test.expectedAck}, ackExt.QueryAcks("agent", []uint64{id}))
+		})
+	}
+}
+
+func TestHandleOTLPReqBodyTooLarge(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "localhost:0"
+	cfg.MaxRequestBodySize = 8
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("too large to be read")
+	body, err := plogotlp.NewExportRequestFromLogs(ld).MarshalProto()
+	require.NoError(t, err)
+
+	sink := new(consumertest.LogsSink)
+	receiver, err := newLogsReceiver(receivertest.NewNopSettings(), *cfg, sink)
+	require.NoError(t, err, "Failed to create receiver")
+
+	req := httptest.NewRequest("POST", "http://localhost/v1/logs", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	w := httptest.NewRecorder()
+	receiver.(*eventReceiver).handleOTLPReq(w, req)
+
+	require.Equal(t, http.StatusRequestEntityTooLarge, w.Result().StatusCode)
+	require.Zero(t, sink.LogRecordCount())
+}
+
+func TestStartWithMissingAckExtension(t *testing.T) {
+	ackID := component.MustNewID("ack")
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "localhost:0"
+	cfg.Ack.Extension = &ackID
+
+	receiver, err := newLogsReceiver(receivertest.NewNopSettings(), *cfg, consumertest.NewNop())
+	require.NoError(t, err, "Failed to create receiver")
+	require.ErrorContains(t, receiver.Start(context.Background(), componenttest.NewNopHost()), `specified ack extension with id "ack" could not be found`)
+}
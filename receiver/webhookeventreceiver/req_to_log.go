@@ -5,18 +5,80 @@ package webhookeventreceiver // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/url"
+	"strings"
 
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/receiver"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/webhookeventreceiver/internal/metadata"
 )
 
+const (
+	splitModeLine = "line"
+	splitModeJSON = "json"
+)
+
+var errInvalidJSONPayload = errors.New("request body is not a valid json array, object or sequence of json values")
+
 func reqToLog(sc *bufio.Scanner,
 	query url.Values,
 	_ *Config,
 	settings receiver.Settings) (plog.Logs, int) {
+	log, scopeLog := newLogs(query, settings)
+
+	for sc.Scan() {
+		logRecord := scopeLog.LogRecords().AppendEmpty()
+		line := sc.Text()
+		logRecord.Body().SetStr(line)
+	}
+
+	return log, scopeLog.LogRecords().Len()
+}
+
+// reqJSONToLog turns each element of a json array, or each value of a sequence of json values such as NDJSON,
+// into a log record. The configured json attributes are promoted to attributes of the log records.
+func reqJSONToLog(reader io.Reader,
+	query url.Values,
+	cfg *Config,
+	settings receiver.Settings) (plog.Logs, int, error) {
+	log, scopeLog := newLogs(query, settings)
+
+	dec := json.NewDecoder(reader)
+	dec.UseNumber()
+	for {
+		var value json.RawMessage
+		err := dec.Decode(&value)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return log, 0, fmt.Errorf("%w: %w", errInvalidJSONPayload, err)
+		}
+		trimmed := bytes.TrimSpace(value)
+		if len(trimmed) > 0 && trimmed[0] == '[' {
+			var elements []json.RawMessage
+			if err = json.Unmarshal(trimmed, &elements); err != nil {
+				return log, 0, fmt.Errorf("%w: %w", errInvalidJSONPayload, err)
+			}
+			for _, element := range elements {
+				appendJSONRecord(scopeLog, element, cfg.JSONAttributes)
+			}
+			continue
+		}
+		appendJSONRecord(scopeLog, trimmed, cfg.JSONAttributes)
+	}
+
+	return log, scopeLog.LogRecords().Len(), nil
+}
+
+func newLogs(query url.Values, settings receiver.Settings) (plog.Logs, plog.ScopeLogs) {
 	log := plog.NewLogs()
 	resourceLog := log.ResourceLogs().AppendEmpty()
 	appendMetadata(resourceLog, query)
@@ -26,14 +88,71 @@ func reqToLog(sc *bufio.Scanner,
 	scopeLog.Scope().SetVersion(settings.BuildInfo.Version)
 	scopeLog.Scope().Attributes().PutStr("source", settings.ID.String())
 	scopeLog.Scope().Attributes().PutStr("receiver", metadata.Type.String())
+	return log, scopeLog
+}
 
-	for sc.Scan() {
-		logRecord := scopeLog.LogRecords().AppendEmpty()
-		line := sc.Text()
-		logRecord.Body().SetStr(line)
+// appendJSONRecord appends a log record holding the json value as its body
+func appendJSONRecord(scopeLog plog.ScopeLogs, value json.RawMessage, attributes []string) {
+	logRecord := scopeLog.LogRecords().AppendEmpty()
+	logRecord.Body().SetStr(string(value))
+	if len(attributes) == 0 {
+		return
 	}
 
-	return log, scopeLog.LogRecords().Len()
+	var fields map[string]any
+	dec := json.NewDecoder(bytes.NewReader(value))
+	dec.UseNumber()
+	if dec.Decode(&fields) != nil {
+		// not a json object, there is no field to promote
+		return
+	}
+	for _, attribute := range attributes {
+		if field, ok := lookupJSONField(fields, attribute); ok {
+			putJSONValue(logRecord.Attributes().PutEmpty(attribute), field)
+		}
+	}
+}
+
+// lookupJSONField returns the field at the dot separated path
+func lookupJSONField(fields map[string]any, path string) (any, bool) {
+	var current any = fields
+	for _, key := range strings.Split(path, ".") {
+		object, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if current, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func putJSONValue(dest pcommon.Value, value any) {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			dest.SetInt(i)
+		} else if f, err := v.Float64(); err == nil {
+			dest.SetDouble(f)
+		} else {
+			dest.SetStr(v.String())
+		}
+	case map[string]any:
+		m := dest.SetEmptyMap()
+		for key, field := range v {
+			putJSONValue(m.PutEmpty(key), field)
+		}
+	case []any:
+		s := dest.SetEmptySlice()
+		for _, element := range v {
+			putJSONValue(s.AppendEmpty(), element)
+		}
+	case string:
+		dest.SetStr(v)
+	case bool:
+		dest.SetBool(v)
+	}
 }
 
 // append query parameters and webhook source as resource attributes
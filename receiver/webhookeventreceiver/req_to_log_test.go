@@ -85,3 +85,85 @@ func TestReqToLog(t *testing.T) {
 		})
 	}
 }
+
+func TestReqJSONToLog(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.SplitMode = splitModeJSON
+	cfg.JSONAttributes = []string{"action", "repository.id", "sender"}
+
+	tests := []struct {
+		desc      string
+		body      string
+		expect    []string
+		expectErr bool
+	}{
+		{
+			desc:   "Array of events",
+			body:   `[{"action":"opened"}, {"action":"closed"}]`,
+			expect: []string{`{"action":"opened"}`, `{"action":"closed"}`},
+		},
+		{
+			desc:   "Single event",
+			body:   `{"action":"opened"}`,
+			expect: []string{`{"action":"opened"}`},
+		},
+		{
+			desc:   "Newline delimited events",
+			body:   "{\"action\":\"opened\"}\n{\"action\":\"closed\"}\n",
+			expect: []string{`{"action":"opened"}`, `{"action":"closed"}`},
+		},
+		{
+			desc:   "Empty body",
+			body:   "",
+			expect: []string{},
+		},
+		{
+			desc:      "Invalid json",
+			body:      `{"action":`,
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			reqLog, reqLen, err := reqJSONToLog(bytes.NewReader([]byte(test.body)), nil, cfg, receivertest.NewNopSettings())
+			if test.expectErr {
+				require.ErrorIs(t, err, errInvalidJSONPayload)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, len(test.expect), reqLen)
+			records := reqLog.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+			for i, body := range test.expect {
+				require.Equal(t, body, records.At(i).Body().Str())
+			}
+		})
+	}
+}
+
+func TestReqJSONToLogAttributes(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.SplitMode = splitModeJSON
+	cfg.JSONAttributes = []string{"action", "repository.id", "repository.stars", "sender", "missing", "private"}
+
+	body := `{"action":"opened","private":false,"repository":{"id":42,"stars":4.5},"sender":{"login":"octocat"}}`
+	reqLog, reqLen, err := reqJSONToLog(bytes.NewReader([]byte(body)), nil, cfg, receivertest.NewNopSettings())
+	require.NoError(t, err)
+	require.Equal(t, 1, reqLen)
+
+	attributes := reqLog.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes()
+	require.Equal(t, 5, attributes.Len())
+	action, _ := attributes.Get("action")
+	require.Equal(t, "opened", action.Str())
+	id, _ := attributes.Get("repository.id")
+	require.Equal(t, int64(42), id.Int())
+	stars, _ := attributes.Get("repository.stars")
+	require.Equal(t, 4.5, stars.Double())
+	private, _ := attributes.Get("private")
+	require.False(t, private.Bool())
+	sender, _ := attributes.Get("sender")
+	login, _ := sender.Map().Get("login")
+	require.Equal(t, "octocat", login.Str())
+	_, ok := attributes.Get("missing")
+	require.False(t, ok)
+}
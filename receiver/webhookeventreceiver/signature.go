@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhookeventreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/webhookeventreceiver"
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" // #nosec G505 -- some webhook providers still sign their payloads with HMAC-SHA1
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	signatureProviderGitHub  = "github"
+	signatureProviderStripe  = "stripe"
+	signatureProviderSlack   = "slack"
+	signatureProviderGeneric = "generic"
+
+	signatureAlgorithmSHA1   = "sha1"
+	signatureAlgorithmSHA256 = "sha256"
+	signatureAlgorithmSHA512 = "sha512"
+
+	signatureEncodingHex    = "hex"
+	signatureEncodingBase64 = "base64"
+
+	githubSignatureHeader = "X-Hub-Signature-256"
+	stripeSignatureHeader = "Stripe-Signature"
+	slackSignatureHeader  = "X-Slack-Signature"
+	slackTimestampHeader  = "X-Slack-Request-Timestamp"
+)
+
+var (
+	errMissingSignature = errors.New("request was missing the signature header")
+	errInvalidSignature = errors.New("request signature does not match its payload")
+	errMissingTimestamp = errors.New("request was missing the signature timestamp")
+	errInvalidTimestamp = errors.New("request signature timestamp is invalid")
+	errExpiredTimestamp = errors.New("request signature timestamp is outside of the accepted window")
+)
+
+// signatureVerifier verifies the HMAC signature of the requests sent by a webhook provider
+type signatureVerifier struct {
+	cfg      SignatureConfig
+	hashFunc func() hash.Hash
+}
+
+func newSignatureVerifier(cfg SignatureConfig) *signatureVerifier {
+	if cfg.Provider == "" {
+		return nil
+	}
+	hashFunc := sha256.New
+	if cfg.Provider == signatureProviderGeneric {
+		switch cfg.Algorithm {
+		case signatureAlgorithmSHA1:
+			hashFunc = sha1.New
+		case signatureAlgorithmSHA512:
+			hashFunc = sha512.New
+		}
+	}
+	return &signatureVerifier{cfg: cfg, hashFunc: hashFunc}
+}
+
+// verify checks the signature of the request body, and that its timestamp, if any, is not older than MaxAge
+func (v *signatureVerifier) verify(header http.Header, body []byte, now time.Time) error {
+	switch v.cfg.Provider {
+	case signatureProviderGitHub:
+		// X-Hub-Signature-256: sha256=<hex HMAC of the body>
+		return v.verifyHex(header.Get(githubSignatureHeader), "sha256=", body)
+	case signatureProviderStripe:
+		return v.verifyStripe(header.Get(stripeSignatureHeader), body, now)
+	case signatureProviderSlack:
+		// X-Slack-Signature: v0=<hex HMAC of v0:<timestamp>:<body>>
+		timestamp := header.Get(slackTimestampHeader)
+		if err := v.checkTimestamp(timestamp, now); err != nil {
+			return err
+		}
+		return v.verifyHex(header.Get(slackSignatureHeader), "v0=", signedPayload("v0:"+timestamp+":", body))
+	default:
+		payload := body
+		if v.cfg.TimestampHeader != "" {
+			timestamp := header.Get(v.cfg.TimestampHeader)
+			if err := v.checkTimestamp(timestamp, now); err != nil {
+				return err
+			}
+			payload = signedPayload(timestamp+".", body)
+		}
+		return v.verifyGeneric(header.Get(v.cfg.Header), payload)
+	}
+}
+
+// verifyStripe checks the Stripe-Signature header: t=<timestamp>,v1=<hex HMAC of <timestamp>.<body>>, which can
+// hold several v1 signatures while the secret is rolled
+func (v *signatureVerifier) verifyStripe(value string, body []byte, now time.Time) error {
+	if value == "" {
+		return errMissingSignature
+	}
+	var timestamp string
+	var signatures []string
+	for _, item := range strings.Split(value, ",") {
+		key, val, _ := strings.Cut(strings.TrimSpace(item), "=")
+		switch key {
+		case "t":
+			timestamp = val
+		case "v1":
+			signatures = append(signatures, val)
+		}
+	}
+	if err := v.checkTimestamp(timestamp, now); err != nil {
+		return err
+	}
+	if len(signatures) == 0 {
+		return errMissingSignature
+	}
+	expected := v.sign(signedPayload(timestamp+".", body))
+	for _, signature := range signatures {
+		if decoded, err := hex.DecodeString(signature); err == nil && hmac.Equal(decoded, expected) {
+			return nil
+		}
+	}
+	return errInvalidSignature
+}
+
+func (v *signatureVerifier) verifyHex(value string, prefix string, payload []byte) error {
+	if value == "" {
+		return errMissingSignature
+	}
+	signature, err := hex.DecodeString(strings.TrimPrefix(value, prefix))
+	if err != nil || !hmac.Equal(signature, v.sign(payload)) {
+		return errInvalidSignature
+	}
+	return nil
+}
+
+func (v *signatureVerifier) verifyGeneric(value string, payload []byte) error {
+	if value == "" {
+		return errMissingSignature
+	}
+	value = strings.TrimPrefix(value, v.cfg.Prefix)
+	var signature []byte
+	var err error
+	if v.cfg.Encoding == signatureEncodingBase64 {
+		signature, err = base64.StdEncoding.DecodeString(value)
+	} else {
+		signature, err = hex.DecodeString(value)
+	}
+	if err != nil || !hmac.Equal(signature, v.sign(payload)) {
+		return errInvalidSignature
+	}
+	return nil
+}
+
+// checkTimestamp rejects the timestamps, in seconds since the epoch, further than MaxAge from now, so that a
+// captured request can't be replayed later
+func (v *signatureVerifier) checkTimestamp(timestamp string, now time.Time) error {
+	if timestamp == "" {
+		return errMissingTimestamp
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidTimestamp, err)
+	}
+	if v.cfg.MaxAge <= 0 {
+		return nil
+	}
+	age := now.Sub(time.Unix(seconds, 0))
+	if age > v.cfg.MaxAge || age < -v.cfg.MaxAge {
+		return errExpiredTimestamp
+	}
+	return nil
+}
+
+func (v *signatureVerifier) sign(payload []byte) []byte {
+	mac := hmac.New(v.hashFunc, []byte(v.cfg.Secret))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func signedPayload(prefix string, body []byte) []byte {
+	payload := make([]byte, 0, len(prefix)+len(body))
+	payload = append(payload, prefix...)
+	return append(payload, body...)
+}
@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhookeventreceiver
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" // #nosec G505 -- used to test the verification of HMAC-SHA1 signatures
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"hash"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "It's a Secret to Everybody"
+
+var testBody = []byte(`{"action":"opened"}`)
+
+func testSign(hashFunc func() hash.Hash, payload string) []byte {
+	mac := hmac.New(hashFunc, []byte(testSecret))
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+func TestSignatureVerifier(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	old := strconv.FormatInt(now.Add(-10*time.Minute).Unix(), 10)
+
+	tests := []struct {
+		desc   string
+		cfg    SignatureConfig
+		header http.Header
+		expect error
+	}{
+		{
+			desc: "GitHub valid signature",
+			cfg:  SignatureConfig{Provider: "github"},
+			header: http.Header{
+				"X-Hub-Signature-256": {"sha256=" + hex.EncodeToString(testSign(sha256.New, string(testBody)))},
+			},
+		},
+		{
+			desc: "GitHub invalid signature",
+			cfg:  SignatureConfig{Provider: "github"},
+			header: http.Header{
+				"X-Hub-Signature-256": {"sha256=" + hex.EncodeToString(testSign(sha256.New, "tampered"))},
+			},
+			expect: errInvalidSignature,
+		},
+		{
+			desc:   "GitHub missing signature",
+			cfg:    SignatureConfig{Provider: "github"},
+			header: http.Header{},
+			expect: errMissingSignature,
+		},
+		{
+			desc: "Stripe valid signature",
+			cfg:  SignatureConfig{Provider: "stripe", MaxAge: 5 * time.Minute},
+			header: http.Header{
+				"Stripe-Signature": {"t=" + timestamp + ",v1=" + hex.EncodeToString(testSign(sha256.New, "rolled")) +
+					",v1=" + hex.EncodeToString(testSign(sha256.New, timestamp+"."+string(testBody)))},
+			},
+		},
+		{
+			desc: "Stripe replayed request",
+			cfg:  SignatureConfig{Provider: "stripe", MaxAge: 5 * time.Minute},
+			header: http.Header{
+				"Stripe-Signature": {"t=" + old + ",v1=" + hex.EncodeToString(testSign(sha256.New, old+"."+string(testBody)))},
+			},
+			expect: errExpiredTimestamp,
+		},
+		{
+			desc: "Stripe missing timestamp",
+			cfg:  SignatureConfig{Provider: "stripe", MaxAge: 5 * time.Minute},
+			header: http.Header{
+				"Stripe-Signature": {"v1=" + hex.EncodeToString(testSign(sha256.New, string(testBody)))},
+			},
+			expect: errMissingTimestamp,
+		},
+		{
+			desc: "Slack valid signature",
+			cfg:  SignatureConfig{Provider: "slack", MaxAge: 5 * time.Minute},
+			header: http.Header{
+				"X-Slack-Request-Timestamp": {timestamp},
+				"X-Slack-Signature":         {"v0=" + hex.EncodeToString(testSign(sha256.New, "v0:"+timestamp+":"+string(testBody)))},
+			},
+		},
+		{
+			desc: "Slack replayed request",
+			cfg:  SignatureConfig{Provider: "slack", MaxAge: 5 * time.Minute},
+			header: http.Header{
+				"X-Slack-Request-Timestamp": {old},
+				"X-Slack-Signature":         {"v0=" + hex.EncodeToString(testSign(sha256.New, "v0:"+old+":"+string(testBody)))},
+			},
+			expect: errExpiredTimestamp,
+		},
+		{
+			desc: "Slack replayed request without max age",
+			cfg:  SignatureConfig{Provider: "slack"},
+			header: http.Header{
+				"X-Slack-Request-Timestamp": {old},
+				"X-Slack-Signature":         {"v0=" + hex.EncodeToString(testSign(sha256.New, "v0:"+old+":"+string(testBody)))},
+			},
+		},
+		{
+			desc: "Slack invalid timestamp",
+			cfg:  SignatureConfig{Provider: "slack", MaxAge: 5 * time.Minute},
+			header: http.Header{
+				"X-Slack-Request-Timestamp": {"yesterday"},
+				"X-Slack-Signature":         {"v0=00"},
+			},
+			expect: errInvalidTimestamp,
+		},
+		{
+			desc: "Generic base64 SHA1 signature",
+			cfg:  SignatureConfig{Provider: "generic", Header: "X-Signature", Algorithm: "sha1", Encoding: "base64"},
+			header: http.Header{
+				"X-Signature": {base64.StdEncoding.EncodeToString(testSign(sha1.New, string(testBody)))},
+			},
+		},
+		{
+			desc: "Generic signature with prefix and timestamp",
+			cfg:  SignatureConfig{Provider: "generic", Header: "X-Signature", Prefix: "sha256=", TimestampHeader: "X-Timestamp", MaxAge: 5 * time.Minute},
+			header: http.Header{
+				"X-Timestamp": {timestamp},
+				"X-Signature": {"sha256=" + hex.EncodeToString(testSign(sha256.New, timestamp+"."+string(testBody)))},
+			},
+		},
+		{
+			desc: "Generic signature with the wrong algorithm",
+			cfg:  SignatureConfig{Provider: "generic", Header: "X-Signature", Algorithm: "sha512"},
+			header: http.Header{
+				"X-Signature": {hex.EncodeToString(testSign(sha256.New, string(testBody)))},
+			},
+			expect: errInvalidSignature,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			test.cfg.Secret = testSecret
+			err := newSignatureVerifier(test.cfg).verify(test.header, testBody, now)
+			if test.expect == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, test.expect)
+		})
+	}
+}
+
+func TestSignatureVerifierDisabled(t *testing.T) {
+	require.Nil(t, newSignatureVerifier(SignatureConfig{}))
+}